@@ -0,0 +1,163 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// chunked.go - implement the streaming signature to send the body of unknown length by chunks
+//
+// The request is signed by the bce-auth-v1 Authorization with the x-bce-content-sha256 header of
+// STREAMING-BCE-HMAC-SHA256-PAYLOAD, whose signature is the seed. The body is sent as the chunks
+//     {hex size};chunk-signature={signature}\r\n{data}\r\n
+// ending with the empty chunk, and the signature of every chunk is the hex HMAC-SHA256 of
+//     BCE-HMAC-SHA256-PAYLOAD\n{signDate}\n{previous}\n{hex sha256 of data}
+// by the signing key of the Authorization, where the previous is the signature of the previous
+// chunk or the seed for the first chunk. See the vectors in signer_test.go.
+
+package auth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/util"
+)
+
+const (
+	// STREAMING_PAYLOAD is set to the x-bce-content-sha256 header of the chunked request, which
+	// is signed by the Authorization as the seed signature of the chunks
+	STREAMING_PAYLOAD = "STREAMING-BCE-HMAC-SHA256-PAYLOAD"
+
+	// CHUNK_SIGN_ALGORITHM is the first line of the string to sign of every chunk
+	CHUNK_SIGN_ALGORITHM = "BCE-HMAC-SHA256-PAYLOAD"
+
+	// CHUNKED_CONTENT_ENCODING is set to the Content-Encoding header of the chunked request
+	CHUNKED_CONTENT_ENCODING = "bce-chunked"
+
+	// DEFAULT_CHUNK_SIZE is the size of the data of every signed chunk except the last one
+	DEFAULT_CHUNK_SIZE = 64 * 1024
+)
+
+// SetChunkedHeaders - set the headers of the chunked request before it is signed, the
+// Content-Length and Content-MD5 headers are removed since the body length is unknown
+//
+// PARAMS:
+//     - req: the request whose body is sent by the signed chunks
+func SetChunkedHeaders(req *http.Request) {
+	headers := req.Headers()
+	delete(headers, http.CONTENT_LENGTH)
+	delete(headers, http.CONTENT_MD5)
+	req.SetHeader(http.BCE_CONTENT_SHA256, STREAMING_PAYLOAD)
+	req.SetHeader(http.CONTENT_ENCODING, CHUNKED_CONTENT_ENCODING)
+	req.SetLength(-1)
+}
+
+// ChunkSigner signs the chunks of the body in order, every chunk signature is chained with the
+// previous one and the first chunk is chained with the signature of the Authorization
+type ChunkSigner struct {
+	signKey       string
+	signDate      string
+	prevSignature string
+}
+
+// NewChunkSigner - create the signer of the chunks from the Authorization of the request
+//
+// PARAMS:
+//     - authorization: the bce-auth-v1 Authorization header of the signed request
+//     - secretAccessKey: the secret access key which signs the request
+// RETURNS:
+//     - *ChunkSigner: the signer of the chunks
+//     - error: nil if ok otherwise the error of the invalid authorization
+func NewChunkSigner(authorization, secretAccessKey string) (*ChunkSigner, error) {
+	// bce-auth-v1/{accessKeyId}/{signDate}/{expireSeconds}/{signedHeaders}/{signature}
+	parts := strings.Split(authorization, "/")
+	if len(parts) != 6 || parts[0] != BCE_AUTH_VERSION {
+		return nil, fmt.Errorf("invalid authorization to sign the chunks: %s", authorization)
+	}
+	signKeyInfo := strings.Join(parts[:4], "/")
+	return &ChunkSigner{
+		signKey:       getSigningKey(parts[1], secretAccessKey, signKeyInfo).key,
+		signDate:      parts[2],
+		prevSignature: parts[5],
+	}, nil
+}
+
+// SignChunk - sign the data of the next chunk, the last chunk should be empty
+//
+// PARAMS:
+//     - data: the data of the chunk
+// RETURNS:
+//     - string: the signature of the chunk
+func (s *ChunkSigner) SignChunk(data []byte) string {
+	hash := sha256.Sum256(data)
+	stringToSign := strings.Join([]string{CHUNK_SIGN_ALGORITHM, s.signDate, s.prevSignature,
+		hex.EncodeToString(hash[:])}, SIGN_JOINER)
+	s.prevSignature = util.HmacSha256Hex(s.signKey, stringToSign)
+	return s.prevSignature
+}
+
+// chunkedReader encodes the stream to the signed chunks:
+//     {hex size};chunk-signature={signature}\r\n{data}\r\n
+// and ends with the signed empty chunk
+type chunkedReader struct {
+	stream io.Reader
+	signer *ChunkSigner
+	chunk  []byte
+	buf    bytes.Buffer
+	done   bool
+}
+
+// NewChunkedReader - create the reader of the signed chunks of the stream, which is read one
+// chunk at a time so that the stream is never buffered entirely
+//
+// PARAMS:
+//     - stream: the body of unknown length
+//     - signer: the signer of the chunks created from the Authorization of the request
+//     - chunkSize: the size of the data of every chunk, DEFAULT_CHUNK_SIZE if not positive
+// RETURNS:
+//     - io.Reader: the reader of the encoded chunks
+func NewChunkedReader(stream io.Reader, signer *ChunkSigner, chunkSize int) io.Reader {
+	if chunkSize <= 0 {
+		chunkSize = DEFAULT_CHUNK_SIZE
+	}
+	return &chunkedReader{stream: stream, signer: signer, chunk: make([]byte, chunkSize)}
+}
+
+func (r *chunkedReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(r.stream, r.chunk)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+		if n > 0 {
+			r.writeChunk(r.chunk[:n])
+		}
+		if err != nil {
+			r.writeChunk(nil)
+			r.done = true
+		}
+	}
+	return r.buf.Read(p)
+}
+
+func (r *chunkedReader) writeChunk(data []byte) {
+	fmt.Fprintf(&r.buf, "%x;chunk-signature=%s\r\n", len(data), r.signer.SignChunk(data))
+	r.buf.Write(data)
+	r.buf.WriteString("\r\n")
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// dual.go - provide the primary and secondary credentials for the access key rotation
+
+package auth
+
+import (
+	"errors"
+	"sync"
+)
+
+// FailoverCredentialsProvider is the CredentialsProvider with the backup credentials. When the
+// request is rejected by the service for the invalid signature or access key, the client calls
+// Failover with the rejected credentials and resends the request once with the returned ones.
+type FailoverCredentialsProvider interface {
+	CredentialsProvider
+
+	// Failover switches from the rejected credentials, returns the credentials to resend the
+	// request with and false if there is no other credentials to try
+	Failover(rejected *BceCredentials, cause error) (*BceCredentials, bool)
+}
+
+// CredentialsSwitchFunc is called after the DualCredentialsProvider switches the active
+// credentials, with the credentials switched from and to and the error of the rejected request.
+type CredentialsSwitchFunc func(from, to *BceCredentials, cause error)
+
+// DualCredentialsProvider holds the primary and secondary access key pairs to rotate the access
+// key without downtime: create the new key as the secondary one before disabling the old primary
+// one, the requests rejected with the primary key are resent with the secondary key which is used
+// by the following requests, then set the new key as the primary one by SetCredentials.
+type DualCredentialsProvider struct {
+	mu        sync.RWMutex
+	primary   *BceCredentials
+	secondary *BceCredentials
+	active    *BceCredentials
+	onSwitch  CredentialsSwitchFunc
+}
+
+// NewDualCredentialsProvider - create the provider of the primary and secondary credentials
+//
+// PARAMS:
+//     - primary: the credentials used by default
+//     - secondary: the credentials used if the active one is rejected, may be nil
+//     - onSwitch: the optional callback after switching the active credentials
+// RETURNS:
+//     - *DualCredentialsProvider: the provider using the primary credentials
+//     - error: nil if ok otherwise the error of the nil primary credentials
+func NewDualCredentialsProvider(primary, secondary *BceCredentials,
+	onSwitch CredentialsSwitchFunc) (*DualCredentialsProvider, error) {
+	if primary == nil {
+		return nil, errors.New("primary credentials should not be nil")
+	}
+	return &DualCredentialsProvider{
+		primary:   primary,
+		secondary: secondary,
+		active:    primary,
+		onSwitch:  onSwitch,
+	}, nil
+}
+
+func (p *DualCredentialsProvider) GetCredentials() (*BceCredentials, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.active, nil
+}
+
+func (p *DualCredentialsProvider) Failover(rejected *BceCredentials,
+	cause error) (*BceCredentials, bool) {
+	p.mu.Lock()
+	if !sameKey(rejected, p.active) {
+		// switched by another request rejected concurrently, resend with the active one
+		active := p.active
+		p.mu.Unlock()
+		return active, active != nil
+	}
+	next := p.secondary
+	if sameKey(p.active, p.secondary) {
+		next = p.primary
+	}
+	if next == nil || sameKey(next, p.active) {
+		p.mu.Unlock()
+		return nil, false
+	}
+	from := p.active
+	p.active = next
+	onSwitch := p.onSwitch
+	p.mu.Unlock()
+
+	if onSwitch != nil {
+		onSwitch(from, next, cause)
+	}
+	return next, true
+}
+
+// Active - get the credentials used currently
+func (p *DualCredentialsProvider) Active() *BceCredentials {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.active
+}
+
+// SetCredentials - replace the credentials after the rotation and use the primary one
+//
+// PARAMS:
+//     - primary: the credentials used by default
+//     - secondary: the credentials used if the primary one is rejected, may be nil
+// RETURNS:
+//     - error: nil if ok otherwise the error of the nil primary credentials
+func (p *DualCredentialsProvider) SetCredentials(primary, secondary *BceCredentials) error {
+	if primary == nil {
+		return errors.New("primary credentials should not be nil")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.primary, p.secondary, p.active = primary, secondary, primary
+	return nil
+}
+
+func sameKey(a, b *BceCredentials) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.AccessKeyId == b.AccessKeyId && a.SecretAccessKey == b.SecretAccessKey &&
+		a.SessionToken == b.SessionToken
+}
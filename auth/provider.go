@@ -0,0 +1,42 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// provider.go - define the interface to provide the credentials dynamically
+
+package auth
+
+// CredentialsProvider provides the credentials to sign every request, which is used instead of
+// the static credentials of the client configuration to support the refreshable credentials such
+// as the STS session credentials. The implementation must be safe for concurrent use.
+type CredentialsProvider interface {
+	GetCredentials() (*BceCredentials, error)
+}
+
+type staticCredentialsProvider struct {
+	credentials *BceCredentials
+}
+
+func (p *staticCredentialsProvider) GetCredentials() (*BceCredentials, error) {
+	return p.credentials, nil
+}
+
+// NewStaticCredentialsProvider - create the provider which always returns the given credentials
+//
+// PARAMS:
+//     - credentials: the credentials to be provided
+// RETURNS:
+//     - CredentialsProvider: the static credentials provider
+func NewStaticCredentialsProvider(credentials *BceCredentials) CredentialsProvider {
+	return &staticCredentialsProvider{credentials}
+}
@@ -17,9 +17,16 @@
 package auth
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/baidubce/bce-sdk-go/http"
 	"github.com/baidubce/bce-sdk-go/util"
@@ -37,8 +44,69 @@ var (
 		strings.ToLower(http.CONTENT_TYPE):   {},
 		strings.ToLower(http.CONTENT_MD5):    {},
 	}
+
+	// MAX_CACHED_SIGNING_KEYS is the max number of the access keys whose signing key is cached,
+	// the cache is cleared when it is full, eg: too many temporary access keys of STS
+	MAX_CACHED_SIGNING_KEYS = 1024
+
+	lowerAuthorization = strings.ToLower(http.AUTHORIZATION)
 )
 
+// signingKey caches the signing key derived from the secret access key and the sign key info,
+// which changes only when the sign date changes, so the requests signed in the same second share
+// one derivation. The keyed HMAC hashers are pooled to skip hashing the key pads for every sign.
+type signingKey struct {
+	secretAccessKey string
+	signKeyInfo     string
+	key             string
+	hashers         sync.Pool
+}
+
+func newSigningKey(secretAccessKey, signKeyInfo string) *signingKey {
+	k := &signingKey{
+		secretAccessKey: secretAccessKey,
+		signKeyInfo:     signKeyInfo,
+		key:             util.HmacSha256Hex(secretAccessKey, signKeyInfo),
+	}
+	k.hashers.New = func() interface{} { return hmac.New(sha256.New, []byte(k.key)) }
+	return k
+}
+
+// signature - compute the hex HMAC-SHA256 of the data with the signing key
+func (k *signingKey) signature(data []byte) string {
+	h := k.hashers.Get().(hash.Hash)
+	h.Reset()
+	h.Write(data)
+	var sum [sha256.Size]byte
+	signature := hex.EncodeToString(h.Sum(sum[:0]))
+	k.hashers.Put(h)
+	return signature
+}
+
+var signingKeys = struct {
+	sync.Mutex
+	keys map[string]*signingKey
+}{keys: make(map[string]*signingKey)}
+
+// getSigningKey - get the cached signing key of the access key or derive a new one if the secret
+// access key or the sign key info changes
+func getSigningKey(accessKeyId, secretAccessKey, signKeyInfo string) *signingKey {
+	signingKeys.Lock()
+	defer signingKeys.Unlock()
+	if k, ok := signingKeys.keys[accessKeyId]; ok &&
+		k.secretAccessKey == secretAccessKey && k.signKeyInfo == signKeyInfo {
+		return k
+	}
+	if len(signingKeys.keys) >= MAX_CACHED_SIGNING_KEYS {
+		signingKeys.keys = make(map[string]*signingKey)
+	}
+	k := newSigningKey(secretAccessKey, signKeyInfo)
+	signingKeys.keys[accessKeyId] = k
+	return k
+}
+
+var canonicalBufferPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
 // Signer abstracts the entity that implements the `Sign` method
 type Signer interface {
 	// Sign the given Request with the Credentials and SignOptions
@@ -94,12 +162,9 @@ func (b *BceV1Signer) Sign(req *http.Request, cred *BceCredentials, opt *SignOpt
 	}
 
 	// Prepare the canonical request components
-	signKeyInfo := fmt.Sprintf("%s/%s/%s/%d",
-		BCE_AUTH_VERSION,
-		accessKeyId,
-		signDate,
-		opt.ExpireSeconds)
-	signKey := util.HmacSha256Hex(secretAccessKey, signKeyInfo)
+	signKeyInfo := BCE_AUTH_VERSION + "/" + accessKeyId + "/" + signDate + "/" +
+		strconv.Itoa(opt.ExpireSeconds)
+	signKey := getSigningKey(accessKeyId, secretAccessKey, signKeyInfo)
 	canonicalUri := getCanonicalURIPath(req.Uri())
 	canonicalQueryString := getCanonicalQueryString(req.Params())
 	canonicalHeaders, signedHeadersArr := getCanonicalHeaders(req.Headers(), opt.HeadersToSign)
@@ -112,14 +177,22 @@ func (b *BceV1Signer) Sign(req *http.Request, cred *BceCredentials, opt *SignOpt
 	}
 
 	// Generate signature
-	canonicalParts := []string{req.Method(), canonicalUri, canonicalQueryString, canonicalHeaders}
-	canonicalReq := strings.Join(canonicalParts, SIGN_JOINER)
-	log.Debug("CanonicalRequest data:\n" + canonicalReq)
-	signature := util.HmacSha256Hex(signKey, canonicalReq)
+	canonicalReq := canonicalBufferPool.Get().(*bytes.Buffer)
+	canonicalReq.Reset()
+	canonicalReq.WriteString(req.Method())
+	canonicalReq.WriteString(SIGN_JOINER)
+	canonicalReq.WriteString(canonicalUri)
+	canonicalReq.WriteString(SIGN_JOINER)
+	canonicalReq.WriteString(canonicalQueryString)
+	canonicalReq.WriteString(SIGN_JOINER)
+	canonicalReq.WriteString(canonicalHeaders)
+	log.Debugf("CanonicalRequest data:\n%s", canonicalReq)
+	signature := signKey.signature(canonicalReq.Bytes())
+	canonicalBufferPool.Put(canonicalReq)
 
 	// Generate auth string and add to the reqeust header
 	authStr := signKeyInfo + "/" + signedHeaders + "/" + signature
-	log.Info("Authorization=" + authStr)
+	log.Infof("Authorization=%s", authStr)
 
 	req.SetHeader(http.AUTHORIZATION, authStr)
 }
@@ -143,16 +216,10 @@ func getCanonicalQueryString(params map[string]string) string {
 
 	result := make([]string, 0, len(params))
 	for k, v := range params {
-		if strings.ToLower(k) == strings.ToLower(http.AUTHORIZATION) {
+		if strings.ToLower(k) == lowerAuthorization {
 			continue
 		}
-		item := ""
-		if len(v) == 0 {
-			item = fmt.Sprintf("%s=", util.UriEncode(k, true))
-		} else {
-			item = fmt.Sprintf("%s=%s", util.UriEncode(k, true), util.UriEncode(v, true))
-		}
-		result = append(result, item)
+		result = append(result, util.UriEncode(k, true)+"="+util.UriEncode(v, true))
 	}
 	sort.Strings(result)
 	return strings.Join(result, "&")
@@ -164,7 +231,7 @@ func getCanonicalHeaders(headers map[string]string,
 	signHeaders := make([]string, 0, len(headersToSign))
 	for k, v := range headers {
 		headKey := strings.ToLower(k)
-		if headKey == strings.ToLower(http.AUTHORIZATION) {
+		if headKey == lowerAuthorization {
 			continue
 		}
 		_, headExists := headersToSign[headKey]
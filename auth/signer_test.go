@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+const testTimestamp = 1650000000
+
+func newTestPutRequest() *http.Request {
+	req := &http.Request{}
+	req.SetMethod(http.PUT)
+	req.SetHost("bj.bcebos.com")
+	req.SetUri("/bucket/dir/object name+1.txt")
+	req.SetParams(map[string]string{"partNumber": "1", "uploadId": "a/b c", "acl": ""})
+	req.SetHeaders(map[string]string{
+		http.HOST:               "bj.bcebos.com",
+		http.CONTENT_LENGTH:     "1024",
+		http.CONTENT_TYPE:       "text/plain; charset=utf-8",
+		http.CONTENT_MD5:        "1B2M2Y8AsgTpgAmY7PhCfg==",
+		http.BCE_DATE:           "2022-04-15T05:20:00Z",
+		"x-bce-meta-Key":        "  value with space  ",
+		http.BCE_REQUEST_ID:     "request-id",
+		"X-Not-Signed":          "ignored",
+		http.BCE_CONTENT_SHA256: "e3b0c44298fc1c149afbf4c8996fb924",
+	})
+	return req
+}
+
+func TestBceV1SignerSign(t *testing.T) {
+	cred, _ := NewBceCredentials("ak", "sk")
+	opt := &SignOptions{
+		HeadersToSign: DEFAULT_HEADERS_TO_SIGN,
+		Timestamp:     testTimestamp,
+		ExpireSeconds: DEFAULT_EXPIRE_SECONDS,
+	}
+	signer := &BceV1Signer{}
+	for i := 0; i < 3; i++ { // the later signs hit the cached signing key
+		req := newTestPutRequest()
+		signer.Sign(req, cred, opt)
+		if got := req.Header(http.AUTHORIZATION); got != expectedTestAuthorization {
+			t.Fatalf("unexpected authorization:\n%s\nexpected:\n%s", got, expectedTestAuthorization)
+		}
+	}
+
+	other, _ := NewBceCredentials("ak", "another-sk")
+	req := newTestPutRequest()
+	signer.Sign(req, other, opt)
+	if req.Header(http.AUTHORIZATION) == expectedTestAuthorization {
+		t.Fatal("the signing key of another secret should not be reused")
+	}
+}
+
+func TestDualCredentialsProvider(t *testing.T) {
+	primary, _ := NewBceCredentials("ak1", "sk1")
+	secondary, _ := NewBceCredentials("ak2", "sk2")
+	switches := 0
+	p, err := NewDualCredentialsProvider(primary, secondary, func(from, to *BceCredentials, cause error) {
+		switches++
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred, _ := p.GetCredentials(); cred != primary {
+		t.Fatalf("should use the primary credentials but %v", cred)
+	}
+	if cred, ok := p.Failover(primary, nil); !ok || cred != secondary {
+		t.Fatalf("should fail over to the secondary credentials but %v", cred)
+	}
+	// the request rejected concurrently with the primary one resends with the active one
+	if cred, ok := p.Failover(primary, nil); !ok || cred != secondary || switches != 1 {
+		t.Fatalf("should not switch again but %v, %d switches", cred, switches)
+	}
+	if cred, ok := p.Failover(secondary, nil); !ok || cred != primary || switches != 2 {
+		t.Fatalf("should switch back to the primary credentials but %v", cred)
+	}
+
+	p.SetCredentials(secondary, nil)
+	if cred, ok := p.Failover(secondary, nil); ok {
+		t.Fatalf("should not fail over without the secondary credentials but %v", cred)
+	}
+	if _, err := NewDualCredentialsProvider(nil, secondary, nil); err == nil {
+		t.Fatal("the primary credentials should be required")
+	}
+}
+
+// newTestChunkedRequest - the chunked request signed with the seed signature, the vectors of the
+// seed and chunk signatures below are computed independently by the HMAC-SHA256 chain:
+//     signingKey = HMAC(sk, "bce-auth-v1/{ak}/{date}/{expire}")
+//     seed = HMAC(signingKey, canonicalRequest)
+//     chunkSignature = HMAC(signingKey, "BCE-HMAC-SHA256-PAYLOAD\n{date}\n{prev}\n{sha256(data)}")
+func newTestChunkedRequest() *http.Request {
+	req := &http.Request{}
+	req.SetMethod(http.PUT)
+	req.SetHost("bj.bcebos.com")
+	req.SetUri("/bucket/chunked")
+	req.SetHeaders(map[string]string{
+		http.HOST:           "bj.bcebos.com",
+		http.CONTENT_LENGTH: "11",
+		http.CONTENT_MD5:    "XrY7u+Ae7tCTyyK7j1rNww==",
+		http.BCE_DATE:       "2022-04-15T05:20:00Z",
+	})
+	SetChunkedHeaders(req)
+	cred, _ := NewBceCredentials("ak", "sk")
+	signer := &BceV1Signer{}
+	signer.Sign(req, cred, &SignOptions{
+		HeadersToSign: DEFAULT_HEADERS_TO_SIGN,
+		Timestamp:     testTimestamp,
+		ExpireSeconds: DEFAULT_EXPIRE_SECONDS,
+	})
+	return req
+}
+
+const (
+	testSeedSignature = "4195688ca892a54049b4832cfbde6b84a75148f8e3b2f22cb671c9c697dfce00"
+	testChunk1Sig     = "e93562b70198f2d10bac9b4a4a06a1c3457f420d3ab0a46351fa29e96b49295f"
+	testChunk2Sig     = "f245c85e644380ea5b26667913249df47ab6fe826c6f793c8fa3f285d58ed995"
+	testChunk3Sig     = "3910c39333f8860c436f0bc8e68bcfd9b38cc2829eb6dd20495110b4a221db68"
+	testLastChunkSig  = "9197daa6bf04bc5daecf3c07d9fbd833979fe3f1c0cb66c767c4b6917fcb02f4"
+)
+
+func TestSetChunkedHeaders(t *testing.T) {
+	req := newTestChunkedRequest()
+	if _, ok := req.Headers()[http.CONTENT_LENGTH]; ok {
+		t.Error("the Content-Length should be removed")
+	}
+	if _, ok := req.Headers()[http.CONTENT_MD5]; ok {
+		t.Error("the Content-MD5 should be removed")
+	}
+	if got := req.Header(http.BCE_CONTENT_SHA256); got != STREAMING_PAYLOAD {
+		t.Errorf("unexpected x-bce-content-sha256: %s", got)
+	}
+	if got := req.Header(http.CONTENT_ENCODING); got != CHUNKED_CONTENT_ENCODING {
+		t.Errorf("unexpected Content-Encoding: %s", got)
+	}
+	if req.Length() != -1 {
+		t.Errorf("the length should be unknown but %d", req.Length())
+	}
+	expected := "bce-auth-v1/ak/2022-04-15T05:20:00Z/1800/host;x-bce-content-sha256;x-bce-date/" +
+		testSeedSignature
+	if got := req.Header(http.AUTHORIZATION); got != expected {
+		t.Errorf("unexpected seed authorization:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestChunkSigner(t *testing.T) {
+	req := newTestChunkedRequest()
+	signer, err := NewChunkSigner(req.Header(http.AUTHORIZATION), "sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every signature is chained with the previous one, starting from the seed signature
+	for i, c := range []struct {
+		data     string
+		expected string
+	}{
+		{"hell", testChunk1Sig},
+		{"o wo", testChunk2Sig},
+		{"rld", testChunk3Sig},
+		{"", testLastChunkSig},
+	} {
+		if got := signer.SignChunk([]byte(c.data)); got != c.expected {
+			t.Errorf("unexpected signature of chunk %d: %s, expected %s", i, got, c.expected)
+		}
+	}
+
+	for _, auth := range []string{"", "bce-auth-v2/ak/date/1800/host/sig", "bce-auth-v1/ak/sig"} {
+		if _, err := NewChunkSigner(auth, "sk"); err == nil {
+			t.Errorf("the invalid authorization %q should be rejected", auth)
+		}
+	}
+}
+
+func TestChunkedReader(t *testing.T) {
+	req := newTestChunkedRequest()
+	signer, _ := NewChunkSigner(req.Header(http.AUTHORIZATION), "sk")
+	body, err := ioutil.ReadAll(NewChunkedReader(strings.NewReader("hello world"), signer, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "4;chunk-signature=" + testChunk1Sig + "\r\nhell\r\n" +
+		"4;chunk-signature=" + testChunk2Sig + "\r\no wo\r\n" +
+		"3;chunk-signature=" + testChunk3Sig + "\r\nrld\r\n" +
+		"0;chunk-signature=" + testLastChunkSig + "\r\n\r\n"
+	if string(body) != expected {
+		t.Errorf("unexpected chunks:\n%q\nexpected:\n%q", body, expected)
+	}
+
+	// the stream of the multiple of the chunk size ends with only one empty chunk
+	signer, _ = NewChunkSigner(req.Header(http.AUTHORIZATION), "sk")
+	body, _ = ioutil.ReadAll(NewChunkedReader(strings.NewReader("hell"), signer, 4))
+	if chunks := strings.Count(string(body), "chunk-signature="); chunks != 2 ||
+		!strings.HasPrefix(string(body), "4;chunk-signature="+testChunk1Sig+"\r\nhell\r\n0;") {
+		t.Errorf("unexpected chunks: %q", body)
+	}
+	// the empty stream is only the signed empty chunk
+	signer, _ = NewChunkSigner(req.Header(http.AUTHORIZATION), "sk")
+	body, _ = ioutil.ReadAll(NewChunkedReader(strings.NewReader(""), signer, 4))
+	if !strings.HasPrefix(string(body), "0;chunk-signature=") ||
+		!strings.HasSuffix(string(body), "\r\n\r\n") {
+		t.Errorf("unexpected chunks of the empty stream: %q", body)
+	}
+}
+
+func BenchmarkBceV1SignerSign(b *testing.B) {
+	cred, _ := NewBceCredentials("ak", "sk")
+	opt := &SignOptions{
+		HeadersToSign: DEFAULT_HEADERS_TO_SIGN,
+		Timestamp:     testTimestamp,
+		ExpireSeconds: DEFAULT_EXPIRE_SECONDS,
+	}
+	signer := &BceV1Signer{}
+	req := newTestPutRequest()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		signer.Sign(req, cred, opt)
+	}
+}
+
+var expectedTestAuthorization = "bce-auth-v1/ak/2022-04-15T05:20:00Z/1800/" +
+	"content-length;content-md5;content-type;host;x-bce-content-sha256;x-bce-date;x-bce-meta-key/" +
+	"5756b622d798febdef7002985b102dede4521e5e248c43d69276107aad1b85f9"
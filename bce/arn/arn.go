@@ -0,0 +1,158 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// arn.go - define the parsing and building of the BCE resource names
+
+// Package arn implements the BCE resource name which identifies a resource across the services,
+// such as the resources of the IAM policies and the tagging apis, with the format:
+//
+//     brn:bce:<service>:<region>:<account-id>:<resource>
+//
+// eg: "brn:bce:bos:bj:0123456789abcdef:bucket/object" and "brn:bce:bcc:bj:*:instance/i-xxx". The
+// region and account id are empty for the global resources and can be "*" to match any one.
+package arn
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	PREFIX    = "brn"
+	PARTITION = "bce"
+	WILDCARD  = "*"
+
+	separator = ":"
+	sections  = 6
+)
+
+var (
+	servicePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	regionPattern  = regexp.MustCompile(`^([a-z][a-z0-9-]*|\*)?$`)
+	accountPattern = regexp.MustCompile(`^([0-9a-zA-Z]+|\*)?$`)
+)
+
+// ARN defines the parts of a BCE resource name
+type ARN struct {
+	Partition string
+	Service   string
+	Region    string
+	AccountId string
+	Resource  string
+}
+
+// New - build the resource name in the default partition
+//
+// PARAMS:
+//     - service: the service name, eg: bos, bcc
+//     - region: the region, empty for the global resources
+//     - accountId: the account id of the resource owner
+//     - resource: the resource path, eg: "bucket/object" or "instance/i-xxx"
+// RETURNS:
+//     - *ARN: the resource name
+//     - error: nil if ok otherwise the invalid part
+func New(service, region, accountId, resource string) (*ARN, error) {
+	a := &ARN{
+		Partition: PARTITION,
+		Service:   service,
+		Region:    region,
+		AccountId: accountId,
+		Resource:  resource,
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Parse - parse the resource name string
+//
+// PARAMS:
+//     - s: the resource name string, eg: "brn:bce:bos:bj:0123456789abcdef:bucket/object"
+// RETURNS:
+//     - *ARN: the parsed resource name
+//     - error: nil if ok otherwise the parsing error
+func Parse(s string) (*ARN, error) {
+	// the resource may contain the separator, so it is the rest of the string
+	parts := strings.SplitN(s, separator, sections)
+	if len(parts) != sections || parts[0] != PREFIX {
+		return nil, fmt.Errorf("invalid resource name %q: should be in the format of "+
+			"brn:partition:service:region:account-id:resource", s)
+	}
+	a := &ARN{
+		Partition: parts[1],
+		Service:   parts[2],
+		Region:    parts[3],
+		AccountId: parts[4],
+		Resource:  parts[5],
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// IsARN - check whether the string is a valid resource name
+func IsARN(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+// Validate - check the parts of the resource name
+//
+// RETURNS:
+//     - error: nil if valid otherwise the first invalid part
+func (a *ARN) Validate() error {
+	if a.Partition == "" {
+		return fmt.Errorf("invalid resource name: the partition is empty")
+	}
+	if !servicePattern.MatchString(a.Service) {
+		return fmt.Errorf("invalid service of resource name: %q", a.Service)
+	}
+	if !regionPattern.MatchString(a.Region) {
+		return fmt.Errorf("invalid region of resource name: %q", a.Region)
+	}
+	if !accountPattern.MatchString(a.AccountId) {
+		return fmt.Errorf("invalid account id of resource name: %q", a.AccountId)
+	}
+	if a.Resource == "" {
+		return fmt.Errorf("invalid resource name: the resource is empty")
+	}
+	return nil
+}
+
+// String - format the resource name
+func (a *ARN) String() string {
+	return strings.Join([]string{PREFIX, a.Partition, a.Service, a.Region, a.AccountId,
+		a.Resource}, separator)
+}
+
+// ResourceType - get the type of the resource, which is the part before the first "/" or ":",
+// eg: "instance" of "instance/i-xxx", empty if the resource contains neither of them. Note that
+// the resource of some services is a path without type, such as "bucket/object" of the bos.
+func (a *ARN) ResourceType() string {
+	if i := strings.IndexAny(a.Resource, "/:"); i > 0 {
+		return a.Resource[:i]
+	}
+	return ""
+}
+
+// ResourceId - get the id of the resource, which is the part after the resource type
+func (a *ARN) ResourceId() string {
+	if i := strings.IndexAny(a.Resource, "/:"); i > 0 {
+		return a.Resource[i+1:]
+	}
+	return a.Resource
+}
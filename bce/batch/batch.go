@@ -0,0 +1,175 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// batch.go - execute a list of independent requests concurrently and aggregate their results
+
+// Package batch executes a list of independent requests, such as deleting many documents or
+// copying many objects, with a bounded number of concurrent workers. Every request is retried by
+// the optional retry policy on its own, and the results are returned in the order of the requests:
+//
+//     results := batch.Execute(ctx, funcs, &batch.Options{Parallel: 5})
+//     for i, r := range results {
+//         if r.Err != nil {...}
+//     }
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+)
+
+// DEFAULT_PARALLEL is the max number of the concurrent requests if not set by the Options
+const DEFAULT_PARALLEL = 10
+
+// Func defines a single request of the batch, the value returned is kept in the Result
+type Func func(ctx context.Context) (interface{}, error)
+
+// Options defines the optional settings of the Execute
+type Options struct {
+	// Parallel is the max number of the concurrent requests, DEFAULT_PARALLEL if not positive
+	Parallel int
+
+	// Retry decides whether to retry a failed request and the delay before the next attempt,
+	// the failed requests are not retried if it is nil
+	Retry bce.RetryPolicy
+
+	// FailFast cancels the requests not finished yet after the first failure
+	FailFast bool
+}
+
+// Result defines the result of a single request of the batch
+type Result struct {
+	Value    interface{}
+	Err      error
+	Attempts int
+}
+
+// Results defines the results of all the requests in the order of the requests
+type Results []Result
+
+// Failed - get the indexes of the failed requests
+func (rs Results) Failed() []int {
+	var failed []int
+	for i, r := range rs {
+		if r.Err != nil {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
+
+// Err - get the aggregated error of the failed requests
+//
+// RETURNS:
+//     - error: nil if all the requests succeeded otherwise a *Error
+func (rs Results) Err() error {
+	failed := rs.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	errs := make(map[int]error, len(failed))
+	for _, i := range failed {
+		errs[i] = rs[i].Err
+	}
+	return &Error{Total: len(rs), First: failed[0], Errors: errs}
+}
+
+// Error is the aggregated error of the batch which holds the error of every failed request by
+// the index of the request.
+type Error struct {
+	Total  int
+	First  int
+	Errors map[int]error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d of %d requests failed, the first failure at %d: %v",
+		len(e.Errors), e.Total, e.First, e.Errors[e.First])
+}
+
+// Execute - execute the requests with at most Options.Parallel concurrent workers, the failure
+// of a request does not stop the others unless Options.FailFast is set. The requests not started
+// when the context is done fail with the error of the context.
+//
+// PARAMS:
+//     - ctx: the context to cancel the batch or set the deadline
+//     - funcs: the requests to be executed
+//     - opts: the optional settings, nil to use the default ones
+// RETURNS:
+//     - Results: the result of every request in the order of the funcs
+func Execute(ctx context.Context, funcs []Func, opts *Options) Results {
+	if opts == nil {
+		opts = &Options{}
+	}
+	parallel := opts.Parallel
+	if parallel <= 0 {
+		parallel = DEFAULT_PARALLEL
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(Results, len(funcs))
+	workerPool := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, fn := range funcs {
+		select {
+		case workerPool <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(index int, fn Func) {
+			defer func() {
+				<-workerPool
+				wg.Done()
+			}()
+			results[index] = run(ctx, fn, opts.Retry)
+			if results[index].Err != nil && opts.FailFast {
+				cancel()
+			}
+		}(i, fn)
+	}
+	wg.Wait()
+	return results
+}
+
+// run - call the request until it succeeds or the retry policy gives up
+func run(ctx context.Context, fn Func, retry bce.RetryPolicy) Result {
+	var result Result
+	for retries := 0; ; retries++ {
+		if err := ctx.Err(); err != nil {
+			if result.Err == nil {
+				result.Err = err
+			}
+			return result
+		}
+		result.Value, result.Err = fn(ctx)
+		result.Attempts++
+		if result.Err == nil || retry == nil || !retry.ShouldRetry(result.Err, retries) {
+			return result
+		}
+		timer := time.NewTimer(retry.GetDelayBeforeNextRetryInMillis(result.Err, retries))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result
+		case <-timer.C:
+		}
+	}
+}
@@ -17,7 +17,6 @@
 package bce
 
 import (
-	"encoding/json"
 	"fmt"
 )
 
@@ -32,6 +31,7 @@ type RequestBuilder struct {
 	queryParams map[string]string // optional
 	headers     map[string]string // optional
 	body        interface{}       // optional
+	xmlBody     bool              // optional, the body is marshaled to xml instead of json
 	result      interface{}       // optional
 }
 
@@ -69,6 +69,11 @@ func (b *RequestBuilder) WithQueryParamFilter(key, value string) *RequestBuilder
 	return b.WithQueryParam(key, value)
 }
 
+// WithClientToken sets the idempotent clientToken query parameter, see the ClientToken function.
+func (b *RequestBuilder) WithClientToken(token string) *RequestBuilder {
+	return b.WithQueryParamFilter("clientToken", ClientToken(b.client, token))
+}
+
 func (b *RequestBuilder) WithQueryParams(params map[string]string) *RequestBuilder {
 	if b.queryParams == nil {
 		b.queryParams = params
@@ -101,9 +106,19 @@ func (b *RequestBuilder) WithHeaders(headers map[string]string) *RequestBuilder
 
 func (b *RequestBuilder) WithBody(body interface{}) *RequestBuilder {
 	b.body = body
+	b.xmlBody = false
+	return b
+}
+
+// WithXmlBody sets the body marshaled to xml by the MarshalXML with the xml Content-Type, which
+// is used by the services accepting the xml request bodies.
+func (b *RequestBuilder) WithXmlBody(body interface{}) *RequestBuilder {
+	b.body = body
+	b.xmlBody = true
 	return b
 }
 
+// WithResult sets the result decoded from the json or xml response body by the ParseBody.
 func (b *RequestBuilder) WithResult(result interface{}) *RequestBuilder {
 	b.result = result
 	return b
@@ -129,6 +144,28 @@ func (b *RequestBuilder) Do() error {
 	return nil
 }
 
+// DoStream will send request to bce and return the response body as a stream without buffering,
+// the returned StreamBody must be closed by the caller.
+func (b *RequestBuilder) DoStream() (*StreamBody, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := b.buildBceRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &BceResponse{}
+	if err := b.client.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	return resp.ParseStreamBody(), nil
+}
+
 // Validate if the required fields are providered.
 func (b *RequestBuilder) validate() error {
 	if len(b.url) == 0 {
@@ -155,8 +192,12 @@ func (b *RequestBuilder) buildBceRequest() (*BceRequest, error) {
 	if b.queryParams != nil {
 		req.SetParams(b.queryParams)
 	}
-	if b.body != nil {
-		bodyBytes, err := json.Marshal(b.body)
+	if b.body != nil && b.xmlBody {
+		if err := req.SetXmlBody(b.body); err != nil {
+			return nil, err
+		}
+	} else if b.body != nil {
+		bodyBytes, err := MarshalJSON(b.body)
 		if err != nil {
 			return nil, err
 		}
@@ -165,6 +206,7 @@ func (b *RequestBuilder) buildBceRequest() (*BceRequest, error) {
 			return nil, err
 		}
 		req.SetBody(body)
+		req.jsonBody = true
 	}
 
 	return req, nil
@@ -185,5 +227,5 @@ func (b *RequestBuilder) buildBceResponse(req *BceRequest) error {
 		return nil
 	}
 
-	return resp.ParseJsonBody(b.result)
+	return resp.ParseBody(b.result)
 }
@@ -0,0 +1,222 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// cache.go - define the ttl based cache of the results of the read-heavy apis
+
+package bce
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DEFAULT_CACHE_TTL is the default ttl of the cached results if the CacheTTL is not set
+const DEFAULT_CACHE_TTL = 30 * time.Second
+
+// ResponseCache is the storage of the cached results. The service clients only cache the results
+// of the idempotent GET apis, the api package functions are never cached which can be used to
+// bypass the cache.
+type ResponseCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(key string)
+}
+
+type memoryCacheEntry struct {
+	value    []byte
+	expireAt time.Time
+}
+
+type memoryCache struct {
+	lock    sync.RWMutex
+	entries map[string]*memoryCacheEntry
+}
+
+// NewMemoryCache - create the in-memory cache of the results
+//
+// RETURNS:
+//     - ResponseCache: the cache safe for concurrent use
+func NewMemoryCache() ResponseCache {
+	return &memoryCache{entries: make(map[string]*memoryCacheEntry)}
+}
+
+func (m *memoryCache) Get(key string) ([]byte, bool) {
+	m.lock.RLock()
+	entry, ok := m.entries[key]
+	m.lock.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expireAt) {
+		m.Delete(key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (m *memoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.lock.Lock()
+	m.entries[key] = &memoryCacheEntry{value, time.Now().Add(ttl)}
+	m.lock.Unlock()
+}
+
+func (m *memoryCache) Delete(key string) {
+	m.lock.Lock()
+	delete(m.entries, key)
+	m.lock.Unlock()
+}
+
+type diskCache struct {
+	dir string
+}
+
+// NewDiskCache - create the on-disk cache of the results, which can be shared by processes
+//
+// PARAMS:
+//     - dir: the directory to store the cache files, created if not exists
+// RETURNS:
+//     - ResponseCache: the cache stores each entry in a file
+//     - error: nil if ok otherwise the specific error
+func NewDiskCache(dir string) (ResponseCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &diskCache{dir}, nil
+}
+
+func (d *diskCache) path(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
+
+func (d *diskCache) Get(key string) ([]byte, bool) {
+	data, err := ioutil.ReadFile(d.path(key))
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+	expireAt := time.Unix(0, int64(binary.BigEndian.Uint64(data[:8])))
+	if time.Now().After(expireAt) {
+		d.Delete(key)
+		return nil, false
+	}
+	return data[8:], true
+}
+
+func (d *diskCache) Set(key string, value []byte, ttl time.Duration) {
+	data := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(data[:8], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(data[8:], value)
+	// Write to the temporary file and rename it to avoid reading the partial file
+	tmp, err := ioutil.TempFile(d.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || os.Rename(tmp.Name(), d.path(key)) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+func (d *diskCache) Delete(key string) {
+	os.Remove(d.path(key))
+}
+
+// GetCachedResult - get the cached result of the given key if the cache is configured
+//
+// PARAMS:
+//     - cli: the client which may be configured with the cache
+//     - key: the cache key of the result
+//     - result: the pointer to receive the cached result
+// RETURNS:
+//     - bool: true if the result is found in the cache
+func GetCachedResult(cli Client, key string, result interface{}) bool {
+	cache, key, _ := clientCache(cli, key)
+	if cache == nil {
+		return false
+	}
+	data, ok := cache.Get(key)
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(data, result) == nil
+}
+
+// SetCachedResult - cache the result of the given key if the cache is configured
+//
+// PARAMS:
+//     - cli: the client which may be configured with the cache
+//     - key: the cache key of the result
+//     - result: the result to be cached
+func SetCachedResult(cli Client, key string, result interface{}) {
+	cache, key, ttl := clientCache(cli, key)
+	if cache == nil {
+		return
+	}
+	if data, err := json.Marshal(result); err == nil {
+		cache.Set(key, data, ttl)
+	}
+}
+
+// InvalidateCachedResult - remove the cached result of the given key, which is called when the
+// resource is changed by the client
+//
+// PARAMS:
+//     - cli: the client which may be configured with the cache
+//     - key: the cache key of the result
+func InvalidateCachedResult(cli Client, key string) {
+	if cache, key, _ := clientCache(cli, key); cache != nil {
+		cache.Delete(key)
+	}
+}
+
+// clientCache - get the cache of the client and the full cache key, the endpoint and the access
+// key of the client are included in the key so that the cache can be shared by the clients. The
+// access key is resolved from the CredentialsProvider if it is set, like signing the requests, so
+// that the clients of the different identities never share the results, and the cache is not
+// used if the provider fails.
+func clientCache(cli Client, key string) (ResponseCache, string, time.Duration) {
+	if cli == nil {
+		return nil, key, 0
+	}
+	conf := cli.GetBceClientConfig()
+	if conf == nil || conf.Cache == nil {
+		return nil, key, 0
+	}
+	credentials := conf.Credentials
+	if conf.CredentialsProvider != nil {
+		var err error
+		if credentials, err = conf.CredentialsProvider.GetCredentials(); err != nil {
+			return nil, key, 0
+		}
+	}
+	prefix := conf.Endpoint + "|"
+	if credentials != nil {
+		prefix += credentials.AccessKeyId + "|"
+	}
+	ttl := conf.CacheTTL
+	if ttl <= 0 {
+		ttl = DEFAULT_CACHE_TTL
+	}
+	return conf.Cache, prefix + key, ttl
+}
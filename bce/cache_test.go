@@ -0,0 +1,50 @@
+package bce
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/auth"
+)
+
+type failedCredentialsProvider struct{}
+
+func (failedCredentialsProvider) GetCredentials() (*auth.BceCredentials, error) {
+	return nil, errors.New("no credentials")
+}
+
+func TestCachedResultIdentity(t *testing.T) {
+	client, err := NewBceClientWithAkSk("ak", "sk", "bj.bcebos.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Config.Cache = NewMemoryCache()
+	withProvider := func(ak string) *BceClient {
+		credentials, _ := auth.NewBceCredentials(ak, "sk")
+		return client.WithOptions(WithCredentialsProvider(
+			auth.NewStaticCredentialsProvider(credentials)))
+	}
+	alice, bob := withProvider("alice"), withProvider("bob")
+
+	SetCachedResult(alice, "key", "alice's")
+	var result string
+	if !GetCachedResult(alice, "key", &result) || result != "alice's" {
+		t.Errorf("alice: got %q", result)
+	}
+	if GetCachedResult(bob, "key", &result) || GetCachedResult(client, "key", &result) {
+		t.Errorf("the result of alice is shared")
+	}
+	if !GetCachedResult(withProvider("alice"), "key", &result) {
+		t.Errorf("the result is not shared by the clients of the same identity")
+	}
+
+	failed := client.WithOptions(WithCredentialsProvider(failedCredentialsProvider{}))
+	SetCachedResult(failed, "key", "failed")
+	if GetCachedResult(failed, "key", &result) {
+		t.Errorf("the cache is used by the failed provider")
+	}
+	InvalidateCachedResult(alice, "key")
+	if GetCachedResult(alice, "key", &result) {
+		t.Errorf("the result is not invalidated")
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"time"
 
 	"github.com/baidubce/bce-sdk-go/auth"
@@ -68,7 +69,9 @@ type BceClient struct {
 //
 // PARAMS:
 //     - request: the input request object to be built
-func (c *BceClient) buildHttpRequest(request *BceRequest) {
+// RETURNS:
+//     - error: nil if ok otherwise the error to get the credentials
+func (c *BceClient) buildHttpRequest(request *BceRequest) error {
 	// Construct the http request instance for the special fields
 	request.BuildHttpRequest()
 
@@ -79,21 +82,123 @@ func (c *BceClient) buildHttpRequest(request *BceRequest) {
 	if request.Protocol() == "" {
 		request.SetProtocol(DEFAULT_PROTOCOL)
 	}
-	if len(c.Config.ProxyUrl) != 0 {
-		request.SetProxyUrl(c.Config.ProxyUrl)
+	if proxyUrl := c.Config.proxyUrlFor(request.Host()); len(proxyUrl) != 0 {
+		request.SetProxyUrl(proxyUrl)
 	}
 	request.SetTimeout(c.Config.ConnectionTimeoutInMillis / 1000)
+	if c.Config.Context != nil {
+		request.SetContext(c.Config.Context)
+	}
 
 	// Set the BCE request headers
 	request.SetHeader(http.HOST, request.Host())
-	request.SetHeader(http.CONTENT_TYPE, "application/json;charset=UTF-8")
-	request.SetHeader(http.USER_AGENT, c.Config.UserAgent)
-	request.SetHeader(http.BCE_DATE, util.FormatISO8601Date(util.NowUTCSeconds()))
+	// Keep the Content-Type set by the service, eg: the object content type and the xml body, and
+	// only the requests without it are sent as json
+	if len(request.Header(http.CONTENT_TYPE)) == 0 {
+		request.SetHeader(http.CONTENT_TYPE, "application/json;charset=UTF-8")
+	}
+	if len(request.Header(http.USER_AGENT)) == 0 {
+		request.SetHeader(http.USER_AGENT, c.Config.userAgent())
+	}
+	for key, value := range c.Config.CustomHeaders {
+		if len(request.Header(key)) == 0 {
+			request.SetHeader(key, value)
+		}
+	}
+	if err := compressJsonBody(request, c.Config.RequestCompressionThreshold); err != nil {
+		return err
+	}
 
 	// Generate the auth string if needed
-	if c.Config.Credentials != nil {
-		c.Signer.Sign(&request.Request, c.Config.Credentials, c.Config.SignOption)
+	credentials, err := c.credentials()
+	if err != nil {
+		return err
+	}
+	request.credentials = credentials
+	if credentials != nil && request.isChunked() {
+		auth.SetChunkedHeaders(&request.Request)
+	}
+	c.sign(request)
+	return nil
+}
+
+// signChunkedBody - encode the body of unknown length to the chunks signed by the streaming
+// signature, it should be called after the request is signed for the last time since the
+// signature of the first chunk is chained with the Authorization
+//
+// PARAMS:
+//     - request: the signed request
+// RETURNS:
+//     - error: nil if ok otherwise the error of the invalid authorization
+func (c *BceClient) signChunkedBody(request *BceRequest) error {
+	if !request.isChunked() || request.credentials == nil {
+		return nil
+	}
+	signer, err := auth.NewChunkSigner(request.Header(http.AUTHORIZATION),
+		request.credentials.SecretAccessKey)
+	if err != nil {
+		return NewBceClientError(err.Error())
 	}
+	request.Request.SetBody(struct {
+		io.Reader
+		io.Closer
+	}{auth.NewChunkedReader(request.Body(), signer, auth.DEFAULT_CHUNK_SIZE), request.Body()})
+	return nil
+}
+
+// credentials - get the credentials to sign the request from the provider or the configuration
+//
+// RETURNS:
+//     - *auth.BceCredentials: the credentials, nil if the request should not be signed
+//     - error: nil if ok otherwise the error of the provider
+func (c *BceClient) credentials() (*auth.BceCredentials, error) {
+	if c.Config.CredentialsProvider != nil {
+		credentials, err := c.Config.CredentialsProvider.GetCredentials()
+		if err != nil {
+			return nil, NewBceClientError(fmt.Sprintf("get credentials failed: %v", err))
+		}
+		return credentials, nil
+	}
+	return c.Config.Credentials, nil
+}
+
+// IsSignatureError - check whether the error is returned by the service for the invalid signature
+// or access key, which may be fixed by the other credentials during the access key rotation
+//
+// PARAMS:
+//     - err: the error returned by sending the request
+// RETURNS:
+//     - bool: true if it is a signature error
+func IsSignatureError(err error) bool {
+	if e, ok := err.(*BceServiceError); ok {
+		return e.Code == ESIGNATURE_DOES_NOT_MATCH || e.Code == EINVALID_ACCESS_KEY_ID
+	}
+	return false
+}
+
+// failoverCredentials - switch the credentials of the provider supporting failover if the request
+// is rejected for the invalid signature or access key and sign the request again
+//
+// PARAMS:
+//     - req: the request rejected by the service
+//     - err: the service error of the response
+// RETURNS:
+//     - bool: true if the request is signed again and should be resent
+func (c *BceClient) failoverCredentials(req *BceRequest, err error) bool {
+	provider, ok := c.Config.CredentialsProvider.(auth.FailoverCredentialsProvider)
+	if !ok || !IsSignatureError(err) || req.isChunked() || req.credentials == nil {
+		return false
+	}
+	credentials, ok := provider.Failover(req.credentials, err)
+	if !ok || credentials == nil {
+		return false
+	}
+	log.Warnf("the access key %s is rejected: %v, sign the request again with %s",
+		req.credentials.AccessKeyId, err, credentials.AccessKeyId)
+	delete(req.Headers(), http.BCE_SECURITY_TOKEN)
+	req.credentials = credentials
+	c.sign(req)
+	return true
 }
 
 // SendRequest - the client performs sending the http request with retry policy and receive the
@@ -104,49 +209,94 @@ func (c *BceClient) buildHttpRequest(request *BceRequest) {
 //     - resp: the response object to receive the content from BCE service
 // RETURNS:
 //     - error: nil if ok otherwise the specific error
-func (c *BceClient) SendRequest(req *BceRequest, resp *BceResponse) error {
+func (c *BceClient) SendRequest(req *BceRequest, resp *BceResponse) (err error) {
 	// Return client error if it is not nil
 	if req.ClientError() != nil {
 		return req.ClientError()
 	}
 
 	// Build the http request and prepare to send
-	c.buildHttpRequest(req)
+	if err := c.buildHttpRequest(req); err != nil {
+		return err
+	}
+	span := c.startSpan(req)
+	defer func() { endSpan(span, resp, err) }()
 	log.Infof("send http request: %v", req)
+	if err := c.signChunkedBody(req); err != nil {
+		return err
+	}
 
-	// Send request with the given retry policy
-	retries := 0
 	if req.Body() != nil {
 		defer req.Body().Close() // Manually close the ReadCloser body for retry
 	}
+	if policy := c.Config.HedgePolicy; policy != nil && hedgeable(req) {
+		return c.sendHedgedRequest(policy, req, resp)
+	}
+	return c.sendRequestWithRetry(req, resp)
+}
+
+// sendRequestWithRetry - send the built http request with the retry policy
+//
+// PARAMS:
+//     - req: the built request object to be sent
+//     - resp: the response object to receive the content from BCE service
+// RETURNS:
+//     - error: nil if ok otherwise the specific error
+func (c *BceClient) sendRequestWithRetry(req *BceRequest, resp *BceResponse) error {
+	// Send request with the given retry policy, the chunked body of unknown length is not saved
+	// to retry since it may be too large to be buffered
+	retryable := !req.isChunked()
+	retries, redirects := 0, 0
+	skewCorrected, credentialsSwitched := false, false
+	if req.rewindable() {
+		// The transport closes the body after sending, keep it open to be rewound
+		req.Request.SetBody(ioutil.NopCloser(req.Body()))
+	}
 	for {
-		// The request body should be temporarily saved if retry to send the http request
+		// The request body should be read again from the start or temporarily saved if retry to
+		// send the http request
+		rewindable := req.rewindable()
 		var retryBuf bytes.Buffer
 		var teeReader io.Reader
-		if c.Config.Retry.ShouldRetry(nil, 0) && req.Body() != nil {
+		if retryable && !rewindable && c.Config.Retry.ShouldRetry(nil, 0) && req.Body() != nil {
 			teeReader = io.TeeReader(req.Body(), &retryBuf)
 			req.Request.SetBody(ioutil.NopCloser(teeReader))
 		}
-		httpResp, err := http.Execute(&req.Request)
+		replayable := req.Body() == nil || rewindable || teeReader != nil
+		resetBody := func() error {
+			if req.Body() == nil {
+				return nil
+			}
+			if rewindable {
+				return req.rewindBody()
+			}
+			ioutil.ReadAll(teeReader)
+			req.Request.SetBody(ioutil.NopCloser(&retryBuf))
+			return nil
+		}
+		httpResp, err := http.ExecuteWithClient(c.Config.httpClient(), &req.Request)
 
 		if err != nil {
-			if c.Config.Retry.ShouldRetry(err, retries) {
+			if retryable && replayable && c.shouldRetry(err, retries) {
 				delay_in_mills := c.Config.Retry.GetDelayBeforeNextRetryInMillis(err, retries)
-				time.Sleep(delay_in_mills)
+				if err := c.sleepBeforeRetry(delay_in_mills); err != nil {
+					return err
+				}
 			} else {
 				return &BceClientError{
-					fmt.Sprintf("execute http request failed! Retried %d times, error: %v",
-						retries, err)}
+					Message: fmt.Sprintf("execute http request failed! Retried %d times, error: %v",
+						retries, err),
+					Cause: err}
 			}
 			retries++
 			log.Warnf("send request failed: %v, retry for %d time(s)", err, retries)
-			if req.Body() != nil {
-				ioutil.ReadAll(teeReader)
-				req.Request.SetBody(ioutil.NopCloser(&retryBuf))
+			if err := resetBody(); err != nil {
+				return err
 			}
 			continue
 		}
 		resp.SetHttpResponse(httpResp)
+		resp.maxBodySize = c.Config.maxResponseSize()
 		resp.ParseResponse()
 
 		log.Infof("receive http response: status: %s, debugId: %s, requestId: %s, elapsed: %v",
@@ -154,22 +304,40 @@ func (c *BceClient) SendRequest(req *BceRequest, resp *BceResponse) error {
 		for k, v := range resp.Headers() {
 			log.Debugf("%s=%s", k, v)
 		}
+		if redirected, err := c.followRedirect(req, resp, redirects,
+			rewindable || teeReader != nil); err != nil {
+			return err
+		} else if redirected {
+			redirects++
+			if err := resetBody(); err != nil {
+				return err
+			}
+			continue
+		}
 		if resp.IsFail() {
 			err := resp.ServiceError()
-			if c.Config.Retry.ShouldRetry(err, retries) {
+			if !skewCorrected && replayable && c.correctClockSkew(req, resp, err) {
+				// Resend once with the corrected time without counting in the retries
+				skewCorrected = true
+			} else if !credentialsSwitched && replayable && c.failoverCredentials(req, err) {
+				// Resend once with the other credentials without counting in the retries
+				credentialsSwitched = true
+			} else if retryable && replayable && c.shouldRetry(err, retries) {
 				delay_in_mills := c.Config.Retry.GetDelayBeforeNextRetryInMillis(err, retries)
-				time.Sleep(delay_in_mills)
+				if err := c.sleepBeforeRetry(delay_in_mills); err != nil {
+					return err
+				}
+				retries++
+				log.Warnf("send request failed, retry for %d time(s)", retries)
 			} else {
 				return err
 			}
-			retries++
-			log.Warnf("send request failed, retry for %d time(s)", retries)
-			if req.Body() != nil {
-				ioutil.ReadAll(teeReader)
-				req.Request.SetBody(ioutil.NopCloser(&retryBuf))
+			if err := resetBody(); err != nil {
+				return err
 			}
 			continue
 		}
+		c.onRequestSuccess()
 		return nil
 	}
 }
@@ -183,47 +351,76 @@ func (c *BceClient) SendRequest(req *BceRequest, resp *BceResponse) error {
 //     - content: the content of body
 // RETURNS:
 //     - error: nil if ok otherwise the specific error
-func (c *BceClient) SendRequestFromBytes(req *BceRequest, resp *BceResponse, content []byte) error {
+func (c *BceClient) SendRequestFromBytes(req *BceRequest, resp *BceResponse,
+	content []byte) (err error) {
 	// Return client error if it is not nil
 	if req.ClientError() != nil {
 		return req.ClientError()
 	}
 	// Build the http request and prepare to send
-	c.buildHttpRequest(req)
+	if err := c.buildHttpRequest(req); err != nil {
+		return err
+	}
+	span := c.startSpan(req)
+	defer func() { endSpan(span, resp, err) }()
 	log.Infof("send http request: %v", req)
 	// Send request with the given retry policy
-	retries := 0
+	retries, redirects := 0, 0
+	skewCorrected, credentialsSwitched := false, false
 	for {
 		// The request body should be temporarily saved if retry to send the http request
 		buf := bytes.NewBuffer(content)
 		req.Request.SetBody(ioutil.NopCloser(buf))
 		defer req.Request.Body().Close() // Manually close the ReadCloser body for retry
-		httpResp, err := http.Execute(&req.Request)
+		httpResp, err := http.ExecuteWithClient(c.Config.httpClient(), &req.Request)
 		if err != nil {
-			if c.Config.Retry.ShouldRetry(err, retries) {
+			if c.shouldRetry(err, retries) {
 				delay_in_mills := c.Config.Retry.GetDelayBeforeNextRetryInMillis(err, retries)
-				time.Sleep(delay_in_mills)
+				if err := c.sleepBeforeRetry(delay_in_mills); err != nil {
+					return err
+				}
 			} else {
 				return &BceClientError{
-					fmt.Sprintf("execute http request failed! Retried %d times, error: %v",
-						retries, err)}
+					Message: fmt.Sprintf("execute http request failed! Retried %d times, error: %v",
+						retries, err),
+					Cause: err}
 			}
 			retries++
 			log.Warnf("send request failed: %v, retry for %d time(s)", err, retries)
 			continue
 		}
 		resp.SetHttpResponse(httpResp)
+		resp.maxBodySize = c.Config.maxResponseSize()
 		resp.ParseResponse()
 		log.Infof("receive http response: status: %s, debugId: %s, requestId: %s, elapsed: %v",
 			resp.StatusText(), resp.DebugId(), resp.RequestId(), resp.ElapsedTime())
 		for k, v := range resp.Headers() {
 			log.Debugf("%s=%s", k, v)
 		}
+		if redirected, err := c.followRedirect(req, resp, redirects, true); err != nil {
+			return err
+		} else if redirected {
+			redirects++
+			if req.Length() == 0 {
+				content = nil // the body is dropped when redirected to GET
+			}
+			continue
+		}
 		if resp.IsFail() {
 			err := resp.ServiceError()
-			if c.Config.Retry.ShouldRetry(err, retries) {
+			if !skewCorrected && c.correctClockSkew(req, resp, err) {
+				skewCorrected = true
+				continue
+			}
+			if !credentialsSwitched && c.failoverCredentials(req, err) {
+				credentialsSwitched = true
+				continue
+			}
+			if c.shouldRetry(err, retries) {
 				delay_in_mills := c.Config.Retry.GetDelayBeforeNextRetryInMillis(err, retries)
-				time.Sleep(delay_in_mills)
+				if err := c.sleepBeforeRetry(delay_in_mills); err != nil {
+					return err
+				}
 			} else {
 				return err
 			}
@@ -231,7 +428,28 @@ func (c *BceClient) SendRequestFromBytes(req *BceRequest, resp *BceResponse, con
 			log.Warnf("send request failed, retry for %d time(s)", retries)
 			continue
 		}
+		c.onRequestSuccess()
+		return nil
+	}
+}
+
+// sleepBeforeRetry - sleep for the delay before the next retry, it returns early with the error
+// of the context if the context of the configuration is done
+func (c *BceClient) sleepBeforeRetry(delay time.Duration) error {
+	ctx := c.Config.Context
+	if ctx == nil {
+		time.Sleep(delay)
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
 		return nil
+	case <-ctx.Done():
+		return &BceClientError{
+			Message: fmt.Sprintf("the request is canceled before retrying: %v", ctx.Err()),
+			Cause:   ctx.Err()}
 	}
 }
 
@@ -239,8 +457,62 @@ func (c *BceClient) GetBceClientConfig() *BceClientConfiguration {
 	return c.Config
 }
 
+// ClientToken - get the idempotent clientToken of the create-type request. A random UUID is
+// generated if the given token is empty, so that the retried requests of the same call will not
+// create duplicate resources. It can be disabled by the DisableAutoClientToken configuration.
+//
+// PARAMS:
+//     - cli: the client agent which sends the request
+//     - token: the clientToken given by the caller
+// RETURNS:
+//     - string: the clientToken to be sent, empty if not given and generating is disabled
+func ClientToken(cli Client, token string) string {
+	if len(token) != 0 || cli == nil {
+		return token
+	}
+	if conf := cli.GetBceClientConfig(); conf != nil && conf.DisableAutoClientToken {
+		return token
+	}
+	return util.NewUUID()
+}
+
+// GetUrl - send the unsigned GET request to the given url, eg: the presigned url or the download
+// url returned by the services, with the http client, proxy, timeout and context of the client
+//
+// PARAMS:
+//     - rawUrl: the url to get
+// RETURNS:
+//     - *http.Response: the response whose body should be closed by the caller
+//     - error: nil if ok otherwise the error of sending the request
+func (c *BceClient) GetUrl(rawUrl string) (*http.Response, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	req := &http.Request{}
+	req.SetMethod(http.GET)
+	req.SetEndpoint(u.Scheme + "://" + u.Host)
+	req.SetUri(u.Path)
+	query := u.Query()
+	for k := range query {
+		req.SetParam(k, query.Get(k))
+	}
+	if proxyUrl := c.Config.proxyUrlFor(req.Host()); len(proxyUrl) != 0 {
+		req.SetProxyUrl(proxyUrl)
+	}
+	req.SetTimeout(c.Config.ConnectionTimeoutInMillis / 1000)
+	if c.Config.Context != nil {
+		req.SetContext(c.Config.Context)
+	}
+	return http.ExecuteWithClient(c.Config.customHttpClient(), req)
+}
+
 func NewBceClient(conf *BceClientConfiguration, sign auth.Signer) *BceClient {
-	clientConfig := http.ClientConfig{RedirectDisabled: conf.RedirectDisabled}
+	// The dns and dial settings of the configuration use their own transport instead of the
+	// shared one, see BceClientConfiguration.customHttpClient
+	clientConfig := http.ClientConfig{
+		RedirectDisabled: conf.RedirectDisabled,
+	}
 	http.InitClient(clientConfig)
 	return &BceClient{conf, sign}
 }
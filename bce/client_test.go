@@ -0,0 +1,39 @@
+package bce
+
+import (
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+func TestBuildHttpRequestContentType(t *testing.T) {
+	client, err := NewBceClientWithAkSk("ak", "sk", "bj.bcebos.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		contentType string
+		expected    string
+	}{
+		{"", "application/json;charset=UTF-8"},
+		{"text/plain", "text/plain"},
+		{XML_CONTENT_TYPE, XML_CONTENT_TYPE},
+	}
+	for _, c := range cases {
+		req := &BceRequest{}
+		req.SetMethod(http.PUT)
+		req.SetUri("/bucket/object")
+		if len(c.contentType) != 0 {
+			req.SetHeader(http.CONTENT_TYPE, c.contentType)
+		}
+		if err := client.buildHttpRequest(req); err != nil {
+			t.Fatal(err)
+		}
+		if got := req.Header(http.CONTENT_TYPE); got != c.expected {
+			t.Errorf("content type of %q: got %q, expected %q", c.contentType, got, c.expected)
+		}
+		if len(req.Header(http.AUTHORIZATION)) == 0 {
+			t.Errorf("content type of %q: the request is not signed", c.contentType)
+		}
+	}
+}
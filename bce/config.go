@@ -17,11 +17,18 @@
 package bce
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
+	"net/http"
 	"reflect"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/baidubce/bce-sdk-go/auth"
+	bcehttp "github.com/baidubce/bce-sdk-go/http"
 )
 
 // Constants and default values for the package bce
@@ -33,11 +40,18 @@ const (
 	DEFAULT_REGION                       = "bj"
 	DEFAULT_CONTENT_TYPE                 = "application/json;charset=utf-8"
 	DEFAULT_CONNECTION_TIMEOUT_IN_MILLIS = 1200 * 1000
+	DEFAULT_MAX_RESPONSE_SIZE            = 64 * 1024 * 1024
+
+	// the settings of the DEFAULT_RETRY_POLICY
+	DEFAULT_MAX_RETRIES                   = 3
+	DEFAULT_RETRY_MAX_DELAY_IN_MILLIS     = 20000
+	DEFAULT_RETRY_BASE_INTERVAL_IN_MILLIS = 300
 )
 
 var (
 	DEFAULT_USER_AGENT   string
-	DEFAULT_RETRY_POLICY = NewBackOffRetryPolicy(3, 20000, 300)
+	DEFAULT_RETRY_POLICY = NewBackOffRetryPolicy(DEFAULT_MAX_RETRIES,
+		DEFAULT_RETRY_MAX_DELAY_IN_MILLIS, DEFAULT_RETRY_BASE_INTERVAL_IN_MILLIS)
 )
 
 func init() {
@@ -50,31 +64,195 @@ func init() {
 
 // BceClientConfiguration defines the config components structure.
 type BceClientConfiguration struct {
-	Endpoint                  string
-	ProxyUrl                  string
-	Region                    string
-	UserAgent                 string
-	Credentials               *auth.BceCredentials
+	Endpoint string
+	// ProxyUrl supports the http, https and socks5 schemes, eg: "http://127.0.0.1:8080" or
+	// "socks5://127.0.0.1:1080", and the http scheme is used if no scheme is given. The proxy
+	// credentials can be given in the url user info or by the ProxyUsername and ProxyPassword.
+	ProxyUrl      string
+	ProxyUsername string
+	ProxyPassword string
+	// NoProxy is the list of hosts that should be accessed directly without proxy with the
+	// same format of the NO_PROXY environment variable: "*" matches all hosts, a domain name
+	// matches itself and its subdomains, an ip or cidr matches the ip address of the host.
+	NoProxy   []string
+	Region    string
+	UserAgent string
+	// UserAgentSuffix is appended to the UserAgent to identify the application, eg: "myapp/1.0.2"
+	UserAgentSuffix string
+	// CustomHeaders are set to every request sent by the client unless the same header is given
+	// by the request itself, eg: the header to identify the internal application or tenant
+	CustomHeaders map[string]string
+	Credentials   *auth.BceCredentials
+	// CredentialsProvider provides the credentials to sign the requests instead of the static
+	// Credentials if it is set, eg: the sts.AssumeRoleProvider refreshing automatically
+	CredentialsProvider       auth.CredentialsProvider
 	SignOption                *auth.SignOptions
 	Retry                     RetryPolicy
 	ConnectionTimeoutInMillis int
 	// CnameEnabled should be true when use custom domain as endpoint to visit bos resource
-	CnameEnabled     bool
+	CnameEnabled bool
+	// VirtualHostEnabled uses the virtual-hosted style address "bucket.endpoint" instead of the
+	// path style "endpoint/bucket" to visit bos resource, the path style is still used for the
+	// bucket names containing dots and the ip endpoint
+	VirtualHostEnabled bool
+	// BackupEndpoint and BackupEndpoints are tried in order if the request to the Endpoint fails
+	// with the connection-level error or the server error, and the last known-good endpoint is
+	// preferred by the following requests, see the Endpoints method
 	BackupEndpoint   string
+	BackupEndpoints  []string
 	RedirectDisabled bool
+	// RedirectPolicy follows the redirects by the client to sign the redirected requests again and
+	// resend the body if it is set, see the RedirectPolicy. It is ignored if RedirectDisabled.
+	RedirectPolicy *RedirectPolicy
+	// DisableAutoClientToken disables generating the idempotent clientToken automatically for the
+	// create-type requests whose clientToken is not given, see the ClientToken function
+	DisableAutoClientToken bool
+	// Cache caches the results of the read-heavy apis of the service clients for the CacheTTL,
+	// see the ResponseCache interface for the cached apis and how to bypass the cache
+	Cache    ResponseCache
+	CacheTTL time.Duration
+	// HedgePolicy sends the GET and HEAD requests again if they are slow and the first successful
+	// response wins to reduce the tail latency if it is set, see the HedgePolicy
+	HedgePolicy *HedgePolicy
+	// RetryBudget limits the retries of all the requests sharing it by a token bucket refilled by
+	// the successful requests if it is set, so that the retries stop when the service browns out
+	// instead of multiplying the traffic, see the RetryBudget
+	RetryBudget *RetryBudget
+	// RequestCompressionThreshold compresses the json request bodies not smaller than it in bytes
+	// by gzip if it is positive, it should only be set for the services accepting the gzip
+	// Content-Encoding. The gzip-encoded responses are always decompressed.
+	RequestCompressionThreshold int64
+	// Context cancels the requests or sets their deadline if it is set, it should be set for the
+	// calls of the copied client by the WithContext option instead of the shared configuration
+	Context context.Context
+	// Tracer creates a span for every request if it is set, see the Tracer interface
+	Tracer Tracer
+	// MaxResponseSize is the max bytes of the json or error response body read into memory, the
+	// larger body fails with the *ResponseTooLargeError instead of exhausting the memory, eg: the
+	// body returned by the misbehaving proxies or the wrong endpoints. The DEFAULT_MAX_RESPONSE_SIZE
+	// is used if it is 0 and the negative value means no limit. The streamed bodies such as the
+	// object content and the ParseJsonArrayBody are not limited.
+	MaxResponseSize int64
+	// HTTPClient is used to send the requests instead of the shared http client of the SDK if it
+	// is set, then the proxy, timeout and dns settings should be done by the client itself.
+	HTTPClient *http.Client
+	// Transport is used to send the requests instead of the shared transport if it is set and the
+	// HTTPClient is nil, eg: the instrumented transport, the mTLS transport or the mock transport.
+	Transport http.RoundTripper
+	// TLSConfig is used by the https connections instead of the system defaults if it is set and
+	// the HTTPClient and Transport are nil, eg: the custom root CAs of the private-cloud endpoints,
+	// the client certificate of the mTLS gateways and the min version, see the NewTLSConfig.
+	TLSConfig *tls.Config
+	// The dns and dial settings of the connections, see http.ClientConfig. The clients with any
+	// of them set use the transport of their own settings shared by the clients with the same
+	// settings if the HTTPClient and Transport are nil, see http.DialTransport.
+	Resolver          *net.Resolver
+	DNSCacheTTL       time.Duration
+	DialFallbackDelay time.Duration
+	DialNetwork       string
 }
 
 func (c *BceClientConfiguration) String() string {
 	return fmt.Sprintf(`BceClientConfiguration [
         Endpoint=%s;
         ProxyUrl=%s;
+        NoProxy=%v;
         Region=%s;
         UserAgent=%s;
+        UserAgentSuffix=%s;
         Credentials=%v;
         SignOption=%v;
         RetryPolicy=%v;
         ConnectionTimeoutInMillis=%v;
 		RedirectDisabled=%v
-    ]`, c.Endpoint, c.ProxyUrl, c.Region, c.UserAgent, c.Credentials,
-		c.SignOption, reflect.TypeOf(c.Retry).Name(), c.ConnectionTimeoutInMillis, c.RedirectDisabled)
+    ]`, c.Endpoint, c.ProxyUrl, c.NoProxy, c.Region, c.UserAgent, c.UserAgentSuffix,
+		c.Credentials, c.SignOption, reflect.TypeOf(c.Retry).Name(), c.ConnectionTimeoutInMillis, c.RedirectDisabled)
+}
+
+// userAgent - get the User-Agent header value with the configured application suffix
+//
+// RETURNS:
+//     - string: the User-Agent header value
+func (c *BceClientConfiguration) userAgent() string {
+	userAgent := c.UserAgent
+	if len(userAgent) == 0 {
+		userAgent = DEFAULT_USER_AGENT
+	}
+	if suffix := strings.TrimSpace(c.UserAgentSuffix); len(suffix) != 0 {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// maxResponseSize - get the max bytes of the response body read into memory
+//
+// RETURNS:
+//     - int64: the max bytes, 0 if not limited
+func (c *BceClientConfiguration) maxResponseSize() int64 {
+	switch {
+	case c.MaxResponseSize == 0:
+		return DEFAULT_MAX_RESPONSE_SIZE
+	case c.MaxResponseSize < 0:
+		return 0
+	}
+	return c.MaxResponseSize
+}
+
+// httpClient - get the http client to send the requests, which does not follow the redirects if
+// they are followed by the RedirectPolicy
+//
+// RETURNS:
+//     - *http.Client: the client to send the requests, nil if the shared client of the SDK is used
+func (c *BceClientConfiguration) httpClient() *http.Client {
+	client := c.customHttpClient()
+	if c.RedirectPolicy == nil || c.RedirectDisabled {
+		return client
+	}
+	if client == nil {
+		client = &http.Client{Timeout: time.Duration(c.ConnectionTimeoutInMillis) * time.Millisecond}
+		if transport := bcehttp.SharedTransport(); transport != nil {
+			client.Transport = transport
+		}
+	} else {
+		copied := *client
+		client = &copied
+	}
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return client
+}
+
+// customHttpClient - get the user provided http client to send the requests
+//
+// RETURNS:
+//     - *http.Client: the user provided client or the client of the custom transport, nil if the
+//       shared client of the SDK is used
+func (c *BceClientConfiguration) customHttpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	transport := c.Transport
+	if transport == nil {
+		dialConfig := bcehttp.ClientConfig{
+			Resolver:          c.Resolver,
+			DNSCacheTTL:       c.DNSCacheTTL,
+			DialFallbackDelay: c.DialFallbackDelay,
+			DialNetwork:       c.DialNetwork,
+		}
+		if c.TLSConfig == nil && !dialConfig.HasDialSettings() {
+			return nil
+		}
+		transport = bcehttp.DialTransport(dialConfig, c.TLSConfig)
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(c.ConnectionTimeoutInMillis) * time.Millisecond,
+	}
+	if c.RedirectDisabled {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return client
 }
@@ -16,6 +16,8 @@
 
 package bce
 
+import "strconv"
+
 const (
 	EACCESS_DENIED            = "AccessDenied"
 	EINAPPROPRIATE_JSON       = "InappropriateJSON"
@@ -29,27 +31,45 @@ const (
 	EOPT_IN_REQUIRED          = "OptInRequired"
 	EPRECONDITION_FAILED      = "PreconditionFailed"
 	EREQUEST_EXPIRED          = "RequestExpired"
+	EREQUEST_TIME_TOO_SKEWED  = "RequestTimeTooSkewed"
 	ESIGNATURE_DOES_NOT_MATCH = "SignatureDoesNotMatch"
 )
 
+const (
+	// MAX_ERROR_BODY_SIZE is the max size of the raw error body kept in the BceServiceError
+	MAX_ERROR_BODY_SIZE = 64 * 1024
+
+	// MAX_ERROR_MESSAGE_SIZE is the max size of the message taken from the non-json error body
+	MAX_ERROR_MESSAGE_SIZE = 256
+)
+
 // BceError abstracts the error for BCE
 type BceError interface {
 	error
 }
 
-// BceClientError defines the error struct for the client when making request
-type BceClientError struct{ Message string }
+// BceClientError defines the error struct for the client when making request, the Cause is the
+// underlying error of sending the http request if any
+type BceClientError struct {
+	Message string
+	Cause   error
+}
 
 func (b *BceClientError) Error() string { return b.Message }
 
-func NewBceClientError(msg string) *BceClientError { return &BceClientError{msg} }
+func NewBceClientError(msg string) *BceClientError { return &BceClientError{Message: msg} }
 
-// BceServiceError defines the error struct for the BCE service when receiving response
+// BceServiceError defines the error struct for the BCE service when receiving response. The
+// RawBody and Headers keep the original error response, which is useful when the error is not
+// returned by the BCE service itself but by the gateways or proxies on the way, the RawBody is
+// truncated to MAX_ERROR_BODY_SIZE bytes.
 type BceServiceError struct {
 	Code       string
 	Message    string
 	RequestId  string
 	StatusCode int
+	RawBody    []byte            `json:"-"`
+	Headers    map[string]string `json:"-"`
 }
 
 func (b *BceServiceError) Error() string {
@@ -59,6 +79,21 @@ func (b *BceServiceError) Error() string {
 	return ret
 }
 
+// ResponseTooLargeError defines the error of the response body larger than the MaxResponseSize of
+// the client configuration, the ContentLength is -1 if the body size is unknown in advance
+type ResponseTooLargeError struct {
+	Limit         int64
+	ContentLength int64
+	StatusCode    int
+	RequestId     string
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return "response body is too large: [Limit: " + strconv.FormatInt(e.Limit, 10) +
+		"; ContentLength: " + strconv.FormatInt(e.ContentLength, 10) +
+		"; StatusCode: " + strconv.Itoa(e.StatusCode) + "; RequestId: " + e.RequestId + "]"
+}
+
 func NewBceServiceError(code, msg, reqId string, status int) *BceServiceError {
-	return &BceServiceError{code, msg, reqId, status}
+	return &BceServiceError{Code: code, Message: msg, RequestId: reqId, StatusCode: status}
 }
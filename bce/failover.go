@@ -0,0 +1,104 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// failover.go - classify the connection-level errors and track the healthy endpoints
+
+package bce
+
+import (
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// knownGoodEndpoints maps the primary endpoint to its last known-good endpoint
+var knownGoodEndpoints sync.Map
+
+// IsConnectionError - check whether the error is a connection-level error, such as connection
+// refused or reset, dns resolving failure, network unreachable and timeout, which means the
+// endpoint can not be accessed and the request can be sent to another endpoint
+//
+// PARAMS:
+//     - err: the error returned by sending the request
+// RETURNS:
+//     - bool: true if it is a connection-level error
+func IsConnectionError(err error) bool {
+	for err != nil {
+		switch e := err.(type) {
+		case *BceClientError:
+			err = e.Cause
+		case *url.Error:
+			err = e.Err
+		case *net.OpError:
+			if e.Timeout() {
+				return true
+			}
+			err = e.Err
+		case *os.SyscallError:
+			err = e.Err
+		case *net.DNSError:
+			return true
+		case syscall.Errno:
+			return e == syscall.ECONNREFUSED || e == syscall.ECONNRESET ||
+				e == syscall.ECONNABORTED || e == syscall.ENETUNREACH ||
+				e == syscall.EHOSTUNREACH || e == syscall.ETIMEDOUT
+		default:
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return true
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				return true
+			}
+			return false
+		}
+	}
+	return false
+}
+
+// Endpoints - get the endpoints to send the request in order, the last known-good endpoint is
+// the first one and followed by the Endpoint, BackupEndpoint and BackupEndpoints
+//
+// RETURNS:
+//     - []string: the endpoints without duplicates
+func (c *BceClientConfiguration) Endpoints() []string {
+	candidates := append([]string{c.Endpoint, c.BackupEndpoint}, c.BackupEndpoints...)
+	if val, ok := knownGoodEndpoints.Load(c.Endpoint); ok {
+		candidates = append([]string{val.(string)}, candidates...)
+	}
+	endpoints := make([]string, 0, len(candidates))
+	seen := make(map[string]bool, len(candidates))
+	for _, endpoint := range candidates {
+		if len(endpoint) != 0 && !seen[endpoint] {
+			seen[endpoint] = true
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// MarkEndpointGood - record the endpoint which the request is sent to successfully, so that it is
+// preferred by the following requests of the clients with the same Endpoint
+//
+// PARAMS:
+//     - endpoint: the endpoint which is known-good
+func (c *BceClientConfiguration) MarkEndpointGood(endpoint string) {
+	if endpoint == c.Endpoint {
+		knownGoodEndpoints.Delete(c.Endpoint)
+	} else {
+		knownGoodEndpoints.Store(c.Endpoint, endpoint)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// gzip.go - compress the large json request bodies and decompress the gzip-encoded responses
+
+package bce
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/util"
+)
+
+const GZIP_CONTENT_ENCODING = "gzip"
+
+// compressJsonBody - compress the json body of the request by gzip if it is not smaller than the
+// threshold, it should be called before signing since the body headers are changed
+//
+// PARAMS:
+//     - request: the request to compress
+//     - threshold: the min size of the body to compress, not compressed if it is not positive
+// RETURNS:
+//     - error: nil if ok otherwise the error of reading or compressing the body
+func compressJsonBody(request *BceRequest, threshold int64) error {
+	if threshold <= 0 || !request.jsonBody || request.Body() == nil ||
+		request.Length() < threshold || len(request.Header(http.CONTENT_ENCODING)) != 0 {
+		return nil
+	}
+	raw, err := ioutil.ReadAll(request.Body())
+	request.Body().Close()
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(raw); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	contentMD5, err := util.CalculateContentMD5(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return err
+	}
+	request.SetBody(newBytesBody(buf.Bytes(), contentMD5))
+	request.SetHeader(http.CONTENT_ENCODING, GZIP_CONTENT_ENCODING)
+	return nil
+}
+
+// gzipReadCloser reads the decompressed content and closes both the gzip reader and the body
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (g *gzipReadCloser) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
+}
+
+// decompressedBody - get the response body decompressed if it is encoded by gzip, the standard
+// transport decompresses the body itself and removes the header if it requests the gzip
+// encoding, so this handles the custom transports and the servers compressing unrequested
+func decompressedBody(r *BceResponse) (io.ReadCloser, error) {
+	body := r.Body()
+	if !strings.EqualFold(strings.TrimSpace(r.Header(http.CONTENT_ENCODING)), GZIP_CONTENT_ENCODING) {
+		return body, nil
+	}
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		body.Close()
+		return nil, fmt.Errorf("decompress gzip response body failed: %v", err)
+	}
+	return &gzipReadCloser{reader, body}, nil
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// hedge.go - re-issue the slow idempotent read requests to reduce the tail latency
+
+package bce
+
+import (
+	"context"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+const (
+	DEFAULT_HEDGE_PERCENTILE  = 95
+	HEDGE_LATENCY_WINDOW_SIZE = 128 // the number of the recent latencies to compute the percentile
+	HEDGE_MIN_SAMPLES         = 16  // the number of the latencies before using the percentile
+)
+
+// HedgePolicy defines the hedged requests of the GET and HEAD requests without body: if the
+// response is not received after the hedge delay, the same request is sent again and the first
+// successful response wins while the other one is discarded. The delay is the Percentile of the
+// recently observed latencies and not less than the MinDelay, the MinDelay is used until enough
+// latencies are observed. It is safe to be shared by the clients for concurrent use.
+type HedgePolicy struct {
+	MinDelay   time.Duration
+	Percentile float64
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+// NewHedgePolicy - create the hedge policy with the default percentile
+//
+// PARAMS:
+//     - minDelay: the minimum delay before sending the hedged request
+// RETURNS:
+//     - *HedgePolicy: the hedge policy
+func NewHedgePolicy(minDelay time.Duration) *HedgePolicy {
+	return &HedgePolicy{MinDelay: minDelay, Percentile: DEFAULT_HEDGE_PERCENTILE}
+}
+
+// WithHedging sends the GET and HEAD requests with the hedge policy, nil disables hedging.
+func WithHedging(policy *HedgePolicy) RequestOption {
+	return func(c *BceClientConfiguration) { c.HedgePolicy = policy }
+}
+
+// Delay - get the delay before sending the hedged request
+//
+// RETURNS:
+//     - time.Duration: the percentile of the observed latencies, at least the MinDelay
+func (p *HedgePolicy) Delay() time.Duration {
+	p.mu.Lock()
+	if len(p.latencies) < HEDGE_MIN_SAMPLES || p.Percentile <= 0 || p.Percentile >= 100 {
+		p.mu.Unlock()
+		return p.MinDelay
+	}
+	sorted := make([]time.Duration, len(p.latencies))
+	copy(sorted, p.latencies)
+	p.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := int(math.Ceil(p.Percentile/100*float64(len(sorted)))) - 1
+	if delay := sorted[index]; delay > p.MinDelay {
+		return delay
+	}
+	return p.MinDelay
+}
+
+// Observe - record the latency of a successful request to compute the delay
+//
+// PARAMS:
+//     - latency: the elapsed time of the request
+func (p *HedgePolicy) Observe(latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.latencies) < HEDGE_LATENCY_WINDOW_SIZE {
+		p.latencies = append(p.latencies, latency)
+		return
+	}
+	p.latencies[p.next] = latency
+	p.next = (p.next + 1) % HEDGE_LATENCY_WINDOW_SIZE
+}
+
+// hedgeable - check whether the request is idempotent and can be sent twice concurrently
+func hedgeable(req *BceRequest) bool {
+	return req.Body() == nil && (req.Method() == http.GET || req.Method() == http.HEAD)
+}
+
+type hedgeResult struct {
+	resp    *BceResponse
+	err     error
+	attempt int
+}
+
+// cancelOnClose cancels the context of the winning hedged request once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// sendHedgedRequest - send the request with the retry policy, and send it again if there is no
+// response after the hedge delay, the first successful response is set to the given response.
+// Each attempt is sent by its own copy of the request with a derived context, and the slower one
+// is canceled once the first successful response is received.
+//
+// PARAMS:
+//     - policy: the hedge policy
+//     - req: the built request object to be sent
+//     - resp: the response object to receive the winning response
+// RETURNS:
+//     - error: nil if any of the requests is ok otherwise the error of the last failed one
+func (c *BceClient) sendHedgedRequest(policy *HedgePolicy, req *BceRequest,
+	resp *BceResponse) error {
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	send := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		cancels = append(cancels, cancel)
+		attempt, index := req.clone(), len(cancels)-1
+		attempt.SetContext(ctx)
+		go func() {
+			start := time.Now()
+			r := &BceResponse{}
+			err := c.sendRequestWithRetry(attempt, r)
+			if err == nil {
+				policy.Observe(time.Since(start))
+			}
+			results <- hedgeResult{r, err, index}
+		}()
+	}
+	send()
+
+	timer := time.NewTimer(policy.Delay())
+	defer timer.Stop()
+	timeout := timer.C
+	pending := 1
+	for {
+		select {
+		case <-timeout:
+			log.Infof("no response after the hedge delay, send the hedged request: %v", req)
+			timeout = nil
+			pending++
+			send()
+		case result := <-results:
+			pending--
+			*resp = *result.resp
+			if result.err != nil {
+				cancels[result.attempt]()
+				if pending == 0 {
+					return result.err
+				}
+				continue
+			}
+			// cancel the slower request and discard its response if it is received anyway
+			httpResp := result.resp.response.HttpResponse()
+			httpResp.Body = &cancelOnClose{httpResp.Body, cancels[result.attempt]}
+			for i, cancel := range cancels {
+				if i != result.attempt {
+					cancel()
+				}
+			}
+			go func(n int) {
+				for ; n > 0; n-- {
+					if r := <-results; r.err == nil {
+						r.resp.Body().Close()
+					}
+				}
+			}(pending)
+			return nil
+		}
+	}
+}
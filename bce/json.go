@@ -0,0 +1,63 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// json.go - define the central json marshaling of the request bodies and the optional values
+
+package bce
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JSONMarshal is used by MarshalJSON to marshal the request bodies of all services, it can be
+// replaced by a compatible implementation such as the jsoniter, and it should be set before
+// creating the clients since it is not safe for concurrent modification.
+var JSONMarshal = marshalJSON
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	// keep the characters like "&" and "<" in the object keys and urls as they are
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// MarshalJSON - marshal the request body to json by the JSONMarshal. The zero value of the field
+// with the "omitempty" option is not sent, so the optional field whose zero value is meaningful,
+// such as the false of a filter, should be defined as a pointer and set by Bool, Int and so on.
+//
+// PARAMS:
+//     - v: the request body to marshal
+// RETURNS:
+//     - []byte: the json bytes
+//     - error: nil if ok otherwise the marshal error
+func MarshalJSON(v interface{}) ([]byte, error) {
+	return JSONMarshal(v)
+}
+
+// Bool returns the pointer of the bool value to set the optional field.
+func Bool(v bool) *bool { return &v }
+
+// Int returns the pointer of the int value to set the optional field.
+func Int(v int) *int { return &v }
+
+// Int64 returns the pointer of the int64 value to set the optional field.
+func Int64(v int64) *int64 { return &v }
+
+// String returns the pointer of the string value to set the optional field.
+func String(v string) *string { return &v }
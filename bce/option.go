@@ -0,0 +1,98 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// option.go - define the request options to override the client configuration per call
+
+package bce
+
+import (
+	"context"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/auth"
+)
+
+// RequestOption overrides the configuration of a copied client, so that the calls made by the
+// copied client use the overridden settings while the shared client is kept unchanged, eg:
+//
+//     cli.WithOptions(bce.WithTimeout(5*time.Second), bce.WithHeader("x-app", "foo")).Xxx(...)
+type RequestOption func(*BceClientConfiguration)
+
+// WithTimeout overrides the connection timeout of the requests.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(c *BceClientConfiguration) {
+		c.ConnectionTimeoutInMillis = int(timeout / time.Millisecond)
+	}
+}
+
+// WithContext sets the context to cancel the requests or set their deadline, the retries stop
+// once the context is done.
+func WithContext(ctx context.Context) RequestOption {
+	return func(c *BceClientConfiguration) { c.Context = ctx }
+}
+
+// WithRetryPolicy overrides the retry policy of the requests, use NewNoRetryPolicy to disable it.
+func WithRetryPolicy(retry RetryPolicy) RequestOption {
+	return func(c *BceClientConfiguration) { c.Retry = retry }
+}
+
+// WithHeader adds a header to the requests unless the same header is given by the request.
+func WithHeader(key, value string) RequestOption {
+	return func(c *BceClientConfiguration) { c.CustomHeaders[key] = value }
+}
+
+// WithCredentials overrides the credentials to sign the requests.
+func WithCredentials(credentials *auth.BceCredentials) RequestOption {
+	return func(c *BceClientConfiguration) {
+		c.Credentials = credentials
+		c.CredentialsProvider = nil
+	}
+}
+
+// WithCredentialsProvider overrides the credentials provider to sign the requests.
+func WithCredentialsProvider(provider auth.CredentialsProvider) RequestOption {
+	return func(c *BceClientConfiguration) { c.CredentialsProvider = provider }
+}
+
+// WithRequestCompression overrides the min size of the json request bodies compressed by gzip,
+// 0 disables the compression.
+func WithRequestCompression(threshold int64) RequestOption {
+	return func(c *BceClientConfiguration) { c.RequestCompressionThreshold = threshold }
+}
+
+// WithMaxResponseSize overrides the max bytes of the response body read into memory, the negative
+// value means no limit.
+func WithMaxResponseSize(size int64) RequestOption {
+	return func(c *BceClientConfiguration) { c.MaxResponseSize = size }
+}
+
+// WithOptions - copy the client with the configuration overridden by the given options
+//
+// PARAMS:
+//     - opts: the options to override the configuration
+// RETURNS:
+//     - *BceClient: the copied client sharing the signer with the original one
+func (c *BceClient) WithOptions(opts ...RequestOption) *BceClient {
+	conf := *c.Config
+	conf.CustomHeaders = make(map[string]string, len(c.Config.CustomHeaders))
+	for k, v := range c.Config.CustomHeaders {
+		conf.CustomHeaders[k] = v
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&conf)
+		}
+	}
+	return NewBceClient(&conf, c.Signer)
+}
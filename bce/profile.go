@@ -0,0 +1,459 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// profile.go - load the client configuration of the named profiles from the config file
+
+package bce
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/auth"
+)
+
+const (
+	// DEFAULT_CONFIG_FILE is the path of the config file relative to the home directory, it is
+	// overridden by the BCE_CONFIG_FILE environment variable
+	DEFAULT_CONFIG_FILE = ".bce/config"
+	CONFIG_FILE_ENV     = "BCE_CONFIG_FILE"
+	DEFAULT_PROFILE     = "default"
+
+	// the keys of the profile and the per-service sections
+	PROFILE_ACCESS_KEY_ID          = "access_key_id"
+	PROFILE_SECRET_ACCESS_KEY      = "secret_access_key"
+	PROFILE_SESSION_TOKEN          = "session_token"
+	PROFILE_REGION                 = "region"
+	PROFILE_ENDPOINT               = "endpoint"
+	PROFILE_MAX_RETRIES            = "max_retries"
+	PROFILE_RETRY_MAX_DELAY_MS     = "retry_max_delay_ms"
+	PROFILE_RETRY_BASE_INTERVAL_MS = "retry_base_interval_ms"
+	PROFILE_TIMEOUT_MS             = "timeout_ms"
+	PROFILE_PROXY_URL              = "proxy_url"
+	PROFILE_USER_AGENT_SUFFIX      = "user_agent_suffix"
+)
+
+var profileKeys = map[string]bool{
+	PROFILE_ACCESS_KEY_ID:          true,
+	PROFILE_SECRET_ACCESS_KEY:      true,
+	PROFILE_SESSION_TOKEN:          true,
+	PROFILE_REGION:                 true,
+	PROFILE_ENDPOINT:               true,
+	PROFILE_MAX_RETRIES:            true,
+	PROFILE_RETRY_MAX_DELAY_MS:     true,
+	PROFILE_RETRY_BASE_INTERVAL_MS: true,
+	PROFILE_TIMEOUT_MS:             true,
+	PROFILE_PROXY_URL:              true,
+	PROFILE_USER_AGENT_SUFFIX:      true,
+}
+
+// ConfigFile defines the named profiles loaded from the config file in the INI format:
+//
+//     [prod]
+//     access_key_id = ak
+//     secret_access_key = sk
+//     region = gz
+//     timeout_ms = 30000
+//
+//     [prod.bos]
+//     endpoint = gz.bcebos.com
+//
+// or the YAML format with the per-service sections in the services map:
+//
+//     prod:
+//       access_key_id: ak
+//       secret_access_key: sk
+//       region: gz
+//       services:
+//         bos:
+//           endpoint: gz.bcebos.com
+type ConfigFile struct {
+	profiles map[string]*Profile
+}
+
+// Profile defines the settings of a named profile, the settings of a service section override
+// the ones of the profile for the clients of the service
+type Profile struct {
+	Name     string
+	settings map[string]string
+	services map[string]map[string]string
+}
+
+// DefaultConfigFile - get the path of the config file given by the BCE_CONFIG_FILE environment
+// variable or ~/.bce/config by default
+func DefaultConfigFile() string {
+	if path := os.Getenv(CONFIG_FILE_ENV); len(path) != 0 {
+		return path
+	}
+	home := os.Getenv("HOME")
+	if len(home) == 0 {
+		home = os.Getenv("USERPROFILE")
+	}
+	return filepath.Join(home, DEFAULT_CONFIG_FILE)
+}
+
+// LoadConfigFile - load the profiles from the config file, the file with the .yaml or .yml
+// extension is parsed as YAML, otherwise it is parsed as INI if its first section starts with
+// "[" or as YAML if not
+//
+// PARAMS:
+//     - path: the path of the config file
+// RETURNS:
+//     - *ConfigFile: the loaded profiles
+//     - error: nil if ok otherwise the read or parse error
+func LoadConfigFile(path string) (*ConfigFile, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" || !looksLikeINI(content) {
+		return parseYAMLConfig(content)
+	}
+	return parseINIConfig(content)
+}
+
+// Profile - get the named profile
+//
+// PARAMS:
+//     - name: the name of the profile, DEFAULT_PROFILE if it is empty
+// RETURNS:
+//     - *Profile: the profile
+//     - error: nil if ok otherwise the error of the missing profile
+func (f *ConfigFile) Profile(name string) (*Profile, error) {
+	if len(name) == 0 {
+		name = DEFAULT_PROFILE
+	}
+	profile, ok := f.profiles[name]
+	if !ok {
+		return nil, NewBceClientError(fmt.Sprintf("profile %q is not found in the config file", name))
+	}
+	return profile, nil
+}
+
+// Profiles - get the names of all profiles in order
+func (f *ConfigFile) Profiles() []string {
+	names := make([]string, 0, len(f.profiles))
+	for name := range f.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get - get the setting of the service, or the setting of the profile if it is not set by the
+// service section
+//
+// PARAMS:
+//     - service: the service name such as "bos", empty to get the setting of the profile
+//     - key: the setting key such as PROFILE_ENDPOINT
+// RETURNS:
+//     - string: the setting value, empty if it is not set
+func (p *Profile) Get(service, key string) string {
+	if value, ok := p.services[service][key]; ok {
+		return value
+	}
+	return p.settings[key]
+}
+
+// ClientConfig - build the client configuration of the service from the profile, the settings
+// not given by the profile are the defaults of NewBceClientWithAkSk
+//
+// PARAMS:
+//     - service: the service name such as "bos", empty to use the settings of the profile only
+// RETURNS:
+//     - *BceClientConfiguration: the client configuration
+//     - error: nil if ok otherwise the error of the invalid setting
+func (p *Profile) ClientConfig(service string) (*BceClientConfiguration, error) {
+	conf := &BceClientConfiguration{
+		Endpoint:  p.Get(service, PROFILE_ENDPOINT),
+		Region:    p.Get(service, PROFILE_REGION),
+		UserAgent: DEFAULT_USER_AGENT,
+		SignOption: &auth.SignOptions{
+			HeadersToSign: auth.DEFAULT_HEADERS_TO_SIGN,
+			ExpireSeconds: auth.DEFAULT_EXPIRE_SECONDS},
+		Retry:                     DEFAULT_RETRY_POLICY,
+		ConnectionTimeoutInMillis: DEFAULT_CONNECTION_TIMEOUT_IN_MILLIS,
+		ProxyUrl:                  p.Get(service, PROFILE_PROXY_URL),
+		UserAgentSuffix:           p.Get(service, PROFILE_USER_AGENT_SUFFIX),
+	}
+	if len(conf.Region) == 0 {
+		conf.Region = DEFAULT_REGION
+	}
+
+	ak, sk := p.Get(service, PROFILE_ACCESS_KEY_ID), p.Get(service, PROFILE_SECRET_ACCESS_KEY)
+	if len(ak) != 0 || len(sk) != 0 {
+		var err error
+		if token := p.Get(service, PROFILE_SESSION_TOKEN); len(token) != 0 {
+			conf.Credentials, err = auth.NewSessionBceCredentials(ak, sk, token)
+		} else {
+			conf.Credentials, err = auth.NewBceCredentials(ak, sk)
+		}
+		if err != nil {
+			return nil, NewBceClientError(fmt.Sprintf("invalid credentials of profile %q: %v", p.Name, err))
+		}
+	}
+
+	ints := make(map[string]int64)
+	for _, key := range []string{PROFILE_MAX_RETRIES, PROFILE_RETRY_MAX_DELAY_MS,
+		PROFILE_RETRY_BASE_INTERVAL_MS, PROFILE_TIMEOUT_MS} {
+		value := p.Get(service, key)
+		if len(value) == 0 {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			return nil, NewBceClientError(fmt.Sprintf("invalid %s of profile %q: %q", key, p.Name, value))
+		}
+		ints[key] = n
+	}
+	if timeout, ok := ints[PROFILE_TIMEOUT_MS]; ok {
+		conf.ConnectionTimeoutInMillis = int(timeout)
+	}
+	maxRetries, ok1 := ints[PROFILE_MAX_RETRIES]
+	maxDelay, ok2 := ints[PROFILE_RETRY_MAX_DELAY_MS]
+	baseInterval, ok3 := ints[PROFILE_RETRY_BASE_INTERVAL_MS]
+	if ok1 || ok2 || ok3 {
+		// keep the default of the settings not given, the same as DEFAULT_RETRY_POLICY
+		if !ok1 {
+			maxRetries = DEFAULT_MAX_RETRIES
+		}
+		if !ok2 {
+			maxDelay = DEFAULT_RETRY_MAX_DELAY_IN_MILLIS
+		}
+		if !ok3 {
+			baseInterval = DEFAULT_RETRY_BASE_INTERVAL_IN_MILLIS
+		}
+		conf.Retry = NewBackOffRetryPolicy(int(maxRetries), maxDelay, baseInterval)
+	}
+	return conf, nil
+}
+
+// LoadProfileConfig - load the client configuration of the service from the named profile of
+// the default config file, see DefaultConfigFile
+//
+// PARAMS:
+//     - profile: the name of the profile, DEFAULT_PROFILE if it is empty
+//     - service: the service name such as "bos", empty to use the settings of the profile only
+// RETURNS:
+//     - *BceClientConfiguration: the client configuration
+//     - error: nil if ok otherwise the specific error
+func LoadProfileConfig(profile, service string) (*BceClientConfiguration, error) {
+	file, err := LoadConfigFile(DefaultConfigFile())
+	if err != nil {
+		return nil, err
+	}
+	p, err := file.Profile(profile)
+	if err != nil {
+		return nil, err
+	}
+	return p.ClientConfig(service)
+}
+
+// NewClientFromProfile - create the general client from the named profile of the default config
+// file, the endpoint must be given by the profile
+//
+// PARAMS:
+//     - profile: the name of the profile, DEFAULT_PROFILE if it is empty
+// RETURNS:
+//     - *BceClient: the client signed by the bce-auth-v1
+//     - error: nil if ok otherwise the specific error
+func NewClientFromProfile(profile string) (*BceClient, error) {
+	conf, err := LoadProfileConfig(profile, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(conf.Endpoint) == 0 {
+		return nil, NewBceClientError(fmt.Sprintf("endpoint is not set by profile %q", profile))
+	}
+	return NewBceClient(conf, &auth.BceV1Signer{}), nil
+}
+
+func newConfigFile() *ConfigFile {
+	return &ConfigFile{profiles: make(map[string]*Profile)}
+}
+
+func (f *ConfigFile) profile(name string) *Profile {
+	p, ok := f.profiles[name]
+	if !ok {
+		p = &Profile{
+			Name:     name,
+			settings: make(map[string]string),
+			services: make(map[string]map[string]string),
+		}
+		f.profiles[name] = p
+	}
+	return p
+}
+
+// set - set the setting of the profile or its service section, the unknown key is rejected to
+// find the typo early
+func (f *ConfigFile) set(profile, service, key, value string, line int) error {
+	if !profileKeys[key] {
+		return NewBceClientError(fmt.Sprintf("unknown key %q at line %d of the config file", key, line))
+	}
+	p := f.profile(profile)
+	if len(service) == 0 {
+		p.settings[key] = value
+		return nil
+	}
+	if p.services[service] == nil {
+		p.services[service] = make(map[string]string)
+	}
+	p.services[service][key] = value
+	return nil
+}
+
+func looksLikeINI(content []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		return line[0] == '['
+	}
+	return false
+}
+
+// parseINIConfig - parse the sections named "profile" or "profile.service", the optional
+// "profile " prefix of the section name is ignored
+func parseINIConfig(content []byte) (*ConfigFile, error) {
+	file := newConfigFile()
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	var profile, service string
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, NewBceClientError(fmt.Sprintf("invalid section at line %d of the config file", lineNo))
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			name = strings.TrimSpace(strings.TrimPrefix(name, "profile "))
+			parts := strings.SplitN(name, ".", 2)
+			profile, service = parts[0], ""
+			if len(parts) == 2 {
+				service = parts[1]
+			}
+			if len(profile) == 0 {
+				return nil, NewBceClientError(fmt.Sprintf("empty profile name at line %d of the config file", lineNo))
+			}
+			file.profile(profile)
+			continue
+		}
+		eq := strings.Index(line, "=")
+		if eq < 0 || len(profile) == 0 {
+			return nil, NewBceClientError(fmt.Sprintf("invalid line %d of the config file", lineNo))
+		}
+		key := strings.TrimSpace(line[:eq])
+		value := unquote(strings.TrimSpace(line[eq+1:]))
+		if err := file.set(profile, service, key, value, lineNo); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// parseYAMLConfig - parse the subset of YAML with the nested maps of the scalar values, which is
+// enough for the profiles, the lists and the multi-line values are not supported
+func parseYAMLConfig(content []byte) (*ConfigFile, error) {
+	file := newConfigFile()
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	// the keys of the enclosing maps and their indents: profile, "services", service
+	var keys []string
+	var indents []int
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		raw := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(raw, " ")
+		if len(trimmed) == 0 || trimmed[0] == '#' || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") || strings.HasPrefix(trimmed, "- ") {
+			return nil, NewBceClientError(fmt.Sprintf("unsupported yaml at line %d of the config file", lineNo))
+		}
+		indent := len(raw) - len(trimmed)
+		for len(indents) > 0 && indent <= indents[len(indents)-1] {
+			keys, indents = keys[:len(keys)-1], indents[:len(indents)-1]
+		}
+		colon := strings.Index(trimmed, ":")
+		if colon <= 0 {
+			return nil, NewBceClientError(fmt.Sprintf("invalid line %d of the config file", lineNo))
+		}
+		key := strings.TrimSpace(trimmed[:colon])
+		value := unquote(stripYAMLComment(strings.TrimSpace(trimmed[colon+1:])))
+
+		if len(value) == 0 {
+			// start of a nested map
+			valid := len(keys) == 0 || (len(keys) == 1 && key == "services") ||
+				(len(keys) == 2 && keys[1] == "services")
+			if !valid {
+				return nil, NewBceClientError(fmt.Sprintf("unexpected map %q at line %d of the config file", key, lineNo))
+			}
+			if len(keys) == 0 {
+				file.profile(key)
+			}
+			keys, indents = append(keys, key), append(indents, indent)
+			continue
+		}
+		var err error
+		switch len(keys) {
+		case 1:
+			err = file.set(keys[0], "", key, value, lineNo)
+		case 3:
+			err = file.set(keys[0], keys[2], key, value, lineNo)
+		default:
+			err = NewBceClientError(fmt.Sprintf("unexpected value of %q at line %d of the config file", key, lineNo))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func stripYAMLComment(value string) string {
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[:end+2]
+		}
+		return value
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		return strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
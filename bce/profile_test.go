@@ -0,0 +1,233 @@
+package bce
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testINIConfig = `
+# the comment line
+; the other comment line
+[default]
+access_key_id = ak
+secret_access_key = "sk with space"
+region = 'gz'
+
+[profile prod]
+access_key_id=prod-ak
+secret_access_key=prod-sk
+max_retries = 5
+
+[prod.bos]
+endpoint = gz.bcebos.com
+timeout_ms = 30000
+`
+
+const testYAMLConfig = `
+---
+# the comment line
+default:
+  access_key_id: ak
+  secret_access_key: "sk # not a comment"
+  region: gz # the comment
+prod:
+  access_key_id: 'prod-ak'
+  secret_access_key: prod-sk
+  retry_base_interval_ms: 100
+  services:
+    bos:
+      endpoint: gz.bcebos.com
+      timeout_ms: 30000
+`
+
+func TestParseINIConfig(t *testing.T) {
+	file, err := parseINIConfig([]byte(testINIConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := strings.Join(file.Profiles(), ","); names != "default,prod" {
+		t.Fatalf("profiles: got %q", names)
+	}
+	p, _ := file.Profile("")
+	cases := []struct {
+		service, key, expected string
+	}{
+		{"", PROFILE_ACCESS_KEY_ID, "ak"},
+		{"", PROFILE_SECRET_ACCESS_KEY, "sk with space"},
+		{"", PROFILE_REGION, "gz"},
+		{"bos", PROFILE_REGION, "gz"},
+	}
+	for _, c := range cases {
+		if got := p.Get(c.service, c.key); got != c.expected {
+			t.Errorf("default %s/%s: got %q, expected %q", c.service, c.key, got, c.expected)
+		}
+	}
+
+	p, err = file.Profile("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Get("bos", PROFILE_ENDPOINT); got != "gz.bcebos.com" {
+		t.Errorf("prod bos endpoint: got %q", got)
+	}
+	if got := p.Get("", PROFILE_ENDPOINT); got != "" {
+		t.Errorf("prod endpoint: got %q, expected empty", got)
+	}
+	if got := p.Get("bos", PROFILE_ACCESS_KEY_ID); got != "prod-ak" {
+		t.Errorf("prod bos access key: got %q", got)
+	}
+}
+
+func TestParseYAMLConfig(t *testing.T) {
+	file, err := parseYAMLConfig([]byte(testYAMLConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := file.Profile(DEFAULT_PROFILE)
+	if got := p.Get("", PROFILE_SECRET_ACCESS_KEY); got != "sk # not a comment" {
+		t.Errorf("quoted value: got %q", got)
+	}
+	if got := p.Get("", PROFILE_REGION); got != "gz" {
+		t.Errorf("value with comment: got %q", got)
+	}
+	p, err = file.Profile("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Get("", PROFILE_ACCESS_KEY_ID); got != "prod-ak" {
+		t.Errorf("single quoted value: got %q", got)
+	}
+	if got := p.Get("bos", PROFILE_ENDPOINT); got != "gz.bcebos.com" {
+		t.Errorf("service value: got %q", got)
+	}
+	if got := p.Get("bos", PROFILE_TIMEOUT_MS); got != "30000" {
+		t.Errorf("service timeout: got %q", got)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		parse   func([]byte) (*ConfigFile, error)
+		content string
+	}{
+		{"ini unknown key", parseINIConfig, "[default]\nacess_key_id = ak\n"},
+		{"ini key before section", parseINIConfig, "access_key_id = ak\n"},
+		{"ini unclosed section", parseINIConfig, "[default\n"},
+		{"ini empty profile", parseINIConfig, "[.bos]\n"},
+		{"yaml unknown key", parseYAMLConfig, "default:\n  acess_key_id: ak\n"},
+		{"yaml list", parseYAMLConfig, "default:\n  - ak\n"},
+		{"yaml unexpected map", parseYAMLConfig, "default:\n  region:\n    gz: 1\n"},
+	}
+	for _, c := range cases {
+		if _, err := c.parse([]byte(c.content)); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+	}
+}
+
+func TestMissingProfile(t *testing.T) {
+	file, err := parseINIConfig([]byte(testINIConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Profile("test"); err == nil || !strings.Contains(err.Error(), `"test"`) {
+		t.Errorf("missing profile: got %v", err)
+	}
+}
+
+func TestProfileClientConfig(t *testing.T) {
+	file, err := parseINIConfig([]byte(testINIConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := file.Profile("")
+	conf, err := p.ClientConfig("bos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Retry != DEFAULT_RETRY_POLICY {
+		t.Errorf("retry: got %v, expected the default policy", conf.Retry)
+	}
+	if conf.ConnectionTimeoutInMillis != DEFAULT_CONNECTION_TIMEOUT_IN_MILLIS {
+		t.Errorf("timeout: got %d", conf.ConnectionTimeoutInMillis)
+	}
+	if conf.Credentials.SecretAccessKey != "sk with space" {
+		t.Errorf("secret access key: got %q", conf.Credentials.SecretAccessKey)
+	}
+
+	p, _ = file.Profile("prod")
+	conf, err = p.ClientConfig("bos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Endpoint != "gz.bcebos.com" || conf.Region != DEFAULT_REGION {
+		t.Errorf("endpoint and region: got %q and %q", conf.Endpoint, conf.Region)
+	}
+	if conf.ConnectionTimeoutInMillis != 30000 {
+		t.Errorf("timeout: got %d", conf.ConnectionTimeoutInMillis)
+	}
+	retry, ok := conf.Retry.(*BackOffRetryPolicy)
+	if !ok {
+		t.Fatalf("retry: got %T", conf.Retry)
+	}
+	expected := NewBackOffRetryPolicy(5, DEFAULT_RETRY_MAX_DELAY_IN_MILLIS,
+		DEFAULT_RETRY_BASE_INTERVAL_IN_MILLIS)
+	if *retry != *expected {
+		t.Errorf("retry: got %+v, expected %+v", *retry, *expected)
+	}
+
+	p.settings[PROFILE_MAX_RETRIES] = "-1"
+	if _, err := p.ClientConfig(""); err == nil {
+		t.Errorf("negative max retries: expected error")
+	}
+}
+
+func TestLoadProfileConfigFromEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bce-profile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(testYAMLConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	origin, set := os.LookupEnv(CONFIG_FILE_ENV)
+	defer func() {
+		if set {
+			os.Setenv(CONFIG_FILE_ENV, origin)
+		} else {
+			os.Unsetenv(CONFIG_FILE_ENV)
+		}
+	}()
+	os.Setenv(CONFIG_FILE_ENV, path)
+
+	if got := DefaultConfigFile(); got != path {
+		t.Errorf("config file: got %q, expected %q", got, path)
+	}
+	conf, err := LoadProfileConfig("prod", "bos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Endpoint != "gz.bcebos.com" || conf.Credentials.AccessKeyId != "prod-ak" {
+		t.Errorf("config: got endpoint %q and access key %q", conf.Endpoint,
+			conf.Credentials.AccessKeyId)
+	}
+	retry := conf.Retry.(*BackOffRetryPolicy)
+	expected := NewBackOffRetryPolicy(DEFAULT_MAX_RETRIES, DEFAULT_RETRY_MAX_DELAY_IN_MILLIS, 100)
+	if *retry != *expected {
+		t.Errorf("retry: got %+v, expected %+v", *retry, *expected)
+	}
+	if _, err := LoadProfileConfig("missing", ""); err == nil {
+		t.Errorf("missing profile: expected error")
+	}
+
+	os.Setenv(CONFIG_FILE_ENV, filepath.Join(dir, "not-exist"))
+	if _, err := LoadProfileConfig("", ""); err == nil {
+		t.Errorf("missing config file: expected error")
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// proxy.go - resolve the proxy url for the request according to the client configuration
+
+package bce
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// proxyUrlFor - get the proxy url to access the given host
+//
+// PARAMS:
+//     - host: the host of the request with optional port
+// RETURNS:
+//     - string: the proxy url with credentials, empty if the host should be accessed directly
+func (c *BceClientConfiguration) proxyUrlFor(host string) string {
+	if len(c.ProxyUrl) == 0 || matchNoProxy(host, c.NoProxy) {
+		return ""
+	}
+	proxyUrl := c.ProxyUrl
+	if !strings.Contains(proxyUrl, "://") {
+		proxyUrl = "http://" + proxyUrl
+	}
+	if len(c.ProxyUsername) == 0 {
+		return proxyUrl
+	}
+	u, err := url.Parse(proxyUrl)
+	if err != nil {
+		return proxyUrl
+	}
+	u.User = url.UserPassword(c.ProxyUsername, c.ProxyPassword)
+	return u.String()
+}
+
+// matchNoProxy - check whether the given host matches any of the no proxy patterns
+//
+// PARAMS:
+//     - host: the host of the request with optional port
+//     - patterns: the no proxy patterns
+// RETURNS:
+//     - bool: true if the host should not use proxy
+func matchNoProxy(host string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if len(pattern) == 0 {
+			continue
+		}
+		if pattern == "*" {
+			return true
+		}
+		if p, _, err := net.SplitHostPort(pattern); err == nil {
+			pattern = p
+		}
+		if ip != nil {
+			if _, cidr, err := net.ParseCIDR(pattern); err == nil {
+				if cidr.Contains(ip) {
+					return true
+				}
+				continue
+			}
+			if patternIp := net.ParseIP(pattern); patternIp != nil && patternIp.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		pattern = strings.TrimPrefix(pattern, "*")
+		pattern = strings.TrimPrefix(pattern, ".")
+		if host == pattern || strings.HasSuffix(host, "."+pattern) {
+			return true
+		}
+	}
+	return false
+}
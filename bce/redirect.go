@@ -0,0 +1,184 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// redirect.go - follow the http redirects with the configurable policy
+
+package bce
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+// DEFAULT_MAX_REDIRECTS is the max redirects followed for a request if not set by the policy, it
+// is the same as the standard library
+const DEFAULT_MAX_REDIRECTS = 10
+
+// RedirectPolicy defines how the redirects are followed by the client instead of the standard
+// library, which keeps the stale signature of the original url and can not resend the body.
+//
+// The 301, 302 and 303 redirects of the GET and HEAD requests keep the method, and the other
+// requests are changed to GET without body like the standard library. The 307 and 308 redirects
+// keep the method, and the requests with body are only followed if the PreserveMethod is true
+// and the body can be sent again. The redirects not followed are returned as the responses.
+type RedirectPolicy struct {
+	// MaxRedirects is the max redirects followed for a request, DEFAULT_MAX_REDIRECTS if it is 0
+	// and none is followed if it is negative
+	MaxRedirects int
+	// ReSign signs the redirected request again with the credentials since the signature covers
+	// the host and path. Only the requests redirected to the same host or the ReSignHosts are
+	// signed again, the signature and the security token are always removed if the request is
+	// redirected to another host, so that the credentials are not leaked to the Location.
+	ReSign bool
+	// ReSignHosts are the other hosts trusted to receive the signed requests if ReSign is true,
+	// eg: the region endpoints of the service, matched with or without the port ignoring case
+	ReSignHosts []string
+	// PreserveMethod sends the same method and body for the 307 and 308 redirects of the
+	// requests with body
+	PreserveMethod bool
+}
+
+// NewRedirectPolicy - create the redirect policy signing the requests redirected to the same host
+// again and preserving the method for the 307 and 308 redirects
+//
+// PARAMS:
+//     - maxRedirects: the max redirects followed for a request, 0 for the default
+// RETURNS:
+//     - *RedirectPolicy: the redirect policy
+func NewRedirectPolicy(maxRedirects int) *RedirectPolicy {
+	return &RedirectPolicy{MaxRedirects: maxRedirects, ReSign: true, PreserveMethod: true}
+}
+
+// WithRedirectPolicy overrides the redirect policy of the requests, nil restores the default
+// behavior of the http client.
+func WithRedirectPolicy(policy *RedirectPolicy) RequestOption {
+	return func(c *BceClientConfiguration) { c.RedirectPolicy = policy }
+}
+
+func (p *RedirectPolicy) maxRedirects() int {
+	if p.MaxRedirects == 0 {
+		return DEFAULT_MAX_REDIRECTS
+	}
+	return p.MaxRedirects
+}
+
+// trusted - check whether the redirected request to the host is signed again
+func (p *RedirectPolicy) trusted(host, originalHost string) bool {
+	if !p.ReSign {
+		return false
+	}
+	if strings.EqualFold(host, originalHost) {
+		return true
+	}
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	for _, trusted := range p.ReSignHosts {
+		if strings.EqualFold(trusted, host) || strings.EqualFold(trusted, hostname) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRedirect - check whether the status code is a redirect with the Location to follow
+func isRedirect(statusCode int) bool {
+	switch statusCode {
+	case 301, 302, 303, 307, 308:
+		return true
+	}
+	return false
+}
+
+// followRedirect - point the request to the Location of the redirect response by the redirect
+// policy, and sign the request again if required
+//
+// PARAMS:
+//     - req: the request redirected by the response
+//     - resp: the response of the request
+//     - redirects: the number of the redirects followed before
+//     - replayable: whether the body of the request can be sent again
+// RETURNS:
+//     - bool: true if the request should be sent again to the new location
+//     - error: the error if there are too many redirects or the Location is invalid
+func (c *BceClient) followRedirect(req *BceRequest, resp *BceResponse, redirects int,
+	replayable bool) (bool, error) {
+	policy := c.Config.RedirectPolicy
+	if policy == nil || !isRedirect(resp.StatusCode()) || policy.maxRedirects() < 0 {
+		return false, nil
+	}
+	location := resp.Header(http.LOCATION)
+	if len(location) == 0 {
+		return false, nil
+	}
+	statusCode, method := resp.StatusCode(), req.Method()
+	keepMethod := statusCode == 307 || statusCode == 308 ||
+		method == http.GET || method == http.HEAD
+	if keepMethod && req.Body() != nil && (!policy.PreserveMethod || !replayable) {
+		return false, nil
+	}
+	if redirects >= policy.maxRedirects() {
+		return false, NewBceClientError(fmt.Sprintf("stopped after %d redirects, the last location: %s",
+			redirects, location))
+	}
+	base := &url.URL{Scheme: req.Protocol(), Host: req.Host(), Path: req.Uri()}
+	target, err := base.Parse(location)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+		return false, NewBceClientError(fmt.Sprintf("invalid redirect location: %s", location))
+	}
+	if resp.Body() != nil {
+		resp.Body().Close()
+	}
+	log.Infof("follow the %d redirect of %s %s to %s", statusCode, method, req.Uri(), target)
+
+	if !keepMethod {
+		req.SetMethod(http.GET)
+		req.Request.SetBody(nil)
+		req.SetLength(0)
+		delete(req.Headers(), http.CONTENT_LENGTH)
+		delete(req.Headers(), http.CONTENT_MD5)
+	}
+	originalHost := req.Host()
+	trusted := policy.trusted(target.Host, originalHost)
+	req.SetPort(0)
+	req.SetEndpoint(target.Scheme + "://" + target.Host)
+	req.SetUri(target.Path)
+	params := make(map[string]string)
+	for k, v := range target.Query() {
+		params[k] = v[0]
+	}
+	req.SetParams(params)
+	req.SetHeader(http.HOST, req.Host())
+	if proxyUrl := c.Config.proxyUrlFor(req.Host()); proxyUrl != req.ProxyUrl() {
+		req.SetProxyUrl(proxyUrl)
+	}
+
+	if trusted {
+		c.sign(req)
+	} else if !strings.EqualFold(target.Host, originalHost) {
+		log.Warnf("remove the credentials of the request redirected to the untrusted host %s",
+			target.Host)
+		delete(req.Headers(), http.AUTHORIZATION)
+		delete(req.Headers(), http.BCE_SECURITY_TOKEN)
+		// never sign the request again by the following redirects, retries or failovers
+		req.credentials = nil
+	}
+	return true, nil
+}
@@ -0,0 +1,96 @@
+package bce
+
+import (
+	gohttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/auth"
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+// redirectServer - start the server redirecting the "/to/{url}" to the url and recording the
+// credentials of the requests by the path
+type redirectServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	auths map[string][2]string
+}
+
+func newRedirectServer() *redirectServer {
+	s := &redirectServer{auths: make(map[string][2]string)}
+	s.Server = httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		s.mu.Lock()
+		s.auths[r.URL.Path] = [2]string{r.Header.Get(http.AUTHORIZATION),
+			r.Header.Get(http.BCE_SECURITY_TOKEN)}
+		s.mu.Unlock()
+		if strings.HasPrefix(r.URL.Path, "/to/") {
+			gohttp.Redirect(w, r, "http://"+strings.TrimPrefix(r.URL.Path, "/to/"), gohttp.StatusFound)
+		}
+	}))
+	return s
+}
+
+func (s *redirectServer) host() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func TestRedirectCredentials(t *testing.T) {
+	origin, other := newRedirectServer(), newRedirectServer()
+	defer origin.Close()
+	defer other.Close()
+	client, err := NewBceClientWithAkSk("ak", "sk", origin.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Config.Credentials, _ = auth.NewSessionBceCredentials("ak", "sk", "token")
+	client.Config.Retry = NewNoRetryPolicy()
+
+	cases := []struct {
+		name       string
+		policy     *RedirectPolicy
+		path       string
+		server     *redirectServer
+		target     string
+		signed     bool
+		withHeader bool
+	}{
+		{"same host", NewRedirectPolicy(0), "/to/" + origin.host() + "/same", origin, "/same",
+			true, true},
+		{"other host", NewRedirectPolicy(0), "/to/" + other.host() + "/other", other, "/other",
+			false, false},
+		{"trusted host", &RedirectPolicy{ReSign: true, ReSignHosts: []string{other.host()}},
+			"/to/" + other.host() + "/trusted", other, "/trusted", true, true},
+		{"same host of the other host", NewRedirectPolicy(0),
+			"/to/" + other.host() + "/to/" + other.host() + "/chained", other, "/chained",
+			false, false},
+		{"other host without signing", &RedirectPolicy{}, "/to/" + other.host() + "/unsigned",
+			other, "/unsigned", false, false},
+		{"same host without signing", &RedirectPolicy{}, "/to/" + origin.host() + "/stale",
+			origin, "/stale", false, true},
+	}
+	for _, c := range cases {
+		err := NewRequestBuilder(client.WithOptions(WithRedirectPolicy(c.policy))).
+			WithURL(c.path).
+			WithMethod(http.GET).
+			Do()
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		auth, ok := c.server.auths[c.target]
+		if !ok {
+			t.Errorf("%s: the request is not redirected", c.name)
+			continue
+		}
+		if (len(auth[0]) != 0) != c.withHeader || (auth[1] == "token") != c.withHeader {
+			t.Errorf("%s: got the authorization %q and the token %q", c.name, auth[0], auth[1])
+		}
+		// the stale signature of the original path is kept if not signed again
+		if c.withHeader && (auth[0] != origin.auths[c.path][0]) != c.signed {
+			t.Errorf("%s: got the authorization %q, signed again: %v", c.name, auth[0], c.signed)
+		}
+	}
+}
@@ -0,0 +1,484 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// replay.go - record the signed requests and their responses to the fixture files and replay them
+
+// Package replay records the requests sent by the SDK clients and the responses to the fixture
+// files, and serves the recorded responses back without the network, so that the tests of the
+// service packages are deterministic and run without the live credentials. The transport is set
+// to the Transport of the client configuration:
+//
+//     transport, done, err := replay.NewTransport("testdata/list_buckets.json")
+//     client.Config.Transport = transport
+//     defer func() {
+//         if err := done(); err != nil {...}
+//     }()
+//
+// The transport records the interactions by the real endpoint if the BCE_REPLAY_MODE environment
+// variable is "record", and replays the fixture otherwise. The requests are matched by the method,
+// the path and the sorted query string, so the replayed client may use any endpoint.
+package replay
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	bcehttp "github.com/baidubce/bce-sdk-go/http"
+)
+
+const (
+	MODE_RECORD = "record"
+	MODE_REPLAY = "replay"
+
+	// MODE_ENV is the environment variable to choose the mode of the NewTransport
+	MODE_ENV = "BCE_REPLAY_MODE"
+
+	// REDACTED replaces the secrets of the recorded requests
+	REDACTED = "REDACTED"
+
+	BODY_ENCODING_BASE64 = "base64"
+)
+
+// DEFAULT_IGNORED_PARAMS are the query parameters generated randomly by the clients, which are
+// ignored when matching the requests
+var DEFAULT_IGNORED_PARAMS = []string{"clientToken"}
+
+// DEFAULT_REDACTED_FIELDS are the json fields of the response bodies redacted by default, which
+// are the temporary credentials returned by the STS
+var DEFAULT_REDACTED_FIELDS = []string{"accessKeyId", "secretAccessKey", "sessionToken"}
+
+// Fixture defines the recorded interactions in the order they are sent
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction defines a request and the response to it
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request defines the recorded request, the body is base64 encoded if it is not the utf-8 text
+type Request struct {
+	Method       string      `json:"method"`
+	Url          string      `json:"url"`
+	Header       http.Header `json:"header"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// Response defines the recorded response, the body is base64 encoded if it is not the utf-8 text
+type Response struct {
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// LoadFixture - load the fixture from the file
+//
+// PARAMS:
+//     - path: the path of the fixture file
+// RETURNS:
+//     - *Fixture: the recorded interactions
+//     - error: nil if success otherwise the specific error
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fixture := &Fixture{}
+	if err := json.Unmarshal(data, fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %v", path, err)
+	}
+	return fixture, nil
+}
+
+// Save - save the fixture to the file, the parent directories are created if not exist
+//
+// PARAMS:
+//     - path: the path of the fixture file
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Recorder is the transport sending the requests by the underlying transport and recording them
+// with the responses. The Authorization and the security token of the headers and the STS
+// credentials of the response bodies are redacted by default, the redactions only change the
+// recorded interactions but not the requests and the responses.
+type Recorder struct {
+	Transport http.RoundTripper
+	// Redact redacts the request headers
+	Redact func(header http.Header)
+	// RedactResponseHeader redacts the response headers
+	RedactResponseHeader func(header http.Header)
+	// RedactResponseBody returns the redacted response body, the Content-Length of the recorded
+	// response is updated if the length is changed
+	RedactResponseBody func(body []byte) []byte
+
+	lock    sync.Mutex
+	fixture Fixture
+}
+
+// NewRecorder - create the recorder sending the requests by the given transport
+//
+// PARAMS:
+//     - transport: the underlying transport, the shared transport of the SDK if nil
+// RETURNS:
+//     - *Recorder: the recorder
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	return &Recorder{
+		Transport:            transport,
+		Redact:               RedactCredentials,
+		RedactResponseHeader: RedactCredentials,
+		RedactResponseBody:   RedactSTSCredentials,
+	}
+}
+
+// RoundTrip - send the request by the underlying transport and record the interaction
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	transport := r.Transport
+	if transport == nil {
+		if shared := bcehttp.SharedTransport(); shared != nil {
+			transport = shared
+		} else {
+			transport = http.DefaultTransport
+		}
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Request:  Request{Method: req.Method, Url: req.URL.String(), Header: cloneHeader(req.Header)},
+		Response: Response{StatusCode: resp.StatusCode, Header: cloneHeader(resp.Header)},
+	}
+	if len(req.Host) != 0 {
+		interaction.Request.Header.Set("Host", req.Host)
+	}
+	if r.Redact != nil {
+		r.Redact(interaction.Request.Header)
+	}
+	if r.RedactResponseHeader != nil {
+		r.RedactResponseHeader(interaction.Response.Header)
+	}
+	recordedBody := respBody
+	if r.RedactResponseBody != nil && len(respBody) != 0 {
+		recordedBody = r.RedactResponseBody(respBody)
+		header := interaction.Response.Header
+		if len(recordedBody) != len(respBody) && len(header.Get("Content-Length")) != 0 {
+			header.Set("Content-Length", strconv.Itoa(len(recordedBody)))
+		}
+	}
+	interaction.Request.Body, interaction.Request.BodyEncoding = encodeBody(reqBody)
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeBody(recordedBody)
+
+	r.lock.Lock()
+	r.fixture.Interactions = append(r.fixture.Interactions, interaction)
+	r.lock.Unlock()
+	return resp, nil
+}
+
+// Fixture - get a copy of the interactions recorded so far
+func (r *Recorder) Fixture() *Fixture {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return &Fixture{Interactions: append([]Interaction{}, r.fixture.Interactions...)}
+}
+
+// Save - save the interactions recorded so far to the fixture file
+func (r *Recorder) Save(path string) error {
+	return r.Fixture().Save(path)
+}
+
+// RedactCredentials - redact the access key id and the signature of the Authorization and the
+// security token, the signed headers and the timestamp of the Authorization are kept
+func RedactCredentials(header http.Header) {
+	if auth := header.Get(bcehttp.AUTHORIZATION); len(auth) != 0 {
+		// bce-auth-v1/{accessKeyId}/{signDate}/{expireSeconds}/{signedHeaders}/{signature}
+		parts := strings.Split(auth, "/")
+		if len(parts) == 6 {
+			parts[1] = REDACTED
+			parts[5] = REDACTED
+			header.Set(bcehttp.AUTHORIZATION, strings.Join(parts, "/"))
+		} else {
+			header.Set(bcehttp.AUTHORIZATION, REDACTED)
+		}
+	}
+	if len(header.Get(bcehttp.BCE_SECURITY_TOKEN)) != 0 {
+		header.Set(bcehttp.BCE_SECURITY_TOKEN, REDACTED)
+	}
+}
+
+// RedactJSONFields - create the redaction of the response body which replaces the string values
+// of the given json fields at any level with the REDACTED, the field names are case-insensitive
+// and the rest of the body is kept as it is
+//
+// PARAMS:
+//     - fields: the names of the json fields to redact
+// RETURNS:
+//     - func(body []byte) []byte: the redaction to set to the RedactResponseBody
+func RedactJSONFields(fields ...string) func(body []byte) []byte {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, regexp.QuoteMeta(f))
+	}
+	pattern := regexp.MustCompile(`(?i)("(?:` + strings.Join(names, "|") +
+		`)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	replacement := []byte(`${1}"` + REDACTED + `"`)
+	return func(body []byte) []byte {
+		return pattern.ReplaceAll(body, replacement)
+	}
+}
+
+// RedactSTSCredentials - redact the DEFAULT_REDACTED_FIELDS of the response body, eg: the
+// temporary credentials returned by the GetSessionToken and the AssumeRole of the STS
+func RedactSTSCredentials(body []byte) []byte {
+	return redactSTSCredentials(body)
+}
+
+var redactSTSCredentials = RedactJSONFields(DEFAULT_REDACTED_FIELDS...)
+
+// Replayer is the transport serving the recorded responses, each recorded interaction is served
+// once to the first request matching it. The request matching none of the unused interactions
+// fails with the error telling its canonical key.
+type Replayer struct {
+	MatchBody    bool     // match the sha256 of the request body besides the canonical key
+	IgnoreParams []string // the query parameters ignored when matching
+
+	lock    sync.Mutex
+	fixture *Fixture
+	used    []bool
+}
+
+// NewReplayer - create the replayer of the fixture
+//
+// PARAMS:
+//     - fixture: the recorded interactions
+// RETURNS:
+//     - *Replayer: the replayer ignoring the DEFAULT_IGNORED_PARAMS
+func NewReplayer(fixture *Fixture) *Replayer {
+	return &Replayer{
+		IgnoreParams: DEFAULT_IGNORED_PARAMS,
+		fixture:      fixture,
+		used:         make([]bool, len(fixture.Interactions)),
+	}
+}
+
+// RoundTrip - serve the response of the first unused interaction matching the request
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	key := CanonicalKey(req.Method, req.URL, r.IgnoreParams)
+	bodyHash := hashBody(reqBody)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i, interaction := range r.fixture.Interactions {
+		if r.used[i] || interaction.Request.Method != req.Method {
+			continue
+		}
+		recorded, err := url.Parse(interaction.Request.Url)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recorded url %s: %v", interaction.Request.Url, err)
+		}
+		if CanonicalKey(interaction.Request.Method, recorded, r.IgnoreParams) != key {
+			continue
+		}
+		if r.MatchBody {
+			recordedBody, err := decodeBody(interaction.Request.Body, interaction.Request.BodyEncoding)
+			if err != nil {
+				return nil, err
+			}
+			if hashBody(recordedBody) != bodyHash {
+				continue
+			}
+		}
+		body, err := decodeBody(interaction.Response.Body, interaction.Response.BodyEncoding)
+		if err != nil {
+			return nil, err
+		}
+		r.used[i] = true
+		status := interaction.Response.StatusCode
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        cloneHeader(interaction.Response.Header),
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("replay: no recorded interaction for %s", key)
+}
+
+// Remaining - get the number of the recorded interactions not served yet
+func (r *Replayer) Remaining() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	count := 0
+	for _, used := range r.used {
+		if !used {
+			count++
+		}
+	}
+	return count
+}
+
+// CanonicalKey - get the key to match the request, which is the method, the path and the query
+// string sorted by the keys and the values without the ignored parameters
+//
+// PARAMS:
+//     - method: the http method
+//     - u: the url of the request
+//     - ignoreParams: the query parameters to ignore
+// RETURNS:
+//     - string: the canonical key, eg: "GET /bucket/object?acl=&uploadId=1"
+func CanonicalKey(method string, u *url.URL, ignoreParams []string) string {
+	query := u.Query()
+	for _, p := range ignoreParams {
+		query.Del(p)
+	}
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	params := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := append([]string{}, query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			params = append(params, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	path := u.EscapedPath()
+	if len(path) == 0 {
+		path = "/"
+	}
+	key := strings.ToUpper(method) + " " + path
+	if len(params) != 0 {
+		key += "?" + strings.Join(params, "&")
+	}
+	return key
+}
+
+// NewTransport - create the recorder or the replayer of the fixture file by the BCE_REPLAY_MODE
+// environment variable
+//
+// PARAMS:
+//     - path: the path of the fixture file
+// RETURNS:
+//     - http.RoundTripper: the recorder if the mode is "record" otherwise the replayer
+//     - func() error: the function called after the test, which saves the fixture if recording,
+//       or returns the error if any recorded interaction is not served if replaying
+//     - error: nil if success otherwise the specific error
+func NewTransport(path string) (http.RoundTripper, func() error, error) {
+	if os.Getenv(MODE_ENV) == MODE_RECORD {
+		recorder := NewRecorder(nil)
+		return recorder, func() error { return recorder.Save(path) }, nil
+	}
+	fixture, err := LoadFixture(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	replayer := NewReplayer(fixture)
+	done := func() error {
+		if remaining := replayer.Remaining(); remaining != 0 {
+			return fmt.Errorf("replay: %d recorded interactions of %s are not served", remaining, path)
+		}
+		return nil
+	}
+	return replayer, done, nil
+}
+
+// readBody reads the body and replaces it with the one reading the same content
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = ioutil.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func encodeBody(data []byte) (string, string) {
+	if utf8.Valid(data) {
+		return string(data), ""
+	}
+	return base64.StdEncoding.EncodeToString(data), BODY_ENCODING_BASE64
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	if encoding == BODY_ENCODING_BASE64 {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
+
+func hashBody(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func cloneHeader(header http.Header) http.Header {
+	result := make(http.Header, len(header))
+	for k, v := range header {
+		result[k] = append([]string{}, v...)
+	}
+	return result
+}
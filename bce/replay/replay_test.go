@@ -0,0 +1,89 @@
+package replay
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const stsResponse = `{
+  "accessKeyId": "tmp-ak",
+  "secretAccessKey" : "tmp-sk",
+  "sessionToken": "token\"with/escapes==",
+  "expiration": "2022-05-01T00:00:00Z",
+  "nested": {"AccessKeyId": "nested-ak"}
+}`
+
+func TestRedactJSONFields(t *testing.T) {
+	redacted := string(RedactSTSCredentials([]byte(stsResponse)))
+	for _, secret := range []string{"tmp-ak", "tmp-sk", "token", "nested-ak"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("%s is not redacted: %s", secret, redacted)
+		}
+	}
+	for _, kept := range []string{`"accessKeyId": "REDACTED"`, `"secretAccessKey" : "REDACTED"`,
+		`"expiration": "2022-05-01T00:00:00Z"`} {
+		if !strings.Contains(redacted, kept) {
+			t.Errorf("%s is not kept: %s", kept, redacted)
+		}
+	}
+
+	redact := RedactJSONFields("password")
+	if got := string(redact([]byte(`{"password":"p","accessKeyId":"ak"}`))); got !=
+		`{"password":"REDACTED","accessKeyId":"ak"}` {
+		t.Errorf("custom fields: got %s", got)
+	}
+}
+
+func TestRecorderRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(stsResponse)))
+		w.Header().Set("X-Bce-Security-Token", "response-token")
+		w.Write([]byte(stsResponse))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(http.DefaultTransport)
+	req, _ := http.NewRequest("POST", server.URL+"/v1/sessionToken", nil)
+	req.Header.Set("Authorization", "bce-auth-v1/ak/2022-04-15T05:20:00Z/1800/host/signature")
+	resp, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != stsResponse {
+		t.Errorf("the response to the client is redacted: %s", body)
+	}
+
+	interaction := recorder.Fixture().Interactions[0]
+	if got := interaction.Request.Header.Get("Authorization"); got !=
+		"bce-auth-v1/REDACTED/2022-04-15T05:20:00Z/1800/host/REDACTED" {
+		t.Errorf("authorization: got %s", got)
+	}
+	if got := interaction.Response.Header.Get("X-Bce-Security-Token"); got != REDACTED {
+		t.Errorf("response security token: got %s", got)
+	}
+	if strings.Contains(interaction.Response.Body, "tmp-sk") {
+		t.Errorf("response body is not redacted: %s", interaction.Response.Body)
+	}
+	if got := interaction.Response.Header.Get("Content-Length"); got !=
+		strconv.Itoa(len(interaction.Response.Body)) {
+		t.Errorf("content length: got %s, expected %d", got, len(interaction.Response.Body))
+	}
+
+	// replay the redacted fixture
+	replayer := NewReplayer(recorder.Fixture())
+	req, _ = http.NewRequest("POST", "http://sts.bj.baidubce.com/v1/sessionToken", nil)
+	resp, err = replayer.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = ioutil.ReadAll(resp.Body)
+	if string(body) != interaction.Response.Body || replayer.Remaining() != 0 {
+		t.Errorf("replay: got %s", body)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"os"
 
+	"github.com/baidubce/bce-sdk-go/auth"
 	"github.com/baidubce/bce-sdk-go/http"
 	"github.com/baidubce/bce-sdk-go/util"
 )
@@ -31,20 +32,56 @@ import (
 // Every BCE request that sets the body field must set its content-length and content-md5 headers
 // to ensure the correctness of the body content forcely, and users can also set the content-sha256
 // header to strengthen the correctness with the "SetHeader" method.
+//
+// The bodies built from the bytes, files, sections of file, io.ReadSeeker and factories are
+// rewindable, they are read again from the start to resend the request on retry instead of being
+// buffered in memory. The other bodies are buffered while they are sent if the retry is enabled.
 type Body struct {
 	stream     io.ReadCloser
 	size       int64
 	contentMD5 string
+	rewind     func() (io.Reader, error) // get the reader from the start of the body, nil if not rewindable
 }
 
 func (b *Body) Stream() io.ReadCloser { return b.stream }
 
-func (b *Body) SetStream(stream io.ReadCloser) { b.stream = stream }
+// SetStream replaces the stream of the body, eg: the wrapper to count the progress, and the body
+// is not rewindable any more since the wrapper may not be read again.
+func (b *Body) SetStream(stream io.ReadCloser) {
+	b.stream = stream
+	b.rewind = nil
+}
 
 func (b *Body) Size() int64 { return b.size }
 
 func (b *Body) ContentMD5() string { return b.contentMD5 }
 
+// Rewindable - check whether the body can be read again from the start to resend the request
+func (b *Body) Rewindable() bool { return b.rewind != nil }
+
+// Rewind - get the reader of the body from the start to resend the request, the stream of the
+// body should not be read by others concurrently
+//
+// RETURNS:
+//     - io.Reader: the reader from the start of the body
+//     - error: the error of seeking or reopening the body, or the body is not rewindable
+func (b *Body) Rewind() (io.Reader, error) {
+	if b.rewind == nil {
+		return nil, NewBceClientError("the body is not rewindable")
+	}
+	return b.rewind()
+}
+
+// newBytesBody - build a rewindable Body object from the bytes with the calculated content-md5
+func newBytesBody(data []byte, contentMD5 string) *Body {
+	return &Body{
+		stream:     ioutil.NopCloser(bytes.NewReader(data)),
+		size:       int64(len(data)),
+		contentMD5: contentMD5,
+		rewind:     func() (io.Reader, error) { return bytes.NewReader(data), nil },
+	}
+}
+
 // NewBodyFromBytes - build a Body object from the byte stream to be used in the http request, it
 // calculates the content-md5 of the byte stream and store the size as well as the stream.
 //
@@ -60,8 +97,7 @@ func NewBodyFromBytes(stream []byte) (*Body, error) {
 	if err != nil {
 		return nil, err
 	}
-	buf = bytes.NewBuffer(stream)
-	return &Body{ioutil.NopCloser(buf), size, contentMD5}, nil
+	return newBytesBody(stream, contentMD5), nil
 }
 
 // NewBodyFromString - build a Body object from the string to be used in the http request, it
@@ -79,8 +115,7 @@ func NewBodyFromString(str string) (*Body, error) {
 	if err != nil {
 		return nil, err
 	}
-	buf = bytes.NewBufferString(str)
-	return &Body{ioutil.NopCloser(buf), size, contentMD5}, nil
+	return newBytesBody([]byte(str), contentMD5), nil
 }
 
 // NewBodyFromFile - build a Body object from the given file name to be used in the http request,
@@ -107,7 +142,13 @@ func NewBodyFromFile(fname string) (*Body, error) {
 	if _, err = file.Seek(0, 0); err != nil {
 		return nil, err
 	}
-	return &Body{file, fileInfo.Size(), contentMD5}, nil
+	rewind := func() (io.Reader, error) {
+		if _, err := file.Seek(0, 0); err != nil {
+			return nil, err
+		}
+		return file, nil
+	}
+	return &Body{file, fileInfo.Size(), contentMD5, rewind}, nil
 }
 
 // NewBodyFromSectionFile - build a Body object from the given file pointer with offset and size.
@@ -132,7 +173,8 @@ func NewBodyFromSectionFile(file *os.File, off, size int64) (*Body, error) {
 		return nil, err
 	}
 	section := io.NewSectionReader(file, off, size)
-	return &Body{ioutil.NopCloser(section), size, contentMD5}, nil
+	rewind := func() (io.Reader, error) { return io.NewSectionReader(file, off, size), nil }
+	return &Body{ioutil.NopCloser(section), size, contentMD5, rewind}, nil
 }
 
 // NewBodyFromSizedReader - build a Body object from the given reader with size.
@@ -168,12 +210,124 @@ func NewBodyFromSizedReader(r io.Reader, size int64) (*Body, error) {
 	if err != nil {
 		return nil, err
 	}
-	body := &Body{
-		stream:     ioutil.NopCloser(&buffer),
-		size:       rlen,
-		contentMD5: contentMD5,
+	return newBytesBody(buffer.Bytes(), contentMD5), nil
+}
+
+// NewBodyFromReadSeeker - build a rewindable Body object from the current offset of the given
+// io.ReadSeeker, which is seeked back to the offset to resend the request on retry instead of
+// being buffered. It calculates the content-md5 of the content and closes the reader after the
+// request is sent if it is an io.Closer.
+//
+// PARAMS:
+//     - r: the input reader
+//     - size: the size of the content from the current offset, -1 is to the end
+// RETURNS:
+//     - *Body: the return Body object
+//     - error: error if any specific error occurs
+func NewBodyFromReadSeeker(r io.ReadSeeker, size int64) (*Body, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		end, err := r.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, err
+		}
+		size = end - start
+		if _, err := r.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	contentMD5, err := util.CalculateContentMD5(r, size)
+	if err != nil {
+		return nil, err
+	}
+	rewind := func() (io.Reader, error) {
+		if _, err := r.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.LimitReader(r, size), nil
+	}
+	reader, err := rewind()
+	if err != nil {
+		return nil, err
+	}
+	var closer io.Closer = ioutil.NopCloser(nil)
+	if c, ok := r.(io.Closer); ok {
+		closer = c
+	}
+	stream := struct {
+		io.Reader
+		io.Closer
+	}{reader, closer}
+	return &Body{stream, size, contentMD5, rewind}, nil
+}
+
+// NewBodyFromFactory - build a rewindable Body object from the content opened by the factory,
+// eg: the file or the object downloaded again, the content is opened once more to calculate the
+// content-md5 and reopened to resend the request on retry, the previously opened one is closed.
+//
+// PARAMS:
+//     - open: the factory to open the content from the start
+//     - size: the size of the content
+// RETURNS:
+//     - *Body: the return Body object
+//     - error: error if any specific error occurs
+func NewBodyFromFactory(open func() (io.ReadCloser, error), size int64) (*Body, error) {
+	if open == nil || size < 0 {
+		return nil, NewBceClientError("the factory should not be nil and the size should be given")
+	}
+	rc, err := open()
+	if err != nil {
+		return nil, err
 	}
-	return body, nil
+	contentMD5, err := util.CalculateContentMD5(rc, size)
+	rc.Close()
+	if err != nil {
+		return nil, err
+	}
+	stream := &reopenableStream{open: open}
+	if stream.current, err = open(); err != nil {
+		return nil, err
+	}
+	return &Body{stream, size, contentMD5, stream.reopen}, nil
+}
+
+// reopenableStream reads the content last opened by the factory
+type reopenableStream struct {
+	open    func() (io.ReadCloser, error)
+	current io.ReadCloser
+}
+
+func (s *reopenableStream) Read(p []byte) (int, error) { return s.current.Read(p) }
+
+func (s *reopenableStream) Close() error { return s.current.Close() }
+
+func (s *reopenableStream) reopen() (io.Reader, error) {
+	rc, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	s.current.Close()
+	s.current = rc
+	return s, nil
+}
+
+// NewBodyFromStream - build a Body object from the stream of unknown length, which is sent by
+// the chunked transfer encoding and every chunk is signed by the streaming signature instead of
+// the content-md5, so that the stream does not need to be buffered. The request with this body
+// is not retried since the stream can not be read again.
+//
+// PARAMS:
+//     - stream: the input stream
+// RETURNS:
+//     - *Body: the return Body object whose size is -1
+func NewBodyFromStream(stream io.Reader) *Body {
+	if rc, ok := stream.(io.ReadCloser); ok {
+		return &Body{stream: rc, size: -1}
+	}
+	return &Body{stream: ioutil.NopCloser(stream), size: -1}
 }
 
 // BceRequest defines the request structure for accessing BCE services
@@ -181,6 +335,9 @@ type BceRequest struct {
 	http.Request
 	requestId   string
 	clientError *BceClientError
+	credentials *auth.BceCredentials // the credentials which sign the request
+	jsonBody    bool                 // whether the body is json which can be compressed
+	body        *Body                // the body set by SetBody to be rewound for the retries
 }
 
 func (b *BceRequest) RequestId() string { return b.requestId }
@@ -192,14 +349,93 @@ func (b *BceRequest) ClientError() *BceClientError { return b.clientError }
 func (b *BceRequest) SetClientError(err *BceClientError) { b.clientError = err }
 
 func (b *BceRequest) SetBody(body *Body) { // override SetBody derived from http.Request
+	b.body = body
 	b.Request.SetBody(body.Stream())
-	b.SetLength(body.Size()) // set field of "net/http.Request.ContentLength"
+	b.SetLength(body.Size()) // set field of "net/http.Request.ContentLength", -1 if chunked
 	if body.Size() > 0 {
 		b.SetHeader(http.CONTENT_MD5, body.ContentMD5())
 		b.SetHeader(http.CONTENT_LENGTH, fmt.Sprintf("%d", body.Size()))
 	}
 }
 
+// isChunked - check whether the body is of unknown length and sent by chunks
+func (b *BceRequest) isChunked() bool {
+	return b.Body() != nil && b.Length() < 0
+}
+
+// rewindable - check whether the body can be read again from the start to resend the request
+// instead of being buffered
+func (b *BceRequest) rewindable() bool {
+	return b.Body() != nil && !b.isChunked() && b.body != nil && b.body.Rewindable()
+}
+
+// rewindBody - set the body read from the start to resend the request, the body is not closed
+// by the transport so that it can be read again
+//
+// RETURNS:
+//     - error: nil if ok otherwise the error of rewinding the body
+func (b *BceRequest) rewindBody() error {
+	reader, err := b.body.Rewind()
+	if err != nil {
+		return NewBceClientError(fmt.Sprintf("rewind the request body failed: %v", err))
+	}
+	b.Request.SetBody(ioutil.NopCloser(reader))
+	return nil
+}
+
+// clone - copy the request with its own headers and params, so that the copies can be signed and
+// sent concurrently, eg: the hedged requests. The body is not copied but shared by the copies, so
+// only the request without body should be sent by the copies concurrently.
+//
+// RETURNS:
+//     - *BceRequest: the copy of the request
+func (b *BceRequest) clone() *BceRequest {
+	copied := *b
+	headers := make(map[string]string, len(b.Headers()))
+	for k, v := range b.Headers() {
+		headers[k] = v
+	}
+	copied.SetHeaders(headers)
+	params := make(map[string]string, len(b.Params()))
+	for k, v := range b.Params() {
+		params[k] = v
+	}
+	copied.SetParams(params)
+	return &copied
+}
+
+// Resendable - check whether the request can be sent again as a whole, eg: to another endpoint,
+// which is true if it has no body or the body can be rewound to the start
+func (b *BceRequest) Resendable() bool {
+	return b.Body() == nil || b.rewindable()
+}
+
+// HoldBody - keep the rewindable body open after the request is sent so that it can be rewound
+// to send the request again by RewindBody, the caller must call the returned function to close
+// the body finally, it does nothing if the body is not rewindable
+//
+// RETURNS:
+//     - func() error: the function to close the body
+func (b *BceRequest) HoldBody() func() error {
+	if !b.rewindable() {
+		return func() error { return nil }
+	}
+	stream := b.Body()
+	b.Request.SetBody(ioutil.NopCloser(stream))
+	return stream.Close
+}
+
+// RewindBody - rewind the body held by HoldBody to the start before sending the request again
+//
+// RETURNS:
+//     - error: nil if ok otherwise the error of rewinding the body
+func (b *BceRequest) RewindBody() error {
+	if !b.rewindable() {
+		return nil
+	}
+	return b.rewindBody()
+}
+
 func (b *BceRequest) BuildHttpRequest() {
 	// Only need to build the specific `requestId` field for BCE, other fields are same as the
 	// `http.Request` as well as its methods.
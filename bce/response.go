@@ -19,10 +19,14 @@ package bce
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/baidubce/bce-sdk-go/http"
 )
@@ -35,6 +39,7 @@ type BceResponse struct {
 	debugId      string
 	response     *http.Response
 	serviceError *BceServiceError
+	maxBodySize  int64 // the max bytes of the body read into memory, 0 means no limit
 }
 
 func (r *BceResponse) IsFail() bool {
@@ -90,39 +95,283 @@ func (r *BceResponse) ParseResponse() {
 		r.serviceError = NewBceServiceError("", r.statusText, r.requestId, r.statusCode)
 
 		// First try to read the error `Code' and `Message' from body
-		rawBody, _ := ioutil.ReadAll(r.Body())
-		defer r.Body().Close()
-		if len(rawBody) != 0 {
-			jsonDecoder := json.NewDecoder(bytes.NewBuffer(rawBody))
-			if err := jsonDecoder.Decode(r.serviceError); err != nil {
-				r.serviceError = NewBceServiceError(
-					EMALFORMED_JSON,
-					"Service json error message decode failed",
-					r.requestId,
-					r.statusCode)
+		// The error body larger than the limit is truncated
+		var rawBody []byte
+		if body, err := decompressedBody(r); err == nil {
+			var reader io.Reader = body
+			if r.maxBodySize > 0 {
+				reader = io.LimitReader(body, r.maxBodySize)
 			}
+			rawBody, _ = ioutil.ReadAll(reader)
+			defer body.Close()
+		}
+		if len(rawBody) != 0 {
+			r.parseErrorBody(rawBody)
+		}
+
+		// Then guess the `Code' by the return status code if not given
+		if len(r.serviceError.Code) == 0 {
+			r.serviceError.Code = codeOfStatus(r.statusCode, r.statusText)
+		}
+		r.serviceError.Headers = r.response.GetHeaders()
+		if len(rawBody) > MAX_ERROR_BODY_SIZE {
+			rawBody = rawBody[:MAX_ERROR_BODY_SIZE]
+		}
+		r.serviceError.RawBody = rawBody
+	}
+}
+
+// parseErrorBody - read the error `Code' and `Message' from the body according to its format,
+// the BCE services return json but the gateways and proxies may return xml, html or text.
+func (r *BceResponse) parseErrorBody(rawBody []byte) {
+	content := bytes.TrimSpace(rawBody)
+	contentType := strings.ToLower(r.response.GetHeader(http.CONTENT_TYPE))
+	switch {
+	case strings.Contains(contentType, "json") || bytes.HasPrefix(content, []byte("{")):
+		jsonDecoder := json.NewDecoder(bytes.NewBuffer(content))
+		if err := jsonDecoder.Decode(r.serviceError); err != nil {
+			r.serviceError.Code = EMALFORMED_JSON
+			r.serviceError.Message = "Service json error message decode failed"
+		}
+	case strings.Contains(contentType, "html") || isHtmlContent(content):
+		r.serviceError.Message = htmlErrorMessage(content, r.statusText)
+	case strings.Contains(contentType, "xml") || bytes.HasPrefix(content, []byte("<")):
+		xmlErr := &xmlServiceError{}
+		if err := xml.Unmarshal(content, xmlErr); err != nil || len(xmlErr.Code) == 0 {
+			r.serviceError.Message = textErrorMessage(content, r.statusText)
 			return
 		}
+		r.serviceError.Code = xmlErr.Code
+		r.serviceError.Message = xmlErr.Message
+		if len(xmlErr.RequestId) != 0 {
+			r.serviceError.RequestId = xmlErr.RequestId
+		}
+	default:
+		r.serviceError.Message = textErrorMessage(content, r.statusText)
+	}
+}
+
+// xmlServiceError defines the S3 style xml error body returned by some gateways and proxies.
+type xmlServiceError struct {
+	Code      string `xml:"Code"`
+	Message   string `xml:"Message"`
+	RequestId string `xml:"RequestId"`
+}
+
+var (
+	htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	spacePattern     = regexp.MustCompile(`\s+`)
+)
+
+func isHtmlContent(content []byte) bool {
+	prefix := content
+	if len(prefix) > 512 {
+		prefix = prefix[:512]
+	}
+	prefix = bytes.ToLower(prefix)
+	return bytes.HasPrefix(prefix, []byte("<!doctype html")) || bytes.Contains(prefix, []byte("<html"))
+}
+
+// htmlErrorMessage - use the title of the html page as the message, or the text of the page if
+// there is no title
+func htmlErrorMessage(content []byte, statusText string) string {
+	if m := htmlTitlePattern.FindSubmatch(content); m != nil {
+		if title := spacePattern.ReplaceAllString(string(m[1]), " "); len(strings.TrimSpace(title)) != 0 {
+			return strings.TrimSpace(title)
+		}
+	}
+	return textErrorMessage(htmlTagPattern.ReplaceAll(content, []byte(" ")), statusText)
+}
 
-		// Then guess the `Message' from by the return status code
-		switch r.statusCode {
-		case 400:
-			r.serviceError.Code = EINVALID_HTTP_REQUEST
-		case 403:
-			r.serviceError.Code = EACCESS_DENIED
-		case 412:
-			r.serviceError.Code = EPRECONDITION_FAILED
-		case 500:
-			r.serviceError.Code = EINTERNAL_ERROR
-		default:
-			words := strings.Split(r.statusText, " ")
-			r.serviceError.Code = strings.Join(words[1:], "")
+// textErrorMessage - use the collapsed text as the message which is truncated to
+// MAX_ERROR_MESSAGE_SIZE bytes, or the status text if the text is empty
+func textErrorMessage(content []byte, statusText string) string {
+	msg := strings.TrimSpace(spacePattern.ReplaceAllString(string(content), " "))
+	if len(msg) == 0 {
+		return statusText
+	}
+	if len(msg) > MAX_ERROR_MESSAGE_SIZE {
+		n := MAX_ERROR_MESSAGE_SIZE
+		for n > 0 && !utf8.RuneStart(msg[n]) {
+			n--
 		}
+		msg = msg[:n] + "..."
+	}
+	return msg
+}
+
+func codeOfStatus(statusCode int, statusText string) string {
+	switch statusCode {
+	case 400:
+		return EINVALID_HTTP_REQUEST
+	case 403:
+		return EACCESS_DENIED
+	case 412:
+		return EPRECONDITION_FAILED
+	case 500:
+		return EINTERNAL_ERROR
+	default:
+		words := strings.Split(statusText, " ")
+		return strings.Join(words[1:], "")
+	}
+}
+
+func (r *BceResponse) ContentLength() int64 {
+	return r.response.ContentLength()
+}
+
+// StreamBody defines the unbuffered response body with its content length and etag. The caller
+// takes over the body and must call `Close' after reading to release the connection.
+type StreamBody struct {
+	Body          io.ReadCloser
+	ContentLength int64
+	ContentType   string
+	ETag          string
+}
+
+func (s *StreamBody) Read(p []byte) (int, error) { return s.Body.Read(p) }
+
+func (s *StreamBody) Close() error { return s.Body.Close() }
+
+// ParseStreamBody - take over the response body as a stream without buffering it in memory
+//
+// RETURNS:
+//     - *StreamBody: the response body stream which must be closed by the caller
+func (r *BceResponse) ParseStreamBody() *StreamBody {
+	return &StreamBody{
+		Body:          r.Body(),
+		ContentLength: r.ContentLength(),
+		ContentType:   r.Header(http.CONTENT_TYPE),
+		ETag:          strings.Trim(r.Header(http.ETAG), "\""),
 	}
 }
 
+// ParseJsonBody - decode the json response body to the result, the gzip-encoded body is
+// decompressed automatically. The body larger than the MaxResponseSize of the client fails with
+// the *ResponseTooLargeError.
 func (r *BceResponse) ParseJsonBody(result interface{}) error {
-	defer r.Body().Close()
-	jsonDecoder := json.NewDecoder(r.Body())
+	reader, closer, err := r.openBody()
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+	jsonDecoder := json.NewDecoder(reader)
 	return jsonDecoder.Decode(result)
 }
+
+// ParseJsonArrayBody - decode the json object body whose array field is decoded element by element
+// without reading the whole body into memory, which is used for the large list responses and is
+// not limited by the MaxResponseSize. The fn is called with the decoder to decode each element of
+// the array field, and the other fields are decoded to the result.
+//
+// PARAMS:
+//     - result: the result to decode the fields other than the array field, nil to ignore them
+//     - field: the name of the array field
+//     - fn: the function to decode an element by the decoder, eg: dec.Decode(&item)
+// RETURNS:
+//     - error: nil if success otherwise the decode error or the error returned by the fn
+func (r *BceResponse) ParseJsonArrayBody(result interface{}, field string,
+	fn func(dec *json.Decoder) error) error {
+	body, err := decompressedBody(r)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	dec := json.NewDecoder(body)
+	if err := expectJsonDelim(dec, '{'); err != nil {
+		return err
+	}
+	others := make(map[string]json.RawMessage)
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		if key != field {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			others[key] = value
+			continue
+		}
+		if err := parseJsonArray(dec, fn); err != nil {
+			return err
+		}
+	}
+	if err := expectJsonDelim(dec, '}'); err != nil {
+		return err
+	}
+	if result == nil || len(others) == 0 {
+		return nil
+	}
+	content, err := json.Marshal(others)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, result)
+}
+
+func parseJsonArray(dec *json.Decoder, fn func(dec *json.Decoder) error) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil { // the null array
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("json array expected but got %v", token)
+	}
+	for dec.More() {
+		if err := fn(dec); err != nil {
+			return err
+		}
+	}
+	return expectJsonDelim(dec, ']')
+}
+
+func expectJsonDelim(dec *json.Decoder, expected json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("json delimiter %v expected but got %v", expected, token)
+	}
+	return nil
+}
+
+func (r *BceResponse) tooLargeError() *ResponseTooLargeError {
+	return &ResponseTooLargeError{
+		Limit:         r.maxBodySize,
+		ContentLength: r.ContentLength(),
+		StatusCode:    r.statusCode,
+		RequestId:     r.requestId,
+	}
+}
+
+// sizeLimitedReader fails with the *ResponseTooLargeError once more than the limited bytes are
+// read, unlike the io.LimitedReader which ends silently.
+type sizeLimitedReader struct {
+	reader io.Reader
+	left   int64
+	resp   *BceResponse
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	if r.left < 0 {
+		return 0, r.resp.tooLargeError()
+	}
+	if int64(len(p)) > r.left+1 {
+		p = p[:r.left+1]
+	}
+	n, err := r.reader.Read(p)
+	r.left -= int64(n)
+	if r.left < 0 {
+		return 0, r.resp.tooLargeError()
+	}
+	return n, err
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// retrybudget.go - limit the retries of all the requests sharing the client by a token bucket
+
+package bce
+
+import (
+	"sync"
+
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+const (
+	DEFAULT_RETRY_BUDGET_CAPACITY = 500
+	DEFAULT_RETRY_COST            = 5
+	DEFAULT_RETRY_SUCCESS_REFILL  = 1
+)
+
+// RetryBudget limits the retries of all the goroutines sharing the client: every retry allowed by
+// the retry policy takes RetryCost tokens from the bucket and every successful request puts
+// SuccessRefill tokens back up to the Capacity. When the service browns out, the failures drain
+// the bucket and the requests fail fast without retrying instead of multiplying the traffic, and
+// the retries resume as the successful requests refill it. It is safe for concurrent use.
+type RetryBudget struct {
+	Capacity      float64
+	RetryCost     float64
+	SuccessRefill float64
+
+	mu        sync.Mutex
+	once      sync.Once
+	tokens    float64
+	retries   uint64
+	exhausted uint64
+	successes uint64
+}
+
+// RetryBudgetStats is the snapshot of the retry budget for monitoring
+type RetryBudgetStats struct {
+	Tokens    float64 // the tokens left in the bucket
+	Retries   uint64  // the retries allowed by the budget
+	Exhausted uint64  // the retries denied for the budget is exhausted
+	Successes uint64  // the successful requests refilling the budget
+}
+
+// NewRetryBudget - create the retry budget with the default cost and refill
+//
+// PARAMS:
+//     - capacity: the max tokens of the bucket, which is full at the beginning
+// RETURNS:
+//     - *RetryBudget: the retry budget
+func NewRetryBudget(capacity float64) *RetryBudget {
+	return &RetryBudget{
+		Capacity:      capacity,
+		RetryCost:     DEFAULT_RETRY_COST,
+		SuccessRefill: DEFAULT_RETRY_SUCCESS_REFILL,
+	}
+}
+
+// NewDefaultRetryBudget - create the retry budget with the default settings
+//
+// RETURNS:
+//     - *RetryBudget: the retry budget
+func NewDefaultRetryBudget() *RetryBudget {
+	return NewRetryBudget(DEFAULT_RETRY_BUDGET_CAPACITY)
+}
+
+// WithRetryBudget limits the retries by the budget, nil means unlimited.
+func WithRetryBudget(budget *RetryBudget) RequestOption {
+	return func(c *BceClientConfiguration) { c.RetryBudget = budget }
+}
+
+func (b *RetryBudget) init() {
+	b.once.Do(func() { b.tokens = b.Capacity })
+}
+
+// Acquire - take the tokens of a retry from the bucket
+//
+// RETURNS:
+//     - bool: true if the retry is allowed, false if the budget is exhausted
+func (b *RetryBudget) Acquire() bool {
+	b.init()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < b.RetryCost {
+		b.exhausted++
+		return false
+	}
+	b.tokens -= b.RetryCost
+	b.retries++
+	return true
+}
+
+// OnSuccess - put the tokens of a successful request back to the bucket
+func (b *RetryBudget) OnSuccess() {
+	b.init()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.successes++
+	if b.tokens += b.SuccessRefill; b.tokens > b.Capacity {
+		b.tokens = b.Capacity
+	}
+}
+
+// Stats - get the snapshot of the retry budget
+//
+// RETURNS:
+//     - RetryBudgetStats: the tokens left and the counters since the budget is created
+func (b *RetryBudget) Stats() RetryBudgetStats {
+	b.init()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return RetryBudgetStats{
+		Tokens:    b.tokens,
+		Retries:   b.retries,
+		Exhausted: b.exhausted,
+		Successes: b.successes,
+	}
+}
+
+// shouldRetry - check the retry policy of the client and take the tokens from the retry budget
+// if it is set, the budget is only charged if the policy allows the retry. The request is never
+// retried once the context of the configuration is done.
+func (c *BceClient) shouldRetry(err BceError, retries int) bool {
+	if ctx := c.Config.Context; ctx != nil && ctx.Err() != nil {
+		return false
+	}
+	if !c.Config.Retry.ShouldRetry(err, retries) {
+		return false
+	}
+	if budget := c.Config.RetryBudget; budget != nil && !budget.Acquire() {
+		log.Warnf("retry budget exhausted, give up retrying: %v", err)
+		return false
+	}
+	return true
+}
+
+// onRequestSuccess - refill the retry budget if it is set
+func (c *BceClient) onRequestSuccess() {
+	if budget := c.Config.RetryBudget; budget != nil {
+		budget.OnSuccess()
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// skew.go - detect the clock skew between the client and the service and correct the sign time
+
+package bce
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/auth"
+	bcehttp "github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/util"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+// MIN_CLOCK_SKEW is the minimum offset between the client and service clocks to be corrected,
+// the smaller offset is covered by the precision of the Date header and the network latency
+const MIN_CLOCK_SKEW = 5 * time.Second
+
+// clockSkews maps the endpoint to the offset of the service clock relative to the local clock
+var clockSkews sync.Map
+
+// ClockSkew - get the offset of the service clock relative to the local clock detected from the
+// responses of the clients with the same Endpoint, it is added to the local time to sign requests
+//
+// RETURNS:
+//     - time.Duration: the offset, positive if the service clock is ahead
+func (c *BceClientConfiguration) ClockSkew() time.Duration {
+	if val, ok := clockSkews.Load(c.Endpoint); ok {
+		return val.(time.Duration)
+	}
+	return 0
+}
+
+// SetClockSkew - set the offset of the service clock relative to the local clock, 0 to reset
+//
+// PARAMS:
+//     - skew: the offset, positive if the service clock is ahead
+func (c *BceClientConfiguration) SetClockSkew(skew time.Duration) {
+	if skew == 0 {
+		clockSkews.Delete(c.Endpoint)
+	} else {
+		clockSkews.Store(c.Endpoint, skew)
+	}
+}
+
+// signTimestamp - get the current time of the service clock in unix seconds to sign the request
+func (c *BceClientConfiguration) signTimestamp() int64 {
+	return util.NowUTCSeconds() + int64(c.ClockSkew()/time.Second)
+}
+
+// IsClockSkewError - check whether the error is returned by the service for the request time
+// too far from the service time, which is fixed by signing the request again with the service time
+//
+// PARAMS:
+//     - err: the error returned by sending the request
+// RETURNS:
+//     - bool: true if it is a clock skew error
+func IsClockSkewError(err error) bool {
+	if e, ok := err.(*BceServiceError); ok {
+		return e.Code == EREQUEST_TIME_TOO_SKEWED || e.Code == EREQUEST_EXPIRED
+	}
+	return false
+}
+
+// sign - set the date header and sign the request with the skew-corrected time
+func (c *BceClient) sign(request *BceRequest) {
+	timestamp := c.Config.signTimestamp()
+	request.SetHeader(bcehttp.BCE_DATE, util.FormatISO8601Date(timestamp))
+	if request.credentials == nil {
+		return
+	}
+	option := c.Config.SignOption
+	if option == nil {
+		option = &auth.SignOptions{
+			HeadersToSign: auth.DEFAULT_HEADERS_TO_SIGN,
+			ExpireSeconds: auth.DEFAULT_EXPIRE_SECONDS}
+	}
+	if option.Timestamp == 0 && c.Config.ClockSkew() != 0 {
+		skewed := *option
+		skewed.Timestamp = timestamp
+		option = &skewed
+	}
+	c.Signer.Sign(&request.Request, request.credentials, option)
+}
+
+// correctClockSkew - record the clock skew from the Date header of the response if the request
+// is rejected for the clock skew and sign the request again with the corrected time
+//
+// PARAMS:
+//     - req: the request rejected by the service
+//     - resp: the response with the Date header of the service time
+//     - err: the service error of the response
+// RETURNS:
+//     - bool: true if the request is signed again and should be resent
+func (c *BceClient) correctClockSkew(req *BceRequest, resp *BceResponse, err error) bool {
+	if !IsClockSkewError(err) || req.isChunked() {
+		return false
+	}
+	serverTime, parseErr := http.ParseTime(resp.Header(bcehttp.DATE))
+	if parseErr != nil {
+		return false
+	}
+	skew := serverTime.Sub(time.Now())
+	if skew > -MIN_CLOCK_SKEW && skew < MIN_CLOCK_SKEW {
+		return false
+	}
+	skew = skew.Round(time.Second)
+	// the skew is positive if the service clock is ahead, that is the local clock is behind
+	direction, offset := "behind", skew
+	if skew < 0 {
+		direction, offset = "ahead of", -skew
+	}
+	log.Warnf("the local clock is %v %s the service of %s, sign the request again", offset,
+		direction, c.Config.Endpoint)
+	c.Config.SetClockSkew(skew)
+	c.sign(req)
+	return true
+}
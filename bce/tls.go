@@ -0,0 +1,89 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// tls.go - build the tls config of the https connections from the common options
+
+package bce
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+)
+
+// TLSOptions defines the common options to build the tls config by the NewTLSConfig.
+type TLSOptions struct {
+	// CAFile and CAPEM are the PEM encoded CA certificates to verify the endpoints, which are
+	// trusted besides the system root CAs unless the ExcludeSystemCAs is true
+	CAFile           string
+	CAPEM            []byte
+	ExcludeSystemCAs bool
+
+	// CertFile and KeyFile are the PEM encoded client certificate and key for the mTLS gateways
+	CertFile string
+	KeyFile  string
+
+	// MinVersion is the min tls version such as tls.VersionTLS12, the default of Go if it is 0
+	MinVersion uint16
+	// CipherSuites are the enabled cipher suites of TLS 1.2 and lower, the default if it is empty
+	CipherSuites []uint16
+	// ServerName is used to verify the certificate of the endpoints accessed by the ip address
+	ServerName string
+}
+
+// NewTLSConfig - build the tls config to set to the TLSConfig of the client configuration
+//
+// PARAMS:
+//     - opts: the tls options
+// RETURNS:
+//     - *tls.Config: the tls config
+//     - error: nil if ok otherwise the error of loading the certificates
+func NewTLSConfig(opts *TLSOptions) (*tls.Config, error) {
+	conf := &tls.Config{
+		MinVersion:   opts.MinVersion,
+		CipherSuites: opts.CipherSuites,
+		ServerName:   opts.ServerName,
+	}
+
+	caPEM := opts.CAPEM
+	if len(opts.CAFile) != 0 {
+		content, err := ioutil.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, NewBceClientError("read the ca file failed: " + err.Error())
+		}
+		caPEM = append(append(caPEM, '\n'), content...)
+	}
+	if len(caPEM) != 0 {
+		var pool *x509.CertPool
+		if !opts.ExcludeSystemCAs {
+			pool, _ = x509.SystemCertPool()
+		}
+		if pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, NewBceClientError("no valid ca certificate is found")
+		}
+		conf.RootCAs = pool
+	}
+
+	if len(opts.CertFile) != 0 || len(opts.KeyFile) != 0 {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, NewBceClientError("load the client certificate failed: " + err.Error())
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
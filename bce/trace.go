@@ -0,0 +1,104 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// trace.go - define the tracing hook to create spans for the requests to BCE services
+
+package bce
+
+import "context"
+
+// Constants of the span attribute keys set by the BceClient
+const (
+	TRACE_ATTR_HTTP_METHOD      = "http.method"
+	TRACE_ATTR_HTTP_STATUS_CODE = "http.status_code"
+	TRACE_ATTR_ENDPOINT         = "bce.endpoint"
+	TRACE_ATTR_URI              = "bce.uri"
+	TRACE_ATTR_REQUEST_ID       = "bce.request_id"
+)
+
+// Tracer is the hook to create a span for every request sent by the BceClient. It keeps the SDK
+// free of the tracing dependencies, and an adapter of OpenTelemetry can be implemented easily:
+//
+//     func (t *otelTracer) StartSpan(ctx context.Context, operation string,
+//         carrier map[string]string) (context.Context, bce.Span) {
+//         ctx, span := t.tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient))
+//         otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(carrier))
+//         return ctx, &otelSpan{span}
+//     }
+type Tracer interface {
+	// StartSpan starts a span with the operation name as the child of the span in the context,
+	// which is the Config.Context set by the WithContext option or the background context. The
+	// trace context headers to be propagated to the BCE services can be set into the carrier, and
+	// the returned context carrying the span is used to send the request.
+	StartSpan(ctx context.Context, operation string, carrier map[string]string) (context.Context,
+		Span)
+}
+
+// Span stands for a single traced request created by the Tracer.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// startSpan - start the span for the given request if the tracer is configured
+//
+// PARAMS:
+//     - req: the built request to be sent
+// RETURNS:
+//     - Span: the started span, nil if tracing is not enabled
+func (c *BceClient) startSpan(req *BceRequest) Span {
+	if c.Config == nil || c.Config.Tracer == nil {
+		return nil
+	}
+	carrier := make(map[string]string)
+	ctx, span := c.Config.Tracer.StartSpan(req.Context(), req.Method()+" "+req.Host(), carrier)
+	if span == nil {
+		return nil
+	}
+	if ctx != nil {
+		req.SetContext(ctx)
+	}
+	if len(carrier) != 0 {
+		for k, v := range carrier {
+			req.SetHeader(k, v)
+		}
+		// Sign again in case of any propagated header should be signed
+		c.sign(req)
+	}
+	span.SetAttribute(TRACE_ATTR_HTTP_METHOD, req.Method())
+	span.SetAttribute(TRACE_ATTR_ENDPOINT, req.Endpoint())
+	span.SetAttribute(TRACE_ATTR_URI, req.Uri())
+	span.SetAttribute(TRACE_ATTR_REQUEST_ID, req.RequestId())
+	return span
+}
+
+// endSpan - record the result of the request and end the span
+//
+// PARAMS:
+//     - span: the span started by startSpan
+//     - resp: the response of the request
+//     - err: the error returned to the caller
+func endSpan(span Span, resp *BceResponse, err error) {
+	if span == nil {
+		return
+	}
+	if resp != nil && resp.StatusCode() != 0 {
+		span.SetAttribute(TRACE_ATTR_HTTP_STATUS_CODE, resp.StatusCode())
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
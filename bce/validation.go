@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// validation.go - define the validator to check the request arguments before sending
+
+package bce
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError defines a violation of a field of the request arguments.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (f FieldError) String() string { return f.Field + ": " + f.Message }
+
+// ValidationError defines the error of all the violations of the request arguments, which is
+// returned before sending the request.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (v *ValidationError) Error() string {
+	msgs := make([]string, 0, len(v.Errors))
+	for _, e := range v.Errors {
+		msgs = append(msgs, e.String())
+	}
+	return "invalid arguments: " + strings.Join(msgs, "; ")
+}
+
+// Validator collects the violations of the request arguments, the zero value is ready to use.
+type Validator struct {
+	errs []FieldError
+}
+
+// Check adds the violation of the field with the message if the condition is false.
+func (v *Validator) Check(ok bool, field, message string) *Validator {
+	if !ok {
+		v.errs = append(v.errs, FieldError{field, message})
+	}
+	return v
+}
+
+// Required checks the string field is not empty.
+func (v *Validator) Required(field, value string) *Validator {
+	return v.Check(len(value) != 0, field, "is required")
+}
+
+// MaxLength checks the character count of the string field is not greater than max.
+func (v *Validator) MaxLength(field, value string, max int) *Validator {
+	return v.Check(utf8.RuneCountInString(value) <= max, field,
+		fmt.Sprintf("length should not be greater than %d", max))
+}
+
+// Range checks the number field is in the range [min, max].
+func (v *Validator) Range(field string, value, min, max int64) *Validator {
+	return v.Check(value >= min && value <= max, field,
+		fmt.Sprintf("should be in range [%d, %d] but %d", min, max, value))
+}
+
+// OneOf checks the string field is one of the allowed values, the empty value is allowed which
+// should be checked by Required if it is not optional.
+func (v *Validator) OneOf(field, value string, allowed ...string) *Validator {
+	if len(value) == 0 {
+		return v
+	}
+	for _, a := range allowed {
+		if value == a {
+			return v
+		}
+	}
+	return v.Check(false, field, fmt.Sprintf("should be one of %v but %q", allowed, value))
+}
+
+// Err returns the ValidationError of all the violations, nil if there is no violation.
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{v.errs}
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+
+// task.go - the uniform abstraction of the async tasks returned by the services
+
+package waiter
+
+import (
+	"context"
+	"sync"
+)
+
+// Task defines the long-running operation started asynchronously by the services, such as the
+// CDN purge, the BCC image creation or the DOC conversion, so that they are handled uniformly.
+type Task interface {
+	// ID returns the identity of the task, such as the task ID or the resource ID
+	ID() string
+
+	// Poll queries the task once, it returns StateRetry if the task is still running,
+	// StateSuccess if it is finished, or StateFailure with the *FailureError if it is failed.
+	// The query error is returned with StateRetry and the task can be polled again.
+	Poll(ctx context.Context) (State, error)
+
+	// Wait polls the task until it is finished or failed, or the context is done
+	Wait(ctx context.Context) error
+
+	// Result returns the result of the last polling, nil if never polled
+	Result() interface{}
+}
+
+// PollFunc queries the task once and returns the result with the state of the task, the error
+// is the reason of the failure if the state is StateFailure, otherwise the query error
+type PollFunc func(ctx context.Context) (result interface{}, state State, err error)
+
+// PollingTask implements the Task by polling the PollFunc with the backoff, it is safe for
+// concurrent use and stops querying the service once the task is finished or failed.
+type PollingTask struct {
+	id      string
+	poll    PollFunc
+	backoff *Backoff
+
+	mu     sync.Mutex
+	result interface{}
+	state  State
+	err    error
+}
+
+// NewTask - create the task polled by the given function
+//
+// PARAMS:
+//     - id: the identity of the task
+//     - poll: the function to query the task once
+//     - backoff: the delays between the polling of Wait, DefaultBackoff if it is nil
+// RETURNS:
+//     - *PollingTask: the task
+func NewTask(id string, poll PollFunc, backoff *Backoff) *PollingTask {
+	return &PollingTask{id: id, poll: poll, backoff: backoff}
+}
+
+func (t *PollingTask) ID() string {
+	return t.id
+}
+
+func (t *PollingTask) Poll(ctx context.Context) (State, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state != StateRetry {
+		return t.state, t.err
+	}
+	result, state, err := t.poll(ctx)
+	if state == StateRetry && err != nil {
+		return StateRetry, err
+	}
+	t.result, t.state = result, state
+	if state == StateFailure {
+		t.err = &FailureError{Result: result, Err: err}
+	}
+	return t.state, t.err
+}
+
+func (t *PollingTask) Wait(ctx context.Context) error {
+	op := func(ctx context.Context) (interface{}, error) {
+		return t.Poll(ctx)
+	}
+	finished := func(result interface{}, err error) bool {
+		return result.(State) != StateRetry
+	}
+	// the failure is recorded by the Poll, so stop waiting in both cases and return it below
+	if _, err := Wait(ctx, op, []Acceptor{{State: StateSuccess, Matcher: finished}},
+		t.backoff); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+func (t *PollingTask) Result() interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.result
+}
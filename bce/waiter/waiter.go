@@ -0,0 +1,179 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// waiter.go - poll the long-running operations until they reach the expected state
+
+// Package waiter implements the polling of the long-running operations shared by the services,
+// such as waiting for a document to be published or an instance to be running. The operation is
+// called repeatedly with the backoff until an acceptor matches its result or the context is done:
+//
+//     result, err := waiter.Wait(ctx, op, []waiter.Acceptor{
+//         {State: waiter.StateSuccess, Matcher: func(r interface{}, err error) bool {...}},
+//         {State: waiter.StateFailure, Matcher: func(r interface{}, err error) bool {...}},
+//     }, nil)
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	DEFAULT_INITIAL_DELAY = 2 * time.Second
+	DEFAULT_MAX_DELAY     = 30 * time.Second
+	DEFAULT_MULTIPLIER    = 1.5
+	DEFAULT_JITTER        = 0.2
+)
+
+// State defines the state of the waiting decided by the acceptor
+type State int
+
+const (
+	StateRetry   State = iota // poll again after the delay
+	StateSuccess              // stop waiting and return the result
+	StateFailure              // stop waiting and return the FailureError
+)
+
+// Operation defines the polling operation, which usually queries the status of a resource
+type Operation func(ctx context.Context) (interface{}, error)
+
+// Acceptor decides the state of the waiting if the Matcher matches the result and error of the
+// operation. The acceptors are matched in order, if none matches, the waiting stops with the error
+// of the operation if it is not nil, otherwise the operation is polled again.
+type Acceptor struct {
+	State   State
+	Matcher func(result interface{}, err error) bool
+}
+
+// Backoff defines the delays between the polling: the delay starts from the Initial and grows by
+// the Multiplier up to the Max, then it is randomized by the Jitter ratio in [-Jitter, +Jitter].
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// DefaultBackoff - get the backoff with the default settings
+func DefaultBackoff() *Backoff {
+	return &Backoff{
+		Initial:    DEFAULT_INITIAL_DELAY,
+		Max:        DEFAULT_MAX_DELAY,
+		Multiplier: DEFAULT_MULTIPLIER,
+		Jitter:     DEFAULT_JITTER,
+	}
+}
+
+// Delay - get the delay before the next polling
+//
+// PARAMS:
+//     - attempt: the number of the polling done, starts from 1
+// RETURNS:
+//     - time.Duration: the delay with jitter
+func (b *Backoff) Delay(attempt int) time.Duration {
+	delay := float64(b.Initial)
+	for i := 1; i < attempt && (b.Max <= 0 || delay < float64(b.Max)); i++ {
+		if b.Multiplier > 1 {
+			delay *= b.Multiplier
+		}
+	}
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		delay += delay * b.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// FailureError is returned if a failure acceptor matches the result of the operation
+type FailureError struct {
+	Result interface{}
+	Err    error
+}
+
+func (e *FailureError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("waiter reached the failure state: %v", e.Err)
+	}
+	return fmt.Sprintf("waiter reached the failure state: %+v", e.Result)
+}
+
+// TimeoutError is returned if the context is done before any acceptor matches, the LastResult
+// and LastErr are the ones of the last polling
+type TimeoutError struct {
+	LastResult interface{}
+	LastErr    error
+	Err        error // the error of the context
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("waiter stopped before reaching the expected state: %v, last error: %v",
+		e.Err, e.LastErr)
+}
+
+// IsTimeout - check whether the error is returned for the context is done
+func IsTimeout(err error) bool {
+	_, ok := err.(*TimeoutError)
+	return ok
+}
+
+// Wait - poll the operation until an acceptor reaches the success or failure state
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - op: the polling operation
+//     - acceptors: the acceptors to decide the state by the result of the operation
+//     - backoff: the delays between the polling, DefaultBackoff if it is nil
+// RETURNS:
+//     - interface{}: the result of the operation in the success state, or the last result
+//     - error: nil if succeeded, *FailureError in the failure state, *TimeoutError if the context
+//       is done, otherwise the error of the operation not matched by any acceptor
+func Wait(ctx context.Context, op Operation, acceptors []Acceptor,
+	backoff *Backoff) (interface{}, error) {
+	if backoff == nil {
+		backoff = DefaultBackoff()
+	}
+	for attempt := 1; ; attempt++ {
+		result, err := op(ctx)
+		state, matched := StateRetry, false
+		for _, acceptor := range acceptors {
+			if acceptor.Matcher(result, err) {
+				state, matched = acceptor.State, true
+				break
+			}
+		}
+		switch {
+		case state == StateSuccess:
+			return result, nil
+		case state == StateFailure:
+			return result, &FailureError{Result: result, Err: err}
+		case !matched && err != nil:
+			if ctx.Err() != nil {
+				return result, &TimeoutError{LastResult: result, LastErr: err, Err: ctx.Err()}
+			}
+			return result, err
+		}
+
+		timer := time.NewTimer(backoff.Delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, &TimeoutError{LastResult: result, LastErr: err, Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// xml.go - define the xml marshaling of the request bodies and the parsing of the xml responses
+
+package bce
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+// XML_CONTENT_TYPE is the Content-Type of the xml request bodies
+const XML_CONTENT_TYPE = "application/xml;charset=utf-8"
+
+// MarshalXML - marshal the request body to xml with the standard xml header, the element names
+// are defined by the "xml" tags of the body like the encoding/xml
+//
+// PARAMS:
+//     - v: the request body to marshal
+// RETURNS:
+//     - []byte: the xml bytes
+//     - error: nil if ok otherwise the marshal error
+func MarshalXML(v interface{}) ([]byte, error) {
+	content, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+// SetXmlBody - marshal the body to xml and set it to the request with the xml Content-Type
+// unless the Content-Type is given
+//
+// PARAMS:
+//     - v: the request body to marshal
+// RETURNS:
+//     - error: nil if ok otherwise the marshal error
+func (b *BceRequest) SetXmlBody(v interface{}) error {
+	content, err := MarshalXML(v)
+	if err != nil {
+		return err
+	}
+	body, err := NewBodyFromBytes(content)
+	if err != nil {
+		return err
+	}
+	b.SetBody(body)
+	if len(b.Header(http.CONTENT_TYPE)) == 0 {
+		b.SetHeader(http.CONTENT_TYPE, XML_CONTENT_TYPE)
+	}
+	return nil
+}
+
+// ParseXmlBody - decode the xml response body to the result like the ParseJsonBody, the gzip
+// decompression and the MaxResponseSize limit work in the same way
+func (r *BceResponse) ParseXmlBody(result interface{}) error {
+	reader, closer, err := r.openBody()
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+	return xml.NewDecoder(reader).Decode(result)
+}
+
+// ParseBody - decode the response body to the result by the Content-Type of the response: the
+// xml is decoded by the ParseXmlBody and the json by the ParseJsonBody. If the Content-Type is
+// neither of them, eg: the "application/octet-stream" returned by some gateways, the body
+// starting with "<" is decoded as xml and the others as json.
+func (r *BceResponse) ParseBody(result interface{}) error {
+	contentType := strings.ToLower(r.Header(http.CONTENT_TYPE))
+	switch {
+	case strings.Contains(contentType, "json"):
+		return r.ParseJsonBody(result)
+	case strings.Contains(contentType, "xml"):
+		return r.ParseXmlBody(result)
+	}
+	reader, closer, err := r.openBody()
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+	buffered := bufio.NewReader(reader)
+	if isXmlContent(buffered) {
+		return xml.NewDecoder(buffered).Decode(result)
+	}
+	return json.NewDecoder(buffered).Decode(result)
+}
+
+// isXmlContent - peek the first non-space byte of the body to check whether it is xml
+func isXmlContent(reader *bufio.Reader) bool {
+	for n := 1; ; n++ {
+		peeked, err := reader.Peek(n)
+		if len(peeked) < n {
+			return false
+		}
+		switch peeked[n-1] {
+		case ' ', '\t', '\r', '\n':
+			if err != nil {
+				return false
+			}
+			continue
+		}
+		return peeked[n-1] == '<'
+	}
+}
+
+// openBody - get the decompressed response body limited by the MaxResponseSize, the closer must
+// be closed after reading
+func (r *BceResponse) openBody() (io.Reader, io.Closer, error) {
+	if r.maxBodySize > 0 && r.ContentLength() > r.maxBodySize {
+		r.Body().Close()
+		return nil, nil, r.tooLargeError()
+	}
+	body, err := decompressedBody(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var reader io.Reader = body
+	if r.maxBodySize > 0 {
+		reader = &sizeLimitedReader{reader: body, left: r.maxBodySize, resp: r}
+	}
+	return reader, body, nil
+}
@@ -20,6 +20,8 @@
 package http
 
 import (
+	"context"
+	"crypto/tls"
 	"net"
 	"net/http"
 	"net/url"
@@ -67,27 +69,45 @@ func (c *timeoutConn) SetDeadline(t time.Time) error      { return c.conn.SetDea
 func (c *timeoutConn) SetReadDeadline(t time.Time) error  { return c.conn.SetReadDeadline(t) }
 func (c *timeoutConn) SetWriteDeadline(t time.Time) error { return c.conn.SetWriteDeadline(t) }
 
+// proxyContextKey is the context key to carry the proxy url of each request, so that the clients
+// with different proxy settings can share the same transport safely.
+type proxyContextKey struct{}
+
+func proxyFromContext(req *http.Request) (*url.URL, error) {
+	if proxyUrl, ok := req.Context().Value(proxyContextKey{}).(*url.URL); ok {
+		return proxyUrl, nil
+	}
+	return nil, nil
+}
+
 type ClientConfig struct {
 	RedirectDisabled bool
+	// Resolver is the custom resolver to look up the hosts, the default resolver is used if nil
+	Resolver *net.Resolver
+	// DNSCacheTTL enables the built-in dns cache with the given ttl if it is positive
+	DNSCacheTTL time.Duration
+	// DialFallbackDelay is the delay before falling back to the other ip family when dialing a
+	// host with both IPv4 and IPv6 addresses, zero uses the default and negative disables it
+	DialFallbackDelay time.Duration
+	// DialNetwork restricts the ip family of the connections, "tcp4" or "tcp6", default is "tcp"
+	DialNetwork string
 }
 
 var customizeInit sync.Once
 
+// InitClient - initialize the shared http client used by the clients without their own http
+// client, transport, tls or dial settings, only the config of the first call works
+//
+// PARAMS:
+//     - config: the config of the shared http client
 func InitClient(config ClientConfig) {
 	customizeInit.Do(func() {
 		httpClient = &http.Client{}
 		transport = &http.Transport{
 			MaxIdleConnsPerHost:   defaultMaxIdleConnsPerHost,
 			ResponseHeaderTimeout: defaultResponseHeaderTimeout,
-			Dial: func(network, address string) (net.Conn, error) {
-				conn, err := net.DialTimeout(network, address, defaultDialTimeout)
-				if err != nil {
-					return nil, err
-				}
-				tc := &timeoutConn{conn, defaultSmallInterval, defaultLargeInterval}
-				tc.SetReadDeadline(time.Now().Add(defaultLargeInterval))
-				return tc, nil
-			},
+			Proxy:                 proxyFromContext,
+			Dial:                  newDial(config),
 		}
 		httpClient.Transport = transport
 		if config.RedirectDisabled {
@@ -98,6 +118,106 @@ func InitClient(config ClientConfig) {
 	})
 }
 
+// HasDialSettings - check whether any of the dns and dial settings is set
+func (c ClientConfig) HasDialSettings() bool {
+	return c.Resolver != nil || c.DNSCacheTTL != 0 || c.DialFallbackDelay != 0 ||
+		len(c.DialNetwork) != 0
+}
+
+// newDial - create the dial function with the dns and dial settings of the config, the dialed
+// connections time out if there is no reading or writing for a long time
+func newDial(config ClientConfig) func(network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:       defaultDialTimeout,
+		Resolver:      config.Resolver,
+		FallbackDelay: config.DialFallbackDelay,
+	}
+	var cache *dnsCache
+	if config.DNSCacheTTL > 0 {
+		cache = newDnsCache(config.Resolver, config.DNSCacheTTL)
+	}
+	return func(network, address string) (net.Conn, error) {
+		if len(config.DialNetwork) != 0 {
+			network = config.DialNetwork
+		}
+		var conn net.Conn
+		var err error
+		if cache != nil {
+			conn, err = cache.dial(dialer, network, address)
+		} else {
+			conn, err = dialer.Dial(network, address)
+		}
+		if err != nil {
+			return nil, err
+		}
+		tc := &timeoutConn{conn, defaultSmallInterval, defaultLargeInterval}
+		tc.SetReadDeadline(time.Now().Add(defaultLargeInterval))
+		return tc, nil
+	}
+}
+
+// SharedTransport - get the transport of the shared http client, which is nil before InitClient
+//
+// RETURNS:
+//     - *http.Transport: the shared transport
+func SharedTransport() *http.Transport {
+	return transport
+}
+
+// transportKey is the settings of the cached transports, the clients with the same settings reuse
+// the same transport and its connections
+type transportKey struct {
+	tlsConfig     *tls.Config
+	resolver      *net.Resolver
+	dnsCacheTTL   time.Duration
+	fallbackDelay time.Duration
+	network       string
+}
+
+// transports caches the transports of the custom tls and dial settings
+var transports sync.Map
+
+// TLSTransport - get the transport using the given tls config, which has the same dial and proxy
+// settings as the shared transport and is reused by the clients with the same tls config
+//
+// PARAMS:
+//     - tlsConfig: the tls config of the connections, should not be modified after used
+// RETURNS:
+//     - *http.Transport: the transport using the tls config
+func TLSTransport(tlsConfig *tls.Config) *http.Transport {
+	return DialTransport(ClientConfig{}, tlsConfig)
+}
+
+// DialTransport - get the transport with the dns and dial settings of the config and the tls
+// config, which is reused by the clients with the same settings. The dial of the shared transport
+// is used if the config has no dial settings, and the RedirectDisabled of the config is ignored.
+//
+// PARAMS:
+//     - config: the dns and dial settings, the resolver should not be modified after used
+//     - tlsConfig: the tls config of the connections, nil uses the system defaults
+// RETURNS:
+//     - *http.Transport: the transport with the settings
+func DialTransport(config ClientConfig, tlsConfig *tls.Config) *http.Transport {
+	key := transportKey{tlsConfig, config.Resolver, config.DNSCacheTTL,
+		config.DialFallbackDelay, config.DialNetwork}
+	if val, ok := transports.Load(key); ok {
+		return val.(*http.Transport)
+	}
+	t := &http.Transport{
+		MaxIdleConnsPerHost:   defaultMaxIdleConnsPerHost,
+		ResponseHeaderTimeout: defaultResponseHeaderTimeout,
+		Proxy:                 proxyFromContext,
+		TLSClientConfig:       tlsConfig,
+	}
+	if config.HasDialSettings() {
+		t.Dial = newDial(config)
+	} else if transport != nil {
+		t.Dial = transport.Dial
+	}
+	val, _ := transports.LoadOrStore(key, t)
+	return val.(*http.Transport)
+}
+
 // Execute - do the http requset and get the response
 //
 // PARAMS:
@@ -106,16 +226,51 @@ func InitClient(config ClientConfig) {
 //     - response: the http response returned from the server
 //     - error: nil if ok otherwise the specific error
 func Execute(request *Request) (*Response, error) {
-	// Build the request object for the current requesting
+	httpRequest, err := buildHttpRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the connection timeout for current request on a copy of the shared client, so that the
+	// concurrent requests do not race on it
+	client := *httpClient
+	client.Timeout = time.Duration(request.Timeout()) * time.Second
+
+	return doRequest(&client, transport, httpRequest)
+}
+
+// ExecuteWithClient - do the http request with the given http client instead of the global one,
+// the timeout and proxy settings of the request are ignored and should be set by the client
+//
+// PARAMS:
+//     - client: the http client provided by the user
+//     - request: the http request instance to be sent
+// RETURNS:
+//     - response: the http response returned from the server
+//     - error: nil if ok otherwise the specific error
+func ExecuteWithClient(client *http.Client, request *Request) (*Response, error) {
+	if client == nil {
+		return Execute(request)
+	}
+	httpRequest, err := buildHttpRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	roundTripper := client.Transport
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	return doRequest(client, roundTripper, httpRequest)
+}
+
+// buildHttpRequest - build the request object of the standard library for the current requesting
+func buildHttpRequest(request *Request) (*http.Request, error) {
 	httpRequest := &http.Request{
 		Proto:      "HTTP/1.1",
 		ProtoMajor: 1,
 		ProtoMinor: 1,
 	}
 
-	// Set the connection timeout for current request
-	httpClient.Timeout = time.Duration(request.Timeout()) * time.Second
-
 	// Set the request method
 	httpRequest.Method = request.Method()
 
@@ -147,27 +302,39 @@ func Execute(request *Request) (*Response, error) {
 	}
 
 	// Set the proxy setting if needed
+	ctx := request.Context()
 	if len(request.ProxyUrl()) != 0 {
-		transport.Proxy = func(_ *http.Request) (*url.URL, error) {
-			return url.Parse(request.ProxyUrl())
+		proxyUrl, err := url.Parse(request.ProxyUrl())
+		if err != nil {
+			return nil, err
 		}
+		ctx = context.WithValue(ctx, proxyContextKey{}, proxyUrl)
 	}
+	return httpRequest.WithContext(ctx), nil
+}
 
-	// Perform the http request and get response
+// doRequest - perform the http request and get response
+func doRequest(client *http.Client, roundTripper http.RoundTripper,
+	httpRequest *http.Request) (*Response, error) {
 	// It needs to explicitly close the keep-alive connections when error occurs for the request
 	// that may continue sending request's data subsequently.
+	closeIdle := func() {
+		if t, ok := roundTripper.(interface{ CloseIdleConnections() }); ok {
+			t.CloseIdleConnections()
+		}
+	}
 	start := time.Now()
 
-	httpResponse, err := httpClient.Do(httpRequest)
+	httpResponse, err := client.Do(httpRequest)
 
 	end := time.Now()
 	if err != nil {
-		transport.CloseIdleConnections()
+		closeIdle()
 		return nil, err
 	}
 	if httpResponse.StatusCode >= 400 &&
 		(httpRequest.Method == PUT || httpRequest.Method == POST) {
-		transport.CloseIdleConnections()
+		closeIdle()
 	}
 	response := &Response{httpResponse, end.Sub(start)}
 	return response, nil
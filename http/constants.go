@@ -43,6 +43,10 @@ const (
 	ETAG                = "Etag"
 	EXPIRES             = "Expires"
 	HOST                = "Host"
+	IF_MATCH            = "If-Match"
+	IF_MODIFIED_SINCE   = "If-Modified-Since"
+	IF_NONE_MATCH       = "If-None-Match"
+	IF_UNMODIFIED_SINCE = "If-Unmodified-Since"
 	LAST_MODIFIED       = "Last-Modified"
 	LOCATION            = "Location"
 	RANGE               = "Range"
@@ -57,6 +61,8 @@ const (
 	BCE_GRANT_FULL_CONTROL   = "x-bce-grant-full-control"
 	BCE_CONTENT_SHA256       = "x-bce-content-sha256"
 	BCE_CONTENT_CRC32        = "x-bce-content-crc32"
+	BCE_CONTENT_CRC32C       = "x-bce-content-crc32c"
+	BCE_CONTENT_CRC32C_FLAG  = "x-bce-content-crc32c-flag"
 	BCE_REQUEST_ID           = "x-bce-request-id"
 	BCE_USER_METADATA_PREFIX = "x-bce-meta-"
 	BCE_SECURITY_TOKEN       = "x-bce-security-token"
@@ -80,4 +86,7 @@ const (
 	BCE_RESTORE                         = "x-bce-restore"
 	BCE_FORBID_OVERWRITE                = "x-bce-forbid-overwrite"
 	BCE_SYMLINK_TARGET                  = "x-bce-symlink-target"
+	BCE_SYMLINK_BUCKET                  = "x-bce-symlink-bucket"
+	BCE_TAGGING                         = "x-bce-tagging"
+	BCE_TAGGING_DIRECTIVE               = "x-bce-tagging-directive"
 )
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// dns.go - define the dns cache used by the dialer of the http client
+
+package http
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// defaultFallbackDelay is the delay before dialing the other ip family as the net.Dialer does
+const defaultFallbackDelay = 300 * time.Millisecond
+
+type dnsCacheEntry struct {
+	ips      []net.IPAddr
+	expireAt time.Time
+}
+
+// dnsCache caches the resolved ip addresses of the hosts for the given ttl, which avoids looking
+// up the same host for every new connection under high QPS.
+type dnsCache struct {
+	resolver *net.Resolver
+	ttl      time.Duration
+	lock     sync.RWMutex
+	entries  map[string]*dnsCacheEntry
+}
+
+func newDnsCache(resolver *net.Resolver, ttl time.Duration) *dnsCache {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &dnsCache{
+		resolver: resolver,
+		ttl:      ttl,
+		entries:  make(map[string]*dnsCacheEntry),
+	}
+}
+
+func (d *dnsCache) lookup(host string) ([]net.IPAddr, error) {
+	d.lock.RLock()
+	entry, ok := d.entries[host]
+	d.lock.RUnlock()
+	if ok && time.Now().Before(entry.expireAt) {
+		return entry.ips, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultDialTimeout)
+	defer cancel()
+	ips, err := d.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		if ok { // use the stale entry if lookup failed
+			return entry.ips, nil
+		}
+		return nil, err
+	}
+	d.lock.Lock()
+	d.entries[host] = &dnsCacheEntry{ips, time.Now().Add(d.ttl)}
+	d.lock.Unlock()
+	return ips, nil
+}
+
+func (d *dnsCache) remove(host string) {
+	d.lock.Lock()
+	delete(d.entries, host)
+	d.lock.Unlock()
+}
+
+// dial - dial the address with the cached ip addresses of the host. The addresses of the same ip
+// family as the first one are dialed one by one, and the addresses of the other family are dialed
+// in parallel after the fallback delay of the dialer as the net.Dialer does, the first connection
+// established wins.
+func (d *dnsCache) dial(dialer *net.Dialer, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		return dialer.Dial(network, address)
+	}
+	ips, err := d.lookup(host)
+	if err != nil {
+		return nil, err
+	}
+	var primaries, fallbacks []string
+	primaryIsIPv4 := false
+	for _, ip := range ips {
+		isIPv4 := ip.IP.To4() != nil
+		if (network == "tcp4" && !isIPv4) || (network == "tcp6" && isIPv4) {
+			continue
+		}
+		addr := net.JoinHostPort(ip.String(), port)
+		if len(primaries) == 0 {
+			primaryIsIPv4 = isIPv4
+		}
+		if isIPv4 == primaryIsIPv4 {
+			primaries = append(primaries, addr)
+		} else {
+			fallbacks = append(fallbacks, addr)
+		}
+	}
+	if len(primaries) == 0 {
+		return nil, &net.AddrError{Err: "no suitable address found", Addr: host}
+	}
+	conn, err := dialParallel(dialer, network, primaries, fallbacks)
+	if err != nil {
+		d.remove(host) // resolve again next time in case of the addresses are changed
+	}
+	return conn, err
+}
+
+type dialResult struct {
+	conn    net.Conn
+	err     error
+	primary bool
+}
+
+// dialParallel - dial the primary addresses one by one and start dialing the fallback addresses
+// after the fallback delay or the primaries failed, the fallbacks are dialed after the primaries
+// if the fallback delay is negative
+func dialParallel(dialer *net.Dialer, network string, primaries, fallbacks []string) (net.Conn,
+	error) {
+	if len(fallbacks) == 0 || dialer.FallbackDelay < 0 {
+		return dialSerial(context.Background(), dialer, network, append(primaries, fallbacks...))
+	}
+	delay := dialer.FallbackDelay
+	if delay == 0 {
+		delay = defaultFallbackDelay
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := make(chan dialResult, 2)
+	start := func(addrs []string, primary bool) {
+		go func() {
+			conn, err := dialSerial(ctx, dialer, network, addrs)
+			results <- dialResult{conn, err, primary}
+		}()
+	}
+	start(primaries, true)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	fallbackStarted, pending := false, 1
+	var primaryErr, fallbackErr error
+	for {
+		select {
+		case <-timer.C:
+			if !fallbackStarted {
+				fallbackStarted, pending = true, pending+1
+				start(fallbacks, false)
+			}
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				if pending > 0 { // close the connection of the slower one if it is established
+					go func() {
+						if other := <-results; other.conn != nil {
+							other.conn.Close()
+						}
+					}()
+				}
+				return result.conn, nil
+			}
+			if result.primary {
+				primaryErr = result.err
+			} else {
+				fallbackErr = result.err
+			}
+			if !fallbackStarted {
+				fallbackStarted, pending = true, pending+1
+				start(fallbacks, false)
+			}
+			if pending == 0 {
+				if primaryErr != nil {
+					return nil, primaryErr
+				}
+				return nil, fallbackErr
+			}
+		}
+	}
+}
+
+// dialSerial - dial the addresses one by one until the first connection is established
+func dialSerial(ctx context.Context, dialer *net.Dialer, network string, addrs []string) (net.Conn,
+	error) {
+	var lastErr error
+	for _, addr := range addrs {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
@@ -17,6 +17,7 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strconv"
@@ -40,6 +41,9 @@ type Request struct {
 	// Optional body and length fields to set the body stream and content length
 	body   io.ReadCloser
 	length int64
+
+	// Optional context to cancel the request or set the deadline
+	ctx context.Context
 }
 
 func (r *Request) Protocol() string {
@@ -186,6 +190,17 @@ func (r *Request) SetTimeout(timeout int) {
 	r.timeout = timeout
 }
 
+func (r *Request) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+func (r *Request) SetContext(ctx context.Context) {
+	r.ctx = ctx
+}
+
 func (r *Request) Body() io.ReadCloser {
 	return r.body
 }
@@ -1,6 +1,66 @@
 package model
 
+import "sort"
+
 type TagModel struct {
 	TagKey   string `json:"tagKey"`
 	TagValue string `json:"tagValue"`
 }
+
+// NewTagModels - convert the key-value map to the tag list ordered by the tag key
+//
+// PARAMS:
+//     - tags: the tag key-value map
+// RETURNS:
+//     - []TagModel: the tag list
+func NewTagModels(tags map[string]string) []TagModel {
+	if len(tags) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]TagModel, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, TagModel{TagKey: k, TagValue: tags[k]})
+	}
+	return result
+}
+
+// TagModelsToMap - convert the tag list to the key-value map
+//
+// PARAMS:
+//     - tags: the tag list
+// RETURNS:
+//     - map[string]string: the tag key-value map
+func TagModelsToMap(tags []TagModel) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[tag.TagKey] = tag.TagValue
+	}
+	return result
+}
+
+// MatchTags - check whether the tag list contains all the tags of the filter, the filter with
+// empty value only requires the tag key to exist
+//
+// PARAMS:
+//     - tags: the tag list of the resource
+//     - filter: the tag key-value map to match
+// RETURNS:
+//     - bool: true if all the filter tags are matched
+func MatchTags(tags []TagModel, filter map[string]string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	exists := TagModelsToMap(tags)
+	for k, v := range filter {
+		value, ok := exists[k]
+		if !ok || (len(v) != 0 && value != v) {
+			return false
+		}
+	}
+	return true
+}
@@ -17,10 +17,13 @@
 package appblb
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
 	"github.com/baidubce/bce-sdk-go/http"
 )
 
@@ -126,6 +129,47 @@ func (c *Client) DescribeLoadBalancerDetail(blbId string) (*DescribeLoadBalancer
 	return result, err
 }
 
+// DEFAULT_WAIT_INTERVAL is the polling interval of the WaitLoadBalancerStatus
+const DEFAULT_WAIT_INTERVAL = 3 * time.Second
+
+// WaitLoadBalancerStatus - poll the LoadBalancer every DEFAULT_WAIT_INTERVAL until it reaches the
+// given status, eg: BLBStatusAvailable after it is created or updated
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - blbId: the LoadBalancer's ID
+//     - status: the expected status
+// RETURNS:
+//     - *DescribeLoadBalancerDetailResult: the LoadBalancer detail in the expected status
+//     - error: nil if ok, *waiter.FailureError if the LoadBalancer is unavailable,
+//       the error of the context if it is done, otherwise the specific error
+func (c *Client) WaitLoadBalancerStatus(ctx context.Context, blbId string,
+	status BLBStatus) (*DescribeLoadBalancerDetailResult, error) {
+	op := func(ctx context.Context) (interface{}, error) {
+		result, err := c.DescribeLoadBalancerDetail(blbId)
+		if err != nil {
+			return nil, err
+		}
+		if result.Status == BLBStatusUnavailable && status != BLBStatusUnavailable {
+			return result, fmt.Errorf("the LoadBalancer %s is unavailable", blbId)
+		}
+		return result, nil
+	}
+	result, err := waiter.Wait(ctx, op, []waiter.Acceptor{
+		{State: waiter.StateSuccess, Matcher: func(result interface{}, err error) bool {
+			return err == nil && result.(*DescribeLoadBalancerDetailResult).Status == status
+		}},
+		{State: waiter.StateFailure, Matcher: func(result interface{}, err error) bool {
+			return err != nil && result != nil
+		}},
+	}, &waiter.Backoff{Initial: DEFAULT_WAIT_INTERVAL, Max: DEFAULT_WAIT_INTERVAL})
+	if timeout, ok := err.(*waiter.TimeoutError); ok {
+		err = timeout.Err
+	}
+	detail, _ := result.(*DescribeLoadBalancerDetailResult)
+	return detail, err
+}
+
 // DeleteLoadBalancer - delete a group
 //
 // PARAMS:
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the APPBLB client
+
+package appblb
+
+import (
+	"context"
+)
+
+// Interface defines all the operations of the APPBLB client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateLoadBalancer(args *CreateLoadBalancerArgs) (*CreateLoadBalanceResult, error)
+	UpdateLoadBalancer(blbId string, args *UpdateLoadBalancerArgs) error
+	DescribeLoadBalancers(args *DescribeLoadBalancersArgs) (*DescribeLoadBalancersResult, error)
+	DescribeLoadBalancerDetail(blbId string) (*DescribeLoadBalancerDetailResult, error)
+	WaitLoadBalancerStatus(ctx context.Context, blbId string, status BLBStatus) (*DescribeLoadBalancerDetailResult, error)
+	DeleteLoadBalancer(blbId string) error
+	CreateAppIpGroup(blbId string, args *CreateAppIpGroupArgs) (*CreateAppIpGroupResult, error)
+	UpdateAppIpGroup(blbId string, args *UpdateAppIpGroupArgs) error
+	DescribeAppIpGroup(blbId string, args *DescribeAppIpGroupArgs) (*DescribeAppIpGroupResult, error)
+	DeleteAppIpGroup(blbId string, args *DeleteAppIpGroupArgs) error
+	CreateAppIpGroupBackendPolicy(blbId string, args *CreateAppIpGroupBackendPolicyArgs) error
+	UpdateAppIpGroupBackendPolicy(blbId string, args *UpdateAppIpGroupBackendPolicyArgs) error
+	DeleteAppIpGroupBackendPolicy(blbId string, args *DeleteAppIpGroupBackendPolicyArgs) error
+	CreateAppIpGroupMember(blbId string, args *CreateAppIpGroupMemberArgs) error
+	UpdateAppIpGroupMember(blbId string, args *UpdateAppIpGroupMemberArgs) error
+	DescribeAppIpGroupMember(blbId string, args *DescribeAppIpGroupMemberArgs) (*DescribeAppIpGroupMemberResult, error)
+	DeleteAppIpGroupMember(blbId string, args *DeleteAppIpGroupMemberArgs) error
+	CreateAppServerGroup(blbId string, args *CreateAppServerGroupArgs) (*CreateAppServerGroupResult, error)
+	UpdateAppServerGroup(blbId string, args *UpdateAppServerGroupArgs) error
+	DescribeAppServerGroup(blbId string, args *DescribeAppServerGroupArgs) (*DescribeAppServerGroupResult, error)
+	DeleteAppServerGroup(blbId string, args *DeleteAppServerGroupArgs) error
+	CreateAppServerGroupPort(blbId string, args *CreateAppServerGroupPortArgs) (*CreateAppServerGroupPortResult, error)
+	UpdateAppServerGroupPort(blbId string, args *UpdateAppServerGroupPortArgs) error
+	DeleteAppServerGroupPort(blbId string, args *DeleteAppServerGroupPortArgs) error
+	CreateBlbRs(blbId string, args *CreateBlbRsArgs) error
+	UpdateBlbRs(blbId string, args *UpdateBlbRsArgs) error
+	DescribeBlbRs(blbId string, args *DescribeBlbRsArgs) (*DescribeBlbRsResult, error)
+	DeleteBlbRs(blbId string, args *DeleteBlbRsArgs) error
+	DescribeRsMount(blbId, sgId string) (*DescribeRsMountResult, error)
+	DescribeRsUnMount(blbId, sgId string) (*DescribeRsMountResult, error)
+	CreateAppTCPListener(blbId string, args *CreateAppTCPListenerArgs) error
+	CreateAppUDPListener(blbId string, args *CreateAppUDPListenerArgs) error
+	CreateAppHTTPListener(blbId string, args *CreateAppHTTPListenerArgs) error
+	CreateAppHTTPSListener(blbId string, args *CreateAppHTTPSListenerArgs) error
+	CreateAppSSLListener(blbId string, args *CreateAppSSLListenerArgs) error
+	UpdateAppTCPListener(blbId string, args *UpdateAppTCPListenerArgs) error
+	UpdateAppUDPListener(blbId string, args *UpdateAppUDPListenerArgs) error
+	UpdateAppHTTPListener(blbId string, args *UpdateAppHTTPListenerArgs) error
+	UpdateAppHTTPSListener(blbId string, args *UpdateAppHTTPSListenerArgs) error
+	UpdateAppSSLListener(blbId string, args *UpdateAppSSLListenerArgs) error
+	DescribeAppTCPListeners(blbId string, args *DescribeAppListenerArgs) (*DescribeAppTCPListenersResult, error)
+	DescribeAppUDPListeners(blbId string, args *DescribeAppListenerArgs) (*DescribeAppUDPListenersResult, error)
+	DescribeAppHTTPListeners(blbId string, args *DescribeAppListenerArgs) (*DescribeAppHTTPListenersResult, error)
+	DescribeAppHTTPSListeners(blbId string, args *DescribeAppListenerArgs) (*DescribeAppHTTPSListenersResult, error)
+	DescribeAppSSLListeners(blbId string, args *DescribeAppListenerArgs) (*DescribeAppSSLListenersResult, error)
+	DescribeAppAllListeners(blbId string, args *DescribeAppListenerArgs) (*DescribeAppAllListenersResult, error)
+	DeleteAppListeners(blbId string, args *DeleteAppListenersArgs) error
+	CreatePolicys(blbId string, args *CreatePolicysArgs) error
+	DescribePolicys(blbId string, args *DescribePolicysArgs) (*DescribePolicysResult, error)
+	DeletePolicys(blbId string, args *DeletePolicysArgs) error
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -240,7 +240,7 @@ type AppBLBModel struct {
 	Layer4ClusterId string           `json:"layer4ClusterId"`
 	Layer7ClusterId string           `json:"layer7ClusterId"`
 	Tags            []model.TagModel `json:"tags"`
-	EipRouteType	string			 `json:"eipRouteType"`
+	EipRouteType    string           `json:"eipRouteType"`
 }
 
 type DescribeLoadBalancersResult struct {
@@ -254,7 +254,7 @@ type ListenerModel struct {
 }
 
 type PortTypeModel struct {
-	Port int `json:"port"`
+	Port int    `json:"port"`
 	Type string `json:"type"`
 }
 
@@ -274,7 +274,7 @@ type DescribeLoadBalancerDetailResult struct {
 	Layer7ClusterId string           `json:"layer7ClusterId"`
 	Listener        []ListenerModel  `json:"listener"`
 	Tags            []model.TagModel `json:"tags"`
-	EipRouteType	string			 `json:"eipRouteType"`
+	EipRouteType    string           `json:"eipRouteType"`
 }
 
 type CreateAppTCPListenerArgs struct {
@@ -285,10 +285,10 @@ type CreateAppTCPListenerArgs struct {
 }
 
 type CreateAppUDPListenerArgs struct {
-	UdpSessionTimeout   int    `json:"udpSessionTimeout,omitempty"`
-	ListenerPort        uint16 `json:"listenerPort"`
-	Scheduler    		string `json:"scheduler"`
-	ClientToken  		string `json:"-"`
+	UdpSessionTimeout int    `json:"udpSessionTimeout,omitempty"`
+	ListenerPort      uint16 `json:"listenerPort"`
+	Scheduler         string `json:"scheduler"`
+	ClientToken       string `json:"-"`
 }
 
 type CreateAppHTTPListenerArgs struct {
@@ -304,34 +304,43 @@ type CreateAppHTTPListenerArgs struct {
 	RedirectPort          uint16 `json:"redirectPort,omitempty"`
 }
 
+// AdditionalCertDomain binds the certificate to the host name of the HTTPS or SSL listener, which
+// is selected by the SNI of the client, the CertIds are used for the other host names
+type AdditionalCertDomain struct {
+	CertId string `json:"certId"`
+	Host   string `json:"host"`
+}
+
 type CreateAppHTTPSListenerArgs struct {
-	ClientToken           string   `json:"-"`
-	ListenerPort          uint16   `json:"listenerPort"`
-	Scheduler             string   `json:"scheduler"`
-	KeepSession           bool     `json:"keepSession,omitempty"`
-	KeepSessionType       string   `json:"keepSessionType,omitempty"`
-	KeepSessionTimeout    int      `json:"keepSessionTimeout,omitempty"`
-	KeepSessionCookieName string   `json:"keepSessionCookieName,omitempty"`
-	XForwardedFor         bool     `json:"xForwardedFor,omitempty"`
-	ServerTimeout         int      `json:"serverTimeout,omitempty"`
-	CertIds               []string `json:"certIds"`
-	EncryptionType        string   `json:"encryptionType,omitempty"`
-	EncryptionProtocols   []string `json:"encryptionProtocols,omitempty"`
-	AppliedCiphers        string   `json:"appliedCiphers,omitempty"`
-	DualAuth              bool     `json:"dualAuth,omitempty"`
-	ClientCertIds         []string `json:"clientCertIds,omitempty"`
+	ClientToken           string                 `json:"-"`
+	ListenerPort          uint16                 `json:"listenerPort"`
+	Scheduler             string                 `json:"scheduler"`
+	KeepSession           bool                   `json:"keepSession,omitempty"`
+	KeepSessionType       string                 `json:"keepSessionType,omitempty"`
+	KeepSessionTimeout    int                    `json:"keepSessionTimeout,omitempty"`
+	KeepSessionCookieName string                 `json:"keepSessionCookieName,omitempty"`
+	XForwardedFor         bool                   `json:"xForwardedFor,omitempty"`
+	ServerTimeout         int                    `json:"serverTimeout,omitempty"`
+	CertIds               []string               `json:"certIds"`
+	AdditionalCertDomains []AdditionalCertDomain `json:"additionalCertDomains,omitempty"`
+	EncryptionType        string                 `json:"encryptionType,omitempty"`
+	EncryptionProtocols   []string               `json:"encryptionProtocols,omitempty"`
+	AppliedCiphers        string                 `json:"appliedCiphers,omitempty"`
+	DualAuth              bool                   `json:"dualAuth,omitempty"`
+	ClientCertIds         []string               `json:"clientCertIds,omitempty"`
 }
 
 type CreateAppSSLListenerArgs struct {
-	ClientToken         string   `json:"-"`
-	ListenerPort        uint16   `json:"listenerPort"`
-	Scheduler           string   `json:"scheduler"`
-	CertIds             []string `json:"certIds"`
-	EncryptionType      string   `json:"encryptionType,omitempty"`
-	EncryptionProtocols []string `json:"encryptionProtocols,omitempty"`
-	AppliedCiphers      string   `json:"appliedCiphers,omitempty"`
-	DualAuth            bool     `json:"dualAuth,omitempty"`
-	ClientCertIds       []string `json:"clientCertIds,omitempty"`
+	ClientToken           string                 `json:"-"`
+	ListenerPort          uint16                 `json:"listenerPort"`
+	Scheduler             string                 `json:"scheduler"`
+	CertIds               []string               `json:"certIds"`
+	AdditionalCertDomains []AdditionalCertDomain `json:"additionalCertDomains,omitempty"`
+	EncryptionType        string                 `json:"encryptionType,omitempty"`
+	EncryptionProtocols   []string               `json:"encryptionProtocols,omitempty"`
+	AppliedCiphers        string                 `json:"appliedCiphers,omitempty"`
+	DualAuth              bool                   `json:"dualAuth,omitempty"`
+	ClientCertIds         []string               `json:"clientCertIds,omitempty"`
 }
 
 type UpdateAppListenerArgs struct {
@@ -364,33 +373,35 @@ type UpdateAppHTTPListenerArgs struct {
 }
 
 type UpdateAppHTTPSListenerArgs struct {
-	ClientToken           string   `json:"-"`
-	ListenerPort          uint16   `json:"listenerPort"`
-	Scheduler             string   `json:"scheduler"`
-	KeepSession           bool     `json:"keepSession,omitempty"`
-	KeepSessionType       string   `json:"keepSessionType,omitempty"`
-	KeepSessionTimeout    int      `json:"keepSessionTimeout,omitempty"`
-	KeepSessionCookieName string   `json:"keepSessionCookieName,omitempty"`
-	XForwardedFor         bool     `json:"xForwardedFor,omitempty"`
-	ServerTimeout         int      `json:"serverTimeout,omitempty"`
-	CertIds               []string `json:"certIds"`
-	EncryptionType        string   `json:"encryptionType,omitempty"`
-	EncryptionProtocols   []string `json:"encryptionProtocols,omitempty"`
-	AppliedCiphers        string   `json:"appliedCiphers,omitempty"`
-	DualAuth              bool     `json:"dualAuth,omitempty"`
-	ClientCertIds         []string `json:"clientCertIds,omitempty"`
+	ClientToken           string                 `json:"-"`
+	ListenerPort          uint16                 `json:"listenerPort"`
+	Scheduler             string                 `json:"scheduler"`
+	KeepSession           bool                   `json:"keepSession,omitempty"`
+	KeepSessionType       string                 `json:"keepSessionType,omitempty"`
+	KeepSessionTimeout    int                    `json:"keepSessionTimeout,omitempty"`
+	KeepSessionCookieName string                 `json:"keepSessionCookieName,omitempty"`
+	XForwardedFor         bool                   `json:"xForwardedFor,omitempty"`
+	ServerTimeout         int                    `json:"serverTimeout,omitempty"`
+	CertIds               []string               `json:"certIds"`
+	AdditionalCertDomains []AdditionalCertDomain `json:"additionalCertDomains,omitempty"`
+	EncryptionType        string                 `json:"encryptionType,omitempty"`
+	EncryptionProtocols   []string               `json:"encryptionProtocols,omitempty"`
+	AppliedCiphers        string                 `json:"appliedCiphers,omitempty"`
+	DualAuth              bool                   `json:"dualAuth,omitempty"`
+	ClientCertIds         []string               `json:"clientCertIds,omitempty"`
 }
 
 type UpdateAppSSLListenerArgs struct {
-	ClientToken         string   `json:"-"`
-	ListenerPort        uint16   `json:"-"`
-	Scheduler           string   `json:"scheduler"`
-	CertIds             []string `json:"certIds"`
-	EncryptionType      string   `json:"encryptionType,omitempty"`
-	EncryptionProtocols []string `json:"encryptionProtocols,omitempty"`
-	AppliedCiphers      string   `json:"appliedCiphers,omitempty"`
-	DualAuth            bool     `json:"dualAuth,omitempty"`
-	ClientCertIds       []string `json:"clientCertIds,omitempty"`
+	ClientToken           string                 `json:"-"`
+	ListenerPort          uint16                 `json:"-"`
+	Scheduler             string                 `json:"scheduler"`
+	CertIds               []string               `json:"certIds"`
+	AdditionalCertDomains []AdditionalCertDomain `json:"additionalCertDomains,omitempty"`
+	EncryptionType        string                 `json:"encryptionType,omitempty"`
+	EncryptionProtocols   []string               `json:"encryptionProtocols,omitempty"`
+	AppliedCiphers        string                 `json:"appliedCiphers,omitempty"`
+	DualAuth              bool                   `json:"dualAuth,omitempty"`
+	ClientCertIds         []string               `json:"clientCertIds,omitempty"`
 }
 
 type AppListenerModel struct {
@@ -421,53 +432,56 @@ type AppHTTPListenerModel struct {
 }
 
 type AppHTTPSListenerModel struct {
-	ListenerPort          uint16   `json:"listenerPort"`
-	Scheduler             string   `json:"scheduler"`
-	KeepSession           bool     `json:"keepSession"`
-	KeepSessionType       string   `json:"keepSessionType"`
-	KeepSessionTimeout    int      `json:"keepSessionTimeout"`
-	KeepSessionCookieName string   `json:"keepSessionCookieName"`
-	XForwardedFor         bool     `json:"xForwardedFor"`
-	ServerTimeout         int      `json:"serverTimeout"`
-	CertIds               []string `json:"certIds"`
-	EncryptionType        string   `json:"encryptionType"`
-	EncryptionProtocols   []string `json:"encryptionProtocols"`
-	AppliedCiphers        string   `json:"appliedCiphers"`
-	DualAuth              bool     `json:"dualAuth"`
-	ClientCertIds         []string `json:"clientCertIds"`
+	ListenerPort          uint16                 `json:"listenerPort"`
+	Scheduler             string                 `json:"scheduler"`
+	KeepSession           bool                   `json:"keepSession"`
+	KeepSessionType       string                 `json:"keepSessionType"`
+	KeepSessionTimeout    int                    `json:"keepSessionTimeout"`
+	KeepSessionCookieName string                 `json:"keepSessionCookieName"`
+	XForwardedFor         bool                   `json:"xForwardedFor"`
+	ServerTimeout         int                    `json:"serverTimeout"`
+	CertIds               []string               `json:"certIds"`
+	AdditionalCertDomains []AdditionalCertDomain `json:"additionalCertDomains"`
+	EncryptionType        string                 `json:"encryptionType"`
+	EncryptionProtocols   []string               `json:"encryptionProtocols"`
+	AppliedCiphers        string                 `json:"appliedCiphers"`
+	DualAuth              bool                   `json:"dualAuth"`
+	ClientCertIds         []string               `json:"clientCertIds"`
 }
 
 type AppSSLListenerModel struct {
-	ListenerPort        uint16   `json:"listenerPort"`
-	Scheduler           string   `json:"scheduler"`
-	CertIds             []string `json:"certIds"`
-	EncryptionType      string   `json:"encryptionType"`
-	EncryptionProtocols []string `json:"encryptionProtocols"`
-	AppliedCiphers      string   `json:"appliedCiphers"`
-	DualAuth            bool     `json:"dualAuth"`
-	ClientCertIds       []string `json:"clientCertIds"`
+	ListenerPort          uint16                 `json:"listenerPort"`
+	Scheduler             string                 `json:"scheduler"`
+	CertIds               []string               `json:"certIds"`
+	AdditionalCertDomains []AdditionalCertDomain `json:"additionalCertDomains"`
+	EncryptionType        string                 `json:"encryptionType"`
+	EncryptionProtocols   []string               `json:"encryptionProtocols"`
+	AppliedCiphers        string                 `json:"appliedCiphers"`
+	DualAuth              bool                   `json:"dualAuth"`
+	ClientCertIds         []string               `json:"clientCertIds"`
 }
 
 type AppAllListenerModel struct {
-	ListenerPort          uint16   `json:"listenerPort"`
-	ListenerType          string   `json:"listenerType"`
-	Scheduler             string   `json:"scheduler"`
-	TcpSessionTimeout     int      `json:"tcpSessionTimeout"`
-	UdpSessionTimeout     int      `json:"udpSessionTimeout"`
-	KeepSession           bool     `json:"keepSession"`
-	KeepSessionType       string   `json:"keepSessionType"`
-	KeepSessionTimeout    int      `json:"keepSessionTimeout"`
-	KeepSessionCookieName string   `json:"keepSessionCookieName"`
-	XForwardedFor         bool     `json:"xForwardedFor"`
-	xForwardedProto       bool     `json:"xForwardedProto"`
-	ServerTimeout         int      `json:"serverTimeout"`
-	RedirectPort          int      `json:"redirectPort"`
-	CertIds               []string `json:"certIds"`
-	EncryptionType        string   `json:"encryptionType"`
-	EncryptionProtocols   []string `json:"encryptionProtocols"`
-	AppliedCiphers        string   `json:"appliedCiphers"`
-	DualAuth              bool     `json:"dualAuth"`
-	ClientCertIds         []string `json:"clientCertIds"`
+	ListenerPort          uint16                 `json:"listenerPort"`
+	ListenerType          string                 `json:"listenerType"`
+	Scheduler             string                 `json:"scheduler"`
+	TcpSessionTimeout     int                    `json:"tcpSessionTimeout"`
+	UdpSessionTimeout     int                    `json:"udpSessionTimeout"`
+	KeepSession           bool                   `json:"keepSession"`
+	KeepSessionType       string                 `json:"keepSessionType"`
+	KeepSessionTimeout    int                    `json:"keepSessionTimeout"`
+	KeepSessionCookieName string                 `json:"keepSessionCookieName"`
+	XForwardedFor         bool                   `json:"xForwardedFor"`
+	xForwardedProto       bool                   `json:"xForwardedProto"`
+	ServerTimeout         int                    `json:"serverTimeout"`
+	RedirectPort          int                    `json:"redirectPort"`
+	CertIds               []string               `json:"certIds"`
+	AdditionalCertDomains []AdditionalCertDomain `json:"additionalCertDomains"`
+	EncryptionType        string                 `json:"encryptionType"`
+	EncryptionProtocols   []string               `json:"encryptionProtocols"`
+	AppliedCiphers        string                 `json:"appliedCiphers"`
+	DualAuth              bool                   `json:"dualAuth"`
+	ClientCertIds         []string               `json:"clientCertIds"`
 }
 
 type DescribeAppListenerArgs struct {
@@ -507,9 +521,9 @@ type DescribeAppAllListenersResult struct {
 }
 
 type DeleteAppListenersArgs struct {
-	ClientToken string   `json:"-"`
-	PortList    []uint16 `json:"portList"`
-	PortTypeList    []PortTypeModel `json:"portTypeList"`
+	ClientToken  string          `json:"-"`
+	PortList     []uint16        `json:"portList"`
+	PortTypeList []PortTypeModel `json:"portTypeList"`
 }
 
 type AppRule struct {
@@ -557,7 +571,7 @@ type DeletePolicysArgs struct {
 	ClientToken  string   `json:"-"`
 	Port         uint16   `json:"port"`
 	PolicyIdList []string `json:"policyIdList"`
-	Type         string      `json:"type"`
+	Type         string   `json:"type"`
 }
 
 type CreateAppIpGroupArgs struct {
@@ -568,10 +582,10 @@ type CreateAppIpGroupArgs struct {
 }
 
 type AppIpGroupMember struct {
-	Ip     string `json:"ip,omitempty"`
-	Port   int    `json:"port,omitempty"`
-	Weight int    `json:"weight,omitempty"`
-	MemberId     string `json:"memberId,omitempty"`
+	Ip       string `json:"ip,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	Weight   int    `json:"weight,omitempty"`
+	MemberId string `json:"memberId,omitempty"`
 }
 
 type CreateAppIpGroupResult struct {
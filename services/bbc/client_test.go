@@ -1,6 +1,7 @@
 package bbc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/baidubce/bce-sdk-go/model"
 	"github.com/baidubce/bce-sdk-go/util/log"
@@ -848,3 +850,27 @@ func TestGetBbcStockWithDeploySet(t *testing.T) {
 		fmt.Printf("get bbc stock, result : %s", data)
 	}
 }
+
+func TestSelectFlavorRaid(t *testing.T) {
+	raid, err := BBC_CLIENT.SelectFlavorRaid("BBC-G4-01S", "raid5")
+	ExpectEqual(t.Errorf, err, nil)
+	fmt.Println(raid)
+	_, err = BBC_CLIENT.SelectFlavorRaid("BBC-G4-01S", "no-such-raid")
+	ExpectEqual(t.Errorf, err != nil, true)
+}
+
+func TestListFlavorInventory(t *testing.T) {
+	res, err := BBC_CLIENT.ListFlavorInventory(BBC_TestFlavorId, PaymentTimingPostPaid)
+	ExpectEqual(t.Errorf, err, nil)
+	for _, inventory := range res {
+		fmt.Println(inventory.ZoneName, inventory.Count)
+	}
+}
+
+func TestWaitInstanceStatus(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	res, err := BBC_CLIENT.WaitInstanceStatus(ctx, BBC_TestBbcId, InstanceStatusRunning)
+	ExpectEqual(t.Errorf, err, nil)
+	fmt.Println(res)
+}
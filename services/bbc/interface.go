@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the BBC client
+
+package bbc
+
+import (
+	"context"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+)
+
+// Interface defines all the operations of the BBC client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateInstance(args *CreateInstanceArgs) (*CreateInstanceResult, error)
+	CreateInstanceByLabel(args *CreateSpecialInstanceArgs) (*CreateInstanceResult, error)
+	ListInstances(args *ListInstancesArgs) (*ListInstancesResult, error)
+	GetInstanceDetail(instanceId string) (*InstanceModel, error)
+	GetInstanceDetailWithDeploySet(instanceId string, isDeploySet bool) (*InstanceModel, error)
+	GetInstanceDetailWithDeploySetAndFailed(instanceId string, isDeploySet bool, containsFailed bool) (*InstanceModel, error)
+	StartInstance(instanceId string) error
+	StopInstance(instanceId string, forceStop bool) error
+	RebootInstance(instanceId string, forceStop bool) error
+	ModifyInstanceName(instanceId string, args *ModifyInstanceNameArgs) error
+	ModifyInstanceDesc(instanceId string, args *ModifyInstanceDescArgs) error
+	RebuildInstance(instanceId string, isPreserveData bool, args *RebuildInstanceArgs) error
+	GetInstanceVNC(instanceId string) (*GetInstanceVNCResult, error)
+	BatchRebuildInstances(args *RebuildBatchInstanceArgs) (*BatchRebuildResponse, error)
+	InstancePurchaseReserved(instanceId string, args *PurchaseReservedArgs) error
+	DeleteInstance(instanceId string) error
+	ListRecycledInstances(args *ListRecycledInstancesArgs) (*ListRecycledInstancesResult, error)
+	RecoveryInstances(args *RecoveryInstancesArgs) error
+	DeleteInstances(args *DeleteInstanceArgs) error
+	ModifyInstancePassword(instanceId string, args *ModifyInstancePasswordArgs) error
+	InstanceChangeSubnet(args *InstanceChangeSubnetArgs) error
+	InstanceChangeVpc(args *InstanceChangeVpcArgs) error
+	GetVpcSubnet(args *GetVpcSubnetArgs) (*GetVpcSubnetResult, error)
+	BatchAddIP(args *BatchAddIpArgs) (*BatchAddIpResponse, error)
+	BatchDelIP(args *BatchDelIpArgs) error
+	BatchAddIPCrossSubnet(args *BatchAddIpCrossSubnetArgs) (*BatchAddIpResponse, error)
+	BindTags(instanceId string, args *BindTagsArgs) error
+	UnbindTags(instanceId string, args *UnbindTagsArgs) error
+	ListFlavors() (*ListFlavorsResult, error)
+	GetFlavorDetail(flavorId string) (*GetFlavorDetailResult, error)
+	GetFlavorRaid(flavorId string) (*GetFlavorRaidResult, error)
+	CreateImageFromInstanceId(args *CreateImageArgs) (*CreateImageResult, error)
+	ListImage(args *ListImageArgs) (*ListImageResult, error)
+	ListCustomFlavorImage(args *ListImageArgs) (*FlavorImageResult, error)
+	ListFlavorImage(args *ListImageArgs) (*FlavorImageResult, error)
+	GetImageDetail(imageId string) (*GetImageDetailResult, error)
+	DeleteImage(imageId string) error
+	GetOperationLog(args *GetOperationLogArgs) (*GetOperationLogResult, error)
+	CreateDeploySet(args *CreateDeploySetArgs) (*CreateDeploySetResult, error)
+	ListDeploySets() (*ListDeploySetsResult, error)
+	ListDeploySetsPage(args *ListDeploySetsArgs) (*ListDeploySetsResult, error)
+	GetDeploySet(deploySetId string) (*DeploySetResult, error)
+	DeleteDeploySet(deploySetId string) error
+	BindSecurityGroups(args *BindSecurityGroupsArgs) error
+	UnBindSecurityGroups(args *UnBindSecurityGroupsArgs) error
+	ListFlavorZones(args *ListFlavorZonesArgs) (*ListZonesResult, error)
+	ListZoneFlavors(args *ListZoneFlavorsArgs) (*ListFlavorInfosResult, error)
+	GetCommonImage(args *GetFlavorImageArgs) (*GetImagesResult, error)
+	GetCustomImage(args *GetFlavorImageArgs) (*GetImagesResult, error)
+	ShareImage(imageId string, args *SharedUser) error
+	UnShareImage(imageId string, args *SharedUser) error
+	GetImageSharedUser(imageId string) (*GetImageSharedUserResult, error)
+	RemoteCopyImage(imageId string, args *RemoteCopyImageArgs) error
+	RemoteCopyImageReturnImageIds(imageId string, args *RemoteCopyImageArgs) (*RemoteCopyImageResult, error)
+	CancelRemoteCopyImage(imageId string) error
+	GetInstanceEni(instanceId string) (*GetInstanceEniResult, error)
+	GetInstanceCreateStock(args *CreateInstanceStockArgs) (*InstanceStockResult, error)
+	GetSimpleFlavor(args *GetSimpleFlavorArgs) (*SimpleFlavorResult, error)
+	GetInstancePirce(args *InstancePirceArgs) (*InstancePirceResult, error)
+	ListRepairTasks(args *ListRepairTaskArgs) (*ListRepairTaskResult, error)
+	ListClosedRepairTasks(args *ListClosedRepairTaskArgs) (*ListClosedRepairTaskResult, error)
+	GetRepairTaskDetail(taskId string) (*GetRepairTaskResult, error)
+	AuthorizeRepairTask(args *TaskIdArgs) error
+	UnAuthorizeRepairTask(args *TaskIdArgs) error
+	ConfirmRepairTask(args *TaskIdArgs) error
+	DisConfirmRepairTask(args *DisconfirmTaskArgs) error
+	GetRepairTaskRecord(args *TaskIdArgs) (*GetRepairRecords, error)
+	ListRule(args *ListRuleArgs) (*ListRuleResult, error)
+	GetRuleDetail(ruleId string) (*Rule, error)
+	CreateRule(args *CreateRuleArgs) (*CreateRuleResult, error)
+	DeleteRule(args *DeleteRuleArgs) error
+	DisableRule(args *DisableRuleArgs) error
+	EnableRule(args *EnableRuleArgs) error
+	BatchCreateAutoRenewRules(args *BbcCreateAutoRenewArgs) error
+	BatchDeleteAutoRenewRules(args *BbcDeleteAutoRenewArgs) error
+	DeleteInstanceIngorePayment(args *DeleteInstanceIngorePaymentArgs) (*DeleteInstanceResult, error)
+	DeleteRecycledInstance(instanceId string) error
+	ListCDSVolume(queryArgs *ListCDSVolumeArgs) (*ListCDSVolumeResult, error)
+	GetBbcStockWithDeploySet(args *GetBbcStockArgs) (*GetBbcStocksResult, error)
+	ListFlavorInventory(flavorId string, productType PaymentTimingType) ([]FlavorInventory, error)
+	SelectFlavorRaid(flavorId, raid string) (*RaidModel, error)
+	WaitInstanceStatus(ctx context.Context, instanceId string, status InstanceStatus) (*InstanceModel, error)
+	WaitInstancesStatus(ctx context.Context, instanceIds []string, status InstanceStatus) ([]*InstanceModel, error)
+	ImageTask(imageId string) waiter.Task
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
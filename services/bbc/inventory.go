@@ -0,0 +1,96 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// inventory.go - query the flavor inventory of the zones and select the RAID for the creation
+
+package bbc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlavorInventory - the stock and the disk configurations of a flavor in a zone
+type FlavorInventory struct {
+	ZoneName  string
+	FlavorId  string
+	Count     int
+	DiskInfos map[string]DiskInfo
+}
+
+// ListFlavorInventory - list the zones selling the flavor with the stock and the disk
+// configurations of each zone, so that the zone with enough machines can be chosen for the fleet
+//
+// PARAMS:
+//     - flavorId: the id of the flavor
+//     - productType: the payment timing, Prepaid or Postpaid
+// RETURNS:
+//     - []FlavorInventory: the inventory of the flavor in every zone selling it
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListFlavorInventory(flavorId string,
+	productType PaymentTimingType) ([]FlavorInventory, error) {
+	zones, err := c.ListFlavorZones(&ListFlavorZonesArgs{FlavorId: flavorId, ProductType: productType})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]FlavorInventory, 0, len(zones.ZoneNames))
+	for _, zoneName := range zones.ZoneNames {
+		flavors, err := c.ListZoneFlavors(&ListZoneFlavorsArgs{
+			ZoneName:    zoneName,
+			ProductType: productType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, flavor := range flavors.BbcFlavorInfoList {
+			if flavor.FlavorId == flavorId {
+				result = append(result, FlavorInventory{
+					ZoneName:  zoneName,
+					FlavorId:  flavorId,
+					Count:     flavor.Count,
+					DiskInfos: flavor.DiskInfos,
+				})
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// SelectFlavorRaid - select the RAID configuration of the flavor by the RAID name such as
+// "Raid5" or "NoRaid" case-insensitively, or by the RAID id, the RaidId of the result should be
+// set to the CreateInstanceArgs or the RebuildInstanceArgs and the root disk size should not
+// exceed the SysRootSize of the result
+//
+// PARAMS:
+//     - flavorId: the id of the flavor
+//     - raid: the RAID name or id
+// RETURNS:
+//     - *RaidModel: the RAID configuration with the disk sizes
+//     - error: nil if success otherwise the specific error
+func (c *Client) SelectFlavorRaid(flavorId, raid string) (*RaidModel, error) {
+	raids, err := c.GetFlavorRaid(flavorId)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(raids.Raids))
+	for i := range raids.Raids {
+		if raids.Raids[i].RaidId == raid || strings.EqualFold(raids.Raids[i].Raid, raid) {
+			return &raids.Raids[i], nil
+		}
+		names = append(names, raids.Raids[i].Raid)
+	}
+	return nil, fmt.Errorf("the raid %s is not supported by the flavor %s, available: %s",
+		raid, flavorId, strings.Join(names, ", "))
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// wait.go - wait for the instances and images to reach the expected status
+
+package bbc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+)
+
+// WaitInstanceStatus - poll the instance with the default backoff of the waiter until it reaches
+// the given status, eg: InstanceStatusRunning after it is created, started or rebuilt
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - instanceId: the specific instance ID
+//     - status: the expected status
+// RETURNS:
+//     - *InstanceModel: the last queried instance
+//     - error: nil if ok, *waiter.FailureError if the instance is in the Error status,
+//       *waiter.TimeoutError if the context is done, otherwise the query error
+func (c *Client) WaitInstanceStatus(ctx context.Context, instanceId string,
+	status InstanceStatus) (*InstanceModel, error) {
+	op := func(ctx context.Context) (interface{}, error) {
+		result, err := c.GetInstanceDetail(instanceId)
+		if err != nil {
+			return nil, err
+		}
+		if result.Status == InstanceStatusError && status != InstanceStatusError {
+			return result, fmt.Errorf("the instance %s is in the Error status", instanceId)
+		}
+		return result, nil
+	}
+	result, err := waiter.Wait(ctx, op, []waiter.Acceptor{
+		{State: waiter.StateSuccess, Matcher: func(result interface{}, err error) bool {
+			return err == nil && result.(*InstanceModel).Status == status
+		}},
+		{State: waiter.StateFailure, Matcher: func(result interface{}, err error) bool {
+			return err != nil && result != nil
+		}},
+	}, nil)
+	instance, _ := result.(*InstanceModel)
+	return instance, err
+}
+
+// WaitInstancesStatus - wait for all the instances to reach the given status, eg: the instances
+// returned by the CreateInstance or the BatchRebuildInstances
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - instanceIds: the instance IDs
+//     - status: the expected status
+// RETURNS:
+//     - []*InstanceModel: the last queried instances in the order of the instanceIds
+//     - error: the error of the first instance failed to reach the status, see WaitInstanceStatus
+func (c *Client) WaitInstancesStatus(ctx context.Context, instanceIds []string,
+	status InstanceStatus) ([]*InstanceModel, error) {
+	result := make([]*InstanceModel, 0, len(instanceIds))
+	for _, instanceId := range instanceIds {
+		instance, err := c.WaitInstanceStatus(ctx, instanceId, status)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, instance)
+	}
+	return result, nil
+}
+
+// ImageTask - get the async task of the image creation, the result of the task is the
+// *ImageModel of the last query
+//
+// PARAMS:
+//     - imageId: the image ID returned by the CreateImageFromInstanceId
+// RETURNS:
+//     - waiter.Task: the task finished when the image is available, or failed if the image is in
+//       the CreateFailed or Error status
+func (c *Client) ImageTask(imageId string) waiter.Task {
+	return waiter.NewTask(imageId, func(ctx context.Context) (interface{}, waiter.State, error) {
+		result, err := c.GetImageDetail(imageId)
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		if result.Result == nil {
+			return nil, waiter.StateRetry, nil
+		}
+		switch result.Result.Status {
+		case ImageStatusAvailable:
+			return result.Result, waiter.StateSuccess, nil
+		case ImageStatusCreateFailed, ImageStatusError:
+			return result.Result, waiter.StateFailure,
+				fmt.Errorf("the image %s is in the %s status", imageId, result.Result.Status)
+		}
+		return result.Result, waiter.StateRetry, nil
+	}, nil)
+}
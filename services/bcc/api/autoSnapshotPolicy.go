@@ -18,7 +18,6 @@
 package api
 
 import (
-	"encoding/json"
 	"strconv"
 
 	"github.com/baidubce/bce-sdk-go/bce"
@@ -43,7 +42,7 @@ func CreateAutoSnapshotPolicy(cli bce.Client, args *CreateASPArgs) (*CreateASPRe
 		req.SetParam("clientToken", args.ClientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +84,7 @@ func AttachAutoSnapshotPolicy(cli bce.Client, aspId string, args *AttachASPArgs)
 
 	req.SetParam("attach", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -124,7 +123,7 @@ func DetachAutoSnapshotPolicy(cli bce.Client, aspId string, args *DetachASPArgs)
 
 	req.SetParam("detach", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -267,7 +266,7 @@ func UpdateAutoSnapshotPolicy(cli bce.Client, args *UpdateASPArgs) error {
 	req.SetUri(getASPUri() + "/update")
 	req.SetMethod(http.PUT)
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -18,11 +18,11 @@
 package api
 
 import (
-	"encoding/json"
 	"strconv"
 
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/model"
 )
 
 // CreateCDSVolume - create a specified count of cds volumes
@@ -39,11 +39,11 @@ func CreateCDSVolume(cli bce.Client, args *CreateCDSVolumeArgs) (*CreateCDSVolum
 	req.SetUri(getVolumeUri())
 	req.SetMethod(http.POST)
 
-	if args.ClientToken != "" {
-		req.SetParam("clientToken", args.ClientToken)
+	if clientToken := bce.ClientToken(cli, args.ClientToken); clientToken != "" {
+		req.SetParam("clientToken", clientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -83,11 +83,11 @@ func CreateCDSVolumeV3(cli bce.Client, args *CreateCDSVolumeV3Args) (*CreateCDSV
 	req.SetUri(getVolumeV3Uri())
 	req.SetMethod(http.POST)
 
-	if args.ClientToken != "" {
-		req.SetParam("clientToken", args.ClientToken)
+	if clientToken := bce.ClientToken(cli, args.ClientToken); clientToken != "" {
+		req.SetParam("clientToken", clientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +159,15 @@ func ListCDSVolume(cli bce.Client, queryArgs *ListCDSVolumeArgs) (*ListCDSVolume
 	if err := resp.ParseJsonBody(jsonBody); err != nil {
 		return nil, err
 	}
+	if queryArgs != nil && len(queryArgs.Tags) != 0 {
+		filtered := jsonBody.Volumes[:0]
+		for _, item := range jsonBody.Volumes {
+			if model.MatchTags(item.Tags, queryArgs.Tags) {
+				filtered = append(filtered, item)
+			}
+		}
+		jsonBody.Volumes = filtered
+	}
 	return jsonBody, nil
 }
 
@@ -288,7 +297,7 @@ func AttachCDSVolume(cli bce.Client, volumeId string, args *AttachVolumeArgs) (*
 
 	req.SetParam("attach", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -330,7 +339,7 @@ func DetachCDSVolume(cli bce.Client, volumeId string, args *DetachVolumeArgs) er
 
 	req.SetParam("detach", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -395,7 +404,7 @@ func DeleteCDSVolumeNew(cli bce.Client, volumeId string, args *DeleteCDSVolumeAr
 	req.SetUri(getVolumeUriWithId(volumeId))
 	req.SetMethod(http.POST)
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -437,7 +446,7 @@ func ResizeCDSVolume(cli bce.Client, volumeId string, args *ResizeCSDVolumeArgs)
 	}
 	req.SetParam("resize", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -476,7 +485,7 @@ func RollbackCDSVolume(cli bce.Client, volumeId string, args *RollbackCSDVolumeA
 
 	req.SetParam("rollback", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -518,7 +527,7 @@ func PurchaseReservedCDSVolume(cli bce.Client, volumeId string, args *PurchaseRe
 	}
 	req.SetParam("purchaseReserved", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -557,7 +566,7 @@ func RenameCDSVolume(cli bce.Client, volumeId string, args *RenameCSDVolumeArgs)
 
 	req.SetParam("rename", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -596,7 +605,7 @@ func ModifyCDSVolume(cli bce.Client, volumeId string, args *ModifyCSDVolumeArgs)
 
 	req.SetParam("modify", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -635,7 +644,7 @@ func ModifyChargeTypeCDSVolume(cli bce.Client, volumeId string, args *ModifyChar
 
 	req.SetParam("modifyChargeType", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -674,7 +683,7 @@ func AutoRenewCDSVolume(cli bce.Client, args *AutoRenewCDSVolumeArgs) error {
 		req.SetParam("clientToken", args.ClientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -713,7 +722,7 @@ func CancelAutoRenewCDSVolume(cli bce.Client, args *CancelAutoRenewCDSVolumeArgs
 		req.SetParam("clientToken", args.ClientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -778,7 +787,7 @@ func DeletePrepayVolume(cli bce.Client, args *VolumePrepayDeleteRequestArgs) (*V
 	req := &bce.BceRequest{}
 	req.SetUri(getDeletePrepayVolumeUri())
 	req.SetMethod(http.POST)
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -803,3 +812,61 @@ func DeletePrepayVolume(cli bce.Client, args *VolumePrepayDeleteRequestArgs) (*V
 	}
 	return jsonBody, nil
 }
+
+// BindCDSVolumeToTags - bind CDS volume to tags
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - volumeId: the id of the CDS volume
+//     - reqBody: the request body to bind tags
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func BindCDSVolumeToTags(cli bce.Client, volumeId string, reqBody *bce.Body) error {
+	// Build the request
+	req := &bce.BceRequest{}
+	req.SetUri(getBindCDSVolumeToTagsUri(volumeId))
+	req.SetMethod(http.PUT)
+	req.SetBody(reqBody)
+	req.SetParam("bind", "")
+
+	// Send request and get response
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
+// UnBindCDSVolumeToTags - unbind CDS volume to tags
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - volumeId: the id of the CDS volume
+//     - reqBody: the request body to unbind tags
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func UnBindCDSVolumeToTags(cli bce.Client, volumeId string, reqBody *bce.Body) error {
+	// Build the request
+	req := &bce.BceRequest{}
+	req.SetUri(getBindCDSVolumeToTagsUri(volumeId))
+	req.SetMethod(http.PUT)
+	req.SetBody(reqBody)
+	req.SetParam("unbind", "")
+
+	// Send request and get response
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+
+	defer func() { resp.Body().Close() }()
+	return nil
+}
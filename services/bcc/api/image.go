@@ -18,7 +18,6 @@
 package api
 
 import (
-	"encoding/json"
 	"errors"
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/http"
@@ -44,7 +43,7 @@ func CreateImage(cli bce.Client, args *CreateImageArgs) (*CreateImageResult, err
 		req.SetParam("clientToken", args.ClientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -196,7 +195,7 @@ func RemoteCopyImage(cli bce.Client, imageId string, args *RemoteCopyImageArgs)
 
 	req.SetParam("remoteCopy", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -236,7 +235,7 @@ func RemoteCopyImageReturnImageIds(cli bce.Client, imageId string, args *RemoteC
 
 	req.SetParam("remoteCopy", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -306,7 +305,7 @@ func ShareImage(cli bce.Client, imageId string, args *SharedUser) error {
 
 	req.SetParam("share", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -345,7 +344,7 @@ func UnShareImage(cli bce.Client, imageId string, args *SharedUser) error {
 
 	req.SetParam("unshare", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -412,7 +411,7 @@ func GetImageOS(cli bce.Client, args *GetImageOsArgs) (*GetImageOsResult, error)
 	req.SetUri(getImageOsUri())
 	req.SetMethod(http.POST)
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -437,3 +436,137 @@ func GetImageOS(cli bce.Client, args *GetImageOsArgs) (*GetImageOsResult, error)
 	}
 	return jsonBody, nil
 }
+
+// ImportImage - import a custom image from the image file stored in BOS
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - args: the arguments of the image file and its operating system
+// RETURNS:
+//     - *ImportImageResult: the id of the imported image and the import task
+//     - error: nil if success otherwise the specific error
+func ImportImage(cli bce.Client, args *ImportImageArgs) (*ImportImageResult, error) {
+	if args == nil || args.BosUrl == "" {
+		return nil, errors.New("the bos url of the image file should not be empty")
+	}
+	if args.Name == "" || args.OsType == "" || args.OsVersion == "" || args.OsArch == "" {
+		return nil, errors.New("the name, os type, os version and os arch of the image should be set")
+	}
+
+	// Build the request
+	req := &bce.BceRequest{}
+	req.SetUri(getImageImportUri())
+	req.SetMethod(http.POST)
+
+	if args.ClientToken != "" {
+		req.SetParam("clientToken", args.ClientToken)
+	}
+
+	jsonBytes, err := bce.MarshalJSON(args)
+	if err != nil {
+		return nil, err
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBody(body)
+
+	// Send request and get response
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+
+	jsonBody := &ImportImageResult{}
+	if err := resp.ParseJsonBody(jsonBody); err != nil {
+		return nil, err
+	}
+	return jsonBody, nil
+}
+
+// ExportImage - export the custom image to an image file in BOS
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - imageId: id of the custom image
+//     - args: the arguments of the exported image file
+// RETURNS:
+//     - *ExportImageResult: the id of the export task
+//     - error: nil if success otherwise the specific error
+func ExportImage(cli bce.Client, imageId string, args *ExportImageArgs) (*ExportImageResult, error) {
+	if imageId == "" {
+		return nil, errors.New("the image id should not be empty")
+	}
+	if args == nil || args.BosUrl == "" {
+		return nil, errors.New("the bos url of the image file should not be empty")
+	}
+
+	// Build the request
+	req := &bce.BceRequest{}
+	req.SetUri(getImageUriWithId(imageId))
+	req.SetMethod(http.POST)
+
+	req.SetParam("export", "")
+	if args.ClientToken != "" {
+		req.SetParam("clientToken", args.ClientToken)
+	}
+
+	jsonBytes, err := bce.MarshalJSON(args)
+	if err != nil {
+		return nil, err
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBody(body)
+
+	// Send request and get response
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+
+	jsonBody := &ExportImageResult{}
+	if err := resp.ParseJsonBody(jsonBody); err != nil {
+		return nil, err
+	}
+	return jsonBody, nil
+}
+
+// GetImageTask - get the progress of the image import or export task
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - taskId: id of the import or export task
+// RETURNS:
+//     - *ImageTaskModel: the status and progress of the task
+//     - error: nil if success otherwise the specific error
+func GetImageTask(cli bce.Client, taskId string) (*ImageTaskModel, error) {
+	// Build the request
+	req := &bce.BceRequest{}
+	req.SetUri(getImageTaskUriWithId(taskId))
+	req.SetMethod(http.GET)
+
+	// Send request and get response
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+
+	jsonBody := &ImageTaskModel{}
+	if err := resp.ParseJsonBody(jsonBody); err != nil {
+		return nil, err
+	}
+	return jsonBody, nil
+}
@@ -18,11 +18,11 @@
 package api
 
 import (
-	"encoding/json"
 	"strconv"
 
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/model"
 )
 
 // CreateInstance - create an instance with specified parameters
@@ -36,7 +36,7 @@ import (
 func CreateInstance(cli bce.Client, args *CreateInstanceArgs, reqBody *bce.Body) (*CreateInstanceResult,
 	error) {
 	// Build the request
-	clientToken := args.ClientToken
+	clientToken := bce.ClientToken(cli, args.ClientToken)
 	requestToken := args.RequestToken
 	req := &bce.BceRequest{}
 	req.SetUri(getInstanceUri())
@@ -75,7 +75,7 @@ func CreateInstance(cli bce.Client, args *CreateInstanceArgs, reqBody *bce.Body)
 func CreateInstanceByLabel(cli bce.Client, args *CreateSpecialInstanceBySpecArgs, reqBody *bce.Body) (*CreateInstanceResult,
 	error) {
 	// Build the request
-	clientToken := args.ClientToken
+	clientToken := bce.ClientToken(cli, args.ClientToken)
 	requestToken := args.RequestToken
 	req := &bce.BceRequest{}
 	req.SetUri(getInstanceByLabelUri())
@@ -114,7 +114,7 @@ func CreateInstanceByLabel(cli bce.Client, args *CreateSpecialInstanceBySpecArgs
 func CreateInstanceBySpec(cli bce.Client, args *CreateInstanceBySpecArgs, reqBody *bce.Body) (
 	*CreateInstanceBySpecResult, error) {
 	// Build the request
-	clientToken := args.ClientToken
+	clientToken := bce.ClientToken(cli, args.ClientToken)
 	requestToken := args.RequestToken
 	req := &bce.BceRequest{}
 	req.SetUri(getInstanceBySpecUri())
@@ -153,7 +153,7 @@ func CreateInstanceBySpec(cli bce.Client, args *CreateInstanceBySpecArgs, reqBod
 func CreateInstanceV3(cli bce.Client, args *CreateInstanceV3Args, reqBody *bce.Body) (
 	*CreateInstanceV3Result, error) {
 	// Build the request
-	clientToken := args.ClientToken
+	clientToken := bce.ClientToken(cli, args.ClientToken)
 	requestToken := args.RequestToken
 	req := &bce.BceRequest{}
 	req.SetUri(getInstanceUriV3())
@@ -233,6 +233,15 @@ func ListInstances(cli bce.Client, args *ListInstanceArgs) (*ListInstanceResult,
 	if err := resp.ParseJsonBody(jsonBody); err != nil {
 		return nil, err
 	}
+	if args != nil && len(args.Tags) != 0 {
+		filtered := jsonBody.Instances[:0]
+		for _, item := range jsonBody.Instances {
+			if model.MatchTags(item.Tags, args.Tags) {
+				filtered = append(filtered, item)
+			}
+		}
+		jsonBody.Instances = filtered
+	}
 
 	return jsonBody, nil
 }
@@ -251,7 +260,7 @@ func ListRecycleInstances(cli bce.Client, args *ListRecycleInstanceArgs) (*ListR
 	req.SetUri(getRecycleInstanceListUri())
 	req.SetMethod(http.POST)
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -292,7 +301,7 @@ func ListServersByMarkerV3(cli bce.Client, args *ListServerRequestV3Args) (*Logi
 	req.SetUri(getServersByMarkerV3Uri())
 	req.SetMethod(http.POST)
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -447,7 +456,7 @@ func DeleteInstanceIngorePayment(cli bce.Client, args *DeleteInstanceIngorePayme
 	req.SetUri(getDeleteInstanceDeleteIngorePaymentUri())
 	req.SetMethod(http.POST)
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -513,7 +522,7 @@ func AutoReleaseInstance(cli bce.Client, instanceId string, args *AutoReleaseArg
 	req.SetUri(getInstanceUriWithId(instanceId))
 	req.SetMethod(http.PUT)
 	req.SetParam("autorelease", "")
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -1361,6 +1370,7 @@ func CreateBidInstance(cli bce.Client, clientToken string, reqBody *bce.Body) (*
 	req.SetMethod(http.POST)
 	req.SetBody(reqBody)
 
+	clientToken = bce.ClientToken(cli, clientToken)
 	if clientToken != "" {
 		req.SetParam("clientToken", clientToken)
 	}
@@ -1480,13 +1490,62 @@ func ListBidFlavor(cli bce.Client) (*ListBidFlavorResult, error) {
 	return jsonBody, nil
 }
 
+// ListBidEvents - list the interruption and release events of the bidding instances
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - args: the arguments to filter the events
+// RETURNS:
+//     - *ListBidEventsResult: result of the event list
+//     - error: nil if success otherwise the specific error
+func ListBidEvents(cli bce.Client, args *ListBidEventsArgs) (*ListBidEventsResult, error) {
+	// Build the request
+	req := &bce.BceRequest{}
+	req.SetUri(listBidEventsUri())
+	req.SetMethod(http.GET)
+
+	// Optional arguments settings
+	if args != nil {
+		if len(args.Marker) != 0 {
+			req.SetParam("marker", args.Marker)
+		}
+		if args.MaxKeys != 0 {
+			req.SetParam("maxKeys", strconv.Itoa(args.MaxKeys))
+		}
+		if len(args.InstanceId) != 0 {
+			req.SetParam("instanceId", args.InstanceId)
+		}
+		if len(args.ZoneName) != 0 {
+			req.SetParam("zoneName", args.ZoneName)
+		}
+	}
+	if args == nil || args.MaxKeys == 0 {
+		req.SetParam("maxKeys", "1000")
+	}
+
+	// Send request and get response
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+
+	jsonBody := &ListBidEventsResult{}
+	if err := resp.ParseJsonBody(jsonBody); err != nil {
+		return nil, err
+	}
+	return jsonBody, nil
+}
+
 func GetInstanceResizeStock(cli bce.Client, args *ResizeInstanceStockArgs) (*InstanceStockResult, error) {
 	// Build the request
 	req := &bce.BceRequest{}
 	req.SetUri(getResizeInstanceStock())
 	req.SetMethod(http.POST)
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -1555,7 +1614,7 @@ func GetStockWithDeploySet(cli bce.Client, args *GetStockWithDeploySetArgs) (*Ge
 	req.SetUri(getStockWithDeploySet())
 	req.SetMethod(http.POST)
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -1595,7 +1654,7 @@ func GetStockWithSpec(cli bce.Client, args *GetStockWithSpecArgs) (*GetStockWith
 	req.SetUri(getStockWithSpec())
 	req.SetMethod(http.POST)
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -1627,7 +1686,7 @@ func GetInstanceCreateStock(cli bce.Client, args *CreateInstanceStockArgs) (*Ins
 	req.SetUri(getCreateInstanceStock())
 	req.SetMethod(http.POST)
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -1733,7 +1792,7 @@ func ListInstanceByInstanceIds(cli bce.Client, args *ListInstanceByInstanceIdArg
 	if args == nil || args.MaxKeys == 0 {
 		req.SetParam("maxKeys", "1000")
 	}
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
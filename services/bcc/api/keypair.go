@@ -18,7 +18,6 @@
 package api
 
 import (
-	"encoding/json"
 	"strconv"
 
 	"github.com/baidubce/bce-sdk-go/bce"
@@ -36,7 +35,7 @@ func CreateKeypair(cli bce.Client, args *CreateKeypairArgs) (*KeypairResult, err
 		req.SetParam("clientToken", args.ClientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +71,7 @@ func ImportKeypair(cli bce.Client, args *ImportKeypairArgs) (*KeypairResult, err
 		req.SetParam("clientToken", args.ClientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +104,7 @@ func AttachKeypair(cli bce.Client, args *AttackKeypairArgs) error {
 	req.SetMethod(http.PUT)
 	req.SetParam("attach", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -133,7 +132,7 @@ func DetachKeypair(cli bce.Client, args *DetachKeypairArgs) error {
 	req.SetMethod(http.PUT)
 	req.SetParam("detach", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -161,7 +160,7 @@ func DeleteKeypair(cli bce.Client, args *DeleteKeypairArgs) error {
 	req.SetMethod(http.DELETE)
 	req.SetParam("delete", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -246,7 +245,7 @@ func RenameKeypair(cli bce.Client, args *RenameKeypairArgs) error {
 	req.SetMethod(http.PUT)
 	req.SetParam("rename", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -274,7 +273,7 @@ func UpdateKeypairDescription(cli bce.Client, args *KeypairUpdateDescArgs) error
 	req.SetMethod(http.PUT)
 	req.SetParam("updateDesc", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
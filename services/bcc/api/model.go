@@ -17,6 +17,9 @@
 package api
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/baidubce/bce-sdk-go/model"
 )
 
@@ -98,6 +101,19 @@ const (
 	PaymentTimingBidding  PaymentTimingType = "bidding"
 )
 
+// The bid models of the bidding instance, the instance is charged by the market price with the
+// market model, or by the market price not higher than the BidPrice with the custom model
+const (
+	BidModelMarketPrice = "market"
+	BidModelCustomPrice = "custom"
+)
+
+// The types of the events of the bidding instances
+const (
+	BidEventTypeInterrupt = "interrupt"
+	BidEventTypeRelease   = "release"
+)
+
 // Instance define instance model
 type InstanceModel struct {
 	InstanceId            string                 `json:"id"`
@@ -534,6 +550,8 @@ type ListInstanceArgs struct {
 	DedicatedHostId string
 	ZoneName        string
 	KeypairId       string
+	// Tags filters the result by the tags on client side, the empty value only matches the key
+	Tags map[string]string
 }
 
 type ListInstanceResult struct {
@@ -566,7 +584,7 @@ type ListServerRequestV3Args struct {
 	SubnetId          string         `json:"subnetId,omitempty"`
 	DedicatedHostId   string         `json:"dedicatedHostId,omitempty"`
 	ZoneName          string         `json:"zoneName,omitempty"`
-	AutoRenew         bool           `json:"autoRenew,omitempty"`
+	AutoRenew         *bool          `json:"autoRenew,omitempty"` // set by bce.Bool, nil means no filter
 	KeypairId         string         `json:"keypairId,omitempty"`
 	KeypairName       string         `json:"keypairName,omitempty"`
 	DeploymentSetId   string         `json:"deploymentSetId,omitempty"`
@@ -735,6 +753,69 @@ type GetBidInstancePriceResult struct {
 	PerMoney string `json:"perMoney"`
 }
 
+// CheckBidArgs - check the bid model and the bid price of the bidding instance
+//
+// PARAMS:
+//     - bidModel: the bid model, BidModelMarketPrice or BidModelCustomPrice
+//     - bidPrice: the highest price per instance of the custom model
+// RETURNS:
+//     - error: nil if valid otherwise the specific error
+func CheckBidArgs(bidModel, bidPrice string) error {
+	switch bidModel {
+	case BidModelMarketPrice:
+		return nil
+	case BidModelCustomPrice:
+		if price, err := strconv.ParseFloat(bidPrice, 64); err != nil || price <= 0 {
+			return fmt.Errorf("invalid bid price %q of the custom bid model", bidPrice)
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid bid model %q", bidModel)
+}
+
+// ListBidPricesArgs defines the filters to query the market prices of the bidding flavors, all
+// flavors are queried if the filters are empty
+type ListBidPricesArgs struct {
+	ZoneName     string
+	InstanceType InstanceType
+}
+
+// BidPrice defines the current market price of a bidding flavor in a zone
+type BidPrice struct {
+	ZoneName           string       `json:"zoneName"`
+	InstanceType       InstanceType `json:"instanceType"`
+	Spec               string       `json:"spec"`
+	CpuCount           int          `json:"cpuCount"`
+	MemoryCapacityInGB int          `json:"memoryCapacityInGB"`
+	Price              string       `json:"price"`
+}
+
+type ListBidEventsArgs struct {
+	Marker     string
+	MaxKeys    int
+	InstanceId string
+	ZoneName   string
+}
+
+// BidEvent defines the interruption or release event of the bidding instance
+type BidEvent struct {
+	InstanceId   string `json:"instanceId"`
+	InstanceName string `json:"instanceName"`
+	ZoneName     string `json:"zoneName"`
+	Spec         string `json:"spec"`
+	EventType    string `json:"eventType"`
+	EventTime    string `json:"eventTime"`
+	Reason       string `json:"reason"`
+}
+
+type ListBidEventsResult struct {
+	Marker      string     `json:"marker"`
+	IsTruncated bool       `json:"isTruncated"`
+	NextMarker  string     `json:"nextMarker"`
+	MaxKeys     int        `json:"maxKeys"`
+	Events      []BidEvent `json:"events"`
+}
+
 type ListBidFlavorResult struct {
 	ZoneResources []ZoneResource `json:"zoneResources"`
 }
@@ -1053,6 +1134,8 @@ type ListCDSVolumeArgs struct {
 	InstanceId string
 	ZoneName   string
 	Marker     string
+	// Tags filters the result by the tags on client side, the empty value only matches the key
+	Tags map[string]string
 }
 
 type AutoRenewCDSVolumeArgs struct {
@@ -1117,6 +1200,8 @@ type ListSecurityGroupArgs struct {
 	MaxKeys    int
 	InstanceId string
 	VpcId      string
+	// Tags filters the result by the tags on client side, the empty value only matches the key
+	Tags map[string]string
 }
 
 type CreateSecurityGroupResult struct {
@@ -1171,6 +1256,68 @@ const (
 	ImageStatusError        ImageStatus = "Error"
 )
 
+type ImageFormat string
+
+const (
+	ImageFormatQcow2 ImageFormat = "qcow2"
+	ImageFormatRaw   ImageFormat = "raw"
+	ImageFormatVhd   ImageFormat = "vhd"
+	ImageFormatVmdk  ImageFormat = "vmdk"
+)
+
+type ImageTaskType string
+
+const (
+	ImageTaskTypeImport ImageTaskType = "import"
+	ImageTaskTypeExport ImageTaskType = "export"
+)
+
+type ImageTaskStatus string
+
+const (
+	ImageTaskStatusProcessing ImageTaskStatus = "processing"
+	ImageTaskStatusSucceed    ImageTaskStatus = "succeed"
+	ImageTaskStatusFailed     ImageTaskStatus = "failed"
+)
+
+type ImportImageArgs struct {
+	Name        string      `json:"name"`
+	BosUrl      string      `json:"bosUrl"`
+	ImageFormat ImageFormat `json:"imageFormat,omitempty"`
+	OsName      string      `json:"osName"`
+	OsType      string      `json:"osType"`
+	OsVersion   string      `json:"osVersion"`
+	OsArch      string      `json:"osArch"`
+	Desc        string      `json:"desc,omitempty"`
+	ClientToken string      `json:"-"`
+}
+
+type ImportImageResult struct {
+	ImageId string `json:"imageId"`
+	TaskId  string `json:"taskId"`
+}
+
+type ExportImageArgs struct {
+	BosUrl      string      `json:"bosUrl"`
+	ImageFormat ImageFormat `json:"imageFormat,omitempty"`
+	ClientToken string      `json:"-"`
+}
+
+type ExportImageResult struct {
+	TaskId string `json:"taskId"`
+}
+
+type ImageTaskModel struct {
+	TaskId     string          `json:"taskId"`
+	ImageId    string          `json:"imageId"`
+	Type       ImageTaskType   `json:"type"`
+	Status     ImageTaskStatus `json:"status"`
+	Progress   int             `json:"progress"`
+	BosUrl     string          `json:"bosUrl"`
+	CreateTime string          `json:"createTime"`
+	ErrMsg     string          `json:"errMsg"`
+}
+
 type SharedUser struct {
 	AccountId string `json:"accountId,omitempty"`
 	Account   string `json:"account,omitempty"`
@@ -1496,6 +1643,47 @@ type GetDeploySetResult struct {
 	DeploySetModel
 }
 
+const (
+	DeploySetStrategyHostHA = "HOST_HA"
+	DeploySetStrategyRackHA = "RACK_HA"
+	DeploySetStrategyTorHA  = "TOR_HA"
+)
+
+// DeploySetZoneSpread defines the instances of the deploy set placed in one zone.
+type DeploySetZoneSpread struct {
+	ZoneName       string
+	Total          int
+	BccInstanceIds []string
+	BbcInstanceIds []string
+}
+
+// DeploySetSpread defines how the instances of the deploy set are spread over the zones.
+type DeploySetSpread struct {
+	DeploySetId string
+	Name        string
+	Strategy    string
+	Concurrency int
+	Total       int
+	Zones       []DeploySetZoneSpread
+}
+
+// ZoneOf - get the zone of the given instance in the deploy set, empty if it is not in the set
+func (s *DeploySetSpread) ZoneOf(instanceId string) string {
+	for _, z := range s.Zones {
+		for _, id := range z.BccInstanceIds {
+			if id == instanceId {
+				return z.ZoneName
+			}
+		}
+		for _, id := range z.BbcInstanceIds {
+			if id == instanceId {
+				return z.ZoneName
+			}
+		}
+	}
+	return ""
+}
+
 type RebuildBatchInstanceArgs struct {
 	ImageId     string   `json:"imageId"`
 	AdminPass   string   `json:"adminPass"`
@@ -1760,3 +1948,47 @@ type VolumeDeleteResultModel struct {
 type DeletionProtectionArgs struct {
 	DeletionProtection int `json:"deletionProtection"`
 }
+
+// BatchInstanceFailure defines the instance failed in the batch operation and the reason.
+type BatchInstanceFailure struct {
+	InstanceId string
+	Code       string
+	Message    string
+}
+
+// BatchInstanceReport defines the per-instance result of the batch instance operation.
+type BatchInstanceReport struct {
+	Succeeded []string
+	Failed    []BatchInstanceFailure
+}
+
+// SecurityGroupRulesDiff defines the rules to add and to remove for the security group to have
+// the desired rules.
+type SecurityGroupRulesDiff struct {
+	Add    []SecurityGroupRuleModel
+	Remove []SecurityGroupRuleModel
+}
+
+// IsEmpty - check whether the security group already has the desired rules
+func (d *SecurityGroupRulesDiff) IsEmpty() bool {
+	return len(d.Add) == 0 && len(d.Remove) == 0
+}
+
+// ApplySecurityGroupRulesError defines the error of applying the rules diff, the applied changes
+// are rolled back and the RollbackErrors are the changes failed to roll back, in which case the
+// rules of the security group are neither the original nor the desired ones.
+type ApplySecurityGroupRulesError struct {
+	SecurityGroupId string
+	Rule            SecurityGroupRuleModel
+	Err             error
+	RollbackErrors  []error
+}
+
+func (e *ApplySecurityGroupRulesError) Error() string {
+	msg := fmt.Sprintf("apply rules of security group %s failed: %v", e.SecurityGroupId, e.Err)
+	if len(e.RollbackErrors) != 0 {
+		msg += fmt.Sprintf("; %d change(s) failed to roll back, first: %v",
+			len(e.RollbackErrors), e.RollbackErrors[0])
+	}
+	return msg
+}
@@ -18,7 +18,6 @@
 package api
 
 import (
-	"encoding/json"
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/http"
 )
@@ -134,7 +133,7 @@ func GetPriceBySpec(cli bce.Client, args *GetPriceBySpecArgs) (*GetPriceBySpecRe
 	req.SetUri(getPriceBySpecUri())
 	req.SetMethod(http.POST)
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
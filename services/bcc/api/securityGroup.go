@@ -18,11 +18,11 @@
 package api
 
 import (
-	"encoding/json"
 	"strconv"
 
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/model"
 )
 
 // CreateSecurityGroup - create a security group and related rules
@@ -40,11 +40,11 @@ func CreateSecurityGroup(cli bce.Client, args *CreateSecurityGroupArgs) (*Create
 	req.SetUri(getSecurityGroupUri())
 	req.SetMethod(http.POST)
 
-	if args.ClientToken != "" {
-		req.SetParam("clientToken", args.ClientToken)
+	if clientToken := bce.ClientToken(cli, args.ClientToken); clientToken != "" {
+		req.SetParam("clientToken", clientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +116,15 @@ func ListSecurityGroup(cli bce.Client, queryArgs *ListSecurityGroupArgs) (*ListS
 	if err := resp.ParseJsonBody(jsonBody); err != nil {
 		return nil, err
 	}
+	if queryArgs != nil && len(queryArgs.Tags) != 0 {
+		filtered := jsonBody.SecurityGroups[:0]
+		for _, item := range jsonBody.SecurityGroups {
+			if model.MatchTags(item.Tags, queryArgs.Tags) {
+				filtered = append(filtered, item)
+			}
+		}
+		jsonBody.SecurityGroups = filtered
+	}
 	return jsonBody, nil
 }
 
@@ -138,7 +147,7 @@ func AuthorizeSecurityGroupRule(cli bce.Client, securityGroupId string, args *Au
 	}
 	req.SetParam("authorizeRule", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -177,7 +186,7 @@ func RevokeSecurityGroupRule(cli bce.Client, securityGroupId string, args *Revok
 
 	req.SetParam("revokeRule", "")
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return err
 	}
@@ -225,3 +234,61 @@ func DeleteSecurityGroup(cli bce.Client, securityGroupId string) error {
 	defer func() { resp.Body().Close() }()
 	return nil
 }
+
+// BindSecurityGroupToTags - bind security group to tags
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - securityGroupId: the id of the security group
+//     - reqBody: the request body to bind tags
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func BindSecurityGroupToTags(cli bce.Client, securityGroupId string, reqBody *bce.Body) error {
+	// Build the request
+	req := &bce.BceRequest{}
+	req.SetUri(getBindSecurityGroupToTagsUri(securityGroupId))
+	req.SetMethod(http.PUT)
+	req.SetBody(reqBody)
+	req.SetParam("bind", "")
+
+	// Send request and get response
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
+// UnBindSecurityGroupToTags - unbind security group to tags
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - securityGroupId: the id of the security group
+//     - reqBody: the request body to unbind tags
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func UnBindSecurityGroupToTags(cli bce.Client, securityGroupId string, reqBody *bce.Body) error {
+	// Build the request
+	req := &bce.BceRequest{}
+	req.SetUri(getBindSecurityGroupToTagsUri(securityGroupId))
+	req.SetMethod(http.PUT)
+	req.SetBody(reqBody)
+	req.SetParam("unbind", "")
+
+	// Send request and get response
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+
+	defer func() { resp.Body().Close() }()
+	return nil
+}
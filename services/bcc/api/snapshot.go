@@ -18,7 +18,6 @@
 package api
 
 import (
-	"encoding/json"
 	"strconv"
 
 	"github.com/baidubce/bce-sdk-go/bce"
@@ -43,7 +42,7 @@ func CreateSnapshot(cli bce.Client, args *CreateSnapshotArgs) (*CreateSnapshotRe
 		req.SetParam("clientToken", args.ClientToken)
 	}
 
-	jsonBytes, err := json.Marshal(args)
+	jsonBytes, err := bce.MarshalJSON(args)
 	if err != nil {
 		return nil, err
 	}
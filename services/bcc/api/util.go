@@ -38,6 +38,8 @@ const (
 	REQUEST_IMAGE_URI            = "/image"
 	REQUEST_IMAGE_SHAREDUSER_URI = "/sharedUsers"
 	REQUEST_IMAGE_OS_URI         = "/os"
+	REQUEST_IMAGE_IMPORT_URI     = "/import"
+	REQUEST_IMAGE_TASK_URI       = "/task"
 	REQUEST_INSTANCE_URI         = "/instance"
 	REQUEST_INSTANCE_LABEL_URI   = "/instanceByLabel"
 	REQUEST_LIST_URI             = "/list"
@@ -59,6 +61,7 @@ const (
 	REQUEST_CANCEL_AUTO_RENEW_URI = "/cancelAutoRenew"
 	REQUEST_BID_PRICE_URI         = "/bidPrice"
 	REQUEST_BID_FLAVOR_URI        = "/bidFlavor"
+	REQUEST_BID_EVENT_URI         = "/bidEvent"
 	//
 	REQUEST_INSTANCE_PRICE_URI               = "/instance/price"
 	REQUEST_INSTANCE_BY_SPEC_URI             = "/instanceBySpec"
@@ -131,6 +134,10 @@ func listBidFlavorUri() string {
 	return URI_PREFIXV2 + REQUEST_INSTANCE_URI + REQUEST_BID_FLAVOR_URI
 }
 
+func listBidEventsUri() string {
+	return URI_PREFIXV2 + REQUEST_INSTANCE_URI + REQUEST_BID_EVENT_URI
+}
+
 func getInstanceVNCUri(id string) string {
 	return URI_PREFIXV2 + REQUEST_INSTANCE_URI + "/" + id + REQUEST_VNC_SUFFIX
 }
@@ -164,6 +171,10 @@ func getVolumeUriWithId(id string) string {
 	return URI_PREFIXV2 + REQUEST_VOLUME_URI + "/" + id
 }
 
+func getBindCDSVolumeToTagsUri(id string) string {
+	return URI_PREFIXV2 + REQUEST_VOLUME_URI + "/" + id + REQUEST_TAG_URI
+}
+
 func getDeletePrepayVolumeUri() string {
 	return URI_PREFIXV2 + REQUEST_DELETEPREPAY
 }
@@ -191,6 +202,10 @@ func getSecurityGroupUriWithId(id string) string {
 	return URI_PREFIXV2 + REQUEST_SECURITYGROUP_URI + "/" + id
 }
 
+func getBindSecurityGroupToTagsUri(id string) string {
+	return URI_PREFIXV2 + REQUEST_SECURITYGROUP_URI + "/" + id + REQUEST_TAG_URI
+}
+
 func getImageUri() string {
 	return URI_PREFIXV2 + REQUEST_IMAGE_URI
 }
@@ -207,6 +222,14 @@ func getImageOsUri() string {
 	return URI_PREFIXV2 + REQUEST_IMAGE_URI + REQUEST_IMAGE_OS_URI
 }
 
+func getImageImportUri() string {
+	return URI_PREFIXV2 + REQUEST_IMAGE_URI + REQUEST_IMAGE_IMPORT_URI
+}
+
+func getImageTaskUriWithId(taskId string) string {
+	return URI_PREFIXV2 + REQUEST_IMAGE_URI + REQUEST_IMAGE_TASK_URI + "/" + taskId
+}
+
 func getSnapshotUri() string {
 	return URI_PREFIXV2 + REQUEST_SNAPSHOT_URI
 }
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// batch.go - operate a list of instances concurrently and report the result of every instance
+
+package bcc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce/batch"
+	"github.com/baidubce/bce-sdk-go/services/bcc/api"
+)
+
+// DEFAULT_BATCH_PARALLEL is the max number of the concurrent requests of the batch operations,
+// which keeps the requests under the rate limit of the instance apis
+const DEFAULT_BATCH_PARALLEL = 10
+
+// BatchStartInstances - start the instances concurrently
+//
+// PARAMS:
+//     - instanceIds: the instance IDs, the duplicate ones are operated only once
+// RETURNS:
+//     - *api.BatchInstanceReport: the succeeded instances and the failed ones with the reasons
+//     - error: nil if the requests are sent otherwise the specific error
+func (c *Client) BatchStartInstances(instanceIds []string) (*api.BatchInstanceReport, error) {
+	return c.batchInstances(instanceIds, c.StartInstance)
+}
+
+// BatchStopInstances - stop the instances concurrently
+//
+// PARAMS:
+//     - instanceIds: the instance IDs, the duplicate ones are operated only once
+//     - forceStop: choose to force stop the instances or not
+// RETURNS:
+//     - *api.BatchInstanceReport: the succeeded instances and the failed ones with the reasons
+//     - error: nil if the requests are sent otherwise the specific error
+func (c *Client) BatchStopInstances(instanceIds []string, forceStop bool) (*api.BatchInstanceReport, error) {
+	return c.batchInstances(instanceIds, func(instanceId string) error {
+		return c.StopInstance(instanceId, forceStop)
+	})
+}
+
+// BatchRebootInstances - reboot the instances concurrently
+//
+// PARAMS:
+//     - instanceIds: the instance IDs, the duplicate ones are operated only once
+//     - forceStop: choose to force stop the instances or not
+// RETURNS:
+//     - *api.BatchInstanceReport: the succeeded instances and the failed ones with the reasons
+//     - error: nil if the requests are sent otherwise the specific error
+func (c *Client) BatchRebootInstances(instanceIds []string, forceStop bool) (*api.BatchInstanceReport, error) {
+	return c.batchInstances(instanceIds, func(instanceId string) error {
+		return c.RebootInstance(instanceId, forceStop)
+	})
+}
+
+// BatchDeleteInstances - delete the postpaid instances concurrently
+//
+// PARAMS:
+//     - instanceIds: the instance IDs, the duplicate ones are operated only once
+// RETURNS:
+//     - *api.BatchInstanceReport: the succeeded instances and the failed ones with the reasons
+//     - error: nil if the requests are sent otherwise the specific error
+func (c *Client) BatchDeleteInstances(instanceIds []string) (*api.BatchInstanceReport, error) {
+	return c.batchInstances(instanceIds, c.DeleteInstance)
+}
+
+// batchInstances - run the operation for every instance with at most DEFAULT_BATCH_PARALLEL
+// concurrent requests, the failure of an instance does not stop the others
+func (c *Client) batchInstances(instanceIds []string,
+	operate func(instanceId string) error) (*api.BatchInstanceReport, error) {
+	if len(instanceIds) == 0 {
+		return nil, fmt.Errorf("the instance id list is empty")
+	}
+	ids := make([]string, 0, len(instanceIds))
+	seen := make(map[string]bool, len(instanceIds))
+	for _, id := range instanceIds {
+		if len(id) == 0 {
+			return nil, fmt.Errorf("the instance id list contains empty id")
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	funcs := make([]batch.Func, len(ids))
+	for i, id := range ids {
+		instanceId := id
+		funcs[i] = func(context.Context) (interface{}, error) {
+			return nil, operate(instanceId)
+		}
+	}
+	results := batch.Execute(context.Background(), funcs,
+		&batch.Options{Parallel: DEFAULT_BATCH_PARALLEL})
+
+	report := &api.BatchInstanceReport{}
+	for i, id := range ids {
+		err := results[i].Err
+		if err == nil {
+			report.Succeeded = append(report.Succeeded, id)
+			continue
+		}
+		code := "ClientError"
+		if serviceErr, ok := err.(*bce.BceServiceError); ok {
+			code = serviceErr.Code
+		}
+		report.Failed = append(report.Failed,
+			api.BatchInstanceFailure{InstanceId: id, Code: code, Message: err.Error()})
+	}
+	return report, nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// bid.go - query the market prices of the bidding flavors and the events of bidding instances
+
+package bcc
+
+import (
+	"sync"
+
+	"github.com/baidubce/bce-sdk-go/services/bcc/api"
+)
+
+// ListBidPrices - query the current market prices of the bidding flavors per instance type and
+// zone, the prices are queried concurrently with at most DEFAULT_BATCH_PARALLEL requests
+//
+// PARAMS:
+//     - args: the zone and the instance type to filter the flavors, nil to query all flavors
+// RETURNS:
+//     - []api.BidPrice: the market price of every flavor ordered as the ListBidFlavor result
+//     - error: nil if success otherwise the first error
+func (c *Client) ListBidPrices(args *api.ListBidPricesArgs) ([]api.BidPrice, error) {
+	if args == nil {
+		args = &api.ListBidPricesArgs{}
+	}
+	flavors, err := api.ListBidFlavor(c)
+	if err != nil {
+		return nil, err
+	}
+	var prices []api.BidPrice
+	for _, zone := range flavors.ZoneResources {
+		if len(args.ZoneName) != 0 && zone.ZoneName != args.ZoneName {
+			continue
+		}
+		for _, resource := range zone.BccResources {
+			if len(args.InstanceType) != 0 && resource.InstanceType != args.InstanceType {
+				continue
+			}
+			for _, flavor := range resource.Flavors {
+				prices = append(prices, api.BidPrice{
+					ZoneName:           zone.ZoneName,
+					InstanceType:       resource.InstanceType,
+					Spec:               flavor.Spec,
+					CpuCount:           flavor.CpuCount,
+					MemoryCapacityInGB: flavor.MemoryCapacityInGB,
+				})
+			}
+		}
+	}
+
+	errs := make([]error, len(prices))
+	workerPool := make(chan struct{}, DEFAULT_BATCH_PARALLEL)
+	var wg sync.WaitGroup
+	for i := range prices {
+		workerPool <- struct{}{}
+		wg.Add(1)
+		go func(price *api.BidPrice, index int) {
+			defer func() {
+				<-workerPool
+				wg.Done()
+			}()
+			result, err := c.GetBidInstancePrice(&api.GetBidInstancePriceArgs{
+				InstanceType:       price.InstanceType,
+				CpuCount:           price.CpuCount,
+				MemoryCapacityInGB: price.MemoryCapacityInGB,
+				PurchaseCount:      1,
+				BidModel:           api.BidModelMarketPrice,
+				ZoneName:           price.ZoneName,
+			})
+			if err != nil {
+				errs[index] = err
+				return
+			}
+			price.Price = result.PerMoney
+		}(&prices[i], i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return prices, nil
+}
+
+// ListAllBidEvents - list all the interruption and release events of the bidding instances by
+// following the markers
+//
+// PARAMS:
+//     - instanceId: the id of the bidding instance, empty to list the events of all instances
+// RETURNS:
+//     - []api.BidEvent: the events of the bidding instances
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListAllBidEvents(instanceId string) ([]api.BidEvent, error) {
+	args := &api.ListBidEventsArgs{InstanceId: instanceId}
+	var events []api.BidEvent
+	for {
+		res, err := api.ListBidEvents(c, args)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, res.Events...)
+		if !res.IsTruncated || len(res.NextMarker) == 0 {
+			return events, nil
+		}
+		args.Marker = res.NextMarker
+	}
+}
@@ -19,7 +19,6 @@
 package bcc
 
 import (
-	"encoding/json"
 
 	"github.com/baidubce/bce-sdk-go/auth"
 	"github.com/baidubce/bce-sdk-go/bce"
@@ -33,6 +32,20 @@ type Client struct {
 	*bce.BceClient
 }
 
+// WithOptions - copy the client with the configuration overridden by the given options, the
+// calls of the copied client use the overridden settings and the original client is unchanged
+//
+// PARAMS:
+//     - opts: the options such as bce.WithTimeout, bce.WithRetryPolicy, bce.WithHeader and
+//       bce.WithCredentials
+// RETURNS:
+//     - *Client: the copied BCC client
+func (c *Client) WithOptions(opts ...bce.RequestOption) *Client {
+	client := *c
+	client.BceClient = c.BceClient.WithOptions(opts...)
+	return &client
+}
+
 // NewClient make the BCC service client with default configuration.
 // Use `cli.Config.xxx` to access the config or change it to non-default value.
 func NewClient(ak, sk, endPoint string) (*Client, error) {
@@ -77,7 +90,7 @@ func (c *Client) CreateInstance(args *api.CreateInstanceArgs) (*api.CreateInstan
 		args.AdminPass = cryptedPass
 	}
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -106,7 +119,7 @@ func (c *Client) CreateInstanceByLabel(args *api.CreateSpecialInstanceBySpecArgs
 		args.AdminPass = cryptedPass
 	}
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -135,7 +148,7 @@ func (c *Client) CreateInstanceBySpec(args *api.CreateInstanceBySpecArgs) (*api.
 		args.AdminPass = cryptedPass
 	}
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -164,7 +177,7 @@ func (c *Client) CreateInstanceV3(args *api.CreateInstanceV3Args) (*api.CreateIn
 		args.Password = cryptedPass
 	}
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -263,7 +276,7 @@ func (c *Client) AutoReleaseInstance(instanceId string, releaseTime string) erro
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) ResizeInstance(instanceId string, args *api.ResizeInstanceArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -289,7 +302,7 @@ func (c *Client) RebuildInstance(instanceId string, args *api.RebuildInstanceArg
 	}
 	args.AdminPass = cryptedPass
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -324,7 +337,7 @@ func (c *Client) StopInstanceWithNoCharge(instanceId string, forceStop bool, sto
 		ForceStop:        forceStop,
 		StopWithNoCharge: stopWithNoCharge,
 	}
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -347,7 +360,7 @@ func (c *Client) StopInstance(instanceId string, forceStop bool) error {
 		ForceStop: forceStop,
 	}
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -371,7 +384,7 @@ func (c *Client) RebootInstance(instanceId string, forceStop bool) error {
 		ForceStop: forceStop,
 	}
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -384,7 +397,7 @@ func (c *Client) RebootInstance(instanceId string, forceStop bool) error {
 }
 
 func (c *Client) RecoveryInstance(args *api.RecoveryInstanceArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -410,7 +423,7 @@ func (c *Client) ChangeInstancePass(instanceId string, args *api.ChangeInstanceP
 	}
 	args.AdminPass = cryptedPass
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -430,7 +443,7 @@ func (c *Client) ChangeInstancePass(instanceId string, args *api.ChangeInstanceP
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) ModifyDeletionProtection(instanceId string, args *api.DeletionProtectionArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -449,7 +462,7 @@ func (c *Client) ModifyDeletionProtection(instanceId string, args *api.DeletionP
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) ModifyInstanceAttribute(instanceId string, args *api.ModifyInstanceAttributeArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -469,7 +482,7 @@ func (c *Client) ModifyInstanceAttribute(instanceId string, args *api.ModifyInst
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) ModifyInstanceDesc(instanceId string, args *api.ModifyInstanceDescArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -489,7 +502,7 @@ func (c *Client) ModifyInstanceDesc(instanceId string, args *api.ModifyInstanceD
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) ModifyInstanceHostname(instanceId string, args *api.ModifyInstanceHostnameArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -513,7 +526,7 @@ func (c *Client) BindSecurityGroup(instanceId string, securityGroupId string) er
 		SecurityGroupId: securityGroupId,
 	}
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -537,7 +550,7 @@ func (c *Client) UnBindSecurityGroup(instanceId string, securityGroupId string)
 		SecurityGroupId: securityGroupId,
 	}
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -572,7 +585,7 @@ func (c *Client) InstancePurchaseReserved(instanceId string, args *api.PurchaseR
 	args.Billing.PaymentTiming = api.PaymentTimingPrePaid
 	relatedRenewFlag := args.RelatedRenewFlag
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -592,7 +605,7 @@ func (c *Client) InstancePurchaseReserved(instanceId string, args *api.PurchaseR
 //     - *GetBidInstancePriceResult: result of the market price of the specified bidding instance
 //     - error: nil if success otherwise the specific error
 func (c *Client) GetBidInstancePrice(args *api.GetBidInstancePriceArgs) (*api.GetBidInstancePriceResult, error) {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -612,6 +625,17 @@ func (c *Client) ListBidFlavor() (*api.ListBidFlavorResult, error) {
 	return api.ListBidFlavor(c)
 }
 
+// ListBidEvents - list the interruption and release events of the bidding instances
+//
+// PARAMS:
+//     - args: the arguments to filter the events by the instance or the zone
+// RETURNS:
+//     - *api.ListBidEventsResult: result of the event list
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListBidEvents(args *api.ListBidEventsArgs) (*api.ListBidEventsResult, error) {
+	return api.ListBidEvents(c, args)
+}
+
 // DeleteInstanceWithRelateResource - delete an instance and all eip/cds relate it
 //
 // PARAMS:
@@ -620,7 +644,7 @@ func (c *Client) ListBidFlavor() (*api.ListBidFlavorResult, error) {
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) DeleteInstanceWithRelateResource(instanceId string, args *api.DeleteInstanceWithRelateResourceArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -639,7 +663,7 @@ func (c *Client) DeleteInstanceWithRelateResource(instanceId string, args *api.D
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) DeletePrepaidInstanceWithRelateResource(args *api.DeletePrepaidInstanceWithRelateResourceArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -658,7 +682,7 @@ func (c *Client) DeletePrepaidInstanceWithRelateResource(args *api.DeletePrepaid
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) InstanceChangeSubnet(args *api.InstanceChangeSubnetArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -677,7 +701,7 @@ func (c *Client) InstanceChangeSubnet(args *api.InstanceChangeSubnetArgs) error
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) InstanceChangeVpc(args *api.InstanceChangeVpcArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -696,7 +720,7 @@ func (c *Client) InstanceChangeVpc(args *api.InstanceChangeVpcArgs) error {
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) BatchAddIP(args *api.BatchAddIpArgs) (*api.BatchAddIpResponse, error) {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -715,7 +739,7 @@ func (c *Client) BatchAddIP(args *api.BatchAddIpArgs) (*api.BatchAddIpResponse,
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) BatchDelIP(args *api.BatchDelIpArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -1099,6 +1123,40 @@ func (c *Client) GetImageOS(args *api.GetImageOsArgs) (*api.GetImageOsResult, er
 	return api.GetImageOS(c, args)
 }
 
+// ImportImage - import a custom image from the image file stored in BOS
+//
+// PARAMS:
+//     - args: the arguments of the image file and its operating system
+// RETURNS:
+//     - *api.ImportImageResult: the id of the imported image and the import task
+//     - error: nil if success otherwise the specific error
+func (c *Client) ImportImage(args *api.ImportImageArgs) (*api.ImportImageResult, error) {
+	return api.ImportImage(c, args)
+}
+
+// ExportImage - export the custom image to an image file in BOS
+//
+// PARAMS:
+//     - imageId: the specific image ID
+//     - args: the arguments of the exported image file
+// RETURNS:
+//     - *api.ExportImageResult: the id of the export task
+//     - error: nil if success otherwise the specific error
+func (c *Client) ExportImage(imageId string, args *api.ExportImageArgs) (*api.ExportImageResult, error) {
+	return api.ExportImage(c, imageId, args)
+}
+
+// GetImageTask - get the progress of the image import or export task
+//
+// PARAMS:
+//     - taskId: the task ID returned by the ImportImage or ExportImage
+// RETURNS:
+//     - *api.ImageTaskModel: the status and progress of the task
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetImageTask(taskId string) (*api.ImageTaskModel, error) {
+	return api.GetImageTask(c, taskId)
+}
+
 // CreateSnapshot - create a snapshot
 //
 // PARAMS:
@@ -1276,7 +1334,7 @@ func (c *Client) GetPriceBySpec(args *api.GetPriceBySpecArgs) (*api.GetPriceBySp
 //     - *CreateDeploySetResult: results of creating a deploy set
 //     - error: nil if success otherwise the specific error
 func (c *Client) CreateDeploySet(args *api.CreateDeploySetArgs) (*api.CreateDeploySetResult, error) {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1304,7 +1362,7 @@ func (c *Client) ListDeploySets() (*api.ListDeploySetsResult, error) {
 //     - *ModifyDeploySetArgs: the detail of the deploy set
 //     - error: nil if success otherwise the specific error
 func (c *Client) ModifyDeploySet(deploySetId string, args *api.ModifyDeploySetArgs) (error, error) {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1343,7 +1401,7 @@ func (c *Client) GetDeploySet(deploySetId string) (*api.DeploySetResult, error)
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) UpdateInstanceDeploySet(args *api.UpdateInstanceDeployArgs) (error, error) {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1361,7 +1419,7 @@ func (c *Client) UpdateInstanceDeploySet(args *api.UpdateInstanceDeployArgs) (er
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) DelInstanceDeploySet(args *api.DelInstanceDeployArgs) (error, error) {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1380,7 +1438,7 @@ func (c *Client) DelInstanceDeploySet(args *api.DelInstanceDeployArgs) (error, e
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) ResizeInstanceBySpec(instanceId string, args *api.ResizeInstanceArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -1405,7 +1463,7 @@ func (c *Client) BatchRebuildInstances(args *api.RebuildBatchInstanceArgs) error
 	}
 	args.AdminPass = cryptedPass
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -1426,7 +1484,7 @@ func (c *Client) BatchRebuildInstances(args *api.RebuildBatchInstanceArgs) error
 //     - error: nil if success otherwise the specific error
 func (c *Client) ChangeToPrepaid(instanceId string, args *api.ChangeToPrepaidRequest) (*api.ChangeToPrepaidResponse,
 	error) {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1446,7 +1504,7 @@ func (c *Client) ChangeToPrepaid(instanceId string, args *api.ChangeToPrepaidReq
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) BindInstanceToTags(instanceId string, args *api.BindTagsRequest) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -1466,7 +1524,7 @@ func (c *Client) BindInstanceToTags(instanceId string, args *api.BindTagsRequest
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) UnBindInstanceToTags(instanceId string, args *api.UnBindTagsRequest) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -1478,6 +1536,86 @@ func (c *Client) UnBindInstanceToTags(instanceId string, args *api.UnBindTagsReq
 	return api.UnBindInstanceToTags(c, instanceId, body)
 }
 
+// BindCDSVolumeToTags - bind CDS volume to tags
+//
+// PARAMS:
+//     - volumeId: the id of the CDS volume
+//     - args: the arguments to BindCDSVolumeToTags
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) BindCDSVolumeToTags(volumeId string, args *api.BindTagsRequest) error {
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return err
+	}
+
+	return api.BindCDSVolumeToTags(c, volumeId, body)
+}
+
+// UnBindCDSVolumeToTags - unbind CDS volume to tags
+//
+// PARAMS:
+//     - volumeId: the id of the CDS volume
+//     - args: the arguments to unBindCDSVolumeToTags
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) UnBindCDSVolumeToTags(volumeId string, args *api.UnBindTagsRequest) error {
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return err
+	}
+
+	return api.UnBindCDSVolumeToTags(c, volumeId, body)
+}
+
+// BindSecurityGroupToTags - bind security group to tags
+//
+// PARAMS:
+//     - securityGroupId: the id of the security group
+//     - args: the arguments to BindSecurityGroupToTags
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) BindSecurityGroupToTags(securityGroupId string, args *api.BindTagsRequest) error {
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return err
+	}
+
+	return api.BindSecurityGroupToTags(c, securityGroupId, body)
+}
+
+// UnBindSecurityGroupToTags - unbind security group to tags
+//
+// PARAMS:
+//     - securityGroupId: the id of the security group
+//     - args: the arguments to unBindSecurityGroupToTags
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) UnBindSecurityGroupToTags(securityGroupId string, args *api.UnBindTagsRequest) error {
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return err
+	}
+
+	return api.UnBindSecurityGroupToTags(c, securityGroupId, body)
+}
+
 // GetInstanceNoChargeList - get instance with nocharge list
 //
 // PARAMS:
@@ -1497,6 +1635,9 @@ func (c *Client) GetInstanceNoChargeList(args *api.ListInstanceArgs) (*api.ListI
 //     - *api.CreateInstanceResult: the result of create Instance, contains new Instance ID
 //     - error: nil if success otherwise the specific error
 func (c *Client) CreateBidInstance(args *api.CreateInstanceArgs) (*api.CreateInstanceResult, error) {
+	if err := api.CheckBidArgs(args.BidModel, args.BidPrice); err != nil {
+		return nil, err
+	}
 	if len(args.AdminPass) > 0 {
 		cryptedPass, err := api.Aes128EncryptUseSecreteKey(c.Config.Credentials.SecretAccessKey, args.AdminPass)
 		if err != nil {
@@ -1506,7 +1647,7 @@ func (c *Client) CreateBidInstance(args *api.CreateInstanceArgs) (*api.CreateIns
 		args.AdminPass = cryptedPass
 	}
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1525,7 +1666,7 @@ func (c *Client) CreateBidInstance(args *api.CreateInstanceArgs) (*api.CreateIns
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) CancelBidOrder(args *api.CancelBidOrderRequest) (*api.CreateBidInstanceResult, error) {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1661,7 +1802,7 @@ func (c *Client) GetInstanceResizeStock(args *api.ResizeInstanceStockArgs) (*api
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) BatchCreateAutoRenewRules(args *api.BccCreateAutoRenewArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -1679,7 +1820,7 @@ func (c *Client) BatchCreateAutoRenewRules(args *api.BccCreateAutoRenewArgs) err
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) BatchDeleteAutoRenewRules(args *api.BccDeleteAutoRenewArgs) error {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -1,6 +1,7 @@
 package bcc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/baidubce/bce-sdk-go/model"
 	"github.com/baidubce/bce-sdk-go/services/bcc/api"
@@ -302,6 +304,42 @@ func TestRebootInstance(t *testing.T) {
 	ExpectEqual(t.Errorf, err, nil)
 }
 
+func TestListBidPrices(t *testing.T) {
+	prices, err := BCC_CLIENT.ListBidPrices(&api.ListBidPricesArgs{InstanceType: api.InstanceTypeN3})
+	ExpectEqual(t.Errorf, nil, err)
+	for _, price := range prices {
+		ExpectEqual(t.Errorf, api.InstanceTypeN3, price.InstanceType)
+		fmt.Println(price.ZoneName, price.Spec, price.Price)
+	}
+}
+
+func TestListAllBidEvents(t *testing.T) {
+	events, err := BCC_CLIENT.ListAllBidEvents("")
+	ExpectEqual(t.Errorf, nil, err)
+	for _, event := range events {
+		fmt.Println(event.InstanceId, event.EventType, event.EventTime)
+	}
+}
+
+func TestWaitInstanceStatus(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	instance, err := BCC_CLIENT.WaitInstanceStatus(ctx, BCC_TestBccId, api.InstanceStatusRunning)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, api.InstanceStatusRunning, instance.Status)
+}
+
+func TestBatchRebootInstances(t *testing.T) {
+	report, err := BCC_CLIENT.BatchRebootInstances([]string{BCC_TestBccId, BCC_TestBccId, "i-notexist"}, true)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, 1, len(report.Succeeded))
+	ExpectEqual(t.Errorf, 1, len(report.Failed))
+	ExpectEqual(t.Errorf, "i-notexist", report.Failed[0].InstanceId)
+
+	_, err = BCC_CLIENT.BatchStartInstances(nil)
+	ExpectEqual(t.Errorf, false, err == nil)
+}
+
 func TestRebuildInstance(t *testing.T) {
 	rebuildArgs := &api.RebuildInstanceArgs{
 		ImageId:   "ImageId",
@@ -742,6 +780,38 @@ func TestRevokeSecurityGroupRule(t *testing.T) {
 	ExpectEqual(t.Errorf, err, nil)
 }
 
+func TestDiffSecurityGroupRules(t *testing.T) {
+	current := []api.SecurityGroupRuleModel{
+		{Direction: "ingress", Protocol: "tcp", PortRange: "22", SourceIp: "all", Ethertype: "IPv4"},
+		{Direction: "ingress", Protocol: "tcp", PortRange: "80", SourceIp: "10.0.0.1/32"},
+		{Direction: "egress", Protocol: "all", DestIp: "all"},
+	}
+	desired := []api.SecurityGroupRuleModel{
+		{Direction: "ingress", Protocol: "tcp", PortRange: "22-22", Remark: "ssh"},
+		{Direction: "ingress", Protocol: "tcp", PortRange: "443", SourceIp: "0.0.0.0/0"},
+		{Direction: "ingress", Protocol: "tcp", PortRange: "443", SourceIp: ""},
+		{Direction: "egress"},
+	}
+	diff := DiffSecurityGroupRules(current, desired)
+	ExpectEqual(t.Errorf, 1, len(diff.Add))
+	ExpectEqual(t.Errorf, "443", diff.Add[0].PortRange)
+	ExpectEqual(t.Errorf, 1, len(diff.Remove))
+	ExpectEqual(t.Errorf, "80", diff.Remove[0].PortRange)
+	ExpectEqual(t.Errorf, true, DiffSecurityGroupRules(current, current).IsEmpty())
+}
+
+func TestApplySecurityGroupRules(t *testing.T) {
+	desired := []api.SecurityGroupRuleModel{
+		{Direction: "ingress", Protocol: "tcp", PortRange: "22", Remark: "ssh"},
+		{Direction: "egress", Protocol: "all"},
+	}
+	_, err := BCC_CLIENT.ApplySecurityGroupRules(BCC_TestSecurityGroupId, desired)
+	ExpectEqual(t.Errorf, err, nil)
+	diff, err := BCC_CLIENT.PlanSecurityGroupRules(BCC_TestSecurityGroupId, desired)
+	ExpectEqual(t.Errorf, err, nil)
+	ExpectEqual(t.Errorf, true, diff.IsEmpty())
+}
+
 func TestDeleteSecurityGroupRule(t *testing.T) {
 	err := BCC_CLIENT.DeleteSecurityGroup(BCC_TestSecurityGroupId)
 	ExpectEqual(t.Errorf, err, nil)
@@ -803,6 +873,33 @@ func TestGetImageSharedUser(t *testing.T) {
 	ExpectEqual(t.Errorf, err, nil)
 }
 
+func TestImportImage(t *testing.T) {
+	args := &api.ImportImageArgs{
+		Name:        "testImport",
+		BosUrl:      "bos://bucket/image.qcow2",
+		ImageFormat: api.ImageFormatQcow2,
+		OsType:      "linux",
+		OsName:      "CentOS",
+		OsVersion:   "7.9",
+		OsArch:      "x86_64 (64bit)",
+	}
+	result, err := BCC_CLIENT.ImportImage(args)
+	ExpectEqual(t.Errorf, err, nil)
+	if err == nil {
+		_, err = BCC_CLIENT.GetImageTask(result.TaskId)
+		ExpectEqual(t.Errorf, err, nil)
+	}
+}
+
+func TestExportImage(t *testing.T) {
+	args := &api.ExportImageArgs{
+		BosUrl:      "bos://bucket/exported.qcow2",
+		ImageFormat: api.ImageFormatQcow2,
+	}
+	_, err := BCC_CLIENT.ExportImage(BCC_TestImageId, args)
+	ExpectEqual(t.Errorf, err, nil)
+}
+
 func TestGetImageOS(t *testing.T) {
 	args := &api.GetImageOsArgs{}
 	_, err := BCC_CLIENT.GetImageOS(args)
@@ -980,6 +1077,49 @@ func TestUnBindInstanceToTags(t *testing.T) {
 	ExpectEqual(t.Errorf, err, nil)
 }
 
+func TestBindCDSVolumeToTags(t *testing.T) {
+	args := &api.BindTagsRequest{
+		ChangeTags: model.NewTagModels(map[string]string{"env": "test"}),
+	}
+	err := BCC_CLIENT.BindCDSVolumeToTags(BCC_TestCdsId, args)
+	ExpectEqual(t.Errorf, err, nil)
+}
+
+func TestUnBindCDSVolumeToTags(t *testing.T) {
+	args := &api.UnBindTagsRequest{
+		ChangeTags: model.NewTagModels(map[string]string{"env": "test"}),
+	}
+	err := BCC_CLIENT.UnBindCDSVolumeToTags(BCC_TestCdsId, args)
+	ExpectEqual(t.Errorf, err, nil)
+}
+
+func TestBindSecurityGroupToTags(t *testing.T) {
+	args := &api.BindTagsRequest{
+		ChangeTags: model.NewTagModels(map[string]string{"env": "test"}),
+	}
+	err := BCC_CLIENT.BindSecurityGroupToTags(BCC_TestSecurityGroupId, args)
+	ExpectEqual(t.Errorf, err, nil)
+}
+
+func TestUnBindSecurityGroupToTags(t *testing.T) {
+	args := &api.UnBindTagsRequest{
+		ChangeTags: model.NewTagModels(map[string]string{"env": "test"}),
+	}
+	err := BCC_CLIENT.UnBindSecurityGroupToTags(BCC_TestSecurityGroupId, args)
+	ExpectEqual(t.Errorf, err, nil)
+}
+
+func TestListInstancesByTags(t *testing.T) {
+	listArgs := &api.ListInstanceArgs{
+		Tags: map[string]string{"env": "test"},
+	}
+	res, err := BCC_CLIENT.ListInstances(listArgs)
+	ExpectEqual(t.Errorf, err, nil)
+	for _, instance := range res.Instances {
+		ExpectEqual(t.Errorf, model.MatchTags(instance.Tags, listArgs.Tags), true)
+	}
+}
+
 func TestGetInstanceNoChargeList(t *testing.T) {
 	listArgs := &api.ListInstanceArgs{}
 	_, err := BCC_CLIENT.GetInstanceNoChargeList(listArgs)
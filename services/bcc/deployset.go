@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// deployset.go - bind the instances to the deploy sets and query how they are spread
+
+package bcc
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/bcc/api"
+)
+
+// BindInstanceToDeploySets - bind the existing instance to the deploy sets, the instance to
+// create can be bound by the DeployIdList of the CreateInstanceArgs
+//
+// PARAMS:
+//     - instanceId: the id of the instance
+//     - deploySetIds: the ids of the deploy sets
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) BindInstanceToDeploySets(instanceId string, deploySetIds ...string) error {
+	args := &api.UpdateInstanceDeployArgs{
+		InstanceId:   instanceId,
+		DeploySetIds: deploySetIds,
+	}
+	sendErr, err := c.UpdateInstanceDeploySet(args)
+	if err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// UnbindInstancesFromDeploySet - unbind the instances from the deploy set
+//
+// PARAMS:
+//     - deploySetId: the id of the deploy set
+//     - instanceIds: the ids of the instances
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) UnbindInstancesFromDeploySet(deploySetId string, instanceIds ...string) error {
+	args := &api.DelInstanceDeployArgs{
+		DeploySetId: deploySetId,
+		InstanceIds: instanceIds,
+	}
+	sendErr, err := c.DelInstanceDeploySet(args)
+	if err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// GetDeploySetSpread - get the instances of the deploy set grouped by the zones
+//
+// PARAMS:
+//     - deploySetId: the id of the deploy set
+// RETURNS:
+//     - *api.DeploySetSpread: the zones and the instances placed in them
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetDeploySetSpread(deploySetId string) (*api.DeploySetSpread, error) {
+	result, err := c.GetDeploySet(deploySetId)
+	if err != nil {
+		return nil, err
+	}
+	spread := &api.DeploySetSpread{
+		DeploySetId: result.DeploySetId,
+		Name:        result.Name,
+		Strategy:    result.Strategy,
+		Concurrency: result.Concurrency,
+	}
+	if len(spread.DeploySetId) == 0 {
+		spread.DeploySetId = deploySetId
+	}
+	for _, az := range result.InstanceList {
+		zone := api.DeploySetZoneSpread{
+			ZoneName:       az.ZoneName,
+			Total:          az.Total,
+			BccInstanceIds: az.BccInstanceIds,
+			BbcInstanceIds: az.BbcInstanceIds,
+		}
+		// The old deploy sets only return the InstanceIds without telling the BCC from the BBC
+		if len(zone.BccInstanceIds) == 0 && len(zone.BbcInstanceIds) == 0 {
+			zone.BccInstanceIds = az.InstanceIds
+		}
+		if zone.Total == 0 {
+			zone.Total = len(zone.BccInstanceIds) + len(zone.BbcInstanceIds)
+		}
+		spread.Total += zone.Total
+		spread.Zones = append(spread.Zones, zone)
+	}
+	return spread, nil
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the BCC client
+
+package bcc
+
+import (
+	"context"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/bcc/api"
+)
+
+// Interface defines all the operations of the BCC client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	BatchStartInstances(instanceIds []string) (*api.BatchInstanceReport, error)
+	BatchStopInstances(instanceIds []string, forceStop bool) (*api.BatchInstanceReport, error)
+	BatchRebootInstances(instanceIds []string, forceStop bool) (*api.BatchInstanceReport, error)
+	BatchDeleteInstances(instanceIds []string) (*api.BatchInstanceReport, error)
+	ListBidPrices(args *api.ListBidPricesArgs) ([]api.BidPrice, error)
+	ListAllBidEvents(instanceId string) ([]api.BidEvent, error)
+	CreateInstance(args *api.CreateInstanceArgs) (*api.CreateInstanceResult, error)
+	CreateInstanceByLabel(args *api.CreateSpecialInstanceBySpecArgs) (*api.CreateInstanceResult, error)
+	CreateInstanceBySpec(args *api.CreateInstanceBySpecArgs) (*api.CreateInstanceBySpecResult, error)
+	CreateInstanceV3(args *api.CreateInstanceV3Args) (*api.CreateInstanceV3Result, error)
+	ListInstances(args *api.ListInstanceArgs) (*api.ListInstanceResult, error)
+	ListRecycleInstances(args *api.ListRecycleInstanceArgs) (*api.ListRecycleInstanceResult, error)
+	ListServersByMarkerV3(args *api.ListServerRequestV3Args) (*api.LogicMarkerResultResponseV3, error)
+	GetInstanceDetail(instanceId string) (*api.GetInstanceDetailResult, error)
+	GetInstanceDetailWithDeploySet(instanceId string, isDeploySet bool) (*api.GetInstanceDetailResult, error)
+	GetInstanceDetailWithDeploySetAndFailed(instanceId string, isDeploySet bool, containsFailed bool) (*api.GetInstanceDetailResult, error)
+	DeleteInstance(instanceId string) error
+	AutoReleaseInstance(instanceId string, releaseTime string) error
+	ResizeInstance(instanceId string, args *api.ResizeInstanceArgs) error
+	RebuildInstance(instanceId string, args *api.RebuildInstanceArgs) error
+	StartInstance(instanceId string) error
+	StopInstanceWithNoCharge(instanceId string, forceStop bool, stopWithNoCharge bool) error
+	StopInstance(instanceId string, forceStop bool) error
+	RebootInstance(instanceId string, forceStop bool) error
+	RecoveryInstance(args *api.RecoveryInstanceArgs) error
+	ChangeInstancePass(instanceId string, args *api.ChangeInstancePassArgs) error
+	ModifyDeletionProtection(instanceId string, args *api.DeletionProtectionArgs) error
+	ModifyInstanceAttribute(instanceId string, args *api.ModifyInstanceAttributeArgs) error
+	ModifyInstanceDesc(instanceId string, args *api.ModifyInstanceDescArgs) error
+	ModifyInstanceHostname(instanceId string, args *api.ModifyInstanceHostnameArgs) error
+	BindSecurityGroup(instanceId string, securityGroupId string) error
+	UnBindSecurityGroup(instanceId string, securityGroupId string) error
+	GetInstanceVNC(instanceId string) (*api.GetInstanceVNCResult, error)
+	InstancePurchaseReserved(instanceId string, args *api.PurchaseReservedArgs) error
+	GetBidInstancePrice(args *api.GetBidInstancePriceArgs) (*api.GetBidInstancePriceResult, error)
+	ListBidFlavor() (*api.ListBidFlavorResult, error)
+	ListBidEvents(args *api.ListBidEventsArgs) (*api.ListBidEventsResult, error)
+	DeleteInstanceWithRelateResource(instanceId string, args *api.DeleteInstanceWithRelateResourceArgs) error
+	DeletePrepaidInstanceWithRelateResource(args *api.DeletePrepaidInstanceWithRelateResourceArgs) error
+	InstanceChangeSubnet(args *api.InstanceChangeSubnetArgs) error
+	InstanceChangeVpc(args *api.InstanceChangeVpcArgs) error
+	BatchAddIP(args *api.BatchAddIpArgs) (*api.BatchAddIpResponse, error)
+	BatchDelIP(args *api.BatchDelIpArgs) error
+	CreateCDSVolume(args *api.CreateCDSVolumeArgs) (*api.CreateCDSVolumeResult, error)
+	CreateCDSVolumeV3(args *api.CreateCDSVolumeV3Args) (*api.CreateCDSVolumeResult, error)
+	ListCDSVolume(queryArgs *api.ListCDSVolumeArgs) (*api.ListCDSVolumeResult, error)
+	ListCDSVolumeV3(queryArgs *api.ListCDSVolumeArgs) (*api.ListCDSVolumeResultV3, error)
+	GetCDSVolumeDetail(volumeId string) (*api.GetVolumeDetailResult, error)
+	GetCDSVolumeDetailV3(volumeId string) (*api.GetVolumeDetailResultV3, error)
+	AttachCDSVolume(volumeId string, args *api.AttachVolumeArgs) (*api.AttachVolumeResult, error)
+	DetachCDSVolume(volumeId string, args *api.DetachVolumeArgs) error
+	DeleteCDSVolume(volumeId string) error
+	DeleteCDSVolumeNew(volumeId string, args *api.DeleteCDSVolumeArgs) error
+	ResizeCDSVolume(volumeId string, args *api.ResizeCSDVolumeArgs) error
+	RollbackCDSVolume(volumeId string, args *api.RollbackCSDVolumeArgs) error
+	PurchaseReservedCDSVolume(volumeId string, args *api.PurchaseReservedCSDVolumeArgs) error
+	RenameCDSVolume(volumeId string, args *api.RenameCSDVolumeArgs) error
+	ModifyCDSVolume(volumeId string, args *api.ModifyCSDVolumeArgs) error
+	ModifyChargeTypeCDSVolume(volumeId string, args *api.ModifyChargeTypeCSDVolumeArgs) error
+	AutoRenewCDSVolume(args *api.AutoRenewCDSVolumeArgs) error
+	CancelAutoRenewCDSVolume(args *api.CancelAutoRenewCDSVolumeArgs) error
+	CreateSecurityGroup(args *api.CreateSecurityGroupArgs) (*api.CreateSecurityGroupResult, error)
+	ListSecurityGroup(queryArgs *api.ListSecurityGroupArgs) (*api.ListSecurityGroupResult, error)
+	AuthorizeSecurityGroupRule(securityGroupId string, args *api.AuthorizeSecurityGroupArgs) error
+	RevokeSecurityGroupRule(securityGroupId string, args *api.RevokeSecurityGroupArgs) error
+	DeleteSecurityGroup(securityGroupId string) error
+	CreateImage(args *api.CreateImageArgs) (*api.CreateImageResult, error)
+	ListImage(queryArgs *api.ListImageArgs) (*api.ListImageResult, error)
+	GetImageDetail(imageId string) (*api.GetImageDetailResult, error)
+	DeleteImage(imageId string) error
+	RemoteCopyImage(imageId string, args *api.RemoteCopyImageArgs) error
+	RemoteCopyImageReturnImageIds(imageId string, args *api.RemoteCopyImageArgs) (*api.RemoteCopyImageResult, error)
+	CancelRemoteCopyImage(imageId string) error
+	ShareImage(imageId string, args *api.SharedUser) error
+	UnShareImage(imageId string, args *api.SharedUser) error
+	GetImageSharedUser(imageId string) (*api.GetImageSharedUserResult, error)
+	GetImageOS(args *api.GetImageOsArgs) (*api.GetImageOsResult, error)
+	ImportImage(args *api.ImportImageArgs) (*api.ImportImageResult, error)
+	ExportImage(imageId string, args *api.ExportImageArgs) (*api.ExportImageResult, error)
+	GetImageTask(taskId string) (*api.ImageTaskModel, error)
+	CreateSnapshot(args *api.CreateSnapshotArgs) (*api.CreateSnapshotResult, error)
+	ListSnapshot(args *api.ListSnapshotArgs) (*api.ListSnapshotResult, error)
+	ListSnapshotChain(args *api.ListSnapshotChainArgs) (*api.ListSnapshotChainResult, error)
+	GetSnapshotDetail(snapshotId string) (*api.GetSnapshotDetailResult, error)
+	DeleteSnapshot(snapshotId string) error
+	CreateAutoSnapshotPolicy(args *api.CreateASPArgs) (*api.CreateASPResult, error)
+	AttachAutoSnapshotPolicy(aspId string, args *api.AttachASPArgs) error
+	DetachAutoSnapshotPolicy(aspId string, args *api.DetachASPArgs) error
+	DeleteAutoSnapshotPolicy(aspId string) error
+	ListAutoSnapshotPolicy(args *api.ListASPArgs) (*api.ListASPResult, error)
+	GetAutoSnapshotPolicy(aspId string) (*api.GetASPDetailResult, error)
+	UpdateAutoSnapshotPolicy(args *api.UpdateASPArgs) error
+	ListSpec() (*api.ListSpecResult, error)
+	ListZone() (*api.ListZoneResult, error)
+	ListFlavorSpec(args *api.ListFlavorSpecArgs) (*api.ListFlavorSpecResult, error)
+	GetPriceBySpec(args *api.GetPriceBySpecArgs) (*api.GetPriceBySpecResult, error)
+	CreateDeploySet(args *api.CreateDeploySetArgs) (*api.CreateDeploySetResult, error)
+	ListDeploySets() (*api.ListDeploySetsResult, error)
+	ModifyDeploySet(deploySetId string, args *api.ModifyDeploySetArgs) (error, error)
+	DeleteDeploySet(deploySetId string) error
+	GetDeploySet(deploySetId string) (*api.DeploySetResult, error)
+	UpdateInstanceDeploySet(args *api.UpdateInstanceDeployArgs) (error, error)
+	DelInstanceDeploySet(args *api.DelInstanceDeployArgs) (error, error)
+	ResizeInstanceBySpec(instanceId string, args *api.ResizeInstanceArgs) error
+	BatchRebuildInstances(args *api.RebuildBatchInstanceArgs) error
+	ChangeToPrepaid(instanceId string, args *api.ChangeToPrepaidRequest) (*api.ChangeToPrepaidResponse, error)
+	BindInstanceToTags(instanceId string, args *api.BindTagsRequest) error
+	UnBindInstanceToTags(instanceId string, args *api.UnBindTagsRequest) error
+	BindCDSVolumeToTags(volumeId string, args *api.BindTagsRequest) error
+	UnBindCDSVolumeToTags(volumeId string, args *api.UnBindTagsRequest) error
+	BindSecurityGroupToTags(securityGroupId string, args *api.BindTagsRequest) error
+	UnBindSecurityGroupToTags(securityGroupId string, args *api.UnBindTagsRequest) error
+	GetInstanceNoChargeList(args *api.ListInstanceArgs) (*api.ListInstanceResult, error)
+	CreateBidInstance(args *api.CreateInstanceArgs) (*api.CreateInstanceResult, error)
+	CancelBidOrder(args *api.CancelBidOrderRequest) (*api.CreateBidInstanceResult, error)
+	GetAvailableDiskInfo(zoneName string) (*api.GetAvailableDiskInfoResult, error)
+	DeletePrepayVolume(args *api.VolumePrepayDeleteRequestArgs) (*api.VolumeDeleteResultResponse, error)
+	ListTypeZones(args *api.ListTypeZonesArgs) (*api.ListTypeZonesResult, error)
+	ListInstanceEnis(instanceId string) (*api.ListInstanceEniResult, error)
+	CreateKeypair(args *api.CreateKeypairArgs) (*api.KeypairResult, error)
+	ImportKeypair(args *api.ImportKeypairArgs) (*api.KeypairResult, error)
+	AttachKeypair(args *api.AttackKeypairArgs) error
+	DetachKeypair(args *api.DetachKeypairArgs) error
+	DeleteKeypair(args *api.DeleteKeypairArgs) error
+	GetKeypairDetail(keypairId string) (*api.KeypairResult, error)
+	ListKeypairs(args *api.ListKeypairArgs) (*api.ListKeypairResult, error)
+	RenameKeypair(args *api.RenameKeypairArgs) error
+	UpdateKeypairDescription(args *api.KeypairUpdateDescArgs) error
+	GetAllStocks() (*api.GetAllStocksResult, error)
+	GetStockWithDeploySet(args *api.GetStockWithDeploySetArgs) (*api.GetStockWithDeploySetResults, error)
+	GetStockWithSpec(args *api.GetStockWithSpecArgs) (*api.GetStockWithSpecResults, error)
+	GetInstanceCreateStock(args *api.CreateInstanceStockArgs) (*api.InstanceStockResult, error)
+	GetInstanceResizeStock(args *api.ResizeInstanceStockArgs) (*api.InstanceStockResult, error)
+	BatchCreateAutoRenewRules(args *api.BccCreateAutoRenewArgs) error
+	BatchDeleteAutoRenewRules(args *api.BccDeleteAutoRenewArgs) error
+	DeleteInstanceIngorePayment(args *api.DeleteInstanceIngorePaymentArgs) (*api.DeleteInstanceResult, error)
+	DeleteRecycledInstance(instanceId string) error
+	ListInstanceByInstanceIds(args *api.ListInstanceByInstanceIdArgs) (*api.ListInstancesResult, error)
+	BindInstanceToDeploySets(instanceId string, deploySetIds ...string) error
+	UnbindInstancesFromDeploySet(deploySetId string, instanceIds ...string) error
+	GetDeploySetSpread(deploySetId string) (*api.DeploySetSpread, error)
+	GetSecurityGroup(securityGroupId string) (*api.SecurityGroupModel, error)
+	PlanSecurityGroupRules(securityGroupId string, desired []api.SecurityGroupRuleModel) (*api.SecurityGroupRulesDiff, error)
+	ApplySecurityGroupRules(securityGroupId string, desired []api.SecurityGroupRuleModel) (*api.SecurityGroupRulesDiff, error)
+	ApplySecurityGroupRulesDiff(securityGroupId string, diff *api.SecurityGroupRulesDiff) error
+	WaitInstanceStatus(ctx context.Context, instanceId string, status api.InstanceStatus) (*api.InstanceModel, error)
+	ImageTask(imageId string) waiter.Task
+	ImageTransferTask(taskId string) waiter.Task
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
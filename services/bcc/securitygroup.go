@@ -0,0 +1,233 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// securitygroup.go - compute the diff of the security group rules and apply it with rollback
+
+package bcc
+
+import (
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bcc/api"
+)
+
+// DiffSecurityGroupRules - compute the minimal rules to add and to remove to turn the current
+// rules into the desired ones. The rules are compared by the direction, ether type, protocol,
+// port range, ip and group fields with the defaults filled, eg: the empty protocol is the same
+// as "all" and the empty ip is the same as "0.0.0.0/0". The Remark is not compared since the
+// rule can not be updated in place, and the duplicate rules are counted once.
+//
+// PARAMS:
+//     - current: the current rules of the security group
+//     - desired: the desired rules of the security group
+// RETURNS:
+//     - *api.SecurityGroupRulesDiff: the rules to add and to remove
+func DiffSecurityGroupRules(current, desired []api.SecurityGroupRuleModel) *api.SecurityGroupRulesDiff {
+	currentKeys := make(map[string]bool, len(current))
+	for _, rule := range current {
+		currentKeys[securityGroupRuleKey(rule)] = true
+	}
+	desiredKeys := make(map[string]bool, len(desired))
+	diff := &api.SecurityGroupRulesDiff{}
+	for _, rule := range desired {
+		key := securityGroupRuleKey(rule)
+		if desiredKeys[key] {
+			continue
+		}
+		desiredKeys[key] = true
+		if !currentKeys[key] {
+			rule.SecurityGroupId = ""
+			diff.Add = append(diff.Add, rule)
+		}
+	}
+	removed := make(map[string]bool)
+	for _, rule := range current {
+		key := securityGroupRuleKey(rule)
+		if desiredKeys[key] || removed[key] {
+			continue
+		}
+		removed[key] = true
+		rule.SecurityGroupId = ""
+		diff.Remove = append(diff.Remove, rule)
+	}
+	return diff
+}
+
+// securityGroupRuleKey - get the identity of the rule with the defaults filled
+func securityGroupRuleKey(rule api.SecurityGroupRuleModel) string {
+	etherType := strings.ToLower(rule.Ethertype)
+	if etherType == "" {
+		etherType = "ipv4"
+	}
+	protocol := strings.ToLower(rule.Protocol)
+	if protocol == "" {
+		protocol = "all"
+	}
+	portRange := strings.TrimSpace(rule.PortRange)
+	switch {
+	case protocol != "tcp" && protocol != "udp":
+		portRange = ""
+	case portRange == "":
+		portRange = "1-65535"
+	default:
+		if bounds := strings.SplitN(portRange, "-", 2); len(bounds) == 2 && bounds[0] == bounds[1] {
+			portRange = bounds[0]
+		}
+	}
+	return strings.Join([]string{
+		strings.ToLower(rule.Direction),
+		etherType,
+		protocol,
+		portRange,
+		normalizeRuleIp(rule.SourceIp),
+		rule.SourceGroupId,
+		normalizeRuleIp(rule.DestIp),
+		rule.DestGroupId,
+	}, "|")
+}
+
+func normalizeRuleIp(ip string) string {
+	ip = strings.ToLower(strings.TrimSpace(ip))
+	switch ip {
+	case "", "all", "0.0.0.0/0", "::/0":
+		return "all"
+	}
+	if strings.HasSuffix(ip, "/32") && !strings.Contains(ip, ":") {
+		return strings.TrimSuffix(ip, "/32")
+	}
+	if strings.HasSuffix(ip, "/128") {
+		return strings.TrimSuffix(ip, "/128")
+	}
+	return ip
+}
+
+// GetSecurityGroup - get the security group with its rules by the id
+//
+// PARAMS:
+//     - securityGroupId: the id of the security group
+// RETURNS:
+//     - *api.SecurityGroupModel: the security group
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetSecurityGroup(securityGroupId string) (*api.SecurityGroupModel, error) {
+	args := &api.ListSecurityGroupArgs{}
+	for {
+		res, err := c.ListSecurityGroup(args)
+		if err != nil {
+			return nil, err
+		}
+		for i := range res.SecurityGroups {
+			if res.SecurityGroups[i].Id == securityGroupId {
+				return &res.SecurityGroups[i], nil
+			}
+		}
+		if !res.IsTruncated || res.NextMarker == "" {
+			return nil, bce.NewBceClientError("security group not found: " + securityGroupId)
+		}
+		args.Marker = res.NextMarker
+	}
+}
+
+// PlanSecurityGroupRules - compute the rules diff of the security group without applying it
+//
+// PARAMS:
+//     - securityGroupId: the id of the security group
+//     - desired: the desired rules of the security group
+// RETURNS:
+//     - *api.SecurityGroupRulesDiff: the rules to add and to remove
+//     - error: nil if success otherwise the specific error
+func (c *Client) PlanSecurityGroupRules(securityGroupId string,
+	desired []api.SecurityGroupRuleModel) (*api.SecurityGroupRulesDiff, error) {
+	securityGroup, err := c.GetSecurityGroup(securityGroupId)
+	if err != nil {
+		return nil, err
+	}
+	return DiffSecurityGroupRules(securityGroup.Rules, desired), nil
+}
+
+// ApplySecurityGroupRules - make the security group have exactly the desired rules by applying
+// the minimal diff. The missing rules are added before the redundant ones are removed so that
+// the allowed traffic is not interrupted. If any change fails, the applied changes are rolled
+// back in reverse order and the *api.ApplySecurityGroupRulesError is returned.
+//
+// PARAMS:
+//     - securityGroupId: the id of the security group
+//     - desired: the desired rules of the security group
+// RETURNS:
+//     - *api.SecurityGroupRulesDiff: the applied rules diff
+//     - error: nil if success otherwise the specific error
+func (c *Client) ApplySecurityGroupRules(securityGroupId string,
+	desired []api.SecurityGroupRuleModel) (*api.SecurityGroupRulesDiff, error) {
+	diff, err := c.PlanSecurityGroupRules(securityGroupId, desired)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.ApplySecurityGroupRulesDiff(securityGroupId, diff); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// ApplySecurityGroupRulesDiff - apply the rules diff to the security group, the applied changes
+// are rolled back if any change fails
+//
+// PARAMS:
+//     - securityGroupId: the id of the security group
+//     - diff: the rules to add and to remove
+// RETURNS:
+//     - error: nil if success otherwise the *api.ApplySecurityGroupRulesError
+func (c *Client) ApplySecurityGroupRulesDiff(securityGroupId string,
+	diff *api.SecurityGroupRulesDiff) error {
+	var added, removed []api.SecurityGroupRuleModel
+	fail := func(rule api.SecurityGroupRuleModel, err error) error {
+		applyErr := &api.ApplySecurityGroupRulesError{
+			SecurityGroupId: securityGroupId,
+			Rule:            rule,
+			Err:             err,
+		}
+		for i := len(removed) - 1; i >= 0; i-- {
+			rule := removed[i]
+			if err := c.AuthorizeSecurityGroupRule(securityGroupId,
+				&api.AuthorizeSecurityGroupArgs{Rule: &rule}); err != nil {
+				applyErr.RollbackErrors = append(applyErr.RollbackErrors, err)
+			}
+		}
+		for i := len(added) - 1; i >= 0; i-- {
+			rule := added[i]
+			if err := c.RevokeSecurityGroupRule(securityGroupId,
+				&api.RevokeSecurityGroupArgs{Rule: &rule}); err != nil {
+				applyErr.RollbackErrors = append(applyErr.RollbackErrors, err)
+			}
+		}
+		return applyErr
+	}
+
+	for _, rule := range diff.Add {
+		rule := rule
+		if err := c.AuthorizeSecurityGroupRule(securityGroupId,
+			&api.AuthorizeSecurityGroupArgs{Rule: &rule}); err != nil {
+			return fail(rule, err)
+		}
+		added = append(added, rule)
+	}
+	for _, rule := range diff.Remove {
+		rule := rule
+		if err := c.RevokeSecurityGroupRule(securityGroupId,
+			&api.RevokeSecurityGroupArgs{Rule: &rule}); err != nil {
+			return fail(rule, err)
+		}
+		removed = append(removed, rule)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// wait.go - wait for the instances, images and image transfers to reach the expected status
+
+package bcc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/bcc/api"
+)
+
+// WaitInstanceStatus - poll the instance with the default backoff of the waiter until it reaches
+// the given status, eg: InstanceStatusRunning after it is created or started
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - instanceId: the specific instance ID
+//     - status: the expected status
+// RETURNS:
+//     - *api.InstanceModel: the last queried instance
+//     - error: nil if ok, *waiter.FailureError if the instance is in the Error status,
+//       *waiter.TimeoutError if the context is done, otherwise the query error
+func (c *Client) WaitInstanceStatus(ctx context.Context, instanceId string,
+	status api.InstanceStatus) (*api.InstanceModel, error) {
+	op := func(ctx context.Context) (interface{}, error) {
+		result, err := c.GetInstanceDetail(instanceId)
+		if err != nil {
+			return nil, err
+		}
+		if result.Instance.Status == api.InstanceStatusError && status != api.InstanceStatusError {
+			return &result.Instance, fmt.Errorf("the instance %s is in the Error status", instanceId)
+		}
+		return &result.Instance, nil
+	}
+	result, err := waiter.Wait(ctx, op, []waiter.Acceptor{
+		{State: waiter.StateSuccess, Matcher: func(result interface{}, err error) bool {
+			return err == nil && result.(*api.InstanceModel).Status == status
+		}},
+		{State: waiter.StateFailure, Matcher: func(result interface{}, err error) bool {
+			return err != nil && result != nil
+		}},
+	}, nil)
+	instance, _ := result.(*api.InstanceModel)
+	return instance, err
+}
+
+// ImageTask - get the async task of the image creation, the result of the task is the
+// *api.ImageModel of the last query
+//
+// PARAMS:
+//     - imageId: the image ID returned by the CreateImage
+// RETURNS:
+//     - waiter.Task: the task finished when the image is available, or failed if the image is in
+//       the CreateFailed or Error status
+func (c *Client) ImageTask(imageId string) waiter.Task {
+	return waiter.NewTask(imageId, func(ctx context.Context) (interface{}, waiter.State, error) {
+		result, err := c.GetImageDetail(imageId)
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		if result.Image == nil {
+			return nil, waiter.StateRetry, nil
+		}
+		switch result.Image.Status {
+		case api.ImageStatusAvailable:
+			return result.Image, waiter.StateSuccess, nil
+		case api.ImageStatusCreateFailed, api.ImageStatusError:
+			return result.Image, waiter.StateFailure,
+				fmt.Errorf("the image %s is in the %s status", imageId, result.Image.Status)
+		}
+		return result.Image, waiter.StateRetry, nil
+	}, nil)
+}
+
+// ImageTransferTask - get the async task of the image import or export, the result of the task is
+// the *api.ImageTaskModel of the last query which holds the progress
+//
+// PARAMS:
+//     - taskId: the task ID returned by the ImportImage or ExportImage
+// RETURNS:
+//     - waiter.Task: the task finished when the import or export succeeds, or failed if it fails
+func (c *Client) ImageTransferTask(taskId string) waiter.Task {
+	return waiter.NewTask(taskId, func(ctx context.Context) (interface{}, waiter.State, error) {
+		result, err := c.GetImageTask(taskId)
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		switch result.Status {
+		case api.ImageTaskStatusSucceed:
+			return result, waiter.StateSuccess, nil
+		case api.ImageTaskStatusFailed:
+			return result, waiter.StateFailure,
+				fmt.Errorf("the image %s task %s failed: %s", result.Type, taskId, result.ErrMsg)
+		}
+		return result, waiter.StateRetry, nil
+	}, nil)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the BEC client
+
+package bec
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/bec/api"
+)
+
+// Interface defines all the operations of the BEC client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateVmImage(args *api.CreateVmImageArgs) (*api.CreateVmImageResult, error)
+	DeleteVmImage(args []string) (*api.VmImageOperateResult, error)
+	UpdateVmImage(imageId string, args *api.UpdateVmImageArgs) (*api.VmImageOperateResult, error)
+	ListVmImage(args *api.ListVmImageArgs) (*api.ListVmImageResult, error)
+	CreateBlb(args *api.CreateBlbArgs) (*api.CreateBlbResult, error)
+	DeleteBlb(blbId string) (*api.DeleteBlbResult, error)
+	GetBlbList(lbType, order, orderBy, keyword, keywordType, status, region string, pageNo, pageSize int) (*api.GetBlbListResult, error)
+	GetBlbDetail(blbId string) (*api.BlbInstanceVo, error)
+	UpdateBlb(blbId string, args *api.UpdateBlbArgs) (*api.UpdateBlbResult, error)
+	CreateBlbMonitorPort(blbId string, args *api.BlbMonitorArgs) (*api.BlbMonitorResult, error)
+	DeleteBlbMonitorPort(blbId string, args *[]api.Port) (*api.BlbMonitorResult, error)
+	GetBlbMonitorPortList(blbId string, pageNo, pageSize int) (*api.BlbMonitorListResult, error)
+	UpdateBlbMonitorPort(blbId string, args *api.BlbMonitorArgs) (*api.BlbMonitorResult, error)
+	GetBlbMonitorPortDetails(blbId string, protocol api.Protocol, port int) (*api.BlbMonitorArgs, error)
+	BatchCreateBlb(args *api.BatchCreateBlbArgs) (*api.BatchCreateBlbResult, error)
+	BatchDeleteBlb(blbIdList []string) (*api.BatchDeleteBlbResult, error)
+	BatchCreateBlbMonitor(blbId string, args *api.BatchCreateBlbMonitorArg) (*api.BatchCreateBlbMonitorResult, error)
+	GetBlbBackendPodList(blbId string, pageNo, pageSize int) (*api.GetBlbBackendPodListResult, error)
+	GetBlbBackendBindingStsList(blbId string, pageNo, pageSize int, keywordType, keyword string) (*api.GetBlbBackendBindingStsListResult, error)
+	GetBlbBindingPodListWithSts(blbId, stsName string) (*[]api.Backends, error)
+	CreateBlbBinding(blbId string, args *api.CreateBlbBindingArgs) (*api.CreateBlbBindingResult, error)
+	DeleteBlbBindPod(blbId string, args *api.DeleteBlbBindPodArgs) (*api.DeleteBlbBindPodResult, error)
+	UpdateBlbBindPodWeight(blbId string, args *api.UpdateBindPodWeightArgs) (*api.UpdateBindPodWeightResult, error)
+	GetBlbMetrics(blbId, ipType, port, serviceProviderStr string, offsetInSeconds int, metricsType api.MetricsType) (*api.ServiceMetricsResult, error)
+	GetBecAvailableNodeInfoVo(getType string) (*api.GetBecAvailableNodeInfoVoResult, error)
+	CreateService(args *api.CreateServiceArgs) (*api.CreateServiceResult, error)
+	ListService(pageNo, pageSize int, keywordType, keyword, order, orderBy, status string) (*api.ListServiceResult, error)
+	GetService(serviceId string) (*api.ServiceDetailsVo, error)
+	ServiceAction(serviceId string, action api.ServiceAction) (*api.ServiceActionResult, error)
+	UpdateService(serviceId string, args *api.UpdateServiceArgs) (*api.UpdateServiceResult, error)
+	DeleteService(serviceId string) (*api.ServiceActionResult, error)
+	GetServiceMetrics(serviceId string, metricsType api.MetricsType, serviceProviderStr api.ServiceProvider, offsetInSeconds int) (*api.ServiceMetricsResult, error)
+	ServiceBatchOperate(args *api.ServiceBatchOperateArgs) (*api.ServiceBatchOperateResult, error)
+	ServiceBatchDelete(args *[]string) (*api.ServiceBatchOperateResult, error)
+	CreateVmServiceInstance(serviceId string, args *api.CreateVmServiceArgs) (*api.CreateVmServiceResult, error)
+	CreateVmService(args *api.CreateVmServiceArgs) (*api.CreateVmServiceResult, error)
+	UpdateVmService(serviceId string, args *api.UpdateVmServiceArgs) (*api.UpdateVmServiceResult, error)
+	GetVmServiceList(args *api.ListVmServiceArgs) (*api.ListVmServiceResult, error)
+	GetVmServiceDetail(serviceId string) (*api.VmServiceDetailsVo, error)
+	GetVmServiceMetrics(serviceId string, metricType api.MetricsType, offsetInSeconds int, serviceProviderStr api.ServiceProvider) (*api.ServiceMetricsResult, error)
+	VmServiceAction(serviceId string, action api.VmServiceAction) (*api.VmServiceActionResult, error)
+	DeleteVmService(serviceId string) (*api.VmServiceActionResult, error)
+	BatchDeleteVmService(serviceIds *[]string) (*api.VmServiceBatchActionResult, error)
+	BatchOperateVmService(args *api.VmServiceBatchActionArgs) (*api.VmServiceBatchActionResult, error)
+	GetVmInstanceList(args *api.ListRequest) (*api.LogicPageVmInstanceResult, error)
+	GetNodeVmInstanceList(args *api.ListRequest, region, serviceProvider, city string) (*api.GetNodeVmInstanceListResult, error)
+	GetVirtualMachine(vmID string) (*api.VmInstanceDetailsVo, error)
+	DeleteVmInstance(vmID string) (*api.ActionInfoVo, error)
+	UpdateVmDeployment(vmID string, args *api.UpdateVmDeploymentArgs) (*api.UpdateVmDeploymentResult, error)
+	ReinstallVmInstance(vmID string, args *api.ReinstallVmInstanceArg) (*api.ReinstallVmInstanceResult, error)
+	OperateVmDeployment(vmID string, action api.VmInstanceBatchOperateAction) (*api.OperateVmDeploymentResult, error)
+	GetVmInstanceMetrics(vmID string, serviceProvider api.ServiceProvider, offsetInSeconds int, metricsType api.MetricsType) (*api.ServiceMetricsResult, error)
+	GetVmConfig(vmID string) (*api.VmConfigResult, error)
+	CreateVmPrivateIp(vmID string, args *api.CreateVmPrivateIpForm) (*api.VmPrivateIpResult, error)
+	DeleteVmPrivateIp(vmID string, args *api.DeleteVmPrivateIpForm) (*api.VmPrivateIpResult, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
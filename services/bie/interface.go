@@ -0,0 +1,86 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the BIE client
+
+package bie
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/bie/api"
+)
+
+// Interface defines all the operations of the BIE client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	ListGroup(lgr *api.ListGroupReq) (*api.ListGroupResult, error)
+	GetGroup(groupUuid string) (*api.Group, error)
+	CreateGroup(cgr *api.CreateGroupReq) (*api.CreateGroupResult, error)
+	EditGroup(groupid string, egr *api.EditGroupReq) (*api.Group, error)
+	DeleteGroup(groupUuid string) error
+	ListCore(groupUuid string) (*api.ListCoreResult, error)
+	GetCore(groupUuid string, coreid string) (*api.CoreResult, error)
+	RenewCoreAuth(coreid string) (*api.CoreInfo, error)
+	GetCoreStatus(coreid string) (*api.CoreStatus, error)
+	ListConfig(coreid string, lcr *api.ListConfigReq) (*api.ListConfigResult, error)
+	GetConfig(coreid string, ver string) (*api.CfgResult, error)
+	PubConfig(cpr *api.CoreidVersion, cpb *api.CfgPubBody) (*api.CfgResult, error)
+	DeployConfig(coreid string, ver string) error
+	DownloadConfig(cdr *api.CfgDownloadReq) (*api.CfgDownloadResult, error)
+	CreateService(cv *api.CoreidVersion, sr *api.CreateServiceReq) (*api.ServiceResult, error)
+	GetService(ivn *api.IdVerName) (*api.ServiceResult, error)
+	EditService(ivn *api.IdVerName, esr *api.EditServiceReq) (*api.ServiceResult, error)
+	DeleteService(ivn *api.IdVerName) error
+	ReorderService(ivn *api.IdVerName, after string) error
+	VolumeOp(cv *api.CoreidVersion, vor *api.VolumeOpReq) error
+	ListVolumeTpl() (*api.ListVolTemplate, error)
+	CreateVolume(cvr *api.CreateVolReq) (*api.VolumeResult, error)
+	ListVolume(lvr *api.ListVolumeReq) (*api.ListVolumeResult, error)
+	GetVolume(name string) (*api.VolumeResult, error)
+	EditVolume(name string, evr *api.EditVolumeReq) (*api.VolumeResult, error)
+	DeleteVolume(name string) error
+	ListVolumeVer(nv *api.NameVersion) (*api.ListVolumeVerResult, error)
+	PubVolumeVer(name string) (*api.VolumeResult, error)
+	DownloadVolVer(name string, version string) (*api.VolDownloadResult, error)
+	CreateVolFile(name string, cvf *api.CreateVolFileReq) (*api.CreateVolFileReq, error)
+	GetVolumeFile(cvfr *api.GetVolFileReq) (*api.CreateVolFileReq, error)
+	EditVolumeFile(names *api.Name2, body *api.EditVolFileReq) (*api.CreateVolFileReq, error)
+	DeleteVolFile(name string, filename string) error
+	ClearVolFile(name string) error
+	ListVolCore(lvcr *api.ListVolCoreReq) (*api.ListVolCoreResult, error)
+	EditCoreVolVer(name string, ecvr *api.EditCoreVolVerReq) error
+	ImportCfc(name string, icr *api.ImportCfcReq) error
+	ImportBos(name string, ibr *api.ImportBosReq) error
+	ListImageSys(lir *api.ListImageReq) (*api.ListImageResult, error)
+	GetImageSys(uuid string) (*api.Image, error)
+	ListImageUser(lir *api.ListImageReq) (*api.ListImageResult, error)
+	GetImageUser(uuid string) (*api.Image, error)
+	CreateImageUser(cir *api.CreateImageReq) (*api.Image, error)
+	EditImageUser(uuid string, eir *api.EditImageReq) (*api.Image, error)
+	DeleteImageUser(uuid string) error
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// billing.go - the bill APIs definition supported by the BILLING service
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+// GetProductMonthBill - get a page of the costs of the services in the month
+//
+// PARAMS:
+//     - args: the month, the filters and the page of the bill
+// RETURNS:
+//     - *ProductMonthBillResult: the page of the bill
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetProductMonthBill(args *ProductMonthBillArgs) (*ProductMonthBillResult, error) {
+	if args == nil {
+		return nil, errors.New("args should not be nil")
+	}
+	if err := checkMonth(args.Month); err != nil {
+		return nil, err
+	}
+	pageNo, pageSize := normalizePage(args.PageNo, args.PageSize)
+	result := &ProductMonthBillResult{}
+
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getProductMonthBillUri()).
+		WithQueryParam("month", args.Month).
+		WithQueryParamFilter("productType", args.ProductType).
+		WithQueryParamFilter("serviceType", args.ServiceType).
+		WithQueryParamFilter("queryAccountId", args.QueryAccountId).
+		WithQueryParam("pageNo", strconv.Itoa(pageNo)).
+		WithQueryParam("pageSize", strconv.Itoa(pageSize)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// ListAllProductMonthBills - get the costs of the services in the month of all pages
+//
+// PARAMS:
+//     - args: the month and the filters of the bill, the PageNo is ignored
+// RETURNS:
+//     - []ProductBill: the costs of the services
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListAllProductMonthBills(args *ProductMonthBillArgs) ([]ProductBill, error) {
+	if args == nil {
+		return nil, errors.New("args should not be nil")
+	}
+	pageArgs := *args
+	bills := make([]ProductBill, 0)
+	for pageArgs.PageNo = 1; ; pageArgs.PageNo++ {
+		result, err := c.GetProductMonthBill(&pageArgs)
+		if err != nil {
+			return nil, err
+		}
+		bills = append(bills, result.Bills...)
+		if len(result.Bills) == 0 || len(bills) >= result.TotalCount {
+			return bills, nil
+		}
+	}
+}
+
+// GetResourceMonthBill - get a page of the costs and the usages of the resources in the month,
+// each bill is a charge item of a resource
+//
+// PARAMS:
+//     - args: the month, the filters and the page of the bill
+// RETURNS:
+//     - *ResourceMonthBillResult: the page of the bill
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetResourceMonthBill(args *ResourceMonthBillArgs) (*ResourceMonthBillResult, error) {
+	if args == nil {
+		return nil, errors.New("args should not be nil")
+	}
+	if err := checkMonth(args.Month); err != nil {
+		return nil, err
+	}
+	pageNo, pageSize := normalizePage(args.PageNo, args.PageSize)
+	result := &ResourceMonthBillResult{}
+
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getResourceMonthBillUri()).
+		WithQueryParam("month", args.Month).
+		WithQueryParamFilter("productType", args.ProductType).
+		WithQueryParamFilter("serviceType", args.ServiceType).
+		WithQueryParamFilter("instanceId", args.InstanceId).
+		WithQueryParamFilter("queryAccountId", args.QueryAccountId).
+		WithQueryParam("pageNo", strconv.Itoa(pageNo)).
+		WithQueryParam("pageSize", strconv.Itoa(pageSize)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// ListAllResourceMonthBills - get the costs and the usages of the resources in the month of all
+// pages
+//
+// PARAMS:
+//     - args: the month and the filters of the bill, the PageNo is ignored
+// RETURNS:
+//     - []ResourceBill: the charge items of the resources
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListAllResourceMonthBills(args *ResourceMonthBillArgs) ([]ResourceBill, error) {
+	if args == nil {
+		return nil, errors.New("args should not be nil")
+	}
+	pageArgs := *args
+	bills := make([]ResourceBill, 0)
+	for pageArgs.PageNo = 1; ; pageArgs.PageNo++ {
+		result, err := c.GetResourceMonthBill(&pageArgs)
+		if err != nil {
+			return nil, err
+		}
+		bills = append(bills, result.Bills...)
+		if len(result.Bills) == 0 || len(bills) >= result.TotalCount {
+			return bills, nil
+		}
+	}
+}
+
+// GetCostBreakdown - get the costs of the resources in the month grouped by the resources, the
+// charge items of each resource are kept with their usages
+//
+// PARAMS:
+//     - args: the month and the filters of the bill, the PageNo is ignored
+// RETURNS:
+//     - *CostBreakdown: the costs of the resources ordered by the cost descending
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetCostBreakdown(args *ResourceMonthBillArgs) (*CostBreakdown, error) {
+	bills, err := c.ListAllResourceMonthBills(args)
+	if err != nil {
+		return nil, err
+	}
+	return NewCostBreakdown(args.Month, bills), nil
+}
+
+// NewCostBreakdown - group the resource bills by the resources
+//
+// PARAMS:
+//     - month: the month of the bills
+//     - bills: the resource bills
+// RETURNS:
+//     - *CostBreakdown: the costs of the resources ordered by the cost descending
+func NewCostBreakdown(month string, bills []ResourceBill) *CostBreakdown {
+	breakdown := &CostBreakdown{Month: month, Resources: make([]ResourceCost, 0)}
+	index := make(map[string]int)
+	for _, bill := range bills {
+		key := bill.ServiceType + "/" + bill.Region + "/" + bill.InstanceId
+		i, ok := index[key]
+		if !ok {
+			i = len(breakdown.Resources)
+			index[key] = i
+			breakdown.Resources = append(breakdown.Resources, ResourceCost{
+				InstanceId:  bill.InstanceId,
+				ServiceType: bill.ServiceType,
+				ProductType: bill.ProductType,
+				Region:      bill.Region,
+			})
+		}
+		resource := &breakdown.Resources[i]
+		resource.FinancePrice += bill.FinancePrice
+		resource.OriginPrice += bill.OriginPrice
+		resource.Items = append(resource.Items, ChargeItemCost{
+			ChargeItem:     bill.ChargeItem,
+			ChargeItemDesc: bill.ChargeItemDesc,
+			Amount:         bill.Amount,
+			AmountUnit:     bill.AmountUnit,
+			FinancePrice:   bill.FinancePrice,
+		})
+		breakdown.FinancePrice += bill.FinancePrice
+		breakdown.OriginPrice += bill.OriginPrice
+	}
+	sort.SliceStable(breakdown.Resources, func(i, j int) bool {
+		return breakdown.Resources[i].FinancePrice > breakdown.Resources[j].FinancePrice
+	})
+	return breakdown
+}
+
+// ExportBill - export the bill of the month to the BOS bucket asynchronously
+//
+// PARAMS:
+//     - args: the month, the type of the bill and the destination
+// RETURNS:
+//     - *ExportBillResult: the id of the export task
+//     - error: nil if success otherwise the specific error
+func (c *Client) ExportBill(args *ExportBillArgs) (*ExportBillResult, error) {
+	if args == nil {
+		return nil, errors.New("args should not be nil")
+	}
+	if err := checkMonth(args.Month); err != nil {
+		return nil, err
+	}
+	if args.BillType != BILL_TYPE_PRODUCT && args.BillType != BILL_TYPE_RESOURCE {
+		return nil, fmt.Errorf("invalid billType %q", args.BillType)
+	}
+	if len(args.Bucket) == 0 {
+		return nil, errors.New("bucket should not be empty")
+	}
+	result := &ExportBillResult{}
+
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getExportUri()).
+		WithClientToken(args.ClientToken).
+		WithBody(args).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// GetExportTask - get the status of the export task
+//
+// PARAMS:
+//     - exportId: the id of the export task
+// RETURNS:
+//     - *ExportTask: the export task, the exported file is the Object of the Bucket if succeeded
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetExportTask(exportId string) (*ExportTask, error) {
+	if len(exportId) == 0 {
+		return nil, errors.New("exportId should not be empty")
+	}
+	result := &ExportTask{}
+
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getExportUriWithId(exportId)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+func checkMonth(month string) error {
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return fmt.Errorf("invalid month %q, should be in the format of yyyy-MM", month)
+	}
+	return nil
+}
+
+func normalizePage(pageNo, pageSize int) (int, int) {
+	if pageNo <= 0 {
+		pageNo = 1
+	}
+	if pageSize <= 0 || pageSize > MAX_PAGE_SIZE {
+		pageSize = MAX_PAGE_SIZE
+	}
+	return pageNo, pageSize
+}
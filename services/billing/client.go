@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// client.go - define the client for BILLING service
+
+// Package billing defines the Billing services of BCE, which queries the bills of the products
+// and the resources, and exports the bills to BOS.
+//
+// The package is experimental: the request paths and the field names are not verified against
+// the published Billing OpenAPI yet, and they may change in the following releases.
+package billing
+
+import "github.com/baidubce/bce-sdk-go/bce"
+
+const (
+	URI_PREFIX = bce.URI_PREFIX + "v1"
+
+	DEFAULT_ENDPOINT = "billing.baidubce.com"
+
+	BASE_BILL_URL = "/bill"
+
+	PRODUCT_MONTH_BILL_URL = "/product/month"
+
+	RESOURCE_MONTH_BILL_URL = "/resource/month"
+
+	EXPORT_URL = "/export"
+)
+
+// Client of BILLING service is a kind of BceClient, so derived from BceClient
+type Client struct {
+	*bce.BceClient
+}
+
+func NewClient(ak, sk, endPoint string) (*Client, error) {
+	if len(endPoint) == 0 {
+		endPoint = DEFAULT_ENDPOINT
+	}
+	client, err := bce.NewBceClientWithAkSk(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{client}, nil
+}
+
+func getBillUri() string {
+	return URI_PREFIX + BASE_BILL_URL
+}
+
+func getProductMonthBillUri() string {
+	return getBillUri() + PRODUCT_MONTH_BILL_URL
+}
+
+func getResourceMonthBillUri() string {
+	return getBillUri() + RESOURCE_MONTH_BILL_URL
+}
+
+func getExportUri() string {
+	return getBillUri() + EXPORT_URL
+}
+
+func getExportUriWithId(id string) string {
+	return getExportUri() + bce.URI_PREFIX + id
+}
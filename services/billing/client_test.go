@@ -0,0 +1,101 @@
+package billing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+var (
+	BILLING_CLIENT *Client
+)
+
+// For security reason, ak/sk should not hard write here.
+type Conf struct {
+	AK       string
+	SK       string
+	Endpoint string
+}
+
+func init() {
+	_, f, _, _ := runtime.Caller(0)
+	conf := filepath.Join(filepath.Dir(f), "config.json")
+	fp, err := os.Open(conf)
+	if err != nil {
+		log.Fatal("config json file of ak/sk not given:", conf)
+		os.Exit(1)
+	}
+	decoder := json.NewDecoder(fp)
+	confObj := &Conf{}
+	decoder.Decode(confObj)
+
+	BILLING_CLIENT, _ = NewClient(confObj.AK, confObj.SK, confObj.Endpoint)
+	log.SetLogLevel(log.WARN)
+}
+
+func lastMonth() string {
+	return time.Now().AddDate(0, -1, 0).Format("2006-01")
+}
+
+func TestClient_GetProductMonthBill(t *testing.T) {
+	args := &ProductMonthBillArgs{
+		Month:       lastMonth(),
+		ProductType: PRODUCT_TYPE_POSTPAY,
+	}
+	result, err := BILLING_CLIENT.GetProductMonthBill(args)
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		r, _ := json.Marshal(result)
+		fmt.Println(string(r))
+	}
+}
+
+func TestClient_GetCostBreakdown(t *testing.T) {
+	args := &ResourceMonthBillArgs{
+		Month:       lastMonth(),
+		ServiceType: "BCC",
+	}
+	result, err := BILLING_CLIENT.GetCostBreakdown(args)
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		r, _ := json.Marshal(result)
+		fmt.Println(string(r))
+	}
+}
+
+func TestNewCostBreakdown(t *testing.T) {
+	bills := []ResourceBill{
+		{ServiceType: "BCC", Region: "bj", InstanceId: "i-1", ChargeItem: "cpu", FinancePrice: 1},
+		{ServiceType: "CDS", Region: "bj", InstanceId: "v-1", ChargeItem: "size", FinancePrice: 5},
+		{ServiceType: "BCC", Region: "bj", InstanceId: "i-1", ChargeItem: "memory", FinancePrice: 2},
+	}
+	breakdown := NewCostBreakdown("2023-01", bills)
+	if len(breakdown.Resources) != 2 || breakdown.FinancePrice != 8 {
+		t.Fatalf("unexpected breakdown: %+v", breakdown)
+	}
+	if breakdown.Resources[0].InstanceId != "v-1" || breakdown.Resources[1].FinancePrice != 3 ||
+		len(breakdown.Resources[1].Items) != 2 {
+		t.Fatalf("unexpected resources: %+v", breakdown.Resources)
+	}
+}
+
+func TestExportBillCheck(t *testing.T) {
+	_, err := BILLING_CLIENT.ExportBill(&ExportBillArgs{Month: "2023/01", BillType: BILL_TYPE_RESOURCE,
+		Bucket: "bucket"})
+	if err == nil {
+		t.Fatal("invalid month should be rejected")
+	}
+	_, err = BILLING_CLIENT.ExportBill(&ExportBillArgs{Month: "2023-01", BillType: "day",
+		Bucket: "bucket"})
+	if err == nil {
+		t.Fatal("invalid bill type should be rejected")
+	}
+}
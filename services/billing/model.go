@@ -0,0 +1,173 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// model.go - definitions of the request arguments and results data structure model
+package billing
+
+const (
+	PRODUCT_TYPE_PREPAY  = "prepay"
+	PRODUCT_TYPE_POSTPAY = "postpay"
+
+	BILL_TYPE_PRODUCT  = "product"
+	BILL_TYPE_RESOURCE = "resource"
+
+	EXPORT_STATUS_RUNNING   = "RUNNING"
+	EXPORT_STATUS_SUCCEEDED = "SUCCEEDED"
+	EXPORT_STATUS_FAILED    = "FAILED"
+
+	// MAX_PAGE_SIZE is the max number of the bills in a page, which is also the default
+	MAX_PAGE_SIZE = 100
+)
+
+type ProductMonthBillArgs struct {
+	Month          string // the month of the bill in the format of yyyy-MM
+	ProductType    string // PRODUCT_TYPE_PREPAY or PRODUCT_TYPE_POSTPAY, empty for both
+	ServiceType    string // the service such as BCC, empty for all services
+	QueryAccountId string // the member account of the finance group, empty for the caller
+	PageNo         int
+	PageSize       int
+}
+
+type ProductMonthBillResult struct {
+	BillMonth  string        `json:"billMonth"`
+	AccountId  string        `json:"accountId"`
+	LoginName  string        `json:"loginName"`
+	PageNo     int           `json:"pageNo"`
+	PageSize   int           `json:"pageSize"`
+	TotalCount int           `json:"totalCount"`
+	Bills      []ProductBill `json:"bills"`
+}
+
+// ProductBill defines the cost of a service in the month.
+type ProductBill struct {
+	ServiceType     string  `json:"serviceType"`
+	ServiceTypeName string  `json:"serviceTypeName"`
+	ProductType     string  `json:"productType"`
+	Region          string  `json:"region"`
+	OriginPrice     float64 `json:"originPrice"`
+	FinancePrice    float64 `json:"financePrice"`
+	Cash            float64 `json:"cash"`
+	Rebate          float64 `json:"rebate"`
+	CreditCost      float64 `json:"creditCost"`
+	CouponPrice     float64 `json:"couponPrice"`
+	DiscountPrice   float64 `json:"discountPrice"`
+	DebtPrice       float64 `json:"debtPrice"`
+}
+
+type ResourceMonthBillArgs struct {
+	Month          string // the month of the bill in the format of yyyy-MM
+	ProductType    string // PRODUCT_TYPE_PREPAY or PRODUCT_TYPE_POSTPAY, empty for both
+	ServiceType    string // the service such as BCC, empty for all services
+	InstanceId     string // the resource, empty for all resources
+	QueryAccountId string // the member account of the finance group, empty for the caller
+	PageNo         int
+	PageSize       int
+}
+
+type ResourceMonthBillResult struct {
+	BillMonth  string         `json:"billMonth"`
+	AccountId  string         `json:"accountId"`
+	LoginName  string         `json:"loginName"`
+	PageNo     int            `json:"pageNo"`
+	PageSize   int            `json:"pageSize"`
+	TotalCount int            `json:"totalCount"`
+	Bills      []ResourceBill `json:"bills"`
+}
+
+// ResourceBill defines the cost and the usage of a charge item of a resource in the month.
+type ResourceBill struct {
+	ServiceType     string  `json:"serviceType"`
+	ServiceTypeName string  `json:"serviceTypeName"`
+	ProductType     string  `json:"productType"`
+	Region          string  `json:"region"`
+	InstanceId      string  `json:"instanceId"`
+	ShortId         string  `json:"shortId"`
+	Configuration   string  `json:"configuration"`
+	ChargeItem      string  `json:"chargeItem"`
+	ChargeItemDesc  string  `json:"chargeItemDesc"`
+	Amount          string  `json:"amount"`
+	AmountUnit      string  `json:"amountUnit"`
+	UnitPrice       string  `json:"unitPrice"`
+	PricingUnit     string  `json:"pricingUnit"`
+	StartTime       string  `json:"startTime"`
+	EndTime         string  `json:"endTime"`
+	Tag             string  `json:"tag"`
+	OriginPrice     float64 `json:"originPrice"`
+	FinancePrice    float64 `json:"financePrice"`
+	Cash            float64 `json:"cash"`
+	Rebate          float64 `json:"rebate"`
+	CreditCost      float64 `json:"creditCost"`
+	CouponPrice     float64 `json:"couponPrice"`
+	DiscountPrice   float64 `json:"discountPrice"`
+	DebtPrice       float64 `json:"debtPrice"`
+}
+
+// ChargeItemCost defines the cost and the usage of a charge item of the resource.
+type ChargeItemCost struct {
+	ChargeItem     string
+	ChargeItemDesc string
+	Amount         string
+	AmountUnit     string
+	FinancePrice   float64
+}
+
+// ResourceCost defines the total cost of a resource in the month and its charge items.
+type ResourceCost struct {
+	InstanceId   string
+	ServiceType  string
+	ProductType  string
+	Region       string
+	FinancePrice float64
+	OriginPrice  float64
+	Items        []ChargeItemCost
+}
+
+// CostBreakdown defines the costs of the resources in the month, ordered by the cost descending.
+type CostBreakdown struct {
+	Month        string
+	FinancePrice float64
+	OriginPrice  float64
+	Resources    []ResourceCost
+}
+
+type ExportBillArgs struct {
+	Month       string `json:"month"`
+	BillType    string `json:"billType"` // BILL_TYPE_PRODUCT or BILL_TYPE_RESOURCE
+	ServiceType string `json:"serviceType,omitempty"`
+	Bucket      string `json:"bucket"`           // the BOS bucket to store the exported file
+	Object      string `json:"object,omitempty"` // the object name, generated by the service if empty
+	ClientToken string `json:"-"`
+}
+
+type ExportBillResult struct {
+	ExportId string `json:"exportId"`
+}
+
+// ExportTask defines the task exporting the bill to BOS.
+type ExportTask struct {
+	ExportId   string `json:"exportId"`
+	Month      string `json:"month"`
+	BillType   string `json:"billType"`
+	Status     string `json:"status"`
+	Bucket     string `json:"bucket"`
+	Object     string `json:"object"`
+	Message    string `json:"message"`
+	CreateTime string `json:"createTime"`
+	FinishTime string `json:"finishTime"`
+}
+
+// IsFinished - check whether the export task is succeeded or failed
+func (t *ExportTask) IsFinished() bool {
+	return t.Status == EXPORT_STATUS_SUCCEEDED || t.Status == EXPORT_STATUS_FAILED
+}
@@ -1,6 +1,7 @@
 package blb
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -116,6 +117,14 @@ func TestClient_DescribeLoadBalancerDetail(t *testing.T) {
 	ExpectEqual(t.Errorf, nil, err)
 }
 
+func TestClient_WaitLoadBalancerStatus(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	result, err := BLB_CLIENT.WaitLoadBalancerStatus(ctx, BLB_ID, BLBStatusAvailable)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, BLBStatusAvailable, result.Status)
+}
+
 func TestClient_CreateTCPListener(t *testing.T) {
 	createArgs := &CreateTCPListenerArgs{
 		ClientToken:  getClientToken(),
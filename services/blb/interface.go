@@ -0,0 +1,71 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the BLB client
+
+package blb
+
+import (
+	"context"
+)
+
+// Interface defines all the operations of the BLB client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	AddBackendServers(blbId string, args *AddBackendServersArgs) error
+	UpdateBackendServers(blbId string, args *UpdateBackendServersArgs) error
+	DescribeBackendServers(blbId string, args *DescribeBackendServersArgs) (*DescribeBackendServersResult, error)
+	DescribeHealthStatus(blbId string, args *DescribeHealthStatusArgs) (*DescribeHealthStatusResult, error)
+	RemoveBackendServers(blbId string, args *RemoveBackendServersArgs) error
+	CreateLoadBalancer(args *CreateLoadBalancerArgs) (*CreateLoadBalancerResult, error)
+	UpdateLoadBalancer(blbId string, args *UpdateLoadBalancerArgs) error
+	DescribeLoadBalancers(args *DescribeLoadBalancersArgs) (*DescribeLoadBalancersResult, error)
+	DescribeLoadBalancerDetail(blbId string) (*DescribeLoadBalancerDetailResult, error)
+	WaitLoadBalancerStatus(ctx context.Context, blbId string, status BLBStatus) (*DescribeLoadBalancerDetailResult, error)
+	DeleteLoadBalancer(blbId string) error
+	DescribeLbClusterDetail(clusterId string) (*DescribeLbClusterDetailResult, error)
+	DescribeLbClusters(args *DescribeLbClustersArgs) (*DescribeLbClustersResult, error)
+	CreateTCPListener(blbId string, args *CreateTCPListenerArgs) error
+	CreateUDPListener(blbId string, args *CreateUDPListenerArgs) error
+	CreateHTTPListener(blbId string, args *CreateHTTPListenerArgs) error
+	CreateHTTPSListener(blbId string, args *CreateHTTPSListenerArgs) error
+	CreateSSLListener(blbId string, args *CreateSSLListenerArgs) error
+	UpdateTCPListener(blbId string, args *UpdateTCPListenerArgs) error
+	UpdateUDPListener(blbId string, args *UpdateUDPListenerArgs) error
+	UpdateHTTPListener(blbId string, args *UpdateHTTPListenerArgs) error
+	UpdateHTTPSListener(blbId string, args *UpdateHTTPSListenerArgs) error
+	UpdateSSLListener(blbId string, args *UpdateSSLListenerArgs) error
+	DescribeTCPListeners(blbId string, args *DescribeListenerArgs) (*DescribeTCPListenersResult, error)
+	DescribeUDPListeners(blbId string, args *DescribeListenerArgs) (*DescribeUDPListenersResult, error)
+	DescribeHTTPListeners(blbId string, args *DescribeListenerArgs) (*DescribeHTTPListenersResult, error)
+	DescribeHTTPSListeners(blbId string, args *DescribeListenerArgs) (*DescribeHTTPSListenersResult, error)
+	DescribeSSLListeners(blbId string, args *DescribeListenerArgs) (*DescribeSSLListenersResult, error)
+	DescribeAllListeners(blbId string, args *DescribeListenerArgs) (*DescribeAllListenersResult, error)
+	DeleteListeners(blbId string, args *DeleteListenersArgs) error
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
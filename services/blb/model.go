@@ -165,49 +165,58 @@ type CreateHTTPListenerArgs struct {
 	RedirectPort               uint16 `json:"redirectPort,omitempty"`
 }
 
+// AdditionalCertDomain binds the certificate to the host name of the HTTPS or SSL listener, which
+// is selected by the SNI of the client, the CertIds are used for the other host names
+type AdditionalCertDomain struct {
+	CertId string `json:"certId"`
+	Host   string `json:"host"`
+}
+
 type CreateHTTPSListenerArgs struct {
-	ClientToken                string   `json:"-"`
-	ListenerPort               uint16   `json:"listenerPort"`
-	BackendPort                uint16   `json:"backendPort"`
-	Scheduler                  string   `json:"scheduler"`
-	CertIds                    []string `json:"certIds"`
-	KeepSession                bool     `json:"keepSession,omitempty"`
-	KeepSessionType            string   `json:"keepSessionType,omitempty"`
-	KeepSessionDuration        int      `json:"keepSessionDuration,omitempty"`
-	KeepSessionCookieName      string   `json:"keepSessionCookieName,omitempty"`
-	XForwardedFor              bool     `json:"xForwardedFor,omitempty"`
-	HealthCheckType            string   `json:"healthCheckType,omitempty"`
-	HealthCheckPort            uint16   `json:"healthCheckPort,omitempty"`
-	HealthCheckURI             string   `json:"healthCheckURI,omitempty"`
-	HealthCheckTimeoutInSecond int      `json:"healthCheckTimeoutInSecond,omitempty"`
-	HealthCheckInterval        int      `json:"healthCheckInterval,omitempty"`
-	UnhealthyThreshold         int      `json:"unhealthyThreshold,omitempty"`
-	HealthyThreshold           int      `json:"healthyThreshold,omitempty"`
-	HealthCheckNormalStatus    string   `json:"healthCheckNormalStatus,omitempty"`
-	ServerTimeout              int      `json:"serverTimeout,omitempty"`
-	RedirectPort               uint16   `json:"redirectPort,omitempty"`
-	EncryptionType             string   `json:"encryptionType,omitempty"`
-	EncryptionProtocols        []string `json:"encryptionProtocols,omitempty"`
-	AppliedCiphers             string   `json:"appliedCiphers,omitempty"`
-	DualAuth                   bool     `json:"dualAuth,omitempty"`
-	ClientCertIds              []string `json:"clientCertIds,omitempty"`
+	ClientToken                string                 `json:"-"`
+	ListenerPort               uint16                 `json:"listenerPort"`
+	BackendPort                uint16                 `json:"backendPort"`
+	Scheduler                  string                 `json:"scheduler"`
+	CertIds                    []string               `json:"certIds"`
+	AdditionalCertDomains      []AdditionalCertDomain `json:"additionalCertDomains,omitempty"`
+	KeepSession                bool                   `json:"keepSession,omitempty"`
+	KeepSessionType            string                 `json:"keepSessionType,omitempty"`
+	KeepSessionDuration        int                    `json:"keepSessionDuration,omitempty"`
+	KeepSessionCookieName      string                 `json:"keepSessionCookieName,omitempty"`
+	XForwardedFor              bool                   `json:"xForwardedFor,omitempty"`
+	HealthCheckType            string                 `json:"healthCheckType,omitempty"`
+	HealthCheckPort            uint16                 `json:"healthCheckPort,omitempty"`
+	HealthCheckURI             string                 `json:"healthCheckURI,omitempty"`
+	HealthCheckTimeoutInSecond int                    `json:"healthCheckTimeoutInSecond,omitempty"`
+	HealthCheckInterval        int                    `json:"healthCheckInterval,omitempty"`
+	UnhealthyThreshold         int                    `json:"unhealthyThreshold,omitempty"`
+	HealthyThreshold           int                    `json:"healthyThreshold,omitempty"`
+	HealthCheckNormalStatus    string                 `json:"healthCheckNormalStatus,omitempty"`
+	ServerTimeout              int                    `json:"serverTimeout,omitempty"`
+	RedirectPort               uint16                 `json:"redirectPort,omitempty"`
+	EncryptionType             string                 `json:"encryptionType,omitempty"`
+	EncryptionProtocols        []string               `json:"encryptionProtocols,omitempty"`
+	AppliedCiphers             string                 `json:"appliedCiphers,omitempty"`
+	DualAuth                   bool                   `json:"dualAuth,omitempty"`
+	ClientCertIds              []string               `json:"clientCertIds,omitempty"`
 }
 
 type CreateSSLListenerArgs struct {
-	ClientToken                string   `json:"-"`
-	ListenerPort               uint16   `json:"listenerPort"`
-	BackendPort                uint16   `json:"backendPort"`
-	Scheduler                  string   `json:"scheduler"`
-	CertIds                    []string `json:"certIds"`
-	HealthCheckTimeoutInSecond int      `json:"healthCheckTimeoutInSecond,omitempty"`
-	HealthCheckInterval        int      `json:"healthCheckInterval,omitempty"`
-	UnhealthyThreshold         int      `json:"unhealthyThreshold,omitempty"`
-	HealthyThreshold           int      `json:"healthyThreshold,omitempty"`
-	EncryptionType             string   `json:"encryptionType,omitempty"`
-	EncryptionProtocols        []string `json:"encryptionProtocols,omitempty"`
-	AppliedCiphers             string   `json:"appliedCiphers,omitempty"`
-	DualAuth                   bool     `json:"dualAuth,omitempty"`
-	ClientCertIds              []string `json:"clientCertIds,omitempty"`
+	ClientToken                string                 `json:"-"`
+	ListenerPort               uint16                 `json:"listenerPort"`
+	BackendPort                uint16                 `json:"backendPort"`
+	Scheduler                  string                 `json:"scheduler"`
+	CertIds                    []string               `json:"certIds"`
+	AdditionalCertDomains      []AdditionalCertDomain `json:"additionalCertDomains,omitempty"`
+	HealthCheckTimeoutInSecond int                    `json:"healthCheckTimeoutInSecond,omitempty"`
+	HealthCheckInterval        int                    `json:"healthCheckInterval,omitempty"`
+	UnhealthyThreshold         int                    `json:"unhealthyThreshold,omitempty"`
+	HealthyThreshold           int                    `json:"healthyThreshold,omitempty"`
+	EncryptionType             string                 `json:"encryptionType,omitempty"`
+	EncryptionProtocols        []string               `json:"encryptionProtocols,omitempty"`
+	AppliedCiphers             string                 `json:"appliedCiphers,omitempty"`
+	DualAuth                   bool                   `json:"dualAuth,omitempty"`
+	ClientCertIds              []string               `json:"clientCertIds,omitempty"`
 }
 
 type UpdateListenerArgs struct {
@@ -265,45 +274,47 @@ type UpdateHTTPListenerArgs struct {
 }
 
 type UpdateHTTPSListenerArgs struct {
-	ClientToken                string   `json:"-"`
-	ListenerPort               uint16   `json:"listenerPort"`
-	BackendPort                uint16   `json:"backendPort,omitempty"`
-	Scheduler                  string   `json:"scheduler,omitempty"`
-	KeepSession                bool     `json:"keepSession,omitempty"`
-	KeepSessionType            string   `json:"keepSessionType,omitempty"`
-	KeepSessionDuration        int      `json:"keepSessionDuration,omitempty"`
-	KeepSessionCookieName      string   `json:"keepSessionCookieName,omitempty"`
-	XForwardedFor              bool     `json:"xForwardedFor,omitempty"`
-	HealthCheckType            string   `json:"healthCheckType,omitempty"`
-	HealthCheckPort            uint16   `json:"healthCheckPort,omitempty"`
-	HealthCheckURI             string   `json:"healthCheckURI,omitempty"`
-	HealthCheckTimeoutInSecond int      `json:"healthCheckTimeoutInSecond,omitempty"`
-	HealthCheckInterval        int      `json:"healthCheckInterval,omitempty"`
-	UnhealthyThreshold         int      `json:"unhealthyThreshold,omitempty"`
-	HealthyThreshold           int      `json:"healthyThreshold,omitempty"`
-	HealthCheckNormalStatus    string   `json:"healthCheckNormalStatus,omitempty"`
-	ServerTimeout              int      `json:"serverTimeout,omitempty"`
-	CertIds                    []string `json:"certIds,omitempty"`
-	EncryptionType             string   `json:"encryptionType,omitempty"`
-	EncryptionProtocols        []string `json:"encryptionProtocols,omitempty"`
-	AppliedCiphers             string   `json:"appliedCiphers,omitempty"`
+	ClientToken                string                 `json:"-"`
+	ListenerPort               uint16                 `json:"listenerPort"`
+	BackendPort                uint16                 `json:"backendPort,omitempty"`
+	Scheduler                  string                 `json:"scheduler,omitempty"`
+	KeepSession                bool                   `json:"keepSession,omitempty"`
+	KeepSessionType            string                 `json:"keepSessionType,omitempty"`
+	KeepSessionDuration        int                    `json:"keepSessionDuration,omitempty"`
+	KeepSessionCookieName      string                 `json:"keepSessionCookieName,omitempty"`
+	XForwardedFor              bool                   `json:"xForwardedFor,omitempty"`
+	HealthCheckType            string                 `json:"healthCheckType,omitempty"`
+	HealthCheckPort            uint16                 `json:"healthCheckPort,omitempty"`
+	HealthCheckURI             string                 `json:"healthCheckURI,omitempty"`
+	HealthCheckTimeoutInSecond int                    `json:"healthCheckTimeoutInSecond,omitempty"`
+	HealthCheckInterval        int                    `json:"healthCheckInterval,omitempty"`
+	UnhealthyThreshold         int                    `json:"unhealthyThreshold,omitempty"`
+	HealthyThreshold           int                    `json:"healthyThreshold,omitempty"`
+	HealthCheckNormalStatus    string                 `json:"healthCheckNormalStatus,omitempty"`
+	ServerTimeout              int                    `json:"serverTimeout,omitempty"`
+	CertIds                    []string               `json:"certIds,omitempty"`
+	AdditionalCertDomains      []AdditionalCertDomain `json:"additionalCertDomains,omitempty"`
+	EncryptionType             string                 `json:"encryptionType,omitempty"`
+	EncryptionProtocols        []string               `json:"encryptionProtocols,omitempty"`
+	AppliedCiphers             string                 `json:"appliedCiphers,omitempty"`
 }
 
 type UpdateSSLListenerArgs struct {
-	ClientToken                string   `json:"-"`
-	ListenerPort               uint16   `json:"-"`
-	BackendPort                uint16   `json:"backendPort,omitempty"`
-	Scheduler                  string   `json:"scheduler,omitempty"`
-	HealthCheckTimeoutInSecond int      `json:"healthCheckTimeoutInSecond,omitempty"`
-	HealthCheckInterval        int      `json:"healthCheckInterval,omitempty"`
-	UnhealthyThreshold         int      `json:"unhealthyThreshold,omitempty"`
-	HealthyThreshold           int      `json:"healthyThreshold,omitempty"`
-	CertIds                    []string `json:"certIds,omitempty"`
-	EncryptionType             string   `json:"encryptionType,omitempty"`
-	EncryptionProtocols        []string `json:"encryptionProtocols,omitempty"`
-	AppliedCiphers             string   `json:"appliedCiphers,omitempty"`
-	DualAuth                   bool     `json:"dualAuth,omitempty"`
-	ClientCertIds              []string `json:"clientCertIds,omitempty"`
+	ClientToken                string                 `json:"-"`
+	ListenerPort               uint16                 `json:"-"`
+	BackendPort                uint16                 `json:"backendPort,omitempty"`
+	Scheduler                  string                 `json:"scheduler,omitempty"`
+	HealthCheckTimeoutInSecond int                    `json:"healthCheckTimeoutInSecond,omitempty"`
+	HealthCheckInterval        int                    `json:"healthCheckInterval,omitempty"`
+	UnhealthyThreshold         int                    `json:"unhealthyThreshold,omitempty"`
+	HealthyThreshold           int                    `json:"healthyThreshold,omitempty"`
+	CertIds                    []string               `json:"certIds,omitempty"`
+	AdditionalCertDomains      []AdditionalCertDomain `json:"additionalCertDomains,omitempty"`
+	EncryptionType             string                 `json:"encryptionType,omitempty"`
+	EncryptionProtocols        []string               `json:"encryptionProtocols,omitempty"`
+	AppliedCiphers             string                 `json:"appliedCiphers,omitempty"`
+	DualAuth                   bool                   `json:"dualAuth,omitempty"`
+	ClientCertIds              []string               `json:"clientCertIds,omitempty"`
 }
 
 type TCPListenerModel struct {
@@ -354,81 +365,84 @@ type HTTPListenerModel struct {
 }
 
 type HTTPSListenerModel struct {
-	ListenerPort               uint16   `json:"listenerPort"`
-	BackendPort                uint16   `json:"backendPort"`
-	Scheduler                  string   `json:"scheduler"`
-	KeepSession                bool     `json:"keepSession"`
-	KeepSessionType            string   `json:"keepSessionType"`
-	KeepSessionDuration        int      `json:"keepSessionDuration"`
-	KeepSessionCookieName      string   `json:"keepSessionCookieName"`
-	XForwardedFor              bool     `json:"xForwardedFor"`
-	HealthCheckType            string   `json:"healthCheckType"`
-	HealthCheckPort            uint16   `json:"healthCheckPort"`
-	HealthCheckURI             string   `json:"healthCheckURI"`
-	HealthCheckTimeoutInSecond int      `json:"healthCheckTimeoutInSecond"`
-	HealthCheckInterval        int      `json:"healthCheckInterval"`
-	UnhealthyThreshold         int      `json:"unhealthyThreshold"`
-	HealthyThreshold           int      `json:"healthyThreshold"`
-	GetBlbIp                   bool     `json:"getBlbIp"`
-	HealthCheckNormalStatus    string   `json:"healthCheckNormalStatus"`
-	ServerTimeout              int      `json:"serverTimeout"`
-	CertIds                    []string `json:"certIds"`
-	DualAuth                   bool     `json:"dualAuth"`
-	ClientCertIds              []string `json:"clientCertIds"`
-	EncryptionType             string   `json:"encryptionType"`
-	EncryptionProtocols        []string `json:"encryptionProtocols"`
-	AppliedCiphers             string   `json:"appliedCiphers"`
+	ListenerPort               uint16                 `json:"listenerPort"`
+	BackendPort                uint16                 `json:"backendPort"`
+	Scheduler                  string                 `json:"scheduler"`
+	KeepSession                bool                   `json:"keepSession"`
+	KeepSessionType            string                 `json:"keepSessionType"`
+	KeepSessionDuration        int                    `json:"keepSessionDuration"`
+	KeepSessionCookieName      string                 `json:"keepSessionCookieName"`
+	XForwardedFor              bool                   `json:"xForwardedFor"`
+	HealthCheckType            string                 `json:"healthCheckType"`
+	HealthCheckPort            uint16                 `json:"healthCheckPort"`
+	HealthCheckURI             string                 `json:"healthCheckURI"`
+	HealthCheckTimeoutInSecond int                    `json:"healthCheckTimeoutInSecond"`
+	HealthCheckInterval        int                    `json:"healthCheckInterval"`
+	UnhealthyThreshold         int                    `json:"unhealthyThreshold"`
+	HealthyThreshold           int                    `json:"healthyThreshold"`
+	GetBlbIp                   bool                   `json:"getBlbIp"`
+	HealthCheckNormalStatus    string                 `json:"healthCheckNormalStatus"`
+	ServerTimeout              int                    `json:"serverTimeout"`
+	CertIds                    []string               `json:"certIds"`
+	AdditionalCertDomains      []AdditionalCertDomain `json:"additionalCertDomains"`
+	DualAuth                   bool                   `json:"dualAuth"`
+	ClientCertIds              []string               `json:"clientCertIds"`
+	EncryptionType             string                 `json:"encryptionType"`
+	EncryptionProtocols        []string               `json:"encryptionProtocols"`
+	AppliedCiphers             string                 `json:"appliedCiphers"`
 }
 
 type SSLListenerModel struct {
-	ListenerPort               uint16   `json:"listenerPort"`
-	BackendPort                uint16   `json:"backendPort"`
-	Scheduler                  string   `json:"scheduler"`
-	HealthCheckTimeoutInSecond int      `json:"healthCheckTimeoutInSecond"`
-	HealthCheckInterval        int      `json:"healthCheckInterval"`
-	UnhealthyThreshold         int      `json:"unhealthyThreshold"`
-	HealthyThreshold           int      `json:"healthyThreshold"`
-	GetBlbIp                   bool     `json:"getBlbIp"`
-	CertIds                    []string `json:"certIds"`
-	EncryptionType             string   `json:"encryptionType"`
-	EncryptionProtocols        []string `json:"encryptionProtocols"`
-	AppliedCiphers             string   `json:"appliedCiphers"`
-	DualAuth                   bool     `json:"dualAuth"`
-	ClientCertIds              []string `json:"clientCertIds"`
-	ServerTimeout              int      `json:"serverTimeout"`
+	ListenerPort               uint16                 `json:"listenerPort"`
+	BackendPort                uint16                 `json:"backendPort"`
+	Scheduler                  string                 `json:"scheduler"`
+	HealthCheckTimeoutInSecond int                    `json:"healthCheckTimeoutInSecond"`
+	HealthCheckInterval        int                    `json:"healthCheckInterval"`
+	UnhealthyThreshold         int                    `json:"unhealthyThreshold"`
+	HealthyThreshold           int                    `json:"healthyThreshold"`
+	GetBlbIp                   bool                   `json:"getBlbIp"`
+	CertIds                    []string               `json:"certIds"`
+	AdditionalCertDomains      []AdditionalCertDomain `json:"additionalCertDomains"`
+	EncryptionType             string                 `json:"encryptionType"`
+	EncryptionProtocols        []string               `json:"encryptionProtocols"`
+	AppliedCiphers             string                 `json:"appliedCiphers"`
+	DualAuth                   bool                   `json:"dualAuth"`
+	ClientCertIds              []string               `json:"clientCertIds"`
+	ServerTimeout              int                    `json:"serverTimeout"`
 }
 
 type AllListenerModel struct {
-	ListenerPort               uint16   `json:"listenerPort"`
-	ListenerType               string   `json:"listenerType"`
-	BackendPort                uint16   `json:"backendPort"`
-	Scheduler                  string   `json:"scheduler"`
-	GetBlbIp                   bool     `json:"getBlbIp"`
-	TcpSessionTimeout          int      `json:"tcpSessionTimeout"`
-	UdpSessionTimeout          int      `json:"udpSessionTimeout"`
-	HealthCheckString          string   `json:"healthCheckString"`
-	KeepSession                bool     `json:"keepSession"`
-	KeepSessionType            string   `json:"keepSessionType"`
-	KeepSessionDuration        int      `json:"keepSessionDuration"`
-	KeepSessionCookieName      string   `json:"keepSessionCookieName"`
-	XForwardedFor              bool     `json:"xForwardedFor"`
-	HealthCheckType            string   `json:"healthCheckType"`
-	HealthCheckPort            uint16   `json:"healthCheckPort"`
-	HealthCheckURI             string   `json:"healthCheckURI"`
-	HealthCheckTimeoutInSecond int      `json:"healthCheckTimeoutInSecond"`
-	HealthCheckInterval        int      `json:"healthCheckInterval"`
-	UnhealthyThreshold         int      `json:"unhealthyThreshold"`
-	HealthyThreshold           int      `json:"healthyThreshold"`
-	HealthCheckNormalStatus    string   `json:"healthCheckNormalStatus"`
-	HealthCheckHost            string   `json:"healthCheckHost"`
-	ServerTimeout              int      `json:"serverTimeout"`
-	RedirectPort               int      `json:"redirectPort"`
-	CertIds                    []string `json:"certIds"`
-	DualAuth                   bool     `json:"dualAuth"`
-	ClientCertIds              []string `json:"clientCertIds"`
-	EncryptionType             string   `json:"encryptionType"`
-	EncryptionProtocols        []string `json:"encryptionProtocols"`
-	AppliedCiphers             string   `json:"appliedCiphers"`
+	ListenerPort               uint16                 `json:"listenerPort"`
+	ListenerType               string                 `json:"listenerType"`
+	BackendPort                uint16                 `json:"backendPort"`
+	Scheduler                  string                 `json:"scheduler"`
+	GetBlbIp                   bool                   `json:"getBlbIp"`
+	TcpSessionTimeout          int                    `json:"tcpSessionTimeout"`
+	UdpSessionTimeout          int                    `json:"udpSessionTimeout"`
+	HealthCheckString          string                 `json:"healthCheckString"`
+	KeepSession                bool                   `json:"keepSession"`
+	KeepSessionType            string                 `json:"keepSessionType"`
+	KeepSessionDuration        int                    `json:"keepSessionDuration"`
+	KeepSessionCookieName      string                 `json:"keepSessionCookieName"`
+	XForwardedFor              bool                   `json:"xForwardedFor"`
+	HealthCheckType            string                 `json:"healthCheckType"`
+	HealthCheckPort            uint16                 `json:"healthCheckPort"`
+	HealthCheckURI             string                 `json:"healthCheckURI"`
+	HealthCheckTimeoutInSecond int                    `json:"healthCheckTimeoutInSecond"`
+	HealthCheckInterval        int                    `json:"healthCheckInterval"`
+	UnhealthyThreshold         int                    `json:"unhealthyThreshold"`
+	HealthyThreshold           int                    `json:"healthyThreshold"`
+	HealthCheckNormalStatus    string                 `json:"healthCheckNormalStatus"`
+	HealthCheckHost            string                 `json:"healthCheckHost"`
+	ServerTimeout              int                    `json:"serverTimeout"`
+	RedirectPort               int                    `json:"redirectPort"`
+	CertIds                    []string               `json:"certIds"`
+	AdditionalCertDomains      []AdditionalCertDomain `json:"additionalCertDomains"`
+	DualAuth                   bool                   `json:"dualAuth"`
+	ClientCertIds              []string               `json:"clientCertIds"`
+	EncryptionType             string                 `json:"encryptionType"`
+	EncryptionProtocols        []string               `json:"encryptionProtocols"`
+	AppliedCiphers             string                 `json:"appliedCiphers"`
 }
 
 type DescribeListenerArgs struct {
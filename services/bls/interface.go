@@ -0,0 +1,68 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the BLS client
+
+package bls
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/bls/api"
+)
+
+// Interface defines all the operations of the BLS client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateLogStore(logStore string, retention int) error
+	UpdateLogStore(logStore string, retention int) error
+	DescribeLogStore(logStore string) (*api.LogStore, error)
+	DeleteLogStore(logStore string) error
+	ListLogStore(args *api.QueryConditions) (*api.ListLogStoreResult, error)
+	ListLogStream(logStore string, args *api.QueryConditions) (*api.ListLogStreamResult, error)
+	PushLogRecord(logStore string, logStream string, logType string, logRecords []api.LogRecord) error
+	PullLogRecord(logStore string, args *api.PullLogRecordArgs) (*api.PullLogRecordResult, error)
+	QueryLogRecord(logStore string, args *api.QueryLogRecordArgs) (*api.QueryLogResult, error)
+	CreateFastQuery(args *api.CreateFastQueryBody) error
+	DescribeFastQuery(fastQueryName string) (*api.FastQuery, error)
+	UpdateFastQuery(fastQueryName string, args *api.UpdateFastQueryBody) error
+	DeleteFastQuery(fastQueryName string) error
+	ListFastQuery(args *api.QueryConditions) (*api.ListFastQueryResult, error)
+	CreateIndex(logStore string, fulltext bool, fields map[string]api.LogField) error
+	UpdateIndex(logStore string, fulltext bool, fields map[string]api.LogField) error
+	DeleteIndex(logStore string) error
+	DescribeIndex(logStore string) (*api.IndexFields, error)
+	ListLogShipper(args *api.ListLogShipperCondition) (*api.ListShipperResult, error)
+	CreateLogShipper(args *api.CreateLogShipperBody) (string, error)
+	UpdateLogShipper(logShipperID string, args *api.UpdateLogShipperBody) error
+	GetLogShipper(logShipperID string) (*api.LogShipper, error)
+	ListLogShipperRecord(logShipperID string, args *api.ListShipperRecordCondition) (*api.ListShipperRecordResult, error)
+	DeleteSingleLogShipper(logShipperID string) error
+	BulkDeleteLogShipper(args *api.BulkDeleteShipperCondition) error
+	SetSingleLogShipperStatus(logShipperID string, args *api.SetSingleShipperStatusCondition) error
+	BulkSetLogShipperStatus(args *api.BulkSetShipperStatusCondition) error
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// acl.go - put and get the bucket and object ACL by the ACL builder
+
+package bos
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+// PutBucketAclFromBuilder - set the acl of the given bucket with the acl builder, which is
+// validated against the bucket acl rules before sending
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - builder: the acl builder with the canned acl or the custom grants
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) PutBucketAclFromBuilder(bucket string, builder *api.AclBuilder) error {
+	args, err := builder.BuildBucketAcl()
+	if err != nil {
+		return err
+	}
+	if args == nil {
+		return c.PutBucketAclFromCanned(bucket, builder.CannedAcl())
+	}
+	return c.PutBucketAclFromStruct(bucket, args)
+}
+
+// GetBucketAclBuilder - get the acl of the given bucket as an acl builder, which can be
+// modified and put back by the PutBucketAclFromBuilder
+//
+// PARAMS:
+//     - bucket: the bucket name
+// RETURNS:
+//     - *api.AclBuilder: the acl builder holding the current grants of the bucket
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetBucketAclBuilder(bucket string) (*api.AclBuilder, error) {
+	result, err := c.GetBucketAcl(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewAclBuilderFromGrants(result.AccessControlList), nil
+}
+
+// PutObjectAclFromBuilder - set the acl of the given object with the acl builder, which is
+// validated against the object acl rules before sending
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - object: the object name
+//     - builder: the acl builder with the canned acl or the custom grants
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) PutObjectAclFromBuilder(bucket, object string, builder *api.AclBuilder) error {
+	args, err := builder.BuildObjectAcl()
+	if err != nil {
+		return err
+	}
+	if args == nil {
+		return c.PutObjectAclFromCanned(bucket, object, builder.CannedAcl())
+	}
+	return c.PutObjectAclFromStruct(bucket, object, args)
+}
+
+// GetObjectAclBuilder - get the acl of the given object as an acl builder, which can be
+// modified and put back by the PutObjectAclFromBuilder
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - object: the object name
+// RETURNS:
+//     - *api.AclBuilder: the acl builder holding the current grants of the object
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetObjectAclBuilder(bucket, object string) (*api.AclBuilder, error) {
+	result, err := c.GetObjectAcl(bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewAclBuilderFromGrants(result.AccessControlList), nil
+}
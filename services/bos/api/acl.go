@@ -0,0 +1,425 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// acl.go - build and validate the bucket and object ACL instead of crafting the raw json string
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+)
+
+const (
+	PERMISSION_READ           = "READ"
+	PERMISSION_WRITE          = "WRITE"
+	PERMISSION_LIST           = "LIST"
+	PERMISSION_MODIFY         = "MODIFY"
+	PERMISSION_FULL_CONTROL   = "FULL_CONTROL"
+	PERMISSION_GET_OBJECT     = "GetObject"
+	PERMISSION_PUT_OBJECT     = "PutObject"
+	PERMISSION_DELETE_OBJECT  = "DeleteObject"
+	PERMISSION_RENAME_OBJECT  = "RenameObject"
+	PERMISSION_RESTORE_OBJECT = "RestoreObject"
+	PERMISSION_LIST_OBJECTS   = "ListObjects"
+	PERMISSION_GET_OBJECT_ACL = "GetObjectAcl"
+	PERMISSION_PUT_OBJECT_ACL = "PutObjectAcl"
+
+	ACL_EFFECT_ALLOW = "Allow"
+	ACL_EFFECT_DENY  = "Deny"
+
+	GRANTEE_ALL_USERS = "*" // the grantee id matches all users including the anonymous ones
+)
+
+var VALID_BUCKET_PERMISSION = map[string]int{
+	PERMISSION_READ:           1,
+	PERMISSION_WRITE:          1,
+	PERMISSION_LIST:           1,
+	PERMISSION_MODIFY:         1,
+	PERMISSION_FULL_CONTROL:   1,
+	PERMISSION_GET_OBJECT:     1,
+	PERMISSION_PUT_OBJECT:     1,
+	PERMISSION_DELETE_OBJECT:  1,
+	PERMISSION_RENAME_OBJECT:  1,
+	PERMISSION_RESTORE_OBJECT: 1,
+	PERMISSION_LIST_OBJECTS:   1,
+	PERMISSION_GET_OBJECT_ACL: 1,
+	PERMISSION_PUT_OBJECT_ACL: 1,
+}
+
+var VALID_OBJECT_PERMISSION = map[string]int{
+	PERMISSION_READ:         1,
+	PERMISSION_FULL_CONTROL: 1,
+}
+
+// aclCondition and aclReferer are the serialized form of the AclCondType which omits the
+// unset fields, since the server rejects the null ip address and referer lists.
+type aclCondition struct {
+	IpAddress []string    `json:"ipAddress,omitempty"`
+	Referer   *aclReferer `json:"referer,omitempty"`
+}
+
+type aclReferer struct {
+	StringLike   []string `json:"stringLike,omitempty"`
+	StringEquals []string `json:"stringEquals,omitempty"`
+}
+
+// IsEmpty - check whether the condition restricts nothing
+func (c AclCondType) IsEmpty() bool {
+	return len(c.IpAddress) == 0 && len(c.Referer.StringLike) == 0 &&
+		len(c.Referer.StringEquals) == 0
+}
+
+// MarshalJSON - marshal the grant with only the set fields of the condition, and without the
+// condition at all if it is empty
+func (g GrantType) MarshalJSON() ([]byte, error) {
+	type grant GrantType
+	out := struct {
+		grant
+		Condition *aclCondition `json:"condition,omitempty"`
+	}{grant: grant(g)}
+	if !g.Condition.IsEmpty() {
+		out.Condition = &aclCondition{IpAddress: g.Condition.IpAddress}
+		if len(g.Condition.Referer.StringLike) != 0 || len(g.Condition.Referer.StringEquals) != 0 {
+			out.Condition.Referer = &aclReferer{
+				StringLike:   g.Condition.Referer.StringLike,
+				StringEquals: g.Condition.Referer.StringEquals,
+			}
+		}
+	}
+	return json.Marshal(out)
+}
+
+// GrantBuilder builds one grant of the ACL by the chained calls
+type GrantBuilder struct {
+	grant GrantType
+}
+
+// NewGrant - create a grant builder for the given grantee ids
+//
+// PARAMS:
+//     - ids: the user ids to grant, GRANTEE_ALL_USERS for everyone
+// RETURNS:
+//     - *GrantBuilder: the grant builder
+func NewGrant(ids ...string) *GrantBuilder {
+	b := &GrantBuilder{}
+	for _, id := range ids {
+		b.grant.Grantee = append(b.grant.Grantee, GranteeType{Id: id})
+	}
+	return b
+}
+
+// Permission - append the permissions of the grant
+func (b *GrantBuilder) Permission(perms ...string) *GrantBuilder {
+	b.grant.Permission = append(b.grant.Permission, perms...)
+	return b
+}
+
+// Resource - append the resources of the bucket that the grant applies to, such as
+// "bucket/prefix*", only valid for the bucket ACL
+func (b *GrantBuilder) Resource(res ...string) *GrantBuilder {
+	b.grant.Resource = append(b.grant.Resource, res...)
+	return b
+}
+
+// NotResource - append the resources of the bucket that the grant excludes, only valid for the
+// bucket ACL and exclusive with the Resource
+func (b *GrantBuilder) NotResource(res ...string) *GrantBuilder {
+	b.grant.NotResource = append(b.grant.NotResource, res...)
+	return b
+}
+
+// IpAddress - restrict the grant to the given client ips, each one is an ip address, a CIDR
+// block or an IPv4 address with the "*" segments such as "192.168.1.*"
+func (b *GrantBuilder) IpAddress(ips ...string) *GrantBuilder {
+	b.grant.Condition.IpAddress = append(b.grant.Condition.IpAddress, ips...)
+	return b
+}
+
+// RefererStringLike - restrict the grant to the referers matching the given wildcard patterns
+func (b *GrantBuilder) RefererStringLike(referers ...string) *GrantBuilder {
+	b.grant.Condition.Referer.StringLike = append(b.grant.Condition.Referer.StringLike, referers...)
+	return b
+}
+
+// RefererStringEquals - restrict the grant to the referers equal to the given ones
+func (b *GrantBuilder) RefererStringEquals(referers ...string) *GrantBuilder {
+	b.grant.Condition.Referer.StringEquals = append(b.grant.Condition.Referer.StringEquals,
+		referers...)
+	return b
+}
+
+// Allow - make the grant allow the permissions, which is the default effect
+func (b *GrantBuilder) Allow() *GrantBuilder {
+	b.grant.Effect = ACL_EFFECT_ALLOW
+	return b
+}
+
+// Deny - make the grant deny the permissions, only valid for the bucket ACL
+func (b *GrantBuilder) Deny() *GrantBuilder {
+	b.grant.Effect = ACL_EFFECT_DENY
+	return b
+}
+
+// Build - return a copy of the grant built so far
+func (b *GrantBuilder) Build() GrantType {
+	return copyGrant(b.grant)
+}
+
+// AclBuilder builds the ACL of a bucket or an object, either a canned ACL or the custom grants
+type AclBuilder struct {
+	canned string
+	grants []GrantType
+}
+
+// NewAclBuilder - create an empty ACL builder
+func NewAclBuilder() *AclBuilder {
+	return &AclBuilder{}
+}
+
+// NewAclBuilderFromGrants - create an ACL builder from the existing grants, such as the
+// AccessControlList of the GetBucketAclResult or GetObjectAclResult, to modify and put it back
+//
+// PARAMS:
+//     - grants: the existing grants
+// RETURNS:
+//     - *AclBuilder: the ACL builder holding the copy of the grants
+func NewAclBuilderFromGrants(grants []GrantType) *AclBuilder {
+	b := &AclBuilder{}
+	for _, g := range grants {
+		b.grants = append(b.grants, copyGrant(g))
+	}
+	return b
+}
+
+// Canned - use the canned ACL, exclusive with the custom grants
+func (b *AclBuilder) Canned(acl string) *AclBuilder {
+	b.canned = acl
+	return b
+}
+
+// Grant - append the custom grants built by the grant builders
+func (b *AclBuilder) Grant(grants ...*GrantBuilder) *AclBuilder {
+	for _, g := range grants {
+		b.grants = append(b.grants, g.Build())
+	}
+	return b
+}
+
+// AddGrants - append the custom grants
+func (b *AclBuilder) AddGrants(grants ...GrantType) *AclBuilder {
+	for _, g := range grants {
+		b.grants = append(b.grants, copyGrant(g))
+	}
+	return b
+}
+
+// RemoveGrantee - remove the given grantee from all grants, and drop the grants left without
+// any grantee
+func (b *AclBuilder) RemoveGrantee(id string) *AclBuilder {
+	grants := b.grants[:0]
+	for _, g := range b.grants {
+		grantee := make([]GranteeType, 0, len(g.Grantee))
+		for _, e := range g.Grantee {
+			if e.Id != id {
+				grantee = append(grantee, e)
+			}
+		}
+		if len(grantee) != 0 {
+			g.Grantee = grantee
+			grants = append(grants, g)
+		}
+	}
+	b.grants = grants
+	return b
+}
+
+// CannedAcl - return the canned ACL, empty if the custom grants are used
+func (b *AclBuilder) CannedAcl() string {
+	return b.canned
+}
+
+// Grants - return a copy of the custom grants
+func (b *AclBuilder) Grants() []GrantType {
+	result := make([]GrantType, 0, len(b.grants))
+	for _, g := range b.grants {
+		result = append(result, copyGrant(g))
+	}
+	return result
+}
+
+// BuildBucketAcl - validate the grants against the bucket ACL rules and build the args
+//
+// RETURNS:
+//     - *PutBucketAclArgs: the args to put the bucket ACL, nil if the canned ACL is used
+//     - error: nil if the ACL is valid otherwise the specific error
+func (b *AclBuilder) BuildBucketAcl() (*PutBucketAclArgs, error) {
+	if err := b.validate(true); err != nil {
+		return nil, err
+	}
+	if len(b.canned) != 0 {
+		return nil, nil
+	}
+	return &PutBucketAclArgs{AccessControlList: b.Grants()}, nil
+}
+
+// BuildObjectAcl - validate the grants against the object ACL rules and build the args, the
+// object ACL only supports the grantee and the READ or FULL_CONTROL permission
+//
+// RETURNS:
+//     - *PutObjectAclArgs: the args to put the object ACL, nil if the canned ACL is used
+//     - error: nil if the ACL is valid otherwise the specific error
+func (b *AclBuilder) BuildObjectAcl() (*PutObjectAclArgs, error) {
+	if err := b.validate(false); err != nil {
+		return nil, err
+	}
+	if len(b.canned) != 0 {
+		return nil, nil
+	}
+	return &PutObjectAclArgs{AccessControlList: b.Grants()}, nil
+}
+
+func (b *AclBuilder) validate(bucket bool) error {
+	if len(b.canned) != 0 {
+		if len(b.grants) != 0 {
+			return bce.NewBceClientError("canned acl and custom grants can not be used together")
+		}
+		if !validCannedAcl(b.canned) {
+			return bce.NewBceClientError(fmt.Sprintf("invalid canned acl: %s", b.canned))
+		}
+		if !bucket && b.canned == CANNED_ACL_PUBLIC_READ_WRITE {
+			return bce.NewBceClientError("object acl does not support public-read-write")
+		}
+		return nil
+	}
+	if len(b.grants) == 0 {
+		return bce.NewBceClientError("acl should have a canned acl or at least one grant")
+	}
+	for i, g := range b.grants {
+		if err := validateGrant(g, bucket); err != nil {
+			return bce.NewBceClientError(fmt.Sprintf("invalid grant %d: %s", i, err.Error()))
+		}
+	}
+	return nil
+}
+
+func validateGrant(g GrantType, bucket bool) error {
+	if len(g.Grantee) == 0 {
+		return fmt.Errorf("no grantee")
+	}
+	for _, e := range g.Grantee {
+		if len(e.Id) == 0 {
+			return fmt.Errorf("empty grantee id")
+		}
+	}
+	if len(g.Permission) == 0 {
+		return fmt.Errorf("no permission")
+	}
+	valid := VALID_OBJECT_PERMISSION
+	if bucket {
+		valid = VALID_BUCKET_PERMISSION
+	}
+	for _, p := range g.Permission {
+		if _, ok := valid[p]; !ok {
+			return fmt.Errorf("invalid permission %s", p)
+		}
+	}
+	if !bucket {
+		if len(g.Resource) != 0 || len(g.NotResource) != 0 || !g.Condition.IsEmpty() ||
+			len(g.Effect) != 0 {
+			return fmt.Errorf("object acl only supports the grantee and permission")
+		}
+		return nil
+	}
+	if len(g.Resource) != 0 && len(g.NotResource) != 0 {
+		return fmt.Errorf("resource and notResource can not be used together")
+	}
+	for _, r := range append(append([]string{}, g.Resource...), g.NotResource...) {
+		if len(r) == 0 {
+			return fmt.Errorf("empty resource")
+		}
+	}
+	if len(g.Effect) != 0 && g.Effect != ACL_EFFECT_ALLOW && g.Effect != ACL_EFFECT_DENY {
+		return fmt.Errorf("invalid effect %s", g.Effect)
+	}
+	for _, ip := range g.Condition.IpAddress {
+		if !validAclIpAddress(ip) {
+			return fmt.Errorf("invalid ip address %s", ip)
+		}
+	}
+	referers := append(append([]string{}, g.Condition.Referer.StringLike...),
+		g.Condition.Referer.StringEquals...)
+	for _, r := range referers {
+		if len(r) == 0 {
+			return fmt.Errorf("empty referer")
+		}
+	}
+	return nil
+}
+
+// validAclIpAddress checks the ip address, the CIDR block or the IPv4 address whose trailing
+// segments are the "*" wildcard
+func validAclIpAddress(ip string) bool {
+	if strings.Contains(ip, "/") {
+		_, _, err := net.ParseCIDR(ip)
+		return err == nil
+	}
+	if strings.Contains(ip, "*") {
+		segs := strings.Split(ip, ".")
+		if len(segs) != 4 {
+			return false
+		}
+		wildcard := false
+		for i, s := range segs {
+			if s == "*" {
+				wildcard = true
+				segs[i] = "0"
+			} else if wildcard {
+				return false
+			}
+		}
+		ip = strings.Join(segs, ".")
+	}
+	return net.ParseIP(ip) != nil
+}
+
+func copyGrant(g GrantType) GrantType {
+	dup := func(s []string) []string {
+		if s == nil {
+			return nil
+		}
+		return append([]string{}, s...)
+	}
+	result := GrantType{
+		Permission:  dup(g.Permission),
+		Resource:    dup(g.Resource),
+		NotResource: dup(g.NotResource),
+		Effect:      g.Effect,
+		Condition: AclCondType{
+			IpAddress: dup(g.Condition.IpAddress),
+			Referer: AclRefererType{
+				StringLike:   dup(g.Condition.Referer.StringLike),
+				StringEquals: dup(g.Condition.Referer.StringEquals),
+			},
+		},
+	}
+	if g.Grantee != nil {
+		result.Grantee = append([]GranteeType{}, g.Grantee...)
+	}
+	return result
+}
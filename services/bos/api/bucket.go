@@ -20,6 +20,7 @@ package api
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/http"
@@ -60,6 +61,56 @@ func ListBuckets(cli bce.Client) (*ListBucketsResult, error) {
 //     - error: nil if ok otherwise the specific error
 func ListObjects(cli bce.Client, bucket string,
 	args *ListObjectsArgs) (*ListObjectsResult, error) {
+	// Send the request and get result
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, newListObjectsRequest(bucket, args), resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &ListObjectsResult{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListObjectsStream - list the objects of the bucket and decode the object summaries one by one
+// without reading the whole response into memory, which is suitable for the large MaxKeys
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - args: the optional arguments to list objects
+//     - fn: the function called with each object summary, the listing stops if it returns error
+// RETURNS:
+//     - *ListObjectsResult: the result without the Contents
+//     - error: nil if ok otherwise the specific error
+func ListObjectsStream(cli bce.Client, bucket string, args *ListObjectsArgs,
+	fn func(object *ObjectSummaryType) error) (*ListObjectsResult, error) {
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, newListObjectsRequest(bucket, args), resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &ListObjectsResult{}
+	err := resp.ParseJsonArrayBody(result, "contents", func(dec *json.Decoder) error {
+		object := &ObjectSummaryType{}
+		if err := dec.Decode(object); err != nil {
+			return err
+		}
+		return fn(object)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func newListObjectsRequest(bucket string, args *ListObjectsArgs) *bce.BceRequest {
 	req := &bce.BceRequest{}
 	req.SetUri(getBucketUri(bucket))
 	req.SetMethod(http.GET)
@@ -82,20 +133,7 @@ func ListObjects(cli bce.Client, bucket string,
 	if args == nil || args.MaxKeys == 0 {
 		req.SetParam("maxKeys", "1000")
 	}
-
-	// Send the request and get result
-	resp := &bce.BceResponse{}
-	if err := SendRequest(cli, req, resp); err != nil {
-		return nil, err
-	}
-	if resp.IsFail() {
-		return nil, resp.ServiceError()
-	}
-	result := &ListObjectsResult{}
-	if err := resp.ParseJsonBody(result); err != nil {
-		return nil, err
-	}
-	return result, nil
+	return req
 }
 
 // HeadBucket - test the given bucket existed and access authority
@@ -425,7 +463,7 @@ func PutBucketStorageclass(cli bce.Client, bucket, storageClass string) error {
 	req.SetParam("storageClass", "")
 
 	obj := &StorageClassType{storageClass}
-	jsonBytes, jsonErr := json.Marshal(obj)
+	jsonBytes, jsonErr := bce.MarshalJSON(obj)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -554,7 +592,7 @@ func ListBucketReplication(cli bce.Client, bucket string) (*ListBucketReplicatio
 	req.SetParam("replication", "")
 	req.SetParam("list", "")
 	resp := &bce.BceResponse{}
-	if err := cli.SendRequest(req, resp); err != nil {
+	if err := SendRequest(cli, req, resp); err != nil {
 		return nil, err
 	}
 	if resp.IsFail() {
@@ -627,6 +665,252 @@ func GetBucketReplicationProgress(cli bce.Client, bucket string, replicationRule
 	return result, nil
 }
 
+// PutBucketInventory - set the bucket inventory config to generate the object inventory reports
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - inventoryConf: the inventory config body stream
+//     - inventoryId: the inventory config id composed of [0-9 A-Z a-z _ -]
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func PutBucketInventory(cli bce.Client, bucket string, inventoryConf *bce.Body, inventoryId string) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.PUT)
+	req.SetParam("inventory", "")
+	req.SetParam("id", inventoryId)
+	if inventoryConf != nil {
+		req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
+		req.SetBody(inventoryConf)
+	}
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
+// GetBucketInventory - get the bucket inventory config of the given id
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - inventoryId: the inventory config id
+// RETURNS:
+//     - *GetBucketInventoryResult: the result of the bucket inventory config
+//     - error: nil if success otherwise the specific error
+func GetBucketInventory(cli bce.Client, bucket string, inventoryId string) (*GetBucketInventoryResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.GET)
+	req.SetParam("inventory", "")
+	req.SetParam("id", inventoryId)
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &GetBucketInventoryResult{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListBucketInventory - list all inventory config of the given bucket
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+// RETURNS:
+//     - *ListBucketInventoryResult: the list of the bucket inventory config
+//     - error: nil if success otherwise the specific error
+func ListBucketInventory(cli bce.Client, bucket string) (*ListBucketInventoryResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.GET)
+	req.SetParam("inventory", "")
+	req.SetParam("list", "")
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &ListBucketInventoryResult{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteBucketInventory - delete the bucket inventory config of the given id
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - inventoryId: the inventory config id
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func DeleteBucketInventory(cli bce.Client, bucket string, inventoryId string) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.DELETE)
+	req.SetParam("inventory", "")
+	req.SetParam("id", inventoryId)
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
+// InitBucketObjectLock - init the object lock of the bucket, the lock is IN_PROGRESS and can be
+// deleted until it is completed
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - body: the object lock config body
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func InitBucketObjectLock(cli bce.Client, bucket string, body *bce.Body) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.PUT)
+	req.SetParam("objectlock", "")
+	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
+	req.SetBody(body)
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
+// GetBucketObjectLock - get the object lock config of the bucket
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+// RETURNS:
+//     - *GetBucketObjectLockResult: the status and retention period of the object lock
+//     - error: nil if success otherwise the specific error
+func GetBucketObjectLock(cli bce.Client, bucket string) (*GetBucketObjectLockResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.GET)
+	req.SetParam("objectlock", "")
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &GetBucketObjectLockResult{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteBucketObjectLock - delete the object lock of the bucket which is not completed
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func DeleteBucketObjectLock(cli bce.Client, bucket string) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.DELETE)
+	req.SetParam("objectlock", "")
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
+// CompleteBucketObjectLock - complete the object lock of the bucket, the lock becomes LOCKED
+// and it can not be deleted or shortened any more
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func CompleteBucketObjectLock(cli bce.Client, bucket string) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.POST)
+	req.SetParam("completeobjectlock", "")
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
+// ExtendBucketObjectLock - extend the retention period of the completed object lock
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - body: the extended retention period body
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func ExtendBucketObjectLock(cli bce.Client, bucket string, body *bce.Body) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.POST)
+	req.SetParam("extendobjectlock", "")
+	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
+	req.SetBody(body)
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
 // PutBucketEncryption - set the bucket encrpytion config
 //
 // PARAMS:
@@ -642,7 +926,7 @@ func PutBucketEncryption(cli bce.Client, bucket, algorithm string) error {
 	req.SetParam("encryption", "")
 
 	obj := &BucketEncryptionType{algorithm}
-	jsonBytes, jsonErr := json.Marshal(obj)
+	jsonBytes, jsonErr := bce.MarshalJSON(obj)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -715,6 +999,23 @@ func DeleteBucketEncryption(cli bce.Client, bucket string) error {
 	return nil
 }
 
+// Check - check the static website config, at least one of the index and 404 documents should
+// be set and they should be the object names without the leading slash
+//
+// RETURNS:
+//     - error: nil if the config is valid otherwise the specific error
+func (args *PutBucketStaticWebsiteArgs) Check() error {
+	if args == nil || (len(args.Index) == 0 && len(args.NotFound) == 0) {
+		return bce.NewBceClientError("the index or notFound document should be set")
+	}
+	for _, name := range []string{args.Index, args.NotFound} {
+		if strings.HasPrefix(name, "/") {
+			return bce.NewBceClientError("the document should not start with '/': " + name)
+		}
+	}
+	return nil
+}
+
 // PutBucketStaticWebsite - set the bucket static website config
 //
 // PARAMS:
@@ -894,7 +1195,7 @@ func PutBucketCopyrightProtection(cli bce.Client, bucket string, resources ...st
 		return bce.NewBceClientError("the resource to set copyright protection is empty")
 	}
 	arg := &CopyrightProtectionType{resources}
-	jsonBytes, jsonErr := json.Marshal(arg)
+	jsonBytes, jsonErr := bce.MarshalJSON(arg)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -980,7 +1281,7 @@ func PutBucketTrash(cli bce.Client, bucket string, trashReq PutBucketTrashReq) e
 	req.SetUri(getBucketUri(bucket))
 	req.SetMethod(http.PUT)
 	req.SetParam("trash", "")
-	reqByte, _ := json.Marshal(trashReq)
+	reqByte, _ := bce.MarshalJSON(trashReq)
 	body, err := bce.NewBodyFromString(string(reqByte))
 	if err != nil {
 		return err
@@ -1037,11 +1338,15 @@ func PutBucketNotification(cli bce.Client, bucket string, putBucketNotificationR
 	req.SetUri(getBucketUri(bucket))
 	req.SetMethod(http.PUT)
 	req.SetParam("notification", "")
-	reqByte, _ := json.Marshal(putBucketNotificationReq)
-	body, err := bce.NewBodyFromString(string(reqByte))
+	reqByte, err := bce.MarshalJSON(putBucketNotificationReq)
 	if err != nil {
 		return err
 	}
+	body, err := bce.NewBodyFromBytes(reqByte)
+	if err != nil {
+		return err
+	}
+	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
 	req.SetBody(body)
 	resp := &bce.BceResponse{}
 	if err := SendRequest(cli, req, resp); err != nil {
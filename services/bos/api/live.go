@@ -0,0 +1,304 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// live.go - define the live channel apis to push the RTMP stream to the bucket as HLS
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+const (
+	LIVE_CHANNEL_TARGET_HLS = "HLS"
+
+	LIVE_CHANNEL_STATUS_IDLE = "Idle"
+	LIVE_CHANNEL_STATUS_LIVE = "Live"
+
+	MAX_LIVE_CHANNEL_LIST = 1000
+)
+
+// PutLiveChannel - create the live channel or update its configuration
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - channel: the live channel name
+//     - args: the configuration of the live channel
+// RETURNS:
+//     - *PutLiveChannelResult: the publish and play urls of the live channel
+//     - error: nil if success otherwise the specific error
+func PutLiveChannel(cli bce.Client, bucket, channel string,
+	args *PutLiveChannelArgs) (*PutLiveChannelResult, error) {
+	if args == nil || args.Target == nil {
+		return nil, bce.NewBceClientError("the target of the live channel should be set")
+	}
+	if args.Target.Type != LIVE_CHANNEL_TARGET_HLS {
+		return nil, bce.NewBceClientError("invalid live channel target type: " + args.Target.Type)
+	}
+	if len(args.Status) != 0 && args.Status != STATUS_ENABLED && args.Status != STATUS_DISABLED {
+		return nil, bce.NewBceClientError("invalid live channel status: " + args.Status)
+	}
+	jsonBytes, err := bce.MarshalJSON(args)
+	if err != nil {
+		return nil, err
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, channel))
+	req.SetMethod(http.PUT)
+	req.SetParam("livechannel", "")
+	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
+	req.SetBody(body)
+
+	result := &PutLiveChannelResult{}
+	if err := sendLiveChannelRequest(cli, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetLiveChannel - get the configuration of the live channel
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - channel: the live channel name
+// RETURNS:
+//     - *GetLiveChannelResult: the configuration of the live channel
+//     - error: nil if success otherwise the specific error
+func GetLiveChannel(cli bce.Client, bucket, channel string) (*GetLiveChannelResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, channel))
+	req.SetMethod(http.GET)
+	req.SetParam("livechannel", "")
+
+	result := &GetLiveChannelResult{}
+	if err := sendLiveChannelRequest(cli, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListLiveChannel - list the live channels of the bucket
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - args: the optional prefix, marker and max keys to list
+// RETURNS:
+//     - *ListLiveChannelResult: the live channels
+//     - error: nil if success otherwise the specific error
+func ListLiveChannel(cli bce.Client, bucket string,
+	args *ListLiveChannelArgs) (*ListLiveChannelResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.GET)
+	req.SetParam("livechannel", "")
+	if args != nil {
+		if len(args.Prefix) != 0 {
+			req.SetParam("prefix", args.Prefix)
+		}
+		if len(args.Marker) != 0 {
+			req.SetParam("marker", args.Marker)
+		}
+		if maxKeys := args.MaxKeys; maxKeys > 0 {
+			if maxKeys > MAX_LIVE_CHANNEL_LIST {
+				maxKeys = MAX_LIVE_CHANNEL_LIST
+			}
+			req.SetParam("maxKeys", strconv.Itoa(maxKeys))
+		}
+	}
+
+	result := &ListLiveChannelResult{}
+	if err := sendLiveChannelRequest(cli, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteLiveChannel - delete the live channel, the generated fragments and playlists are kept
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - channel: the live channel name
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func DeleteLiveChannel(cli bce.Client, bucket, channel string) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, channel))
+	req.SetMethod(http.DELETE)
+	req.SetParam("livechannel", "")
+	return sendLiveChannelRequest(cli, req, nil)
+}
+
+// PutLiveChannelStatus - enable or disable the live channel, the pushing stream is disconnected
+// if the channel is disabled
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - channel: the live channel name
+//     - status: STATUS_ENABLED or STATUS_DISABLED
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func PutLiveChannelStatus(cli bce.Client, bucket, channel, status string) error {
+	if status != STATUS_ENABLED && status != STATUS_DISABLED {
+		return bce.NewBceClientError("invalid live channel status: " + status)
+	}
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, channel))
+	req.SetMethod(http.PUT)
+	req.SetParam("livechannel", "")
+	req.SetParam("status", status)
+	return sendLiveChannelRequest(cli, req, nil)
+}
+
+// GetLiveChannelStatus - get the pushing status of the live channel
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - channel: the live channel name
+// RETURNS:
+//     - *GetLiveChannelStatusResult: the status and the stream information if it is live
+//     - error: nil if success otherwise the specific error
+func GetLiveChannelStatus(cli bce.Client, bucket, channel string) (*GetLiveChannelStatusResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, channel))
+	req.SetMethod(http.GET)
+	req.SetParam("livechannelstat", "")
+
+	result := &GetLiveChannelStatusResult{}
+	if err := sendLiveChannelRequest(cli, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetLiveChannelHistory - get the recent pushing records of the live channel
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - channel: the live channel name
+// RETURNS:
+//     - *GetLiveChannelHistoryResult: the pushing records
+//     - error: nil if success otherwise the specific error
+func GetLiveChannelHistory(cli bce.Client, bucket, channel string) (*GetLiveChannelHistoryResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, channel))
+	req.SetMethod(http.GET)
+	req.SetParam("livechannelhistory", "")
+
+	result := &GetLiveChannelHistoryResult{}
+	if err := sendLiveChannelRequest(cli, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostVodPlaylist - generate the VOD playlist object from the fragments pushed in the time range
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - channel: the live channel name
+//     - playlistName: the name of the playlist object under the channel, ends with ".m3u8"
+//     - startTime: the start of the time range in unix seconds
+//     - endTime: the end of the time range in unix seconds
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func PostVodPlaylist(cli bce.Client, bucket, channel, playlistName string,
+	startTime, endTime int64) error {
+	if len(playlistName) == 0 {
+		return bce.NewBceClientError("the playlist name should not be empty")
+	}
+	if err := checkVodTimeRange(startTime, endTime); err != nil {
+		return err
+	}
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, channel+"/"+playlistName))
+	req.SetMethod(http.POST)
+	req.SetParam("vod", "")
+	req.SetParam("startTime", strconv.FormatInt(startTime, 10))
+	req.SetParam("endTime", strconv.FormatInt(endTime, 10))
+	return sendLiveChannelRequest(cli, req, nil)
+}
+
+// GetVodPlaylist - get the content of the VOD playlist of the fragments pushed in the time range
+// without generating the playlist object
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - channel: the live channel name
+//     - startTime: the start of the time range in unix seconds
+//     - endTime: the end of the time range in unix seconds
+// RETURNS:
+//     - io.ReadCloser: the m3u8 playlist content which must be closed by the caller
+//     - error: nil if success otherwise the specific error
+func GetVodPlaylist(cli bce.Client, bucket, channel string,
+	startTime, endTime int64) (io.ReadCloser, error) {
+	if err := checkVodTimeRange(startTime, endTime); err != nil {
+		return nil, err
+	}
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, channel))
+	req.SetMethod(http.GET)
+	req.SetParam("vod", "")
+	req.SetParam("startTime", strconv.FormatInt(startTime, 10))
+	req.SetParam("endTime", strconv.FormatInt(endTime, 10))
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	return resp.Body(), nil
+}
+
+func checkVodTimeRange(startTime, endTime int64) error {
+	if startTime <= 0 || endTime <= startTime {
+		return bce.NewBceClientError(fmt.Sprintf("invalid vod time range [%d, %d]", startTime, endTime))
+	}
+	return nil
+}
+
+// sendLiveChannelRequest - send the request and parse the json result if it is not nil
+func sendLiveChannelRequest(cli bce.Client, req *bce.BceRequest, result interface{}) error {
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	if result == nil {
+		resp.Body().Close()
+		return nil
+	}
+	return resp.ParseJsonBody(result)
+}
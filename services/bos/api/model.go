@@ -17,7 +17,13 @@
 package api
 
 import (
+	"fmt"
 	"io"
+	"strings"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce/arn"
 )
 
 type OwnerType struct {
@@ -189,6 +195,39 @@ type BucketReplicationType struct {
 type PutBucketReplicationArgs BucketReplicationType
 type GetBucketReplicationResult BucketReplicationType
 
+// Check - check the required fields of the bucket replication config before putting it
+//
+// RETURNS:
+//     - error: nil if valid otherwise the specific client error
+func (args *PutBucketReplicationArgs) Check() error {
+	if args == nil {
+		return bce.NewBceClientError("the bucket replication config is empty")
+	}
+	if args.Status != STATUS_ENABLED && args.Status != STATUS_DISABLED {
+		return bce.NewBceClientError("invalid bucket replication status: " + args.Status)
+	}
+	if len(args.Resource) == 0 {
+		return bce.NewBceClientError("the resource of bucket replication is empty")
+	}
+	if len(args.ReplicateDeletes) != 0 &&
+		args.ReplicateDeletes != STATUS_ENABLED && args.ReplicateDeletes != STATUS_DISABLED {
+		return bce.NewBceClientError("invalid replicateDeletes value: " + args.ReplicateDeletes)
+	}
+	if args.Destination == nil || len(args.Destination.Bucket) == 0 {
+		return bce.NewBceClientError("the destination bucket of bucket replication is empty")
+	}
+	for _, desc := range []*BucketReplicationDescriptor{args.Destination, args.ReplicateHistory} {
+		if desc != nil && len(desc.StorageClass) != 0 && !validStorageClass(desc.StorageClass) {
+			return bce.NewBceClientError("invalid storage class value: " + desc.StorageClass)
+		}
+	}
+	if args.ReplicateHistory != nil && len(args.ReplicateHistory.Bucket) != 0 &&
+		!strings.EqualFold(args.ReplicateHistory.Bucket, args.Destination.Bucket) {
+		return bce.NewBceClientError("the history replication bucket must be the destination bucket")
+	}
+	return nil
+}
+
 // ListBucketReplicationResult defines output result for replication conf list
 type ListBucketReplicationResult struct {
 	Rules []BucketReplicationType `json:"rules"`
@@ -201,6 +240,129 @@ type GetBucketReplicationProgressResult struct {
 	LatestReplicationTime     string  `json:"latestReplicationTime"`
 }
 
+// BucketInventoryDestination defines where the inventory reports are written to
+type BucketInventoryDestination struct {
+	TargetBucket string `json:"targetBucket"`
+	TargetPrefix string `json:"targetPrefix,omitempty"`
+	Format       string `json:"format"`
+}
+
+// BucketInventoryType defines the data structure for Put and Get of bucket inventory
+type BucketInventoryType struct {
+	Id          string                      `json:"id"`
+	Status      string                      `json:"status"`
+	Resource    []string                    `json:"resource"`
+	Schedule    string                      `json:"schedule"`
+	Destination *BucketInventoryDestination `json:"destination"`
+}
+
+type PutBucketInventoryArgs BucketInventoryType
+type GetBucketInventoryResult BucketInventoryType
+
+// Check - check the required fields of the bucket inventory config before putting it
+//
+// RETURNS:
+//     - error: nil if valid otherwise the specific client error
+func (args *PutBucketInventoryArgs) Check() error {
+	if args == nil {
+		return bce.NewBceClientError("the bucket inventory config is empty")
+	}
+	if len(args.Id) == 0 {
+		return bce.NewBceClientError("the id of bucket inventory is empty")
+	}
+	if args.Status != STATUS_ENABLED && args.Status != STATUS_DISABLED {
+		return bce.NewBceClientError("invalid bucket inventory status: " + args.Status)
+	}
+	if len(args.Resource) == 0 {
+		return bce.NewBceClientError("the resource of bucket inventory is empty")
+	}
+	if args.Schedule != INVENTORY_SCHEDULE_DAILY && args.Schedule != INVENTORY_SCHEDULE_WEEKLY {
+		return bce.NewBceClientError("invalid bucket inventory schedule: " + args.Schedule)
+	}
+	if args.Destination == nil || len(args.Destination.TargetBucket) == 0 {
+		return bce.NewBceClientError("the target bucket of bucket inventory is empty")
+	}
+	if args.Destination.Format != INVENTORY_FORMAT_CSV {
+		return bce.NewBceClientError("invalid bucket inventory format: " + args.Destination.Format)
+	}
+	return nil
+}
+
+// ListBucketInventoryResult defines output result for inventory conf list
+type ListBucketInventoryResult struct {
+	Rules []BucketInventoryType `json:"inventoryRuleList"`
+}
+
+// RetentionDays is the retention period of the bucket object lock, the objects can not be
+// deleted or overwritten until they are stored longer than the period
+type RetentionDays int
+
+// The valid range of the retention period of the bucket object lock
+const (
+	MIN_RETENTION_DAYS RetentionDays = 1
+	MAX_RETENTION_DAYS RetentionDays = 36500
+)
+
+// RetentionDaysOf - convert the duration to the retention period rounded up to whole days
+func RetentionDaysOf(d time.Duration) RetentionDays {
+	day := 24 * time.Hour
+	return RetentionDays((d + day - 1) / day)
+}
+
+// Duration - get the retention period as the time.Duration
+func (d RetentionDays) Duration() time.Duration {
+	return time.Duration(d) * 24 * time.Hour
+}
+
+func (d RetentionDays) check() error {
+	if d < MIN_RETENTION_DAYS || d > MAX_RETENTION_DAYS {
+		return bce.NewBceClientError(fmt.Sprintf("retention days should be in [%d, %d], got %d",
+			MIN_RETENTION_DAYS, MAX_RETENTION_DAYS, d))
+	}
+	return nil
+}
+
+// InitBucketObjectLockArgs defines the input args to init the bucket object lock
+type InitBucketObjectLockArgs struct {
+	RetentionDays RetentionDays `json:"retentionDays"`
+}
+
+// Check - check the retention period before initializing the bucket object lock
+func (args *InitBucketObjectLockArgs) Check() error {
+	if args == nil {
+		return bce.NewBceClientError("the bucket object lock args is empty")
+	}
+	return args.RetentionDays.check()
+}
+
+// ExtendBucketObjectLockArgs defines the input args to extend the retention period of the
+// completed bucket object lock
+type ExtendBucketObjectLockArgs struct {
+	ExtendRetentionDays RetentionDays `json:"extendRetentionDays"`
+}
+
+// Check - check the extended retention period before extending the bucket object lock
+func (args *ExtendBucketObjectLockArgs) Check() error {
+	if args == nil {
+		return bce.NewBceClientError("the bucket object lock args is empty")
+	}
+	return args.ExtendRetentionDays.check()
+}
+
+// GetBucketObjectLockResult defines the output result of the bucket object lock, the dates are
+// unix timestamps in seconds
+type GetBucketObjectLockResult struct {
+	LockStatus     string        `json:"lockStatus"`
+	CreateDate     int64         `json:"createDate"`
+	ExpirationDate int64         `json:"expirationDate"`
+	RetentionDays  RetentionDays `json:"retentionDays"`
+}
+
+// IsLocked - check whether the object lock is completed and can not be deleted any more
+func (r *GetBucketObjectLockResult) IsLocked() bool {
+	return r.LockStatus == OBJECT_LOCK_STATUS_LOCKED
+}
+
 // BucketEncryptionType defines the data structure for Put and Get of bucket encryption
 type BucketEncryptionType struct {
 	EncryptionAlgorithm string `json:"encryptionAlgorithm"`
@@ -246,6 +408,58 @@ type ObjectAclType struct {
 type PutObjectAclArgs ObjectAclType
 type GetObjectAclResult ObjectAclType
 
+// ObjectTagType defines a single key-value tag of the object
+type ObjectTagType struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ObjectTagSetType defines the tag set of the object
+type ObjectTagSetType struct {
+	TagInfo []ObjectTagType `json:"tagInfo"`
+}
+
+// ObjectTaggingType defines the data structure for Put and Get object tagging API
+type ObjectTaggingType struct {
+	TagSet []ObjectTagSetType `json:"tagSet"`
+}
+
+type PutObjectTaggingArgs ObjectTaggingType
+type GetObjectTaggingResult ObjectTaggingType
+
+// NewPutObjectTaggingArgs - build the put object tagging args from the key-value tags
+func NewPutObjectTaggingArgs(tags map[string]string) *PutObjectTaggingArgs {
+	tagInfo := make([]ObjectTagType, 0, len(tags))
+	for _, k := range sortedKeys(tags) {
+		tagInfo = append(tagInfo, ObjectTagType{Key: k, Value: tags[k]})
+	}
+	return &PutObjectTaggingArgs{TagSet: []ObjectTagSetType{{TagInfo: tagInfo}}}
+}
+
+// Tags - get the key-value tags of the object tagging result
+func (r *GetObjectTaggingResult) Tags() map[string]string {
+	tags := make(map[string]string)
+	for _, tagSet := range r.TagSet {
+		for _, tag := range tagSet.TagInfo {
+			tags[tag.Key] = tag.Value
+		}
+	}
+	return tags
+}
+
+// Match - check whether the object has all the given tags, the empty value of a tag matches any
+// value of the same key
+func (r *GetObjectTaggingResult) Match(tags map[string]string) bool {
+	objectTags := r.Tags()
+	for k, v := range tags {
+		value, ok := objectTags[k]
+		if !ok || (len(v) != 0 && value != v) {
+			return false
+		}
+	}
+	return true
+}
+
 // PutObjectArgs defines the optional args structure for the put object api.
 type PutObjectArgs struct {
 	CacheControl       string
@@ -257,8 +471,18 @@ type PutObjectArgs struct {
 	UserMeta           map[string]string
 	ContentSha256      string
 	ContentCrc32       string
+	ContentCrc32cFlag  bool
 	StorageClass       string
 	Process            string
+	Tags               map[string]string
+}
+
+// PutObjectResult defines the result structure of PutObjectWithResult, the checksums are only
+// set when returned by the service.
+type PutObjectResult struct {
+	ETag          string
+	ContentCrc32  string
+	ContentCrc32c string
 }
 
 // CopyObjectArgs defines the optional args structure for the copy object api.
@@ -269,12 +493,41 @@ type CopyObjectArgs struct {
 	IfNoneMatch       string
 	IfModifiedSince   string
 	IfUnmodifiedSince string
+	TaggingDirective  string
+	Tags              map[string]string
 }
 
 type MultiCopyObjectArgs struct {
 	StorageClass string
 }
 
+// CopyProgressFunc is called after each part is copied with the copied bytes and the total size
+// of the source object, the calls are serialized.
+type CopyProgressFunc func(copied, total int64)
+
+// CopyObjectLargeArgs defines the optional arguments of the CopyObjectLarge. The content headers,
+// user metadata and storage class of the source object are kept unless they are overridden here.
+type CopyObjectLargeArgs struct {
+	StorageClass string            // the storage class of the destination object if not empty
+	UserMeta     map[string]string // replace the user metadata of the source object if not nil
+	Threshold    int64             // the max size copied by a single request, default and at most 5GB
+	PartSize     int64             // the part size of the multipart copy, default MultipartSize of the client
+	Parallel     int64             // the number of the parts copied concurrently, default MaxParallel of the client
+	OnProgress   CopyProgressFunc
+}
+
+// SetObjectMetaArgs defines the metadata updated by the SetObjectMeta, the empty fields keep the
+// current values of the object.
+type SetObjectMetaArgs struct {
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentType        string
+	Expires            string
+	StorageClass       string
+	UserMeta           map[string]string // replace all the user metadata if not nil, empty to remove them
+}
+
 // CopyObjectResult defines the result json structure for the copy object api.
 type CopyObjectResult struct {
 	LastModified string `json:"lastModified"`
@@ -291,6 +544,7 @@ type ObjectMeta struct {
 	ContentMD5         string
 	ContentSha256      string
 	ContentCrc32       string
+	ContentCrc32c      string
 	Expires            string
 	LastModified       string
 	ETag               string
@@ -299,14 +553,57 @@ type ObjectMeta struct {
 	NextAppendOffset   string
 	ObjectType         string
 	BceRestore         string
+	Restore            *RestoreStatus
 	BceObjectType      string
 }
 
+// RestoreStatus defines the typed restore status of an archive object parsed from the
+// `x-bce-restore` header. ExpiryDate is only set when the restore has completed.
+type RestoreStatus struct {
+	OngoingRequest bool
+	ExpiryDate     time.Time
+}
+
+// IsRestored returns whether the archive object has been restored and is readable now.
+func (r *RestoreStatus) IsRestored() bool {
+	return r != nil && !r.OngoingRequest && time.Now().Before(r.ExpiryDate)
+}
+
 // GetObjectResult defines the result data of the get object api.
 type GetObjectResult struct {
 	ObjectMeta
 	ContentLanguage string
-	Body            io.ReadCloser
+	// NotModified is true if the object is not returned for the If-None-Match or the
+	// If-Modified-Since condition fails, the Body is empty but should still be closed
+	NotModified bool
+	Body        io.ReadCloser
+}
+
+// GetObjectConditions defines the conditional headers of the get object api, the object is
+// returned only if all the given conditions are satisfied, the empty ones are not sent
+type GetObjectConditions struct {
+	IfMatch           string    // the ETag the object should match, "*" matches any object
+	IfNoneMatch       string    // the ETag the object should not match, eg: the cached one
+	IfModifiedSince   time.Time // the object should be modified after the time
+	IfUnmodifiedSince time.Time // the object should not be modified after the time
+}
+
+// ContentRange defines the parsed Content-Range header of the partial content
+type ContentRange struct {
+	Start int64 // the first byte position, inclusive
+	End   int64 // the last byte position, inclusive
+	Total int64 // the size of the whole object, -1 if unknown
+}
+
+// Length - get the number of the bytes in the range
+func (r *ContentRange) Length() int64 {
+	return r.End - r.Start + 1
+}
+
+// GetObjectRangeResult defines the result of the get object range api.
+type GetObjectRangeResult struct {
+	GetObjectResult
+	Range ContentRange
 }
 
 // GetObjectMetaResult defines the result data of the get object meta api.
@@ -321,8 +618,8 @@ type SelectObjectResult struct {
 
 // selectObject request args
 type SelectObjectArgs struct {
-	SelectType    string               `json: "-"`
-	SelectRequest *SelectObjectRequest `json: "selectRequest"`
+	SelectType    string               `json:"-"`
+	SelectRequest *SelectObjectRequest `json:"selectRequest"`
 }
 
 type SelectObjectRequest struct {
@@ -429,12 +726,21 @@ type DeleteMultipleObjectsResult struct {
 	Errors []DeleteObjectResult `json:"errors"`
 }
 
+// DeleteObjectsReport defines the consolidated result of deleting objects in batches.
+type DeleteObjectsReport struct {
+	Deleted []string
+	Failed  []DeleteObjectResult
+}
+
 // InitiateMultipartUploadArgs defines the input arguments to initiate a multipart upload.
 type InitiateMultipartUploadArgs struct {
 	CacheControl       string
 	ContentDisposition string
+	ContentEncoding    string
 	Expires            string
 	StorageClass       string
+	UserMeta           map[string]string
+	Tags               map[string]string
 }
 
 // InitiateMultipartUploadResult defines the result structure to initiate a multipart upload.
@@ -446,9 +752,17 @@ type InitiateMultipartUploadResult struct {
 
 // UploadPartArgs defines the optinoal argumets for uploading part.
 type UploadPartArgs struct {
-	ContentMD5    string
-	ContentSha256 string
+	ContentMD5        string
+	ContentSha256     string
+	ContentCrc32      string
+	ContentCrc32cFlag bool
+}
+
+// UploadPartResult defines the result structure of UploadPartWithResult.
+type UploadPartResult struct {
+	ETag          string
 	ContentCrc32  string
+	ContentCrc32c string
 }
 
 // UploadPartCopyArgs defines the optional arguments of UploadPartCopy.
@@ -464,6 +778,19 @@ type PutSymlinkArgs struct {
 	ForbidOverwrite string
 	StorageClass    string
 	UserMeta        map[string]string
+	SymlinkBucket   string
+}
+
+// GetSymlinkResult defines the result data of the get symlink api, the Target is the name of
+// the target object and the Bucket is the bucket of it, which is empty if the target object is
+// in the same bucket as the symlink.
+type GetSymlinkResult struct {
+	Target       string
+	Bucket       string
+	StorageClass string
+	ETag         string
+	LastModified string
+	UserMeta     map[string]string
 }
 
 // UploadInfoType defines an uploaded part info structure.
@@ -474,19 +801,21 @@ type UploadInfoType struct {
 
 // CompleteMultipartUploadArgs defines the input arguments structure of CompleteMultipartUpload.
 type CompleteMultipartUploadArgs struct {
-	Parts        []UploadInfoType  `json:"parts"`
-	UserMeta     map[string]string `json:"-"`
-	Process      string            `json:"-"`
-	ContentCrc32 string            `json:"-"`
+	Parts             []UploadInfoType  `json:"parts"`
+	UserMeta          map[string]string `json:"-"`
+	Process           string            `json:"-"`
+	ContentCrc32      string            `json:"-"`
+	ContentCrc32cFlag bool              `json:"-"`
 }
 
 // CompleteMultipartUploadResult defines the result structure of CompleteMultipartUpload.
 type CompleteMultipartUploadResult struct {
-	Location     string `json:"location"`
-	Bucket       string `json:"bucket"`
-	Key          string `json:"key"`
-	ETag         string `json:"eTag"`
-	ContentCrc32 string `json:"-"`
+	Location      string `json:"location"`
+	Bucket        string `json:"bucket"`
+	Key           string `json:"key"`
+	ETag          string `json:"eTag"`
+	ContentCrc32  string `json:"-"`
+	ContentCrc32c string `json:"-"`
 }
 
 // ListPartsArgs defines the input optional arguments of listing parts information.
@@ -563,6 +892,22 @@ type PutBucketNotificationReq struct {
 	Notifications []PutBucketNotificationSt `json:"notifications"`
 }
 
+// Check - check the rules of the bucket notification before putting it
+//
+// RETURNS:
+//     - error: nil if valid otherwise the specific client error of the first invalid rule
+func (req *PutBucketNotificationReq) Check() error {
+	if len(req.Notifications) == 0 {
+		return bce.NewBceClientError("the bucket notification rules are empty")
+	}
+	for _, rule := range req.Notifications {
+		if err := rule.Check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type PutBucketNotificationSt struct {
 	Id        string                        `json:"id"`
 	Name      string                        `json:"name"`
@@ -573,8 +918,143 @@ type PutBucketNotificationSt struct {
 	Apps      []PutBucketNotificationAppsSt `json:"apps"`
 }
 
+// Check - check the required fields of the notification rule, the events should be the
+// NOTIFICATION_EVENT_XXX and the event url of the app should be a http(s) endpoint or the
+// resource name of a CFC function, eg: "brn:bce:cfc:bj:<account-id>:function:<name>:$LATEST"
+//
+// RETURNS:
+//     - error: nil if valid otherwise the specific client error
+func (rule *PutBucketNotificationSt) Check() error {
+	if len(rule.Id) == 0 || len(rule.Name) == 0 {
+		return bce.NewBceClientError("the id and name of bucket notification rule are required")
+	}
+	if len(rule.Status) != 0 && rule.Status != STATUS_ENABLED && rule.Status != STATUS_DISABLED {
+		return bce.NewBceClientError("invalid bucket notification status: " + rule.Status)
+	}
+	if len(rule.Resources) == 0 {
+		return bce.NewBceClientError("the resources of bucket notification rule " + rule.Id +
+			" are empty")
+	}
+	if len(rule.Events) == 0 {
+		return bce.NewBceClientError("the events of bucket notification rule " + rule.Id +
+			" are empty")
+	}
+	for _, event := range rule.Events {
+		if _, ok := VALID_NOTIFICATION_EVENT[event]; !ok {
+			return bce.NewBceClientError("invalid bucket notification event: " + event)
+		}
+	}
+	if len(rule.Apps) == 0 {
+		return bce.NewBceClientError("the apps of bucket notification rule " + rule.Id +
+			" are empty")
+	}
+	for _, app := range rule.Apps {
+		if !strings.HasPrefix(app.EventUrl, "http://") &&
+			!strings.HasPrefix(app.EventUrl, "https://") && !arn.IsARN(app.EventUrl) {
+			return bce.NewBceClientError("invalid event url of bucket notification app: " +
+				app.EventUrl)
+		}
+	}
+	return nil
+}
+
 type PutBucketNotificationAppsSt struct {
 	Id       string `json:"id"`
 	EventUrl string `json:"eventUrl"`
 	XVars    string `json:"xVars"`
 }
+
+// LiveChannelTarget defines the HLS target of the live channel: the stream is sliced to the
+// fragments of FragDuration seconds and the playlist lists the last FragCount fragments.
+type LiveChannelTarget struct {
+	Type         string `json:"type"`
+	FragDuration int    `json:"fragDuration,omitempty"`
+	FragCount    int    `json:"fragCount,omitempty"`
+	PlaylistName string `json:"playlistName,omitempty"`
+}
+
+// PutLiveChannelArgs defines the input args structure of the put live channel api.
+type PutLiveChannelArgs struct {
+	Description string             `json:"description,omitempty"`
+	Status      string             `json:"status,omitempty"`
+	Target      *LiveChannelTarget `json:"target"`
+}
+
+// PutLiveChannelResult defines the result of the put live channel api, the PublishUrls are the
+// RTMP urls to push the stream to and the PlayUrls are the urls of the HLS playlist.
+type PutLiveChannelResult struct {
+	PublishUrls []string `json:"publishUrls"`
+	PlayUrls    []string `json:"playUrls"`
+}
+
+// GetLiveChannelResult defines the configuration of the live channel.
+type GetLiveChannelResult struct {
+	Description string             `json:"description"`
+	Status      string             `json:"status"`
+	Target      *LiveChannelTarget `json:"target"`
+}
+
+// ListLiveChannelArgs defines the input args structure of the list live channel api.
+type ListLiveChannelArgs struct {
+	Prefix  string
+	Marker  string
+	MaxKeys int
+}
+
+// LiveChannelSummary defines the summary of the live channel in the list.
+type LiveChannelSummary struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Status       string   `json:"status"`
+	LastModified string   `json:"lastModified"`
+	PublishUrls  []string `json:"publishUrls"`
+	PlayUrls     []string `json:"playUrls"`
+}
+
+// ListLiveChannelResult defines the result of the list live channel api.
+type ListLiveChannelResult struct {
+	Prefix       string               `json:"prefix"`
+	Marker       string               `json:"marker"`
+	MaxKeys      int                  `json:"maxKeys"`
+	IsTruncated  bool                 `json:"isTruncated"`
+	NextMarker   string               `json:"nextMarker"`
+	LiveChannels []LiveChannelSummary `json:"liveChannels"`
+}
+
+// LiveChannelVideo defines the video stream information of the live channel.
+type LiveChannelVideo struct {
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	FrameRate int    `json:"frameRate"`
+	Bandwidth int64  `json:"bandwidth"`
+	Codec     string `json:"codec"`
+}
+
+// LiveChannelAudio defines the audio stream information of the live channel.
+type LiveChannelAudio struct {
+	Bandwidth  int64  `json:"bandwidth"`
+	SampleRate int    `json:"sampleRate"`
+	Codec      string `json:"codec"`
+}
+
+// GetLiveChannelStatusResult defines the pushing status of the live channel, the stream
+// information is only set when the Status is Live.
+type GetLiveChannelStatusResult struct {
+	Status        string            `json:"status"`
+	ConnectedTime string            `json:"connectedTime"`
+	RemoteAddr    string            `json:"remoteAddr"`
+	Video         *LiveChannelVideo `json:"video"`
+	Audio         *LiveChannelAudio `json:"audio"`
+}
+
+// LiveRecord defines a pushing record of the live channel.
+type LiveRecord struct {
+	StartTime  string `json:"startTime"`
+	EndTime    string `json:"endTime"`
+	RemoteAddr string `json:"remoteAddr"`
+}
+
+// GetLiveChannelHistoryResult defines the recent pushing records of the live channel.
+type GetLiveChannelHistoryResult struct {
+	LiveRecords []LiveRecord `json:"liveRecords"`
+}
@@ -54,8 +54,15 @@ func InitiateMultipartUpload(cli bce.Client, bucket, object, contentType string,
 		setOptionalNullHeaders(req, map[string]string{
 			http.CACHE_CONTROL:       args.CacheControl,
 			http.CONTENT_DISPOSITION: args.ContentDisposition,
+			http.CONTENT_ENCODING:    args.ContentEncoding,
 			http.EXPIRES:             args.Expires,
 		})
+		if err := setUserMetadata(req, args.UserMeta); err != nil {
+			return nil, err
+		}
+		if err := setObjectTagging(req, args.Tags); err != nil {
+			return nil, err
+		}
 
 		if validStorageClass(args.StorageClass) {
 			req.SetHeader(http.BCE_STORAGE_CLASS, args.StorageClass)
@@ -98,13 +105,36 @@ func InitiateMultipartUpload(cli bce.Client, bucket, object, contentType string,
 //     - error: nil if ok otherwise the specific error
 func UploadPart(cli bce.Client, bucket, object, uploadId string, partNumber int,
 	content *bce.Body, args *UploadPartArgs) (string, error) {
+	result, err := UploadPartWithResult(cli, bucket, object, uploadId, partNumber, content, args)
+	if err != nil {
+		return "", err
+	}
+	return result.ETag, nil
+}
+
+// UploadPartWithResult - upload the single part and return the etag with the checksums of the
+// service
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - object: the object name
+//     - uploadId: the multipart upload id
+//     - partNumber: the current part number
+//     - content: the uploaded part content
+//     - args: the optional arguments
+// RETURNS:
+//     - *UploadPartResult: the etag and the checksums of the uploaded part
+//     - error: nil if ok otherwise the specific error
+func UploadPartWithResult(cli bce.Client, bucket, object, uploadId string, partNumber int,
+	content *bce.Body, args *UploadPartArgs) (*UploadPartResult, error) {
 	req := &bce.BceRequest{}
 	req.SetUri(getObjectUri(bucket, object))
 	req.SetMethod(http.PUT)
 	req.SetParam("uploadId", uploadId)
 	req.SetParam("partNumber", fmt.Sprintf("%d", partNumber))
 	if content == nil {
-		return "", bce.NewBceClientError("upload part content should not be empty")
+		return nil, bce.NewBceClientError("upload part content should not be empty")
 	}
 	if content.Size() >= THRESHOLD_100_CONTINUE {
 		req.SetHeader("Expect", "100-continue")
@@ -118,18 +148,25 @@ func UploadPart(cli bce.Client, bucket, object, uploadId string, partNumber int,
 			http.BCE_CONTENT_SHA256: args.ContentSha256,
 			http.BCE_CONTENT_CRC32:  args.ContentCrc32,
 		})
+		if args.ContentCrc32cFlag {
+			req.SetHeader(http.BCE_CONTENT_CRC32C_FLAG, "true")
+		}
 	}
 
 	// Send request and get the result
 	resp := &bce.BceResponse{}
 	if err := SendRequest(cli, req, resp); err != nil {
-		return "", err
+		return nil, err
 	}
 	if resp.IsFail() {
-		return "", resp.ServiceError()
+		return nil, resp.ServiceError()
 	}
 	defer func() { resp.Body().Close() }()
-	return strings.Trim(resp.Header(http.ETAG), "\""), nil
+	return &UploadPartResult{
+		ETag:          strings.Trim(resp.Header(http.ETAG), "\""),
+		ContentCrc32:  resp.Header(http.BCE_CONTENT_CRC32),
+		ContentCrc32c: resp.Header(http.BCE_CONTENT_CRC32C),
+	}, nil
 }
 
 // UploadPartFromBytes - upload the single part in the multipart upload process
@@ -183,6 +220,7 @@ func UploadPartFromBytes(cli bce.Client, bucket, object, uploadId string, partNu
 	}
 	// Send request and get the result
 	resp := &bce.BceResponse{}
+	setRequestAddress(cli.GetBceClientConfig(), req, cli.GetBceClientConfig().Endpoint, req.Uri())
 	if err := cli.SendRequestFromBytes(req, resp, content); err != nil {
 		return "", err
 	}
@@ -282,6 +320,9 @@ func CompleteMultipartUpload(cli bce.Client, bucket, object, uploadId string,
 	if len(args.ContentCrc32) != 0 {
 		req.SetHeader(http.BCE_CONTENT_CRC32, args.ContentCrc32)
 	}
+	if args.ContentCrc32cFlag {
+		req.SetHeader(http.BCE_CONTENT_CRC32C_FLAG, "true")
+	}
 
 	// Send request and get the result
 	resp := &bce.BceResponse{}
@@ -299,6 +340,9 @@ func CompleteMultipartUpload(cli bce.Client, bucket, object, uploadId string,
 	if val, ok := headers[toHttpHeaderKey(http.BCE_CONTENT_CRC32)]; ok {
 		result.ContentCrc32 = val
 	}
+	if val, ok := headers[toHttpHeaderKey(http.BCE_CONTENT_CRC32C)]; ok {
+		result.ContentCrc32c = val
+	}
 	return result, nil
 }
 
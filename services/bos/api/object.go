@@ -17,11 +17,10 @@
 package api
 
 import (
-	"encoding/json"
 	"fmt"
-	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/baidubce/bce-sdk-go/auth"
 	"github.com/baidubce/bce-sdk-go/bce"
@@ -42,11 +41,31 @@ import (
 //     - error: nil if ok otherwise the specific error
 func PutObject(cli bce.Client, bucket, object string, body *bce.Body,
 	args *PutObjectArgs) (string, error) {
+	result, err := PutObjectWithResult(cli, bucket, object, body, args)
+	if err != nil {
+		return "", err
+	}
+	return result.ETag, nil
+}
+
+// PutObjectWithResult - put the object and return the etag with the checksums of the service
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name of the object
+//     - object: the name of the object
+//     - body: the input content of the object
+//     - args: the optional arguments of this api
+// RETURNS:
+//     - *PutObjectResult: the etag and the checksums of the object
+//     - error: nil if ok otherwise the specific error
+func PutObjectWithResult(cli bce.Client, bucket, object string, body *bce.Body,
+	args *PutObjectArgs) (*PutObjectResult, error) {
 	req := &bce.BceRequest{}
 	req.SetUri(getObjectUri(bucket, object))
 	req.SetMethod(http.PUT)
 	if body == nil {
-		return "", bce.NewBceClientError("PutObject body should not be emtpy")
+		return nil, bce.NewBceClientError("PutObject body should not be emtpy")
 	}
 	if body.Size() >= THRESHOLD_100_CONTINUE {
 		req.SetHeader("Expect", "100-continue")
@@ -68,11 +87,11 @@ func PutObject(cli bce.Client, bucket, object string, body *bce.Body,
 			// be reset. The `net/http.Client' does not support the Content-Length bigger than the
 			// body size.
 			if args.ContentLength > body.Size() {
-				return "", bce.NewBceClientError(fmt.Sprintf("ContentLength %d is bigger than body size %d", args.ContentLength, body.Size()))
+				return nil, bce.NewBceClientError(fmt.Sprintf("ContentLength %d is bigger than body size %d", args.ContentLength, body.Size()))
 			}
 			body, err := bce.NewBodyFromSizedReader(body.Stream(), args.ContentLength)
 			if err != nil {
-				return "", bce.NewBceClientError(err.Error())
+				return nil, bce.NewBceClientError(err.Error())
 			}
 			req.SetHeader(http.CONTENT_LENGTH, fmt.Sprintf("%d", args.ContentLength))
 			req.SetBody(body) // re-assign body
@@ -83,33 +102,45 @@ func PutObject(cli bce.Client, bucket, object string, body *bce.Body,
 			req.SetHeader(http.CONTENT_MD5, args.ContentMD5)
 		}
 
+		if args.ContentCrc32cFlag {
+			req.SetHeader(http.BCE_CONTENT_CRC32C_FLAG, "true")
+		}
+
 		if validStorageClass(args.StorageClass) {
 			req.SetHeader(http.BCE_STORAGE_CLASS, args.StorageClass)
 		} else {
 			if len(args.StorageClass) != 0 {
-				return "", bce.NewBceClientError("invalid storage class value: " +
+				return nil, bce.NewBceClientError("invalid storage class value: " +
 					args.StorageClass)
 			}
 		}
 
 		if err := setUserMetadata(req, args.UserMeta); err != nil {
-			return "", err
+			return nil, err
 		}
 
 		if len(args.Process) != 0 {
 			req.SetHeader(http.BCE_PROCESS, args.Process)
 		}
+
+		if err := setObjectTagging(req, args.Tags); err != nil {
+			return nil, err
+		}
 	}
 
 	resp := &bce.BceResponse{}
 	if err := SendRequest(cli, req, resp); err != nil {
-		return "", err
+		return nil, err
 	}
 	if resp.IsFail() {
-		return "", resp.ServiceError()
+		return nil, resp.ServiceError()
 	}
 	defer func() { resp.Body().Close() }()
-	return strings.Trim(resp.Header(http.ETAG), "\""), nil
+	return &PutObjectResult{
+		ETag:          strings.Trim(resp.Header(http.ETAG), "\""),
+		ContentCrc32:  resp.Header(http.BCE_CONTENT_CRC32),
+		ContentCrc32c: resp.Header(http.BCE_CONTENT_CRC32C),
+	}, nil
 }
 
 // CopyObject - copy one object to a new object with new bucket and/or name. It can alse set the
@@ -176,6 +207,21 @@ func CopyObject(cli bce.Client, bucket, object, source string,
 		if err := setUserMetadata(req, args.UserMeta); err != nil {
 			return nil, err
 		}
+		if validTaggingDirective(args.TaggingDirective) {
+			req.SetHeader(http.BCE_TAGGING_DIRECTIVE, args.TaggingDirective)
+		} else {
+			if len(args.TaggingDirective) != 0 {
+				return nil, bce.NewBceClientError(
+					"invalid tagging directive value: " + args.TaggingDirective)
+			}
+		}
+		if len(args.Tags) != 0 && args.TaggingDirective != TAGGING_DIRECTIVE_REPLACE {
+			return nil, bce.NewBceClientError(
+				"tags can only be set with the replace tagging directive")
+		}
+		if err := setObjectTagging(req, args.Tags); err != nil {
+			return nil, err
+		}
 	}
 
 	// Send request and get the result
@@ -206,6 +252,27 @@ func CopyObject(cli bce.Client, bucket, object, source string,
 //     - error: nil if ok otherwise the specific error
 func GetObject(cli bce.Client, bucket, object string, responseHeaders map[string]string,
 	ranges ...int64) (*GetObjectResult, error) {
+	return GetObjectWithConditions(cli, bucket, object, responseHeaders, nil, ranges...)
+}
+
+// GetObjectWithConditions - get the object content only if the conditions are satisfied, the
+// NotModified of the result is true without the content if the If-None-Match or the
+// If-Modified-Since condition fails, and the error code is PreconditionFailed if the If-Match
+// or the If-Unmodified-Since condition fails
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name of the object
+//     - object: the name of the object
+//     - responseHeaders: the optional response headers to get the given object
+//     - conditions: the optional conditions to get the given object
+//     - ranges: the optional range start and end to get the given object
+// RETURNS:
+//     - *GetObjectResult: the output content result of the object
+//     - error: nil if ok otherwise the specific error
+func GetObjectWithConditions(cli bce.Client, bucket, object string,
+	responseHeaders map[string]string, conditions *GetObjectConditions,
+	ranges ...int64) (*GetObjectResult, error) {
 
 	if object == "" {
 		err := fmt.Errorf("Get Object don't accept \"\" as a parameter")
@@ -232,6 +299,7 @@ func GetObject(cli bce.Client, bucket, object string, responseHeaders map[string
 		}
 		req.SetHeader("Range", rangeStr)
 	}
+	conditions.setHeaders(req)
 
 	// Send request and get the result
 	resp := &bce.BceResponse{}
@@ -284,6 +352,9 @@ func GetObject(cli bce.Client, bucket, object string, responseHeaders map[string
 	if val, ok := headers[toHttpHeaderKey(http.BCE_CONTENT_CRC32)]; ok {
 		result.ContentCrc32 = val
 	}
+	if val, ok := headers[toHttpHeaderKey(http.BCE_CONTENT_CRC32C)]; ok {
+		result.ContentCrc32c = val
+	}
 	if val, ok := headers[toHttpHeaderKey(http.BCE_STORAGE_CLASS)]; ok {
 		result.StorageClass = val
 	}
@@ -302,6 +373,7 @@ func GetObject(cli bce.Client, bucket, object string, responseHeaders map[string
 	if val, ok := headers[toHttpHeaderKey(http.BCE_NEXT_APPEND_OFFSET)]; ok {
 		result.NextAppendOffset = val
 	}
+	result.NotModified = resp.StatusCode() == 304
 	result.Body = resp.Body()
 	return result, nil
 }
@@ -368,11 +440,17 @@ func GetObjectMeta(cli bce.Client, bucket, object string) (*GetObjectMetaResult,
 	if val, ok := headers[toHttpHeaderKey(http.BCE_CONTENT_CRC32)]; ok {
 		result.ContentCrc32 = val
 	}
+	if val, ok := headers[toHttpHeaderKey(http.BCE_CONTENT_CRC32C)]; ok {
+		result.ContentCrc32c = val
+	}
 	if val, ok := headers[toHttpHeaderKey(http.BCE_STORAGE_CLASS)]; ok {
 		result.StorageClass = val
 	}
 	if val, ok := headers[toHttpHeaderKey(http.BCE_RESTORE)]; ok {
 		result.BceRestore = val
+		if status, err := ParseRestoreStatus(val); err == nil {
+			result.Restore = status
+		}
 	}
 	if val, ok := headers[http.BCE_OBJECT_TYPE]; ok {
 		result.BceObjectType = val
@@ -413,7 +491,7 @@ func SelectObject(cli bce.Client, bucket, object string, args *SelectObjectArgs)
 	req.SetParam("select", "")
 	req.SetParam("type", args.SelectType)
 
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -564,14 +642,15 @@ func AppendObject(cli bce.Client, bucket, object string, content *bce.Body,
 	if val, ok := headers[http.CONTENT_MD5]; ok {
 		result.ContentMD5 = val
 	}
+	// the next offset is the end of the appended content if it is not returned
+	result.NextAppendOffset = content.Size()
+	if args != nil {
+		result.NextAppendOffset += args.Offset
+	}
 	if val, ok := headers[toHttpHeaderKey(http.BCE_NEXT_APPEND_OFFSET)]; ok {
-		nextOffset, offsetErr := strconv.ParseInt(val, 10, 64)
-		if offsetErr != nil {
-			nextOffset = content.Size()
+		if nextOffset, offsetErr := strconv.ParseInt(val, 10, 64); offsetErr == nil {
+			result.NextAppendOffset = nextOffset
 		}
-		result.NextAppendOffset = nextOffset
-	} else {
-		result.NextAppendOffset = content.Size()
 	}
 	if val, ok := headers[toHttpHeaderKey(http.BCE_CONTENT_CRC32)]; ok {
 		result.ContentCrc32 = val
@@ -676,32 +755,9 @@ func GeneratePresignedUrlInternal(conf *bce.BceClientConfiguration, signer auth.
 		method = http.GET
 	}
 	req.SetMethod(method)
-	req.SetEndpoint(conf.Endpoint)
-	if req.Protocol() == "" {
-		req.SetProtocol(bce.DEFAULT_PROTOCOL)
-	}
-	domain := req.Host()
-	if pos := strings.Index(domain, ":"); pos != -1 {
-		domain = domain[:pos]
-	}
-	if path_style {
-		req.SetUri(getObjectUri(bucket, object))
-		if conf.CnameEnabled || isCnameLikeHost(conf.Endpoint) {
-			req.SetUri(getCnameUri(req.Uri()))
-		}
-	} else {
-		if len(bucket) != 0 && net.ParseIP(domain) == nil { // not use an IP as the endpoint by client
-			req.SetUri(bce.URI_PREFIX + object)
-			if !conf.CnameEnabled && !isCnameLikeHost(conf.Endpoint) {
-				req.SetHost(bucket + "." + req.Host())
-			}
-		} else {
-			req.SetUri(getObjectUri(bucket, object))
-			if conf.CnameEnabled || isCnameLikeHost(conf.Endpoint) {
-				req.SetUri(getCnameUri(req.Uri()))
-			}
-		}
-	}
+	// The presigned url is virtual-hosted unless the path style is required, and it falls back
+	// to the path style for the bucket name with dots and the ip endpoint as the requests do
+	setRequestAddressStyle(conf, req, conf.Endpoint, getObjectUri(bucket, object), !path_style)
 	// Set headers and params if given.
 	req.SetHeader(http.HOST, req.Host())
 	if headers != nil {
@@ -719,6 +775,10 @@ func GeneratePresignedUrlInternal(conf *bce.BceClientConfiguration, signer auth.
 	if expire != 0 {
 		option.ExpireSeconds = expire
 	}
+	// Sign with the service time if the local clock is skewed.
+	if skew := conf.ClockSkew(); option.Timestamp == 0 && skew != 0 {
+		option.Timestamp = util.NowUTCSeconds() + int64(skew/time.Second)
+	}
 	// Generate the authorization string and return the signed url.
 	signer.Sign(&req.Request, conf.Credentials, &option)
 	req.SetParam("authorization", req.Header(http.AUTHORIZATION))
@@ -871,7 +931,7 @@ func RestoreObject(cli bce.Client, bucket string, object string, args ArchiveRes
 	return nil
 }
 
-// PutObjectSymlink - put the object from the string or the stream
+// PutObjectSymlink - create the symlink which points to the given target object
 //
 // PARAMS:
 //     - cli: the client agent which can perform sending request
@@ -909,6 +969,10 @@ func PutObjectSymlink(cli bce.Client, bucket string, object string, symlinkKey s
 				return err
 			}
 		}
+
+		if len(symlinkArgs.SymlinkBucket) != 0 {
+			req.SetHeader(http.BCE_SYMLINK_BUCKET, symlinkArgs.SymlinkBucket)
+		}
 	}
 	req.SetHeader(http.BCE_SYMLINK_TARGET, object)
 
@@ -923,27 +987,157 @@ func PutObjectSymlink(cli bce.Client, bucket string, object string, symlinkKey s
 	return nil
 }
 
-// PutObjectSymlink - put the object from the string or the stream
+// GetObjectSymlink - get the name of the target object of the symlink
 //
 // PARAMS:
 //     - cli: the client agent which can perform sending request
 //     - bucket: the bucket name of the object
 //     - symlinkKey: the name of the symlink
 // RETURNS:
-//	   - string: the name of the target object
+//     - string: the name of the target object
 //     - error: nil if ok otherwise the specific error
 func GetObjectSymlink(cli bce.Client, bucket string, symlinkKey string) (string, error) {
+	result, err := GetObjectSymlinkMeta(cli, bucket, symlinkKey)
+	if err != nil {
+		return "", err
+	}
+	return result.Target, nil
+}
+
+// GetObjectSymlinkMeta - get the target object and the meta data of the symlink
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name of the object
+//     - symlinkKey: the name of the symlink
+// RETURNS:
+//     - *GetSymlinkResult: the target object and the meta data of the symlink
+//     - error: nil if ok otherwise the specific error
+func GetObjectSymlinkMeta(cli bce.Client, bucket string, symlinkKey string) (*GetSymlinkResult, error) {
 	req := &bce.BceRequest{}
 	req.SetUri(getObjectUri(bucket, symlinkKey))
 	req.SetParam("symlink", "")
 	req.SetMethod(http.GET)
 	resp := &bce.BceResponse{}
 	if err := SendRequest(cli, req, resp); err != nil {
-		return "", err
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	result := &GetSymlinkResult{
+		Target:       resp.Header(http.BCE_SYMLINK_TARGET),
+		Bucket:       resp.Header(http.BCE_SYMLINK_BUCKET),
+		StorageClass: resp.Header(http.BCE_STORAGE_CLASS),
+		ETag:         strings.Trim(resp.Header(http.ETAG), "\""),
+		LastModified: resp.Header(http.LAST_MODIFIED),
+	}
+	bcePrefix := toHttpHeaderKey(http.BCE_USER_METADATA_PREFIX)
+	for k, v := range resp.Headers() {
+		if strings.Index(k, bcePrefix) == 0 {
+			if result.UserMeta == nil {
+				result.UserMeta = make(map[string]string)
+			}
+			result.UserMeta[k[len(bcePrefix):]] = v
+		}
+	}
+	return result, nil
+}
+
+// PutObjectTagging - set the tags of the given object
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - object: the object name
+//     - args: the object tagging arguments
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func PutObjectTagging(cli bce.Client, bucket, object string, args *PutObjectTaggingArgs) error {
+	if args == nil {
+		return bce.NewBceClientError("PutObjectTagging args should not be nil")
+	}
+	if err := validObjectTags(args); err != nil {
+		return err
+	}
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, object))
+	req.SetMethod(http.PUT)
+	req.SetParam("tagging", "")
+	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
+
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return err
+	}
+	req.SetBody(body)
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
 	}
 	if resp.IsFail() {
-		return "", resp.ServiceError()
+		return resp.ServiceError()
 	}
 	defer func() { resp.Body().Close() }()
-	return resp.Header(http.BCE_SYMLINK_TARGET), nil
+	return nil
+}
+
+// GetObjectTagging - get the tags of the given object
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - object: the object name
+// RETURNS:
+//     - *GetObjectTaggingResult: the object tagging result object
+//     - error: nil if success otherwise the specific error
+func GetObjectTagging(cli bce.Client, bucket, object string) (*GetObjectTaggingResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, object))
+	req.SetMethod(http.GET)
+	req.SetParam("tagging", "")
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &GetObjectTaggingResult{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteObjectTagging - delete all the tags of the given object
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - object: the object name
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func DeleteObjectTagging(cli bce.Client, bucket, object string) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getObjectUri(bucket, object))
+	req.SetMethod(http.DELETE)
+	req.SetParam("tagging", "")
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	return nil
 }
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// post.go - generate the policy and signature of the browser-based upload by the POST form
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/auth"
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/util"
+)
+
+// The form fields of the PostObject request
+const (
+	POST_FORM_ACCESS_KEY     = "accessKey"
+	POST_FORM_POLICY         = "policy"
+	POST_FORM_SIGNATURE      = "signature"
+	POST_FORM_SECURITY_TOKEN = "x-bce-security-token"
+	POST_FORM_KEY            = "key"
+	POST_FORM_FILE           = "file"
+
+	POST_POLICY_TIME_LAYOUT = "2006-01-02T15:04:05Z"
+)
+
+// PostPolicy defines the policy of the browser-based upload, the form fields of the request
+// must satisfy all the conditions before the expiration, eg: the bucket, the key prefix and the
+// range of the file size. Use the NewPostPolicy and the chained Add methods to build it.
+type PostPolicy struct {
+	Expiration time.Time
+	Conditions []interface{}
+	bucket     string
+	err        error
+}
+
+// NewPostPolicy - create the policy expired at the given time
+//
+// PARAMS:
+//     - expiration: the time after which the upload is rejected
+// RETURNS:
+//     - *PostPolicy: the policy without conditions
+func NewPostPolicy(expiration time.Time) *PostPolicy {
+	return &PostPolicy{Expiration: expiration}
+}
+
+// AddBucket - restrict the upload to the given bucket
+func (p *PostPolicy) AddBucket(bucket string) *PostPolicy {
+	p.bucket = bucket
+	return p.AddCondition("bucket", bucket)
+}
+
+// AddKey - restrict the object name of the upload to the given key
+func (p *PostPolicy) AddKey(key string) *PostPolicy {
+	return p.AddCondition(POST_FORM_KEY, key)
+}
+
+// AddKeyPrefix - restrict the object name of the upload to start with the given prefix
+func (p *PostPolicy) AddKeyPrefix(prefix string) *PostPolicy {
+	return p.AddStartsWith(POST_FORM_KEY, prefix)
+}
+
+// AddCondition - require the form field to be the given value exactly
+func (p *PostPolicy) AddCondition(field, value string) *PostPolicy {
+	if len(field) == 0 {
+		p.setErr("the field of the condition should not be empty")
+	}
+	p.Conditions = append(p.Conditions, map[string]string{field: value})
+	return p
+}
+
+// AddStartsWith - require the form field to start with the given prefix, the empty prefix
+// allows any value of the field
+func (p *PostPolicy) AddStartsWith(field, prefix string) *PostPolicy {
+	if len(field) == 0 {
+		p.setErr("the field of the condition should not be empty")
+	}
+	p.Conditions = append(p.Conditions, []string{"starts-with", "$" + field, prefix})
+	return p
+}
+
+// AddContentLengthRange - restrict the size in bytes of the uploaded file to [min, max]
+func (p *PostPolicy) AddContentLengthRange(min, max int64) *PostPolicy {
+	if min < 0 || min > max {
+		p.setErr(fmt.Sprintf("invalid content length range [%d, %d]", min, max))
+	}
+	p.Conditions = append(p.Conditions, []interface{}{"content-length-range", min, max})
+	return p
+}
+
+func (p *PostPolicy) setErr(msg string) {
+	if p.err == nil {
+		p.err = bce.NewBceClientError(msg)
+	}
+}
+
+// Check - check the policy is valid and restricted to a bucket
+func (p *PostPolicy) Check() error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.Expiration.IsZero() {
+		return bce.NewBceClientError("the expiration of the post policy should be set")
+	}
+	if len(p.bucket) == 0 {
+		return bce.NewBceClientError("the bucket of the post policy should be set by AddBucket")
+	}
+	return nil
+}
+
+// Encode - encode the policy to the base64 json which is the value of the policy form field
+func (p *PostPolicy) Encode() (string, error) {
+	if err := p.Check(); err != nil {
+		return "", err
+	}
+	conditions := p.Conditions
+	if conditions == nil {
+		conditions = []interface{}{}
+	}
+	policy, err := json.Marshal(map[string]interface{}{
+		"expiration": p.Expiration.UTC().Format(POST_POLICY_TIME_LAYOUT),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(policy), nil
+}
+
+// PostPolicyForm defines the url and the form fields for the browser to upload by the POST form,
+// the browser should add the key field if the policy does not give it and the file field last
+type PostPolicyForm struct {
+	Url    string
+	Fields map[string]string
+}
+
+// GeneratePostPolicyForm - sign the policy and generate the form to upload to the bucket
+//
+// PARAMS:
+//     - conf: the client configuration
+//     - credentials: the credentials to sign the policy
+//     - policy: the policy of the upload
+// RETURNS:
+//     - *PostPolicyForm: the url and the form fields
+//     - error: nil if ok otherwise the specific error
+func GeneratePostPolicyForm(conf *bce.BceClientConfiguration, credentials *auth.BceCredentials,
+	policy *PostPolicy) (*PostPolicyForm, error) {
+	if credentials == nil {
+		return nil, bce.NewBceClientError("the credentials to sign the post policy are not set")
+	}
+	if policy == nil {
+		return nil, bce.NewBceClientError("the post policy should not be nil")
+	}
+	encoded, err := policy.Encode()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, []byte(credentials.SecretAccessKey))
+	mac.Write([]byte(encoded))
+
+	fields := map[string]string{
+		POST_FORM_ACCESS_KEY: credentials.AccessKeyId,
+		POST_FORM_POLICY:     encoded,
+		POST_FORM_SIGNATURE:  hex.EncodeToString(mac.Sum(nil)),
+	}
+	if len(credentials.SessionToken) != 0 {
+		fields[POST_FORM_SECURITY_TOKEN] = credentials.SessionToken
+	}
+	return &PostPolicyForm{Url: getPostUrl(conf, policy.bucket), Fields: fields}, nil
+}
+
+// getPostUrl - get the url of the bucket to post the form to, the virtual-hosted style is used
+// unless the endpoint is an ip or a custom domain
+func getPostUrl(conf *bce.BceClientConfiguration, bucket string) string {
+	req := &bce.BceRequest{}
+	req.SetEndpoint(conf.Endpoint)
+	if req.Protocol() == "" {
+		req.SetProtocol(bce.DEFAULT_PROTOCOL)
+	}
+	domain := req.Host()
+	if pos := strings.Index(domain, ":"); pos != -1 {
+		domain = domain[:pos]
+	}
+	uri := getBucketUri(bucket)
+	if conf.CnameEnabled || isCnameLikeHost(conf.Endpoint) {
+		uri = bce.URI_PREFIX
+	} else if net.ParseIP(domain) == nil {
+		uri = bce.URI_PREFIX
+		req.SetHost(bucket + "." + req.Host())
+	}
+	return fmt.Sprintf("%s://%s%s", req.Protocol(), req.Host(), util.UriEncode(uri, false))
+}
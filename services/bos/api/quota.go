@@ -0,0 +1,306 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// quota.go - define the bucket quota and the bucket storage statistics apis
+
+package api
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/util"
+)
+
+const (
+	// The quota value which means no limit of the dimension
+	BUCKET_QUOTA_UNLIMITED = -1
+
+	METRIC_STORAGE_BYTES      = "StorageBytes"
+	METRIC_OBJECT_COUNT       = "ObjectCount"
+	METRIC_INBOUND_TRAFFIC    = "InboundTraffic"
+	METRIC_OUTBOUND_TRAFFIC   = "OutboundTraffic"
+	METRIC_CDN_ORIGIN_TRAFFIC = "CdnOriginTraffic"
+	METRIC_GET_REQUESTS       = "GetRequests"
+	METRIC_PUT_REQUESTS       = "PutRequests"
+
+	STATISTICS_PERIOD_HOUR = 3600
+	STATISTICS_PERIOD_DAY  = 86400
+)
+
+// BucketQuota defines the quota of the bucket, the zero or BUCKET_QUOTA_UNLIMITED value of
+// one dimension means the dimension is not limited.
+type BucketQuota struct {
+	MaxObjectCount       int64 `json:"maxObjectCount"`
+	MaxCapacityMegaBytes int64 `json:"maxCapacityMegaBytes"`
+}
+
+// Check - check the quota values
+//
+// RETURNS:
+//     - error: nil if valid otherwise the specific error
+func (q *BucketQuota) Check() error {
+	if q == nil {
+		return bce.NewBceClientError("the bucket quota should not be nil")
+	}
+	if q.MaxObjectCount < BUCKET_QUOTA_UNLIMITED {
+		return bce.NewBceClientError("invalid max object count of the bucket quota: " +
+			strconv.FormatInt(q.MaxObjectCount, 10))
+	}
+	if q.MaxCapacityMegaBytes < BUCKET_QUOTA_UNLIMITED {
+		return bce.NewBceClientError("invalid max capacity of the bucket quota: " +
+			strconv.FormatInt(q.MaxCapacityMegaBytes, 10))
+	}
+	return nil
+}
+
+// GetBucketStatisticsArgs defines the query of the bucket statistics, the StartTime and
+// EndTime are in unix seconds and the Period is the aggregation interval in seconds.
+type GetBucketStatisticsArgs struct {
+	StartTime int64
+	EndTime   int64
+	Period    int
+	Metrics   []string
+}
+
+// StatisticsDataPoint defines one aggregated value of the time series.
+type StatisticsDataPoint struct {
+	Timestamp string  `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// StatisticsTimeSeries defines the data points of one metric, the points are in ascending
+// order of the timestamp.
+type StatisticsTimeSeries struct {
+	Metric       string                `json:"metric"`
+	StorageClass string                `json:"storageClass,omitempty"`
+	Unit         string                `json:"unit"`
+	DataPoints   []StatisticsDataPoint `json:"dataPoints"`
+}
+
+// GetBucketStatisticsResult defines the statistics of the bucket.
+type GetBucketStatisticsResult struct {
+	Bucket string                 `json:"bucketName"`
+	Period int                    `json:"period"`
+	Series []StatisticsTimeSeries `json:"series"`
+}
+
+// PutBucketQuota - set the quota of the given bucket
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - quota: the quota of the bucket
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func PutBucketQuota(cli bce.Client, bucket string, quota *BucketQuota) error {
+	if err := quota.Check(); err != nil {
+		return err
+	}
+	jsonBytes, err := bce.MarshalJSON(quota)
+	if err != nil {
+		return err
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return err
+	}
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.PUT)
+	req.SetParam("quota", "")
+	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
+	req.SetBody(body)
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
+// GetBucketQuota - get the quota of the given bucket
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+// RETURNS:
+//     - *BucketQuota: the quota of the bucket
+//     - error: nil if success otherwise the specific error
+func GetBucketQuota(cli bce.Client, bucket string) (*BucketQuota, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.GET)
+	req.SetParam("quota", "")
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &BucketQuota{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteBucketQuota - delete the quota of the given bucket
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func DeleteBucketQuota(cli bce.Client, bucket string) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.DELETE)
+	req.SetParam("quota", "")
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	defer func() { resp.Body().Close() }()
+	return nil
+}
+
+// GetBucketStatistics - get the storage and traffic statistics of the given bucket
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - bucket: the bucket name
+//     - args: the time range, period and metrics to query, all metrics if no one is given
+// RETURNS:
+//     - *GetBucketStatisticsResult: the time series of the metrics
+//     - error: nil if success otherwise the specific error
+func GetBucketStatistics(cli bce.Client, bucket string,
+	args *GetBucketStatisticsArgs) (*GetBucketStatisticsResult, error) {
+	if args == nil {
+		return nil, bce.NewBceClientError("the statistics args should not be nil")
+	}
+	if args.StartTime <= 0 || args.EndTime < args.StartTime {
+		return nil, bce.NewBceClientError("invalid time range of the bucket statistics")
+	}
+	if args.Period < 0 {
+		return nil, bce.NewBceClientError("invalid period of the bucket statistics: " +
+			strconv.Itoa(args.Period))
+	}
+	req := &bce.BceRequest{}
+	req.SetUri(getBucketUri(bucket))
+	req.SetMethod(http.GET)
+	req.SetParam("statistics", "")
+	req.SetParam("startTime", util.FormatISO8601Date(args.StartTime))
+	req.SetParam("endTime", util.FormatISO8601Date(args.EndTime))
+	if args.Period > 0 {
+		req.SetParam("period", strconv.Itoa(args.Period))
+	}
+	if len(args.Metrics) != 0 {
+		req.SetParam("metrics", strings.Join(args.Metrics, ","))
+	}
+
+	resp := &bce.BceResponse{}
+	if err := SendRequest(cli, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &GetBucketStatisticsResult{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Get - get the time series of the metric and storage class, the storage class is ignored
+// if it is empty
+//
+// PARAMS:
+//     - metric: the metric name
+//     - storageClass: the storage class of the series
+// RETURNS:
+//     - *StatisticsTimeSeries: the matched series, nil if not found
+func (r *GetBucketStatisticsResult) Get(metric, storageClass string) *StatisticsTimeSeries {
+	for i := range r.Series {
+		if r.Series[i].Metric != metric {
+			continue
+		}
+		if len(storageClass) == 0 || r.Series[i].StorageClass == storageClass {
+			return &r.Series[i]
+		}
+	}
+	return nil
+}
+
+// Latest - get the last data point of the time series
+//
+// RETURNS:
+//     - StatisticsDataPoint: the last data point
+//     - bool: false if the series has no data point
+func (s *StatisticsTimeSeries) Latest() (StatisticsDataPoint, bool) {
+	if len(s.DataPoints) == 0 {
+		return StatisticsDataPoint{}, false
+	}
+	return s.DataPoints[len(s.DataPoints)-1], true
+}
+
+// Sum - get the sum of the data points, which is the total amount for the traffic and
+// request metrics
+//
+// RETURNS:
+//     - float64: the sum of the values
+func (s *StatisticsTimeSeries) Sum() float64 {
+	var sum float64
+	for _, p := range s.DataPoints {
+		sum += p.Value
+	}
+	return sum
+}
+
+// Max - get the max value of the data points, which is the peak for the storage metrics
+//
+// RETURNS:
+//     - float64: the max value, zero if the series has no data point
+func (s *StatisticsTimeSeries) Max() float64 {
+	var max float64
+	for i, p := range s.DataPoints {
+		if i == 0 || p.Value > max {
+			max = p.Value
+		}
+	}
+	return max
+}
+
+// Time - parse the timestamp of the data point
+//
+// RETURNS:
+//     - time.Time: the time of the data point in UTC
+//     - error: nil if success otherwise the parse error
+func (p StatisticsDataPoint) Time() (time.Time, error) {
+	return util.ParseISO8601Date(p.Timestamp)
+}
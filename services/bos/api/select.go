@@ -0,0 +1,293 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// select.go - define the request builders and the response parser of the select object api
+
+package api
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+)
+
+const (
+	SELECT_TYPE_CSV  = "csv"
+	SELECT_TYPE_JSON = "json"
+
+	SELECT_EXPRESSION_TYPE_SQL = "SQL"
+
+	SELECT_COMPRESSION_NONE = "NONE"
+	SELECT_COMPRESSION_GZIP = "GZIP"
+
+	SELECT_JSON_TYPE_DOCUMENT = "DOCUMENT"
+	SELECT_JSON_TYPE_LINES    = "LINES"
+
+	SELECT_CSV_HEADER_USE    = "USE"
+	SELECT_CSV_HEADER_IGNORE = "IGNORE"
+	SELECT_CSV_HEADER_NONE   = "NONE"
+
+	SELECT_MESSAGE_TYPE_HEADER = "message-type"
+	SELECT_MESSAGE_RECORDS     = "Records"
+	SELECT_MESSAGE_CONTINUE    = "Cont"
+	SELECT_MESSAGE_END         = "End"
+
+	selectPreludeLen = 8
+	selectCrc32Len   = 4
+
+	SELECT_MAX_MESSAGE_LEN = 16 * 1024 * 1024 // the max length of a message to be read in memory
+)
+
+// NewSelectObjectArgs - create the select object arguments with the sql expression, the expression
+// and the delimiters set by the builder methods are base64 encoded as the api required
+//
+// PARAMS:
+//     - selectType: the type of the object, csv or json
+//     - expression: the sql expression
+// RETURNS:
+//     - *SelectObjectArgs: the select object arguments
+func NewSelectObjectArgs(selectType, expression string) *SelectObjectArgs {
+	return &SelectObjectArgs{
+		SelectType: selectType,
+		SelectRequest: &SelectObjectRequest{
+			Expression:          base64.StdEncoding.EncodeToString([]byte(expression)),
+			ExpressionType:      SELECT_EXPRESSION_TYPE_SQL,
+			InputSerialization:  &SelectObjectInput{},
+			OutputSerialization: &SelectObjectOutput{},
+		},
+	}
+}
+
+// WithCompression sets the compression type of the object, NONE or GZIP.
+func (args *SelectObjectArgs) WithCompression(compressionType string) *SelectObjectArgs {
+	args.SelectRequest.InputSerialization.CompressionType = compressionType
+	return args
+}
+
+// WithCsvInput sets the csv format of the object, the empty delimiters use the server default.
+func (args *SelectObjectArgs) WithCsvInput(fileHeaderInfo, fieldDelimiter,
+	recordDelimiter string) *SelectObjectArgs {
+	params := make(map[string]string)
+	if len(fileHeaderInfo) != 0 {
+		params["fileHeaderInfo"] = fileHeaderInfo
+	}
+	setSelectDelimiter(params, "fieldDelimiter", fieldDelimiter)
+	setSelectDelimiter(params, "recordDelimiter", recordDelimiter)
+	args.SelectRequest.InputSerialization.CsvParams = params
+	return args
+}
+
+// WithJsonInput sets the json type of the object, DOCUMENT or LINES.
+func (args *SelectObjectArgs) WithJsonInput(jsonType string) *SelectObjectArgs {
+	args.SelectRequest.InputSerialization.JsonParams = map[string]string{"type": jsonType}
+	return args
+}
+
+// WithCsvOutput sets the csv format of the selected records.
+func (args *SelectObjectArgs) WithCsvOutput(fieldDelimiter, recordDelimiter string) *SelectObjectArgs {
+	params := make(map[string]string)
+	setSelectDelimiter(params, "fieldDelimiter", fieldDelimiter)
+	setSelectDelimiter(params, "recordDelimiter", recordDelimiter)
+	args.SelectRequest.OutputSerialization.CsvParams = params
+	return args
+}
+
+// WithJsonOutput sets the record delimiter of the selected json records.
+func (args *SelectObjectArgs) WithJsonOutput(recordDelimiter string) *SelectObjectArgs {
+	params := make(map[string]string)
+	setSelectDelimiter(params, "recordDelimiter", recordDelimiter)
+	args.SelectRequest.OutputSerialization.JsonParams = params
+	return args
+}
+
+// WithOutputHeader sets whether to output the header line of the csv object.
+func (args *SelectObjectArgs) WithOutputHeader(outputHeader bool) *SelectObjectArgs {
+	args.SelectRequest.OutputSerialization.OutputHeader = outputHeader
+	return args
+}
+
+// WithProgress sets whether to return the continuation messages with the scanning progress.
+func (args *SelectObjectArgs) WithProgress(enabled bool) *SelectObjectArgs {
+	args.SelectRequest.RequestProgress = &SelectObjectProgress{Enabled: enabled}
+	return args
+}
+
+func setSelectDelimiter(params map[string]string, key, delimiter string) {
+	if len(delimiter) != 0 {
+		params[key] = base64.StdEncoding.EncodeToString([]byte(delimiter))
+	}
+}
+
+// SelectObjectReader parses the framed messages of the select object response body. Each message
+// is composed of the prelude with the total and headers length, the headers, the payload and the
+// crc32 of all the previous bytes. A record may span the records messages, the partial record at
+// the end of a message is carried over and returned with the following message.
+type SelectObjectReader struct {
+	body            io.ReadCloser
+	recordDelimiter string
+	finished        bool
+	partial         string      // the trailing partial record of the previous records message
+	end             *EndMessage // the end message to return after the last partial record
+}
+
+// NewSelectObjectReader - create the reader to parse the select object response body
+//
+// PARAMS:
+//     - body: the body of the SelectObjectResult
+//     - recordDelimiter: the output record delimiter to split the records, default is "\n"
+// RETURNS:
+//     - *SelectObjectReader: the reader of the messages
+func NewSelectObjectReader(body io.ReadCloser, recordDelimiter string) *SelectObjectReader {
+	if len(recordDelimiter) == 0 {
+		recordDelimiter = "\n"
+	}
+	return &SelectObjectReader{body: body, recordDelimiter: recordDelimiter}
+}
+
+// Next - read the next message of the response
+//
+// RETURNS:
+//     - interface{}: the message, *RecordsMessage, *ContinuationMessage or *EndMessage
+//     - error: io.EOF after the end message is read, otherwise the specific error
+func (r *SelectObjectReader) Next() (interface{}, error) {
+	if r.end != nil {
+		end := r.end
+		r.end, r.finished = nil, true
+		return end, nil
+	}
+	if r.finished {
+		return nil, io.EOF
+	}
+	prelude := make([]byte, selectPreludeLen)
+	if _, err := io.ReadFull(r.body, prelude); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF // the end message is not received
+		}
+		return nil, err
+	}
+	common := CommonMessage{
+		Prelude: Prelude{
+			TotalLen:   binary.BigEndian.Uint32(prelude[0:4]),
+			HeadersLen: binary.BigEndian.Uint32(prelude[4:8]),
+		},
+	}
+	// in uint64 to avoid the overflow of the huge headers length of the malformed message
+	if uint64(common.TotalLen) < selectPreludeLen+selectCrc32Len+uint64(common.HeadersLen) ||
+		common.TotalLen > SELECT_MAX_MESSAGE_LEN {
+		return nil, bce.NewBceClientError(fmt.Sprintf(
+			"invalid select message length: %d with headers length %d",
+			common.TotalLen, common.HeadersLen))
+	}
+	msg := make([]byte, common.TotalLen-selectPreludeLen)
+	if _, err := io.ReadFull(r.body, msg); err != nil {
+		return nil, err
+	}
+	dataLen := len(msg) - selectCrc32Len
+	common.Crc32 = binary.BigEndian.Uint32(msg[dataLen:])
+	checksum := crc32.Update(crc32.ChecksumIEEE(prelude), crc32.IEEETable, msg[:dataLen])
+	if common.Crc32 != 0 && checksum != common.Crc32 {
+		return nil, bce.NewBceClientError(fmt.Sprintf("select message crc32 mismatch: %d != %d",
+			checksum, common.Crc32))
+	}
+	headers, err := parseSelectHeaders(msg[:common.HeadersLen])
+	if err != nil {
+		return nil, err
+	}
+	common.Headers = headers
+	payload := msg[common.HeadersLen:dataLen]
+
+	switch headers[SELECT_MESSAGE_TYPE_HEADER] {
+	case SELECT_MESSAGE_RECORDS:
+		records := strings.SplitAfter(r.partial+string(payload), r.recordDelimiter)
+		r.partial = records[len(records)-1]
+		return &RecordsMessage{CommonMessage: common, Records: records[:len(records)-1]}, nil
+	case SELECT_MESSAGE_CONTINUE:
+		if len(payload) < 16 {
+			return nil, bce.NewBceClientError("invalid select continuation message")
+		}
+		return &ContinuationMessage{
+			CommonMessage: common,
+			BytesScanned:  binary.BigEndian.Uint64(payload[0:8]),
+			BytesReturned: binary.BigEndian.Uint64(payload[8:16]),
+		}, nil
+	case SELECT_MESSAGE_END:
+		if len(r.partial) != 0 {
+			// the last record without the trailing delimiter
+			records := []string{r.partial}
+			r.partial, r.end = "", &EndMessage{CommonMessage: common}
+			return &RecordsMessage{Records: records}, nil
+		}
+		r.finished = true
+		return &EndMessage{CommonMessage: common}, nil
+	default:
+		return nil, bce.NewBceClientError("unknown select message type: " +
+			headers[SELECT_MESSAGE_TYPE_HEADER])
+	}
+}
+
+// ReadRecords - read all the messages and invoke the handler for each record
+//
+// PARAMS:
+//     - handler: the function to handle the record, stop reading if it returns error
+// RETURNS:
+//     - error: nil if all the records are handled otherwise the specific error
+func (r *SelectObjectReader) ReadRecords(handler func(record string) error) error {
+	for {
+		msg, err := r.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if records, ok := msg.(*RecordsMessage); ok {
+			for _, record := range records.Records {
+				if err := handler(record); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// Close - close the response body
+func (r *SelectObjectReader) Close() error {
+	return r.body.Close()
+}
+
+// parseSelectHeaders - parse the headers of the message, each header is composed of the 1 byte
+// name length, the name, the 2 bytes value length and the value
+func parseSelectHeaders(data []byte) (map[string]string, error) {
+	headers := make(map[string]string)
+	for len(data) > 0 {
+		nameLen := int(data[0])
+		if len(data) < 1+nameLen+2 {
+			return nil, bce.NewBceClientError("invalid select message headers")
+		}
+		name := string(data[1 : 1+nameLen])
+		data = data[1+nameLen:]
+		valueLen := int(binary.BigEndian.Uint16(data[0:2]))
+		if len(data) < 2+valueLen {
+			return nil, bce.NewBceClientError("invalid select message headers")
+		}
+		headers[name] = string(data[2 : 2+valueLen])
+		data = data[2+valueLen:]
+	}
+	return headers, nil
+}
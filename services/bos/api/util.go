@@ -18,17 +18,34 @@ package api
 
 import (
 	"bytes"
+	"fmt"
+	"net"
 	net_http "net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/util/log"
 )
 
 const (
 	METADATA_DIRECTIVE_COPY    = "copy"
 	METADATA_DIRECTIVE_REPLACE = "replace"
 
+	TAGGING_DIRECTIVE_COPY    = "copy"
+	TAGGING_DIRECTIVE_REPLACE = "replace"
+
+	MAX_OBJECT_TAG_NUM       = 10
+	MAX_OBJECT_TAG_KEY_LEN   = 128
+	MAX_OBJECT_TAG_VALUE_LEN = 256
+
+	MAX_DELETE_OBJECT_NUM = 1000 // the max number of objects to delete in one request
+
 	STORAGE_CLASS_STANDARD    = "STANDARD"
 	STORAGE_CLASS_STANDARD_IA = "STANDARD_IA"
 	STORAGE_CLASS_COLD        = "COLD"
@@ -50,6 +67,23 @@ const (
 
 	ENCRYPTION_AES256 = "AES256"
 
+	NOTIFICATION_EVENT_PUT_OBJECT                = "PutObject"
+	NOTIFICATION_EVENT_POST_OBJECT               = "PostObject"
+	NOTIFICATION_EVENT_APPEND_OBJECT             = "AppendObject"
+	NOTIFICATION_EVENT_COPY_OBJECT               = "CopyObject"
+	NOTIFICATION_EVENT_FETCH_OBJECT              = "FetchObject"
+	NOTIFICATION_EVENT_COMPLETE_MULTIPART_UPLOAD = "CompleteMultipartUpload"
+	NOTIFICATION_EVENT_DELETE_OBJECT             = "DeleteObject"
+	NOTIFICATION_EVENT_DELETE_MULTIPLE_OBJECTS   = "DeleteMultipleObjects"
+
+	INVENTORY_SCHEDULE_DAILY  = "Daily"
+	INVENTORY_SCHEDULE_WEEKLY = "Weekly"
+	INVENTORY_FORMAT_CSV      = "CSV"
+
+	OBJECT_LOCK_STATUS_IN_PROGRESS = "IN_PROGRESS"
+	OBJECT_LOCK_STATUS_LOCKED      = "LOCKED"
+	OBJECT_LOCK_STATUS_EXPIRED     = "EXPIRED"
+
 	RESTORE_TIER_STANDARD  = "Standard"  //标准取回对象
 	RESTORE_TIER_EXPEDITED = "Expedited" //快速取回对象
 
@@ -68,6 +102,17 @@ var VALID_STORAGE_CLASS_TYPE = map[string]int{
 	STORAGE_CLASS_ARCHIVE:     3,
 }
 
+var VALID_NOTIFICATION_EVENT = map[string]int{
+	NOTIFICATION_EVENT_PUT_OBJECT:                1,
+	NOTIFICATION_EVENT_POST_OBJECT:               1,
+	NOTIFICATION_EVENT_APPEND_OBJECT:             1,
+	NOTIFICATION_EVENT_COPY_OBJECT:               1,
+	NOTIFICATION_EVENT_FETCH_OBJECT:              1,
+	NOTIFICATION_EVENT_COMPLETE_MULTIPART_UPLOAD: 1,
+	NOTIFICATION_EVENT_DELETE_OBJECT:             1,
+	NOTIFICATION_EVENT_DELETE_MULTIPLE_OBJECTS:   1,
+}
+
 var VALID_RESTORE_TIER = map[string]int{
 	RESTORE_TIER_STANDARD:  1,
 	RESTORE_TIER_EXPEDITED: 1,
@@ -116,6 +161,77 @@ func validMetadataDirective(val string) bool {
 	return false
 }
 
+func validTaggingDirective(val string) bool {
+	if val == TAGGING_DIRECTIVE_COPY || val == TAGGING_DIRECTIVE_REPLACE {
+		return true
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validObjectTags - check the number of the tags and the length of their keys and values
+func validObjectTags(args *PutObjectTaggingArgs) error {
+	keys := make(map[string]bool)
+	for _, tagSet := range args.TagSet {
+		for _, tag := range tagSet.TagInfo {
+			if err := validObjectTag(tag.Key, tag.Value); err != nil {
+				return err
+			}
+			if keys[tag.Key] {
+				return bce.NewBceClientError(fmt.Sprintf("object tag key %q is duplicated", tag.Key))
+			}
+			keys[tag.Key] = true
+		}
+	}
+	if len(keys) > MAX_OBJECT_TAG_NUM {
+		return bce.NewBceClientError(fmt.Sprintf("object tags number should not be more than %d",
+			MAX_OBJECT_TAG_NUM))
+	}
+	return nil
+}
+
+func validObjectTag(key, value string) error {
+	if len(key) == 0 {
+		return bce.NewBceClientError("object tag key should not be empty")
+	}
+	if utf8.RuneCountInString(key) > MAX_OBJECT_TAG_KEY_LEN {
+		return bce.NewBceClientError(fmt.Sprintf("object tag key %q is longer than %d characters",
+			key, MAX_OBJECT_TAG_KEY_LEN))
+	}
+	if utf8.RuneCountInString(value) > MAX_OBJECT_TAG_VALUE_LEN {
+		return bce.NewBceClientError(fmt.Sprintf(
+			"object tag value of key %q is longer than %d characters", key, MAX_OBJECT_TAG_VALUE_LEN))
+	}
+	return nil
+}
+
+func setObjectTagging(req *bce.BceRequest, tags map[string]string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	if len(tags) > MAX_OBJECT_TAG_NUM {
+		return bce.NewBceClientError(fmt.Sprintf("object tags number should not be more than %d",
+			MAX_OBJECT_TAG_NUM))
+	}
+	pairs := make([]string, 0, len(tags))
+	for _, k := range sortedKeys(tags) {
+		if err := validObjectTag(k, tags[k]); err != nil {
+			return err
+		}
+		pairs = append(pairs, url.QueryEscape(k)+"="+url.QueryEscape(tags[k]))
+	}
+	req.SetHeader(http.BCE_TAGGING, strings.Join(pairs, "&"))
+	return nil
+}
+
 func validForbidOverwrite(val string) bool {
 	if _, ok := VALID_FORBID_OVERWRITE[val]; ok {
 		return true
@@ -209,6 +325,70 @@ func setOptionalNullHeaders(req *bce.BceRequest, args map[string]string) {
 	}
 }
 
+// setHeaders - set the conditional headers of the get object request, nil sets nothing. The
+// times are formatted in the GMT as required by the HTTP instead of the RFC822Format with UTC.
+func (c *GetObjectConditions) setHeaders(req *bce.BceRequest) {
+	if c == nil {
+		return
+	}
+	if len(c.IfMatch) != 0 {
+		req.SetHeader(http.IF_MATCH, quoteETag(c.IfMatch))
+	}
+	if len(c.IfNoneMatch) != 0 {
+		req.SetHeader(http.IF_NONE_MATCH, quoteETag(c.IfNoneMatch))
+	}
+	if !c.IfModifiedSince.IsZero() {
+		req.SetHeader(http.IF_MODIFIED_SINCE,
+			c.IfModifiedSince.UTC().Format(net_http.TimeFormat))
+	}
+	if !c.IfUnmodifiedSince.IsZero() {
+		req.SetHeader(http.IF_UNMODIFIED_SINCE,
+			c.IfUnmodifiedSince.UTC().Format(net_http.TimeFormat))
+	}
+}
+
+// quoteETag - quote the ETag returned by the SDK without the quotes, "*" is kept as is
+func quoteETag(etag string) string {
+	if etag == "*" || strings.HasPrefix(etag, "\"") || strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return "\"" + etag + "\""
+}
+
+// ParseContentRange - parse the Content-Range header like "bytes 0-99/1000" of the partial
+// content, the total is -1 if it is given as "*"
+//
+// PARAMS:
+//     - value: the value of the Content-Range header
+// RETURNS:
+//     - *ContentRange: the parsed range
+//     - error: nil if ok otherwise the specific error
+func ParseContentRange(value string) (*ContentRange, error) {
+	invalid := fmt.Errorf("invalid Content-Range %q", value)
+	if !strings.HasPrefix(value, "bytes ") {
+		return nil, invalid
+	}
+	parts := strings.SplitN(strings.TrimSpace(value[len("bytes "):]), "/", 2)
+	bounds := strings.SplitN(parts[0], "-", 2)
+	if len(parts) != 2 || len(bounds) != 2 {
+		return nil, invalid
+	}
+	start, err1 := strconv.ParseInt(bounds[0], 10, 64)
+	end, err2 := strconv.ParseInt(bounds[1], 10, 64)
+	if err1 != nil || err2 != nil || start < 0 || end < start {
+		return nil, invalid
+	}
+	result := &ContentRange{Start: start, End: end, Total: -1}
+	if parts[1] != "*" {
+		total, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || total <= end {
+			return nil, invalid
+		}
+		result.Total = total
+	}
+	return result, nil
+}
+
 func setUserMetadata(req *bce.BceRequest, meta map[string]string) error {
 	if meta == nil {
 		return nil
@@ -234,41 +414,141 @@ func isCnameLikeHost(host string) bool {
 	return false
 }
 
-func SendRequest(cli bce.Client, req *bce.BceRequest, resp *bce.BceResponse) error {
-	var (
-		err        error
-		need_retry bool
-	)
+// setRequestAddress - set the endpoint and uri of the request by the addressing style: the bucket
+// is implied by the host for the cname endpoint, and is put into the host for the virtual-hosted
+// style unless the bucket name contains dots or the endpoint is an ip, otherwise the path style
+// is used which puts the bucket at the beginning of the uri.
+func setRequestAddress(conf *bce.BceClientConfiguration, req *bce.BceRequest, endpoint, originUri string) {
+	setRequestAddressStyle(conf, req, endpoint, originUri, conf.VirtualHostEnabled)
+}
 
-	req.SetEndpoint(cli.GetBceClientConfig().Endpoint)
+// setRequestAddressStyle - set the endpoint and uri of the request as the setRequestAddress does
+// with the given addressing style instead of the VirtualHostEnabled of the configuration
+func setRequestAddressStyle(conf *bce.BceClientConfiguration, req *bce.BceRequest, endpoint,
+	originUri string, virtualHost bool) {
+	req.SetEndpoint(endpoint)
+	if conf.CnameEnabled || isCnameLikeHost(endpoint) {
+		req.SetUri(getCnameUri(originUri))
+		return
+	}
+	req.SetUri(originUri)
+	if !virtualHost {
+		return
+	}
+	bucket := strings.TrimPrefix(originUri, bce.URI_PREFIX)
+	if pos := strings.Index(bucket, "/"); pos != -1 {
+		bucket = bucket[:pos]
+	}
+	domain := req.Host()
+	if pos := strings.Index(domain, ":"); pos != -1 {
+		domain = domain[:pos]
+	}
+	if len(bucket) == 0 || strings.Contains(bucket, ".") || net.ParseIP(domain) != nil {
+		return
+	}
+	req.SetHost(bucket + "." + req.Host())
+	req.SetUri(getCnameUri(originUri))
+}
+
+// SendRequest - send the request to the endpoints of the client in order until it succeeds or
+// fails with the error which should not be sent to another endpoint, see shouldFailover. Only the
+// request without body or with the rewindable body is sent to another endpoint, and the body is
+// rewound to the start before each attempt.
+func SendRequest(cli bce.Client, req *bce.BceRequest, resp *bce.BceResponse) error {
+	conf := cli.GetBceClientConfig()
 	origin_uri := req.Uri()
-	// set uri for cname or cdn endpoint
-	if cli.GetBceClientConfig().CnameEnabled || isCnameLikeHost(cli.GetBceClientConfig().Endpoint) {
-		req.SetUri(getCnameUri(origin_uri))
-	}
-
-	if err = cli.SendRequest(req, resp); err != nil {
-		if serviceErr, isServiceErr := err.(*bce.BceServiceError); isServiceErr {
-			if serviceErr.StatusCode == net_http.StatusInternalServerError ||
-				serviceErr.StatusCode == net_http.StatusBadGateway ||
-				serviceErr.StatusCode == net_http.StatusServiceUnavailable ||
-				(serviceErr.StatusCode == net_http.StatusBadRequest && serviceErr.Code == "Http400") {
-				need_retry = true
+	endpoints := conf.Endpoints()
+	if len(endpoints) > 1 {
+		if req.Resendable() {
+			closeBody := req.HoldBody()
+			defer closeBody()
+		} else {
+			endpoints = endpoints[:1]
+		}
+	}
+	var err error
+	for i, endpoint := range endpoints {
+		if i > 0 {
+			if err := req.RewindBody(); err != nil {
+				return err
+			}
+		}
+		setRequestAddress(conf, req, endpoint, origin_uri)
+		if err = cli.SendRequest(req, resp); err == nil {
+			if len(endpoints) > 1 {
+				conf.MarkEndpointGood(endpoint)
 			}
+			return nil
+		}
+		if i == len(endpoints)-1 || !shouldFailover(req, err) {
+			break
+		}
+		log.Warnf("send request to %s failed: %v, fail over to %s", endpoint, err, endpoints[i+1])
+	}
+	return err
+}
+
+// shouldFailover - check whether the request should be sent to another endpoint, which is true
+// for the connection-level error and the server error of the endpoint. The POST request is not
+// idempotent and may have been applied by the endpoint responding the server error, so it is
+// only sent to another endpoint for the connection-level error.
+func shouldFailover(req *bce.BceRequest, err error) bool {
+	if serviceErr, isServiceErr := err.(*bce.BceServiceError); isServiceErr {
+		if serviceErr.StatusCode == net_http.StatusBadRequest && serviceErr.Code == "Http400" {
+			return true
 		}
-		if _, isClientErr := err.(*bce.BceClientError); isClientErr {
-			need_retry = true
+		if req.Method() == http.POST {
+			return false
 		}
-		// retry backup endpoint
-		if need_retry && cli.GetBceClientConfig().BackupEndpoint != "" {
-			req.SetEndpoint(cli.GetBceClientConfig().BackupEndpoint)
-			if cli.GetBceClientConfig().CnameEnabled || isCnameLikeHost(cli.GetBceClientConfig().BackupEndpoint) {
-				req.SetUri(getCnameUri(origin_uri))
+		return serviceErr.StatusCode == net_http.StatusInternalServerError ||
+			serviceErr.StatusCode == net_http.StatusBadGateway ||
+			serviceErr.StatusCode == net_http.StatusServiceUnavailable
+	}
+	return bce.IsConnectionError(err)
+}
+
+// ParseRestoreStatus - parse the value of the `x-bce-restore` header, which looks like
+// `ongoing-request="false", expiry-date="Wed, 07 Nov 2022 00:00:00 GMT"`.
+//
+// PARAMS:
+//     - val: the header value
+// RETURNS:
+//     - *RestoreStatus: the parsed restore status, nil if the value is empty
+//     - error: nil if success otherwise the specific error
+func ParseRestoreStatus(val string) (*RestoreStatus, error) {
+	if len(val) == 0 {
+		return nil, nil
+	}
+	status := &RestoreStatus{}
+	for len(val) > 0 {
+		eq := strings.Index(val, "=")
+		if eq < 0 {
+			return nil, bce.NewBceClientError("invalid restore status: " + val)
+		}
+		key := strings.TrimSpace(strings.TrimLeft(val[:eq], ", "))
+		rest := strings.TrimSpace(val[eq+1:])
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			end := strings.Index(rest[1:], "\"")
+			if end < 0 {
+				return nil, bce.NewBceClientError("invalid restore status: " + val)
 			}
-			if err = cli.SendRequest(req, resp); err != nil {
-				return err
+			value, val = rest[1:end+1], rest[end+2:]
+		} else if comma := strings.Index(rest, ","); comma >= 0 {
+			value, val = rest[:comma], rest[comma:]
+		} else {
+			value, val = rest, ""
+		}
+		switch key {
+		case "ongoing-request":
+			status.OngoingRequest = strings.EqualFold(value, "true")
+		case "expiry-date":
+			date, err := time.Parse(net_http.TimeFormat, value)
+			if err != nil {
+				return nil, bce.NewBceClientError("invalid restore expiry date: " + value)
 			}
+			status.ExpiryDate = date
 		}
 	}
-	return err
+	return status, nil
 }
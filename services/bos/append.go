@@ -0,0 +1,109 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// append.go - the writer to append the content to an appendable object sequentially
+
+package bos
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+// OBJECT_TYPE_APPENDABLE is the object type of the object created by the AppendObject
+const OBJECT_TYPE_APPENDABLE = "Appendable"
+
+// AppendWriter implements the io.Writer interface to append the content of every Write to the
+// object at the tracked next append offset, it is safe for concurrent use and the contents of
+// the concurrent writes are not interleaved. Every Write sends a request, so wrap it with the
+// bufio.Writer to append larger chunks for the small writes such as log lines.
+type AppendWriter struct {
+	client *Client
+	bucket string
+	object string
+	args   api.AppendObjectArgs
+	mu     sync.Mutex
+	offset int64
+}
+
+// NewAppendWriter - create the writer to append to the object, which is created by the first
+// Write if it does not exist, otherwise the writing starts from the end of the appendable object
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the object
+//     - args: the optional arguments used by every append, the Offset is ignored
+// RETURNS:
+//     - *AppendWriter: the writer to append to the object
+//     - error: any error if it occurs
+func (c *Client) NewAppendWriter(bucket, object string,
+	args *api.AppendObjectArgs) (*AppendWriter, error) {
+	w := &AppendWriter{client: c, bucket: bucket, object: object}
+	if args != nil {
+		w.args = *args
+	}
+	meta, err := api.GetObjectMeta(c, bucket, object)
+	if err != nil {
+		if serviceErr, ok := err.(*bce.BceServiceError); ok &&
+			serviceErr.StatusCode == http.StatusNotFound {
+			return w, nil
+		}
+		return nil, err
+	}
+	if meta.ObjectType != OBJECT_TYPE_APPENDABLE {
+		return nil, bce.NewBceClientError("the object " + object + " is not appendable")
+	}
+	if w.offset, err = strconv.ParseInt(meta.NextAppendOffset, 10, 64); err != nil {
+		return nil, bce.NewBceClientError("invalid next append offset: " + meta.NextAppendOffset)
+	}
+	return w, nil
+}
+
+// Write - append the content to the object at the next append offset
+//
+// PARAMS:
+//     - p: the content to append
+// RETURNS:
+//     - int: the length of the appended content, len(p) if success otherwise 0
+//     - error: any error if it occurs
+func (w *AppendWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	body, err := bce.NewBodyFromBytes(p)
+	if err != nil {
+		return 0, err
+	}
+	args := w.args
+	args.Offset = w.offset
+	res, err := api.AppendObject(w.client, w.bucket, w.object, body, &args)
+	if err != nil {
+		return 0, err
+	}
+	w.offset = res.NextAppendOffset
+	return len(p), nil
+}
+
+// Offset - get the next append offset, which is also the size of the object
+func (w *AppendWriter) Offset() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.offset
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// checksum.go - compute the CRC checksums of the uploaded and downloaded data while streaming and
+// verify them with the checksums returned by the service
+
+package bos
+
+import (
+	"io"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+	"github.com/baidubce/bce-sdk-go/util/checksum"
+)
+
+// PutObjectWithChecksum - put the object like the PutObject and compute the checksum of the body
+// while it is sent. The computed checksum is verified with the one returned by the service when
+// the service supports the algorithm, CRC64 is computed only.
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the object
+//     - body: the input content of the object
+//     - alg: the checksum algorithm
+//     - args: the optional arguments
+// RETURNS:
+//     - *api.PutObjectResult: the etag and the checksums returned by the service
+//     - *checksum.Hash: the checksum computed from the sent content
+//     - error: any error if it occurs, a *checksum.MismatchError if the checksums differ
+func (c *Client) PutObjectWithChecksum(bucket, object string, body *bce.Body,
+	alg checksum.Algorithm, args *api.PutObjectArgs) (*api.PutObjectResult, *checksum.Hash, error) {
+	if body == nil {
+		return nil, nil, bce.NewBceClientError("PutObjectWithChecksum body should not be nil")
+	}
+	h, err := checksum.New(alg)
+	if err != nil {
+		return nil, nil, bce.NewBceClientError(err.Error())
+	}
+	if alg == checksum.CRC32C {
+		withFlag := api.PutObjectArgs{}
+		if args != nil {
+			withFlag = *args
+		}
+		withFlag.ContentCrc32cFlag = true
+		args = &withFlag
+	}
+	body.SetStream(newChecksumStream(body.Stream(), h))
+	result, err := api.PutObjectWithResult(c, bucket, object, body, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, h, checksum.Verify(h, serviceChecksum(alg, result.ContentCrc32, result.ContentCrc32c))
+}
+
+// UploadPartWithChecksum - upload the single part like the UploadPart and compute the checksum of
+// the part while it is sent. Add the returned checksum to a checksum.Combiner with the part number
+// to get the checksum of the whole object for the CompleteMultipartUploadWithChecksum.
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the object
+//     - uploadId: the multipart upload id
+//     - partNumber: the current part number
+//     - content: the uploaded part content
+//     - alg: the checksum algorithm
+//     - args: the optional arguments
+// RETURNS:
+//     - *api.UploadPartResult: the etag and the checksums returned by the service
+//     - *checksum.Hash: the checksum computed from the sent part
+//     - error: any error if it occurs, a *checksum.MismatchError if the checksums differ
+func (c *Client) UploadPartWithChecksum(bucket, object, uploadId string, partNumber int,
+	content *bce.Body, alg checksum.Algorithm,
+	args *api.UploadPartArgs) (*api.UploadPartResult, *checksum.Hash, error) {
+	if content == nil {
+		return nil, nil, bce.NewBceClientError("upload part content should not be empty")
+	}
+	h, err := checksum.New(alg)
+	if err != nil {
+		return nil, nil, bce.NewBceClientError(err.Error())
+	}
+	if alg == checksum.CRC32C {
+		withFlag := api.UploadPartArgs{}
+		if args != nil {
+			withFlag = *args
+		}
+		withFlag.ContentCrc32cFlag = true
+		args = &withFlag
+	}
+	content.SetStream(newChecksumStream(content.Stream(), h))
+	result, err := api.UploadPartWithResult(c, bucket, object, uploadId, partNumber, content, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, h, checksum.Verify(h, serviceChecksum(alg, result.ContentCrc32, result.ContentCrc32c))
+}
+
+// CompleteMultipartUploadWithChecksum - complete the multipart upload like the
+// CompleteMultipartUploadFromStruct and verify the checksum of the whole object combined from
+// the checksums of the parts.
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the object
+//     - uploadId: the multipart upload id
+//     - args: the parts to be completed
+//     - parts: the combiner holding the checksums of all the uploaded parts
+// RETURNS:
+//     - *api.CompleteMultipartUploadResult: the result of completing the upload
+//     - uint64: the checksum of the whole object combined from the parts
+//     - error: any error if it occurs, a *checksum.MismatchError if the checksums differ
+func (c *Client) CompleteMultipartUploadWithChecksum(bucket, object, uploadId string,
+	args *api.CompleteMultipartUploadArgs,
+	parts *checksum.Combiner) (*api.CompleteMultipartUploadResult, uint64, error) {
+	if parts == nil {
+		return nil, 0, bce.NewBceClientError("the part checksums should not be nil")
+	}
+	sum, _, err := parts.Sum64()
+	if err != nil {
+		return nil, 0, bce.NewBceClientError(err.Error())
+	}
+	result, err := c.CompleteMultipartUploadFromStruct(bucket, object, uploadId, args)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, sum, checksum.VerifySum(parts.Algorithm, sum,
+		serviceChecksum(parts.Algorithm, result.ContentCrc32, result.ContentCrc32c))
+}
+
+// GetObjectWithChecksum - get the object like the GetObject with the body wrapped to compute the
+// checksum while it is read. When the whole object is got, reading the body to the end returns
+// a *checksum.MismatchError instead of io.EOF if the checksum differs from the one returned by the
+// service. The returned hash holds the final checksum after the body is read to the end.
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the object
+//     - alg: the checksum algorithm
+//     - responseHeaders: the optional response headers to get the given object
+//     - ranges: the optional range start and end to get the given object
+// RETURNS:
+//     - *api.GetObjectResult: the result of the object with the wrapped body
+//     - *checksum.Hash: the checksum computed from the read content
+//     - error: any error if it occurs
+func (c *Client) GetObjectWithChecksum(bucket, object string, alg checksum.Algorithm,
+	responseHeaders map[string]string, ranges ...int64) (*api.GetObjectResult, *checksum.Hash, error) {
+	h, err := checksum.New(alg)
+	if err != nil {
+		return nil, nil, bce.NewBceClientError(err.Error())
+	}
+	result, err := api.GetObject(c, bucket, object, responseHeaders, ranges...)
+	if err != nil {
+		return nil, nil, err
+	}
+	expected := ""
+	if len(ranges) == 0 && result.ContentRange == "" {
+		expected = serviceChecksum(alg, result.ContentCrc32, result.ContentCrc32c)
+	}
+	result.Body = checksum.NewVerifyReadCloser(result.Body, h, expected)
+	return result, h, nil
+}
+
+// serviceChecksum - select the checksum returned by the service of the given algorithm
+func serviceChecksum(alg checksum.Algorithm, crc32, crc32c string) string {
+	switch alg {
+	case checksum.CRC32:
+		return crc32
+	case checksum.CRC32C:
+		return crc32c
+	}
+	return ""
+}
+
+// newChecksumStream - wrap the body stream to write the content to the hash while it is read
+func newChecksumStream(rc io.ReadCloser, h *checksum.Hash) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{checksum.TeeReader(rc, h), rc}
+}
@@ -0,0 +1,158 @@
+package bos
+
+import (
+	"encoding/json"
+	"hash/crc32"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+	"github.com/baidubce/bce-sdk-go/util/checksum"
+)
+
+// newChecksumServer - start the server returning the CRC32 checksum of the stored content, the
+// checksum of the "corrupt" object is wrong
+func newChecksumServer(t *testing.T) (*httptest.Server, *Client) {
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	parts := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := ioutil.ReadAll(r.Body)
+		query := r.URL.Query()
+		key := r.URL.Path
+		var content []byte
+		switch {
+		case r.Method == http.MethodPut && len(query.Get("partNumber")) != 0:
+			parts[query.Get("partNumber")] = body
+			content = body
+		case r.Method == http.MethodPut:
+			objects[key] = body
+			content = body
+		case r.Method == http.MethodPost:
+			args := &api.CompleteMultipartUploadArgs{}
+			if err := json.Unmarshal(body, args); err != nil {
+				t.Error(err)
+			}
+			for _, p := range args.Parts {
+				content = append(content, parts[strconv.Itoa(p.PartNumber)]...)
+			}
+			objects[key] = content
+			w.Header().Set("Content-Type", "application/json")
+			defer w.Write([]byte(`{"bucket":"bucket","key":"object","eTag":"etag"}`))
+		case r.Method == http.MethodGet:
+			content = objects[key]
+			defer w.Write(content)
+		}
+		sum := crc32.ChecksumIEEE(content)
+		if strings.HasSuffix(key, "/corrupt") {
+			sum++
+		}
+		w.Header().Set("ETag", "etag")
+		w.Header().Set("x-bce-content-crc32", strconv.FormatUint(uint64(sum), 10))
+	}))
+	client, err := NewClient("ak", "sk", server.URL)
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	client.Config.Retry = bce.NewNoRetryPolicy()
+	return server, client
+}
+
+func TestPutObjectWithChecksum(t *testing.T) {
+	server, client := newChecksumServer(t)
+	defer server.Close()
+
+	body, _ := bce.NewBodyFromString("123456789")
+	_, h, err := client.PutObjectWithChecksum("bucket", "object", body, checksum.CRC32, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Sum64() != 0xCBF43926 {
+		t.Errorf("checksum: got %x", h.Sum64())
+	}
+
+	body, _ = bce.NewBodyFromString("123456789")
+	_, _, err = client.PutObjectWithChecksum("bucket", "corrupt", body, checksum.CRC32, nil)
+	if !checksum.IsMismatch(err) {
+		t.Errorf("corrupt: got %v", err)
+	}
+
+	// the service does not return the CRC64 which is computed only
+	body, _ = bce.NewBodyFromString("123456789")
+	if _, _, err = client.PutObjectWithChecksum("bucket", "corrupt", body, checksum.CRC64,
+		nil); err != nil {
+		t.Errorf("crc64: got %v", err)
+	}
+}
+
+func TestMultipartUploadWithChecksum(t *testing.T) {
+	server, client := newChecksumServer(t)
+	defer server.Close()
+
+	contents := []string{"hello, ", "", "world"}
+	for _, object := range []string{"object", "corrupt"} {
+		combiner := checksum.NewCombiner(checksum.CRC32)
+		args := &api.CompleteMultipartUploadArgs{}
+		for i, content := range contents {
+			body, _ := bce.NewBodyFromString(content)
+			res, h, err := client.UploadPartWithChecksum("bucket", object, "upload", i+1, body,
+				checksum.CRC32, nil)
+			if err != nil && object == "object" {
+				t.Fatal(err)
+			}
+			combiner.Add(checksum.Part{Number: i + 1, Sum: h.Sum64(), Size: h.Size()})
+			args.Parts = append(args.Parts, api.UploadInfoType{PartNumber: i + 1, ETag: res.ETag})
+		}
+		_, sum, err := client.CompleteMultipartUploadWithChecksum("bucket", object, "upload",
+			args, combiner)
+		if object == "corrupt" {
+			if !checksum.IsMismatch(err) {
+				t.Errorf("corrupt: got %v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := crc32.ChecksumIEEE([]byte("hello, world")); sum != uint64(expected) {
+			t.Errorf("checksum: got %x, expected %x", sum, expected)
+		}
+	}
+}
+
+func TestGetObjectWithChecksum(t *testing.T) {
+	server, client := newChecksumServer(t)
+	defer server.Close()
+
+	for _, object := range []string{"object", "corrupt"} {
+		body, _ := bce.NewBodyFromString("123456789")
+		client.PutObject("bucket", object, body, nil)
+		res, h, err := client.GetObjectWithChecksum("bucket", object, checksum.CRC32, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if string(data) != "123456789" {
+			t.Errorf("%s: got %q", object, data)
+		}
+		if object == "corrupt" {
+			if !checksum.IsMismatch(err) {
+				t.Errorf("corrupt: got %v", err)
+			}
+			continue
+		}
+		if err != nil || h.Sum64() != 0xCBF43926 {
+			t.Errorf("checksum: got %x and %v", h.Sum64(), err)
+		}
+	}
+}
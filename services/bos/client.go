@@ -19,12 +19,13 @@
 package bos
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/baidubce/bce-sdk-go/auth"
 	"github.com/baidubce/bce-sdk-go/bce"
@@ -53,12 +54,27 @@ type Client struct {
 	MultipartSize int64
 }
 
+// WithOptions - copy the client with the configuration overridden by the given options, the
+// calls of the copied client use the overridden settings and the original client is unchanged
+//
+// PARAMS:
+//     - opts: the options such as bce.WithTimeout, bce.WithRetryPolicy, bce.WithHeader and
+//       bce.WithCredentials
+// RETURNS:
+//     - *Client: the copied BOS client
+func (c *Client) WithOptions(opts ...bce.RequestOption) *Client {
+	client := *c
+	client.BceClient = c.BceClient.WithOptions(opts...)
+	return &client
+}
+
 // BosClientConfiguration defines the config components structure by user.
 type BosClientConfiguration struct {
-	Ak               string
-	Sk               string
-	Endpoint         string
-	RedirectDisabled bool
+	Ak                 string
+	Sk                 string
+	Endpoint           string
+	RedirectDisabled   bool
+	VirtualHostEnabled bool
 }
 
 // NewClient make the BOS service client with default configuration.
@@ -97,7 +113,8 @@ func NewClientWithConfig(config *BosClientConfiguration) (*Client, error) {
 		SignOption:                defaultSignOptions,
 		Retry:                     bce.DEFAULT_RETRY_POLICY,
 		ConnectionTimeoutInMillis: bce.DEFAULT_CONNECTION_TIMEOUT_IN_MILLIS,
-		RedirectDisabled:          config.RedirectDisabled}
+		RedirectDisabled:          config.RedirectDisabled,
+		VirtualHostEnabled:        config.VirtualHostEnabled}
 	v1Signer := &auth.BceV1Signer{}
 
 	client := &Client{bce.NewBceClient(defaultConf, v1Signer),
@@ -105,6 +122,28 @@ func NewClientWithConfig(config *BosClientConfiguration) (*Client, error) {
 	return client, nil
 }
 
+// NewClientFromProfile - make the BOS service client from the named profile of the config file,
+// the settings of the "bos" section override the ones of the profile, see bce.LoadConfigFile.
+// The endpoint is "{region}.bcebos.com" if it is not given by the profile.
+//
+// PARAMS:
+//     - profile: the name of the profile, bce.DEFAULT_PROFILE if it is empty
+// RETURNS:
+//     - *Client: the BOS client
+//     - error: nil if ok otherwise the error to load the profile
+func NewClientFromProfile(profile string) (*Client, error) {
+	conf, err := bce.LoadProfileConfig(profile, "bos")
+	if err != nil {
+		return nil, err
+	}
+	if len(conf.Endpoint) == 0 {
+		conf.Endpoint = conf.Region + ".bcebos.com"
+	}
+	client := &Client{bce.NewBceClient(conf, &auth.BceV1Signer{}),
+		DEFAULT_MAX_PARALLEL, DEFAULT_MULTIPART_SIZE}
+	return client, nil
+}
+
 // ListBuckets - list all buckets
 //
 // RETURNS:
@@ -127,6 +166,21 @@ func (c *Client) ListObjects(bucket string,
 	return api.ListObjects(c, bucket, args)
 }
 
+// ListObjectsStream - list the objects of the given bucket and handle them one by one without
+// reading the whole response into memory
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - args: the optional arguments to list objects
+//     - fn: the function called with each object summary, the listing stops if it returns error
+// RETURNS:
+//     - *api.ListObjectsResult: the result without the Contents, eg: the NextMarker
+//     - error: the return error if any occurs
+func (c *Client) ListObjectsStream(bucket string, args *api.ListObjectsArgs,
+	fn func(object *api.ObjectSummaryType) error) (*api.ListObjectsResult, error) {
+	return api.ListObjectsStream(c, bucket, args, fn)
+}
+
 // SimpleListObjects - list all objects of the given bucket with simple arguments
 //
 // PARAMS:
@@ -195,6 +249,7 @@ func (c *Client) PutBucket(bucket string) (string, error) {
 // RETURNS:
 //     - error: nil if delete success otherwise the specific error
 func (c *Client) DeleteBucket(bucket string) error {
+	defer bce.InvalidateCachedResult(c, bucketLocationCacheKey(bucket))
 	return api.DeleteBucket(c, bucket)
 }
 
@@ -206,7 +261,20 @@ func (c *Client) DeleteBucket(bucket string) error {
 //     - string: the location of the bucket
 //     - error: nil if success otherwise the specific error
 func (c *Client) GetBucketLocation(bucket string) (string, error) {
-	return api.GetBucketLocation(c, bucket)
+	var location string
+	key := bucketLocationCacheKey(bucket)
+	if bce.GetCachedResult(c, key, &location) {
+		return location, nil
+	}
+	location, err := api.GetBucketLocation(c, bucket)
+	if err == nil {
+		bce.SetCachedResult(c, key, location)
+	}
+	return location, err
+}
+
+func bucketLocationCacheKey(bucket string) string {
+	return "bos:location:" + bucket
 }
 
 // PutBucketAcl - set the acl of the given bucket with acl body stream
@@ -269,7 +337,7 @@ func (c *Client) PutBucketAclFromString(bucket, aclString string) error {
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) PutBucketAclFromStruct(bucket string, aclObj *api.PutBucketAclArgs) error {
-	jsonBytes, jsonErr := json.Marshal(aclObj)
+	jsonBytes, jsonErr := bce.MarshalJSON(aclObj)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -325,7 +393,7 @@ func (c *Client) PutBucketLoggingFromString(bucket, logging string) error {
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) PutBucketLoggingFromStruct(bucket string, obj *api.PutBucketLoggingArgs) error {
-	jsonBytes, jsonErr := json.Marshal(obj)
+	jsonBytes, jsonErr := bce.MarshalJSON(obj)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -480,7 +548,16 @@ func (c *Client) PutBucketReplicationFromString(bucket, confString string, repli
 //     - error: nil if success otherwise the specific error
 func (c *Client) PutBucketReplicationFromStruct(bucket string,
 	confObj *api.PutBucketReplicationArgs, replicationRuleId string) error {
-	jsonBytes, jsonErr := json.Marshal(confObj)
+	if err := confObj.Check(); err != nil {
+		return err
+	}
+	if len(confObj.Id) == 0 {
+		// set the default id on a copy to keep the caller's config unchanged
+		conf := *confObj
+		conf.Id = replicationRuleId
+		confObj = &conf
+	}
+	jsonBytes, jsonErr := bce.MarshalJSON(confObj)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -538,6 +615,158 @@ func (c *Client) GetBucketReplicationProgress(bucket string, replicationRuleId s
 	return api.GetBucketReplicationProgress(c, bucket, replicationRuleId)
 }
 
+// PutBucketInventory - set the bucket inventory config to generate the object inventory reports
+// on the schedule, the config of the same id is replaced
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - args: the inventory config struct object
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) PutBucketInventory(bucket string, args *api.PutBucketInventoryArgs) error {
+	if err := args.Check(); err != nil {
+		return err
+	}
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return err
+	}
+	return api.PutBucketInventory(c, bucket, body, args.Id)
+}
+
+// PutBucketInventoryFromString - set the bucket inventory config with json string
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - confString: the config string with json format
+//     - inventoryId: the inventory config id composed of [0-9 A-Z a-z _ -]
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) PutBucketInventoryFromString(bucket, confString string, inventoryId string) error {
+	body, err := bce.NewBodyFromString(confString)
+	if err != nil {
+		return err
+	}
+	return api.PutBucketInventory(c, bucket, body, inventoryId)
+}
+
+// GetBucketInventory - get the bucket inventory config of the given id
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - inventoryId: the inventory config id
+// RETURNS:
+//     - *api.GetBucketInventoryResult: the result of the bucket inventory config
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetBucketInventory(bucket string, inventoryId string) (*api.GetBucketInventoryResult, error) {
+	return api.GetBucketInventory(c, bucket, inventoryId)
+}
+
+// ListBucketInventory - get all inventory config of the given bucket
+//
+// PARAMS:
+//     - bucket: the bucket name
+// RETURNS:
+//     - *api.ListBucketInventoryResult: the list of the bucket inventory config
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListBucketInventory(bucket string) (*api.ListBucketInventoryResult, error) {
+	return api.ListBucketInventory(c, bucket)
+}
+
+// DeleteBucketInventory - delete the bucket inventory config of the given id
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - inventoryId: the inventory config id
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) DeleteBucketInventory(bucket string, inventoryId string) error {
+	return api.DeleteBucketInventory(c, bucket, inventoryId)
+}
+
+// InitBucketObjectLock - init the object lock of the bucket, the objects can not be deleted or
+// overwritten within the retention period. The lock stays IN_PROGRESS and can be deleted until
+// CompleteBucketObjectLock is called.
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - args: the object lock args with the retention period
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) InitBucketObjectLock(bucket string, args *api.InitBucketObjectLockArgs) error {
+	if err := args.Check(); err != nil {
+		return err
+	}
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return err
+	}
+	return api.InitBucketObjectLock(c, bucket, body)
+}
+
+// GetBucketObjectLock - get the object lock config of the given bucket
+//
+// PARAMS:
+//     - bucket: the bucket name
+// RETURNS:
+//     - *api.GetBucketObjectLockResult: the status and retention period of the object lock
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetBucketObjectLock(bucket string) (*api.GetBucketObjectLockResult, error) {
+	return api.GetBucketObjectLock(c, bucket)
+}
+
+// DeleteBucketObjectLock - delete the object lock of the given bucket, only the lock which is
+// IN_PROGRESS can be deleted
+//
+// PARAMS:
+//     - bucket: the bucket name
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) DeleteBucketObjectLock(bucket string) error {
+	return api.DeleteBucketObjectLock(c, bucket)
+}
+
+// CompleteBucketObjectLock - complete the object lock of the given bucket, after that the lock
+// can not be deleted and the retention period can only be extended
+//
+// PARAMS:
+//     - bucket: the bucket name
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) CompleteBucketObjectLock(bucket string) error {
+	return api.CompleteBucketObjectLock(c, bucket)
+}
+
+// ExtendBucketObjectLock - extend the retention period of the completed object lock
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - args: the object lock args with the extended retention period
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) ExtendBucketObjectLock(bucket string, args *api.ExtendBucketObjectLockArgs) error {
+	if err := args.Check(); err != nil {
+		return err
+	}
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	body, err := bce.NewBodyFromBytes(jsonBytes)
+	if err != nil {
+		return err
+	}
+	return api.ExtendBucketObjectLock(c, bucket, body)
+}
+
 // PutBucketEncryption - set the bucket encryption config of the given bucket
 //
 // PARAMS:
@@ -605,7 +834,10 @@ func (c *Client) PutBucketStaticWebsiteFromString(bucket, jsonConfig string) err
 //     - error: nil if success otherwise the specific error
 func (c *Client) PutBucketStaticWebsiteFromStruct(bucket string,
 	confObj *api.PutBucketStaticWebsiteArgs) error {
-	jsonBytes, jsonErr := json.Marshal(confObj)
+	if err := confObj.Check(); err != nil {
+		return err
+	}
+	jsonBytes, jsonErr := bce.MarshalJSON(confObj)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -625,7 +857,7 @@ func (c *Client) PutBucketStaticWebsiteFromStruct(bucket string,
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) SimplePutBucketStaticWebsite(bucket, index, notFound string) error {
-	confObj := &api.PutBucketStaticWebsiteArgs{index, notFound}
+	confObj := &api.PutBucketStaticWebsiteArgs{Index: index, NotFound: notFound}
 	return c.PutBucketStaticWebsiteFromStruct(bucket, confObj)
 }
 
@@ -700,7 +932,7 @@ func (c *Client) PutBucketCorsFromString(bucket, jsonConfig string) error {
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) PutBucketCorsFromStruct(bucket string, confObj *api.PutBucketCorsArgs) error {
-	jsonBytes, jsonErr := json.Marshal(confObj)
+	jsonBytes, jsonErr := bce.MarshalJSON(confObj)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -850,23 +1082,158 @@ func (c *Client) PutObjectFromFile(bucket, object, fileName string,
 	return api.PutObject(c, bucket, object, body, args)
 }
 
+// PutObjectFromChunkedStream - upload a new object or rewrite the existed object from the stream
+// of unknown length by a single put without buffering, the stream is sent by the chunked transfer
+// encoding and every chunk is signed by the streaming signature. The request is not retried
+// since the stream can not be read again.
+//
+// PARAMS:
+//     - bucket: the name of the bucket to store the object
+//     - object: the name of the object
+//     - reader: the input stream of the object content
+//     - args: the optional arguments, the ContentLength and ContentMD5 are not supported
+// RETURNS:
+//     - string: etag of the uploaded object
+//     - error: the uploaded error if any occurs
+func (c *Client) PutObjectFromChunkedStream(bucket, object string, reader io.Reader,
+	args *api.PutObjectArgs) (string, error) {
+	if reader == nil {
+		return "", bce.NewBceClientError("PutObjectFromChunkedStream reader should not be nil")
+	}
+	if args != nil && (args.ContentLength > 0 || len(args.ContentMD5) != 0) {
+		return "", bce.NewBceClientError("ContentLength and ContentMD5 are not supported by the chunked stream")
+	}
+	return api.PutObject(c, bucket, object, bce.NewBodyFromStream(reader), args)
+}
+
 // PutObjectFromStream - upload a new object or rewrite the existed object from stream
 //
+// The length of the stream does not need to be known in advance. At most `MultipartSize` bytes
+// are buffered: if the stream ends within this threshold the object is uploaded by a single put,
+// otherwise it falls back to multipart upload and each part is read and sent one by one. The
+// multipart upload keeps the Tags, ContentCrc32 and ContentCrc32cFlag of the args, while the
+// ContentMD5 and ContentSha256 are only verified by the single put.
+//
 // PARAMS:
 //     - bucket: the name of the bucket to store the object
 //     - object: the name of the object
-//     - fileName: the local file full path name
+//     - reader: the input stream of the object content
 //     - args: the optional arguments
 // RETURNS:
 //     - string: etag of the uploaded object
 //     - error: the uploaded error if any occurs
 func (c *Client) PutObjectFromStream(bucket, object string, reader io.Reader,
 	args *api.PutObjectArgs) (string, error) {
-	body, err := bce.NewBodyFromSizedReader(reader, -1)
+	if reader == nil {
+		return "", bce.NewBceClientError("PutObjectFromStream reader should not be nil")
+	}
+	partSize := (c.MultipartSize + MULTIPART_ALIGN - 1) / MULTIPART_ALIGN * MULTIPART_ALIGN
+	if partSize < MIN_MULTIPART_SIZE {
+		partSize = MIN_MULTIPART_SIZE
+	}
+	firstPart, eof, err := readStreamPart(reader, partSize)
 	if err != nil {
 		return "", err
 	}
-	return api.PutObject(c, bucket, object, body, args)
+	if eof {
+		body, err := bce.NewBodyFromBytes(firstPart)
+		if err != nil {
+			return "", err
+		}
+		return api.PutObject(c, bucket, object, body, args)
+	}
+	return c.putObjectFromStreamMultipart(bucket, object, reader, firstPart, partSize, args)
+}
+
+// putObjectFromStreamMultipart - upload the rest of the stream by multipart upload
+//
+// PARAMS:
+//     - bucket: the name of the bucket to store the object
+//     - object: the name of the object
+//     - reader: the input stream of the object content
+//     - firstPart: the content already read from the stream
+//     - partSize: the size of each part
+//     - args: the optional arguments
+// RETURNS:
+//     - string: etag of the uploaded object
+//     - error: the uploaded error if any occurs
+func (c *Client) putObjectFromStreamMultipart(bucket, object string, reader io.Reader,
+	firstPart []byte, partSize int64, args *api.PutObjectArgs) (string, error) {
+	initArgs := &api.InitiateMultipartUploadArgs{}
+	completeArgs := &api.CompleteMultipartUploadArgs{}
+	contentType := ""
+	if args != nil {
+		initArgs.CacheControl = args.CacheControl
+		initArgs.ContentDisposition = args.ContentDisposition
+		initArgs.Expires = args.Expires
+		initArgs.StorageClass = args.StorageClass
+		initArgs.Tags = args.Tags
+		completeArgs.UserMeta = args.UserMeta
+		completeArgs.Process = args.Process
+		completeArgs.ContentCrc32 = args.ContentCrc32
+		completeArgs.ContentCrc32cFlag = args.ContentCrc32cFlag
+		contentType = args.ContentType
+	}
+	initRes, err := api.InitiateMultipartUpload(c, bucket, object, contentType, initArgs)
+	if err != nil {
+		return "", err
+	}
+	uploadId := initRes.UploadId
+
+	content, eof := firstPart, false
+	for partNumber := 1; ; partNumber++ {
+		if partNumber > MAX_PART_NUMBER {
+			c.AbortMultipartUpload(bucket, object, uploadId)
+			return "", bce.NewBceClientError(fmt.Sprintf(
+				"stream is too large, exceeds %d parts of size %d", MAX_PART_NUMBER, partSize))
+		}
+		etag, err := api.UploadPartFromBytes(c, bucket, object, uploadId, partNumber, content, nil)
+		if err != nil {
+			c.AbortMultipartUpload(bucket, object, uploadId)
+			return "", err
+		}
+		completeArgs.Parts = append(completeArgs.Parts,
+			api.UploadInfoType{PartNumber: partNumber, ETag: etag})
+		log.Debugf("upload stream part %d success, etag: %s", partNumber, etag)
+		if eof {
+			break
+		}
+		if content, eof, err = readStreamPart(reader, partSize); err != nil {
+			c.AbortMultipartUpload(bucket, object, uploadId)
+			return "", err
+		}
+		if len(content) == 0 {
+			break
+		}
+	}
+
+	res, err := c.CompleteMultipartUploadFromStruct(bucket, object, uploadId, completeArgs)
+	if err != nil {
+		c.AbortMultipartUpload(bucket, object, uploadId)
+		return "", err
+	}
+	return res.ETag, nil
+}
+
+// readStreamPart - read at most size bytes from the reader
+//
+// PARAMS:
+//     - reader: the input stream
+//     - size: the max bytes to read
+// RETURNS:
+//     - []byte: the content read from the stream
+//     - bool: true if the stream reaches its end
+//     - error: nil if ok otherwise the specific error
+func readStreamPart(reader io.Reader, size int64) ([]byte, bool, error) {
+	buf := make([]byte, size)
+	n, err := io.ReadFull(reader, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return buf[:n], true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return buf, false, nil
 }
 
 // CopyObject - copy a remote object to another one
@@ -913,6 +1280,7 @@ func (c *Client) BasicCopyObject(bucket, object, srcBucket,
 // RETURNS:
 //     - *api.GetObjectResult: result struct which contains "Body" and header fields
 //       for details reference https://cloud.baidu.com/doc/BOS/API.html#GetObject.E6.8E.A5.E5.8F.A3
+//       the "Body" is an unbuffered stream and must be closed by the caller after reading
 //     - error: any error if it occurs
 func (c *Client) GetObject(bucket, object string, responseHeaders map[string]string,
 	ranges ...int64) (*api.GetObjectResult, error) {
@@ -1153,7 +1521,7 @@ func (c *Client) DeleteMultipleObjectsFromString(bucket,
 //     - error: any error if it occurs
 func (c *Client) DeleteMultipleObjectsFromStruct(bucket string,
 	objectListStruct *api.DeleteMultipleObjectsArgs) (*api.DeleteMultipleObjectsResult, error) {
-	jsonBytes, jsonErr := json.Marshal(objectListStruct)
+	jsonBytes, jsonErr := bce.MarshalJSON(objectListStruct)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1177,13 +1545,23 @@ func (c *Client) DeleteMultipleObjectsFromKeyList(bucket string,
 	if len(keyList) == 0 {
 		return nil, fmt.Errorf("the key list to be deleted is empty")
 	}
+	if len(keyList) > api.MAX_DELETE_OBJECT_NUM {
+		report, err := c.DeleteObjectsInBatches(bucket, keyList)
+		if err != nil {
+			return nil, err
+		}
+		if len(report.Failed) == 0 { // keep the same result as deleting all objects successfully
+			return nil, io.EOF
+		}
+		return &api.DeleteMultipleObjectsResult{Errors: report.Failed}, nil
+	}
 	args := make([]api.DeleteObjectArgs, len(keyList))
 	for i, k := range keyList {
 		args[i].Key = k
 	}
 	argsContainer := &api.DeleteMultipleObjectsArgs{args}
 
-	jsonBytes, jsonErr := json.Marshal(argsContainer)
+	jsonBytes, jsonErr := bce.MarshalJSON(argsContainer)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1194,6 +1572,82 @@ func (c *Client) DeleteMultipleObjectsFromKeyList(bucket string,
 	return api.DeleteMultipleObjects(c, bucket, body)
 }
 
+// DeleteObjectsInBatches - delete a list of objects of any size, the key list is split into
+// batches of at most 1000 keys which are deleted concurrently limited by the MaxParallel
+//
+// PARAMS:
+//     - bucket: the name of the bucket to delete
+//     - keyList: the key string list to be deleted
+// RETURNS:
+//     - *api.DeleteObjectsReport: the deleted keys and the failed keys with the reasons, the keys
+//       of the failed batch requests are all reported as failed
+//     - error: nil if the batches are sent otherwise the specific error
+func (c *Client) DeleteObjectsInBatches(bucket string, keyList []string) (*api.DeleteObjectsReport, error) {
+	if len(keyList) == 0 {
+		return nil, fmt.Errorf("the key list to be deleted is empty")
+	}
+	batchNum := (len(keyList) + api.MAX_DELETE_OBJECT_NUM - 1) / api.MAX_DELETE_OBJECT_NUM
+	results := make([]*api.DeleteMultipleObjectsResult, batchNum)
+	errs := make([]error, batchNum)
+
+	parallel := c.MaxParallel
+	if parallel <= 0 {
+		parallel = 1
+	}
+	workerPool := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := 0; i < batchNum; i++ {
+		end := (i + 1) * api.MAX_DELETE_OBJECT_NUM
+		if end > len(keyList) {
+			end = len(keyList)
+		}
+		batch := keyList[i*api.MAX_DELETE_OBJECT_NUM : end]
+		workerPool <- struct{}{}
+		wg.Add(1)
+		go func(index int, keys []string) {
+			defer func() {
+				<-workerPool
+				wg.Done()
+			}()
+			results[index], errs[index] = c.DeleteMultipleObjectsFromKeyList(bucket, keys)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	report := &api.DeleteObjectsReport{}
+	for i := 0; i < batchNum; i++ {
+		end := (i + 1) * api.MAX_DELETE_OBJECT_NUM
+		if end > len(keyList) {
+			end = len(keyList)
+		}
+		batch := keyList[i*api.MAX_DELETE_OBJECT_NUM : end]
+		if errs[i] != nil && errs[i] != io.EOF { // io.EOF means all objects are deleted
+			code := "ClientError"
+			if serviceErr, ok := errs[i].(*bce.BceServiceError); ok {
+				code = serviceErr.Code
+			}
+			for _, key := range batch {
+				report.Failed = append(report.Failed,
+					api.DeleteObjectResult{Key: key, Code: code, Message: errs[i].Error()})
+			}
+			continue
+		}
+		failed := make(map[string]struct{})
+		if results[i] != nil {
+			for _, res := range results[i].Errors {
+				failed[res.Key] = struct{}{}
+				report.Failed = append(report.Failed, res)
+			}
+		}
+		for _, key := range batch {
+			if _, ok := failed[key]; !ok {
+				report.Deleted = append(report.Deleted, key)
+			}
+		}
+	}
+	return report, nil
+}
+
 // InitiateMultipartUpload - initiate a multipart upload to get a upload ID
 //
 // PARAMS:
@@ -1338,7 +1792,7 @@ func (c *Client) CompleteMultipartUpload(bucket, object, uploadId string,
 //     - error: nil if ok otherwise the specific error
 func (c *Client) CompleteMultipartUploadFromStruct(bucket, object, uploadId string,
 	args *api.CompleteMultipartUploadArgs) (*api.CompleteMultipartUploadResult, error) {
-	jsonBytes, jsonErr := json.Marshal(args)
+	jsonBytes, jsonErr := bce.MarshalJSON(args)
 	if jsonErr != nil {
 		return nil, jsonErr
 	}
@@ -1650,6 +2104,45 @@ func (c *Client) BasicGeneratePresignedUrl(bucket, object string, expireInSecond
 		expireInSeconds, "", nil, nil)
 }
 
+// GeneratePostPolicyForm - sign the policy to let the browsers upload to the bucket directly by
+// the POST form without proxying the content through the application
+//
+// PARAMS:
+//     - policy: the policy of the upload built by the api.NewPostPolicy
+// RETURNS:
+//     - *api.PostPolicyForm: the url and the form fields to post
+//     - error: nil if ok otherwise the specific error
+func (c *Client) GeneratePostPolicyForm(policy *api.PostPolicy) (*api.PostPolicyForm, error) {
+	credentials := c.Config.Credentials
+	if c.Config.CredentialsProvider != nil {
+		var err error
+		if credentials, err = c.Config.CredentialsProvider.GetCredentials(); err != nil {
+			return nil, err
+		}
+	}
+	return api.GeneratePostPolicyForm(c.Config, credentials, policy)
+}
+
+// BasicGeneratePostPolicyForm - basic interface to generate the POST form to upload the objects
+// with the given key prefix and size limit to the bucket
+//
+// PARAMS:
+//     - bucket: the target bucket name
+//     - keyPrefix: the prefix of the uploaded object names, empty for any name
+//     - maxSize: the max size in bytes of the uploaded file
+//     - expireInSeconds: the expire time in seconds of the form
+// RETURNS:
+//     - *api.PostPolicyForm: the url and the form fields to post
+//     - error: nil if ok otherwise the specific error
+func (c *Client) BasicGeneratePostPolicyForm(bucket, keyPrefix string, maxSize int64,
+	expireInSeconds int) (*api.PostPolicyForm, error) {
+	policy := api.NewPostPolicy(time.Now().Add(time.Duration(expireInSeconds)*time.Second)).
+		AddBucket(bucket).
+		AddKeyPrefix(keyPrefix).
+		AddContentLengthRange(0, maxSize)
+	return c.GeneratePostPolicyForm(policy)
+}
+
 // PutObjectAcl - set the ACL of the given object
 //
 // PARAMS:
@@ -1738,7 +2231,7 @@ func (c *Client) PutObjectAclFromString(bucket, object, aclString string) error
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) PutObjectAclFromStruct(bucket, object string, aclObj *api.PutObjectAclArgs) error {
-	jsonBytes, jsonErr := json.Marshal(aclObj)
+	jsonBytes, jsonErr := bce.MarshalJSON(aclObj)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -1772,6 +2265,117 @@ func (c *Client) DeleteObjectAcl(bucket, object string) error {
 	return api.DeleteObjectAcl(c, bucket, object)
 }
 
+// PutObjectTagging - set the tags of the given object
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - object: the object name
+//     - tags: the key-value tags of the object
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) PutObjectTagging(bucket, object string, tags map[string]string) error {
+	return api.PutObjectTagging(c, bucket, object, api.NewPutObjectTaggingArgs(tags))
+}
+
+// PutObjectTaggingFromStruct - set the tags of the given object from struct
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - object: the object name
+//     - args: the object tagging arguments
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) PutObjectTaggingFromStruct(bucket, object string,
+	args *api.PutObjectTaggingArgs) error {
+	return api.PutObjectTagging(c, bucket, object, args)
+}
+
+// GetObjectTagging - get the tags of the given object
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - object: the object name
+// RETURNS:
+//     - *api.GetObjectTaggingResult: the object tagging result object
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetObjectTagging(bucket, object string) (*api.GetObjectTaggingResult, error) {
+	return api.GetObjectTagging(c, bucket, object)
+}
+
+// DeleteObjectTagging - delete all the tags of the given object
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - object: the object name
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) DeleteObjectTagging(bucket, object string) error {
+	return api.DeleteObjectTagging(c, bucket, object)
+}
+
+// ListObjectsByTags - list one page of the objects like the ListObjects and keep the objects
+// having all the given tags. The service does not filter the objects by the tags, so the tags of
+// each listed object are got by the GetObjectTagging concurrently limited by the MaxParallel. The
+// IsTruncated and the NextMarker of the page are kept to list the next page.
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - args: the optional arguments to list the objects
+//     - tags: the tags the objects should have, the empty value matches any value of the key
+// RETURNS:
+//     - *api.ListObjectsResult: the listed page with the matched objects only
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListObjectsByTags(bucket string, args *api.ListObjectsArgs,
+	tags map[string]string) (*api.ListObjectsResult, error) {
+	result, err := api.ListObjects(c, bucket, args)
+	if err != nil || len(tags) == 0 {
+		return result, err
+	}
+	matched := make([]bool, len(result.Contents))
+	errs := make([]error, len(result.Contents))
+	parallel := c.MaxParallel
+	if parallel <= 0 {
+		parallel = 1
+	}
+	workerPool := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, object := range result.Contents {
+		workerPool <- struct{}{}
+		wg.Add(1)
+		go func(index int, key string) {
+			defer func() {
+				<-workerPool
+				wg.Done()
+			}()
+			res, err := api.GetObjectTagging(c, bucket, key)
+			if serviceErr, ok := err.(*bce.BceServiceError); ok && serviceErr.StatusCode == 404 {
+				return // the object has no tags or is deleted after listed
+			}
+			if err != nil {
+				errs[index] = err
+				return
+			}
+			matched[index] = res.Match(tags)
+		}(i, object.Key)
+	}
+	wg.Wait()
+
+	contents := make([]api.ObjectSummaryType, 0, len(result.Contents))
+	for i, object := range result.Contents {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if matched[i] {
+			contents = append(contents, object)
+		}
+	}
+	if result.IsTruncated && len(result.NextMarker) == 0 && len(result.Contents) != 0 {
+		result.NextMarker = result.Contents[len(result.Contents)-1].Key
+	}
+	result.Contents = contents
+	return result, nil
+}
+
 // RestoreObject - restore the archive object
 //
 // PARAMS:
@@ -1797,6 +2401,28 @@ func (c *Client) RestoreObject(bucket string, object string, restoreDays int, re
 	return api.RestoreObject(c, bucket, object, args)
 }
 
+// SetObjectStorageClass - change the storage class of the object by copying it to itself, the
+// user metadata of the object is kept. An archive object should be restored before transition.
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - object: the object name
+//     - storageClass: the target storage class
+// RETURNS:
+//     - *api.CopyObjectResult: result struct which contains "ETag" and "LastModified" fields
+//     - error: nil if success otherwise the specific error
+func (c *Client) SetObjectStorageClass(bucket, object, storageClass string) (*api.CopyObjectResult, error) {
+	if _, ok := api.VALID_STORAGE_CLASS_TYPE[storageClass]; !ok {
+		return nil, errors.New("invalid storage class")
+	}
+	args := &api.CopyObjectArgs{
+		ObjectMeta:        api.ObjectMeta{StorageClass: storageClass},
+		MetadataDirective: api.METADATA_DIRECTIVE_COPY,
+	}
+	source := fmt.Sprintf("/%s/%s", bucket, object)
+	return api.CopyObject(c, bucket, object, source, args)
+}
+
 // PutBucketTrash - put the bucket trash
 //
 // PARAMS:
@@ -1829,7 +2455,8 @@ func (c *Client) DeleteBucketTrash(bucket string) error {
 	return api.DeleteBucketTrash(c, bucket)
 }
 
-// PutBucketNotification - put the bucket notification
+// PutBucketNotification - put the bucket notification rules to send the object events to the
+// endpoints or functions, the rules are checked before sending the request
 //
 // PARAMS:
 //     - bucket: the bucket name
@@ -1837,6 +2464,9 @@ func (c *Client) DeleteBucketTrash(bucket string) error {
 // RETURNS:
 //     - error: nil if success otherwise the specific error
 func (c *Client) PutBucketNotification(bucket string, putBucketNotificationReq api.PutBucketNotificationReq) error {
+	if err := putBucketNotificationReq.Check(); err != nil {
+		return err
+	}
 	return api.PutBucketNotification(c, bucket, putBucketNotificationReq)
 }
 
@@ -2175,14 +2805,185 @@ func (c *Client) PutSymlink(bucket string, object string, symlinkKey string, sym
 	return api.PutObjectSymlink(c, bucket, object, symlinkKey, symlinkArgs)
 }
 
-// PutSymlink - create symlink for exist target object
+// GetSymlink - get the target object name of the symlink
 //
 // PARAMS:
 //     - bucket: the name of the bucket
 //     - object: the name of the symlink
 // RETURNS:
-//	   - string: the target of the symlink
-//     - error: the put error if any occurs
+//     - string: the target of the symlink
+//     - error: the get error if any occurs
 func (c *Client) GetSymlink(bucket string, object string) (string, error) {
 	return api.GetObjectSymlink(c, bucket, object)
 }
+
+// GetSymlinkMeta - get the target object with its bucket and the meta data of the symlink
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the symlink
+// RETURNS:
+//     - *api.GetSymlinkResult: the target and the meta data of the symlink
+//     - error: the get error if any occurs
+func (c *Client) GetSymlinkMeta(bucket string, object string) (*api.GetSymlinkResult, error) {
+	return api.GetObjectSymlinkMeta(c, bucket, object)
+}
+
+// PutLiveChannel - create the live channel or update its configuration
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - channel: the name of the live channel
+//     - args: the configuration of the live channel
+// RETURNS:
+//     - *api.PutLiveChannelResult: the publish and play urls of the live channel
+//     - error: the put error if any occurs
+func (c *Client) PutLiveChannel(bucket, channel string,
+	args *api.PutLiveChannelArgs) (*api.PutLiveChannelResult, error) {
+	return api.PutLiveChannel(c, bucket, channel, args)
+}
+
+// GetLiveChannel - get the configuration of the live channel
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - channel: the name of the live channel
+// RETURNS:
+//     - *api.GetLiveChannelResult: the configuration of the live channel
+//     - error: the get error if any occurs
+func (c *Client) GetLiveChannel(bucket, channel string) (*api.GetLiveChannelResult, error) {
+	return api.GetLiveChannel(c, bucket, channel)
+}
+
+// ListLiveChannel - list the live channels of the bucket
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - args: the optional prefix, marker and max keys to list
+// RETURNS:
+//     - *api.ListLiveChannelResult: the live channels
+//     - error: the list error if any occurs
+func (c *Client) ListLiveChannel(bucket string,
+	args *api.ListLiveChannelArgs) (*api.ListLiveChannelResult, error) {
+	return api.ListLiveChannel(c, bucket, args)
+}
+
+// DeleteLiveChannel - delete the live channel
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - channel: the name of the live channel
+// RETURNS:
+//     - error: the delete error if any occurs
+func (c *Client) DeleteLiveChannel(bucket, channel string) error {
+	return api.DeleteLiveChannel(c, bucket, channel)
+}
+
+// PutLiveChannelStatus - enable or disable the live channel
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - channel: the name of the live channel
+//     - status: api.STATUS_ENABLED or api.STATUS_DISABLED
+// RETURNS:
+//     - error: the put error if any occurs
+func (c *Client) PutLiveChannelStatus(bucket, channel, status string) error {
+	return api.PutLiveChannelStatus(c, bucket, channel, status)
+}
+
+// GetLiveChannelStatus - get the pushing status of the live channel
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - channel: the name of the live channel
+// RETURNS:
+//     - *api.GetLiveChannelStatusResult: the status and the stream information
+//     - error: the get error if any occurs
+func (c *Client) GetLiveChannelStatus(bucket, channel string) (*api.GetLiveChannelStatusResult, error) {
+	return api.GetLiveChannelStatus(c, bucket, channel)
+}
+
+// GetLiveChannelHistory - get the recent pushing records of the live channel
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - channel: the name of the live channel
+// RETURNS:
+//     - *api.GetLiveChannelHistoryResult: the pushing records
+//     - error: the get error if any occurs
+func (c *Client) GetLiveChannelHistory(bucket, channel string) (*api.GetLiveChannelHistoryResult, error) {
+	return api.GetLiveChannelHistory(c, bucket, channel)
+}
+
+// PostVodPlaylist - generate the VOD playlist object from the fragments pushed in the time range
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - channel: the name of the live channel
+//     - playlistName: the name of the playlist object under the channel
+//     - startTime: the start of the time range in unix seconds
+//     - endTime: the end of the time range in unix seconds
+// RETURNS:
+//     - error: the post error if any occurs
+func (c *Client) PostVodPlaylist(bucket, channel, playlistName string, startTime, endTime int64) error {
+	return api.PostVodPlaylist(c, bucket, channel, playlistName, startTime, endTime)
+}
+
+// GetVodPlaylist - get the content of the VOD playlist of the fragments pushed in the time range
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - channel: the name of the live channel
+//     - startTime: the start of the time range in unix seconds
+//     - endTime: the end of the time range in unix seconds
+// RETURNS:
+//     - io.ReadCloser: the m3u8 playlist content which must be closed by the caller
+//     - error: the get error if any occurs
+func (c *Client) GetVodPlaylist(bucket, channel string, startTime, endTime int64) (io.ReadCloser, error) {
+	return api.GetVodPlaylist(c, bucket, channel, startTime, endTime)
+}
+
+// PutBucketQuota - set the max object count and capacity of the bucket
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - quota: the quota of the bucket
+// RETURNS:
+//     - error: the put error if any occurs
+func (c *Client) PutBucketQuota(bucket string, quota *api.BucketQuota) error {
+	return api.PutBucketQuota(c, bucket, quota)
+}
+
+// GetBucketQuota - get the quota of the bucket
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+// RETURNS:
+//     - *api.BucketQuota: the quota of the bucket
+//     - error: the get error if any occurs
+func (c *Client) GetBucketQuota(bucket string) (*api.BucketQuota, error) {
+	return api.GetBucketQuota(c, bucket)
+}
+
+// DeleteBucketQuota - delete the quota of the bucket
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+// RETURNS:
+//     - error: the delete error if any occurs
+func (c *Client) DeleteBucketQuota(bucket string) error {
+	return api.DeleteBucketQuota(c, bucket)
+}
+
+// GetBucketStatistics - get the storage and traffic time series of the bucket
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - args: the time range, period and metrics to query
+// RETURNS:
+//     - *api.GetBucketStatisticsResult: the time series of the metrics
+//     - error: the get error if any occurs
+func (c *Client) GetBucketStatistics(bucket string,
+	args *api.GetBucketStatisticsArgs) (*api.GetBucketStatisticsResult, error) {
+	return api.GetBucketStatistics(c, bucket, args)
+}
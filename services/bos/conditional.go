@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// conditional.go - get the object with the conditional headers and the byte range
+
+package bos
+
+import (
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+// GetObjectWithConditions - get the given object only if the conditions are satisfied, eg: the
+// cached copy is validated by the IfNoneMatch with its ETag
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the object
+//     - conditions: the If-Match, If-None-Match, If-Modified-Since and If-Unmodified-Since
+//     - ranges: the optional range start and end to get the given object
+// RETURNS:
+//     - *api.GetObjectResult: result struct which contains "Body" and header fields, the
+//       "NotModified" is true without the content if the If-None-Match or the
+//       If-Modified-Since condition fails
+//     - error: any error if it occurs, the code is PreconditionFailed if the If-Match or the
+//       If-Unmodified-Since condition fails
+func (c *Client) GetObjectWithConditions(bucket, object string,
+	conditions *api.GetObjectConditions, ranges ...int64) (*api.GetObjectResult, error) {
+	return api.GetObjectWithConditions(c, bucket, object, nil, conditions, ranges...)
+}
+
+// GetObjectRange - get the bytes from the start to the end inclusive of the given object, which
+// is convenient to download the large object by chunks
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the object
+//     - start: the first byte position from 0
+//     - end: the last byte position inclusive, negative means to the end of the object
+// RETURNS:
+//     - *api.GetObjectRangeResult: the partial content and the range parsed from the
+//       Content-Range, the "Body" must be closed by the caller after reading
+//     - error: any error if it occurs
+func (c *Client) GetObjectRange(bucket, object string,
+	start, end int64) (*api.GetObjectRangeResult, error) {
+	if start < 0 || (end >= 0 && end < start) {
+		return nil, bce.NewBceClientError(fmt.Sprintf("invalid range %d-%d", start, end))
+	}
+	ranges := []int64{start}
+	if end >= 0 {
+		ranges = append(ranges, end)
+	}
+	res, err := api.GetObject(c, bucket, object, nil, ranges...)
+	if err != nil {
+		return nil, err
+	}
+	result := &api.GetObjectRangeResult{GetObjectResult: *res}
+	if len(res.ContentRange) == 0 {
+		// The whole object is returned if the range is not supported, eg: the empty object
+		result.Range = api.ContentRange{
+			Start: 0,
+			End:   res.ContentLength - 1,
+			Total: res.ContentLength,
+		}
+		return result, nil
+	}
+	contentRange, err := api.ParseContentRange(res.ContentRange)
+	if err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+	result.Range = *contentRange
+	return result, nil
+}
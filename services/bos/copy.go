@@ -0,0 +1,324 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// copy.go - copy the object of any size by a single request or the parallel multipart copy, and
+// update the metadata of the object by copying it to itself
+
+package bos
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+// MIN_COPY_PART_SIZE is the minimum size of the parts of the multipart copy except the last one
+const MIN_COPY_PART_SIZE = 5 * (1 << 20) // 5MB
+
+// CopyObjectLarge - copy the object of any size, the source object larger than the threshold is
+// copied by the parallel UploadPartCopy instead of the CopyObject which is limited to 5GB. The
+// content headers, user metadata and storage class of the source object are kept by default.
+//
+// PARAMS:
+//     - bucket: the name of the destination bucket
+//     - object: the name of the destination object
+//     - srcBucket: the name of the source bucket
+//     - srcObject: the name of the source object
+//     - args: the optional arguments
+// RETURNS:
+//     - *api.CopyObjectResult: the ETag of the destination object, and the LastModified if it is
+//       copied by a single request
+//     - error: any error if it occurs, the multipart copy is aborted on the error
+func (c *Client) CopyObjectLarge(bucket, object, srcBucket, srcObject string,
+	args *api.CopyObjectLargeArgs) (*api.CopyObjectResult, error) {
+	if args == nil {
+		args = &api.CopyObjectLargeArgs{}
+	}
+	meta, err := api.GetObjectMeta(c, srcBucket, srcObject)
+	if err != nil {
+		return nil, err
+	}
+	target := &api.ObjectMeta{
+		CacheControl:       meta.CacheControl,
+		ContentDisposition: meta.ContentDisposition,
+		ContentEncoding:    meta.ContentEncoding,
+		ContentType:        meta.ContentType,
+		Expires:            meta.Expires,
+		StorageClass:       meta.StorageClass,
+		UserMeta:           meta.UserMeta,
+	}
+	if len(args.StorageClass) != 0 {
+		target.StorageClass = args.StorageClass
+	}
+	if args.UserMeta != nil {
+		target.UserMeta = args.UserMeta
+	}
+	return c.copyObjectWithMeta(bucket, object, srcBucket, srcObject, meta, target,
+		args.UserMeta != nil, args)
+}
+
+// SetObjectMeta - update the metadata of the object by copying it to itself with the replace
+// metadata directive. The content headers, user metadata and storage class not given by the args
+// are kept, and the object acl is set again since it is not kept by the copy. The object larger
+// than 5GB is copied by the parallel multipart copy.
+//
+// PARAMS:
+//     - bucket: the bucket name
+//     - object: the object name
+//     - args: the metadata to update
+// RETURNS:
+//     - *api.CopyObjectResult: the result of the copy
+//     - error: any error if it occurs, the copy fails if the object is modified concurrently
+func (c *Client) SetObjectMeta(bucket, object string,
+	args *api.SetObjectMetaArgs) (*api.CopyObjectResult, error) {
+	if args == nil {
+		return nil, bce.NewBceClientError("the metadata to set should not be nil")
+	}
+	meta, err := api.GetObjectMeta(c, bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	acl, err := api.GetObjectAcl(c, bucket, object)
+	if err != nil {
+		if e, ok := err.(*bce.BceServiceError); !ok || e.StatusCode != http.StatusNotFound {
+			return nil, err
+		}
+		acl = nil // the object has no acl of its own
+	}
+
+	target := &api.ObjectMeta{
+		CacheControl:       meta.CacheControl,
+		ContentDisposition: meta.ContentDisposition,
+		ContentEncoding:    meta.ContentEncoding,
+		ContentType:        meta.ContentType,
+		Expires:            meta.Expires,
+		StorageClass:       meta.StorageClass,
+		UserMeta:           meta.UserMeta,
+	}
+	override := func(dst *string, src string) {
+		if len(src) != 0 {
+			*dst = src
+		}
+	}
+	override(&target.CacheControl, args.CacheControl)
+	override(&target.ContentDisposition, args.ContentDisposition)
+	override(&target.ContentEncoding, args.ContentEncoding)
+	override(&target.ContentType, args.ContentType)
+	override(&target.Expires, args.Expires)
+	override(&target.StorageClass, args.StorageClass)
+	if args.UserMeta != nil {
+		target.UserMeta = args.UserMeta
+	}
+
+	result, err := c.copyObjectWithMeta(bucket, object, bucket, object, meta, target, true,
+		&api.CopyObjectLargeArgs{})
+	if err != nil {
+		return nil, err
+	}
+	if acl != nil && len(acl.AccessControlList) != 0 {
+		aclArgs := api.PutObjectAclArgs(*acl)
+		if err := c.PutObjectAclFromStruct(bucket, object, &aclArgs); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// copyObjectWithMeta - copy the object by a single request or the parallel multipart copy with
+// the given metadata of the destination object
+//
+// PARAMS:
+//     - bucket: the name of the destination bucket
+//     - object: the name of the destination object
+//     - srcBucket: the name of the source bucket
+//     - srcObject: the name of the source object
+//     - meta: the metadata of the source object, the copy fails if its ETag is changed
+//     - target: the content headers, user metadata and storage class of the destination object
+//     - replace: whether the metadata are replaced by the target for the single request copy,
+//       otherwise only the storage class is set
+//     - args: the arguments of the threshold, part size, parallel and progress
+// RETURNS:
+//     - *api.CopyObjectResult: the result of the copy
+//     - error: any error if it occurs, the multipart copy is aborted on the error
+func (c *Client) copyObjectWithMeta(bucket, object, srcBucket, srcObject string,
+	meta *api.GetObjectMetaResult, target *api.ObjectMeta, replace bool,
+	args *api.CopyObjectLargeArgs) (*api.CopyObjectResult, error) {
+	threshold := args.Threshold
+	if threshold <= 0 || threshold > MAX_SINGLE_PART_SIZE {
+		threshold = MAX_SINGLE_PART_SIZE
+	}
+	source := fmt.Sprintf("/%s/%s", srcBucket, srcObject)
+	total := meta.ContentLength
+
+	if total <= threshold {
+		copyArgs := &api.CopyObjectArgs{IfMatch: meta.ETag}
+		copyArgs.StorageClass = target.StorageClass
+		if replace {
+			// the content headers should be set again since all the metadata are replaced
+			copyArgs.MetadataDirective = api.METADATA_DIRECTIVE_REPLACE
+			copyArgs.CacheControl = target.CacheControl
+			copyArgs.ContentDisposition = target.ContentDisposition
+			copyArgs.ContentEncoding = target.ContentEncoding
+			copyArgs.ContentType = target.ContentType
+			copyArgs.Expires = target.Expires
+			copyArgs.UserMeta = target.UserMeta
+		}
+		result, err := api.CopyObject(c, bucket, object, source, copyArgs)
+		if err != nil {
+			return nil, err
+		}
+		if args.OnProgress != nil {
+			args.OnProgress(total, total)
+		}
+		return result, nil
+	}
+
+	initArgs := &api.InitiateMultipartUploadArgs{
+		CacheControl:       target.CacheControl,
+		ContentDisposition: target.ContentDisposition,
+		ContentEncoding:    target.ContentEncoding,
+		Expires:            target.Expires,
+		StorageClass:       target.StorageClass,
+		UserMeta:           target.UserMeta,
+	}
+	initResult, err := api.InitiateMultipartUpload(c, bucket, object, target.ContentType, initArgs)
+	if err != nil {
+		return nil, err
+	}
+	uploadId := initResult.UploadId
+	parts, err := c.copyParts(bucket, object, source, uploadId, meta.ETag, total, args)
+	if err != nil {
+		api.AbortMultipartUpload(c, bucket, object, uploadId)
+		return nil, err
+	}
+	completeResult, err := c.CompleteMultipartUploadFromStruct(bucket, object, uploadId,
+		&api.CompleteMultipartUploadArgs{Parts: parts})
+	if err != nil {
+		api.AbortMultipartUpload(c, bucket, object, uploadId)
+		return nil, err
+	}
+	return &api.CopyObjectResult{ETag: completeResult.ETag}, nil
+}
+
+// copyPartSize - get the part size to copy the object within MAX_PART_NUMBER parts
+//
+// PARAMS:
+//     - size: the size of the source object
+//     - partSize: the preferred part size, MultipartSize of the client if it is not positive
+// RETURNS:
+//     - int64: the part size aligned to MULTIPART_ALIGN in [MIN_COPY_PART_SIZE, MAX_SINGLE_PART_SIZE]
+func (c *Client) copyPartSize(size, partSize int64) int64 {
+	if partSize <= 0 {
+		partSize = c.MultipartSize
+	}
+	if partSize < MIN_COPY_PART_SIZE {
+		partSize = MIN_COPY_PART_SIZE
+	}
+	if min := (size + MAX_PART_NUMBER - 1) / MAX_PART_NUMBER; partSize < min {
+		partSize = min
+	}
+	partSize = (partSize + MULTIPART_ALIGN - 1) / MULTIPART_ALIGN * MULTIPART_ALIGN
+	if partSize > MAX_SINGLE_PART_SIZE {
+		partSize = MAX_SINGLE_PART_SIZE
+	}
+	return partSize
+}
+
+// copyParts - copy the parts of the source object in parallel, stop on the first error
+//
+// PARAMS:
+//     - bucket: the name of the destination bucket
+//     - object: the name of the destination object
+//     - source: the copy source
+//     - uploadId: the id of the multipart upload
+//     - etag: the etag of the source object to make sure it is not changed during the copy
+//     - size: the size of the source object
+//     - args: the part size, parallel and progress callback
+// RETURNS:
+//     - []api.UploadInfoType: the copied parts in order
+//     - error: the first error if any occurs
+func (c *Client) copyParts(bucket, object, source, uploadId, etag string, size int64,
+	args *api.CopyObjectLargeArgs) ([]api.UploadInfoType, error) {
+	partSize := c.copyPartSize(size, args.PartSize)
+	partNum := int((size + partSize - 1) / partSize)
+	parallel := args.Parallel
+	if parallel <= 0 {
+		parallel = c.MaxParallel
+	}
+	if parallel <= 0 {
+		parallel = DEFAULT_MAX_PARALLEL
+	}
+
+	parts := make([]api.UploadInfoType, partNum)
+	partChan := make(chan int, partNum)
+	for i := 0; i < partNum; i++ {
+		partChan <- i
+	}
+	close(partChan)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		copied   int64
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+	for w := int64(0); w < parallel && w < int64(partNum); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range partChan {
+				if failed() {
+					return
+				}
+				offset := int64(i) * partSize
+				length := partSize
+				if offset+length > size {
+					length = size - offset
+				}
+				result, err := api.UploadPartCopy(c, bucket, object, source, uploadId, i+1,
+					&api.UploadPartCopyArgs{
+						SourceRange: fmt.Sprintf("bytes=%d-%d", offset, offset+length-1),
+						IfMatch:     etag,
+					})
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					return
+				}
+				parts[i] = api.UploadInfoType{PartNumber: i + 1, ETag: result.ETag}
+				copied += length
+				if args.OnProgress != nil {
+					args.OnProgress(copied, size)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return parts, nil
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the BOS client
+
+package bos
+
+import (
+	"io"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+	"github.com/baidubce/bce-sdk-go/util/checksum"
+)
+
+// Interface defines all the operations of the BOS client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	PutBucketAclFromBuilder(bucket string, builder *api.AclBuilder) error
+	GetBucketAclBuilder(bucket string) (*api.AclBuilder, error)
+	PutObjectAclFromBuilder(bucket, object string, builder *api.AclBuilder) error
+	GetObjectAclBuilder(bucket, object string) (*api.AclBuilder, error)
+	NewAppendWriter(bucket, object string, args *api.AppendObjectArgs) (*AppendWriter, error)
+	PutObjectWithChecksum(bucket, object string, body *bce.Body, alg checksum.Algorithm, args *api.PutObjectArgs) (*api.PutObjectResult, *checksum.Hash, error)
+	UploadPartWithChecksum(bucket, object, uploadId string, partNumber int, content *bce.Body, alg checksum.Algorithm, args *api.UploadPartArgs) (*api.UploadPartResult, *checksum.Hash, error)
+	CompleteMultipartUploadWithChecksum(bucket, object, uploadId string, args *api.CompleteMultipartUploadArgs, parts *checksum.Combiner) (*api.CompleteMultipartUploadResult, uint64, error)
+	GetObjectWithChecksum(bucket, object string, alg checksum.Algorithm, responseHeaders map[string]string, ranges ...int64) (*api.GetObjectResult, *checksum.Hash, error)
+	ListBuckets() (*api.ListBucketsResult, error)
+	ListObjects(bucket string, args *api.ListObjectsArgs) (*api.ListObjectsResult, error)
+	ListObjectsStream(bucket string, args *api.ListObjectsArgs, fn func(object *api.ObjectSummaryType) error) (*api.ListObjectsResult, error)
+	SimpleListObjects(bucket, prefix string, maxKeys int, marker, delimiter string) (*api.ListObjectsResult, error)
+	HeadBucket(bucket string) error
+	DoesBucketExist(bucket string) (bool, error)
+	PutBucket(bucket string) (string, error)
+	DeleteBucket(bucket string) error
+	GetBucketLocation(bucket string) (string, error)
+	PutBucketAcl(bucket string, aclBody *bce.Body) error
+	PutBucketAclFromCanned(bucket, cannedAcl string) error
+	PutBucketAclFromFile(bucket, aclFile string) error
+	PutBucketAclFromString(bucket, aclString string) error
+	PutBucketAclFromStruct(bucket string, aclObj *api.PutBucketAclArgs) error
+	GetBucketAcl(bucket string) (*api.GetBucketAclResult, error)
+	PutBucketLogging(bucket string, body *bce.Body) error
+	PutBucketLoggingFromString(bucket, logging string) error
+	PutBucketLoggingFromStruct(bucket string, obj *api.PutBucketLoggingArgs) error
+	GetBucketLogging(bucket string) (*api.GetBucketLoggingResult, error)
+	DeleteBucketLogging(bucket string) error
+	PutBucketLifecycle(bucket string, lifecycle *bce.Body) error
+	PutBucketLifecycleFromString(bucket, lifecycle string) error
+	GetBucketLifecycle(bucket string) (*api.GetBucketLifecycleResult, error)
+	DeleteBucketLifecycle(bucket string) error
+	PutBucketStorageclass(bucket, storageClass string) error
+	GetBucketStorageclass(bucket string) (string, error)
+	PutBucketReplication(bucket string, replicationConf *bce.Body, replicationRuleId string) error
+	PutBucketReplicationFromFile(bucket, confFile string, replicationRuleId string) error
+	PutBucketReplicationFromString(bucket, confString string, replicationRuleId string) error
+	PutBucketReplicationFromStruct(bucket string, confObj *api.PutBucketReplicationArgs, replicationRuleId string) error
+	GetBucketReplication(bucket string, replicationRuleId string) (*api.GetBucketReplicationResult, error)
+	ListBucketReplication(bucket string) (*api.ListBucketReplicationResult, error)
+	DeleteBucketReplication(bucket string, replicationRuleId string) error
+	GetBucketReplicationProgress(bucket string, replicationRuleId string) (*api.GetBucketReplicationProgressResult, error)
+	PutBucketInventory(bucket string, args *api.PutBucketInventoryArgs) error
+	PutBucketInventoryFromString(bucket, confString string, inventoryId string) error
+	GetBucketInventory(bucket string, inventoryId string) (*api.GetBucketInventoryResult, error)
+	ListBucketInventory(bucket string) (*api.ListBucketInventoryResult, error)
+	DeleteBucketInventory(bucket string, inventoryId string) error
+	InitBucketObjectLock(bucket string, args *api.InitBucketObjectLockArgs) error
+	GetBucketObjectLock(bucket string) (*api.GetBucketObjectLockResult, error)
+	DeleteBucketObjectLock(bucket string) error
+	CompleteBucketObjectLock(bucket string) error
+	ExtendBucketObjectLock(bucket string, args *api.ExtendBucketObjectLockArgs) error
+	PutBucketEncryption(bucket, algorithm string) error
+	GetBucketEncryption(bucket string) (string, error)
+	DeleteBucketEncryption(bucket string) error
+	PutBucketStaticWebsite(bucket string, config *bce.Body) error
+	PutBucketStaticWebsiteFromString(bucket, jsonConfig string) error
+	PutBucketStaticWebsiteFromStruct(bucket string, confObj *api.PutBucketStaticWebsiteArgs) error
+	SimplePutBucketStaticWebsite(bucket, index, notFound string) error
+	GetBucketStaticWebsite(bucket string) (*api.GetBucketStaticWebsiteResult, error)
+	DeleteBucketStaticWebsite(bucket string) error
+	PutBucketCors(bucket string, config *bce.Body) error
+	PutBucketCorsFromFile(bucket, filename string) error
+	PutBucketCorsFromString(bucket, jsonConfig string) error
+	PutBucketCorsFromStruct(bucket string, confObj *api.PutBucketCorsArgs) error
+	GetBucketCors(bucket string) (*api.GetBucketCorsResult, error)
+	DeleteBucketCors(bucket string) error
+	PutBucketCopyrightProtection(bucket string, resources ...string) error
+	GetBucketCopyrightProtection(bucket string) ([]string, error)
+	DeleteBucketCopyrightProtection(bucket string) error
+	PutObject(bucket, object string, body *bce.Body, args *api.PutObjectArgs) (string, error)
+	BasicPutObject(bucket, object string, body *bce.Body) (string, error)
+	PutObjectFromBytes(bucket, object string, bytesArr []byte, args *api.PutObjectArgs) (string, error)
+	PutObjectFromString(bucket, object, content string, args *api.PutObjectArgs) (string, error)
+	PutObjectFromFile(bucket, object, fileName string, args *api.PutObjectArgs) (string, error)
+	PutObjectFromChunkedStream(bucket, object string, reader io.Reader, args *api.PutObjectArgs) (string, error)
+	PutObjectFromStream(bucket, object string, reader io.Reader, args *api.PutObjectArgs) (string, error)
+	CopyObject(bucket, object, srcBucket, srcObject string, args *api.CopyObjectArgs) (*api.CopyObjectResult, error)
+	BasicCopyObject(bucket, object, srcBucket, srcObject string) (*api.CopyObjectResult, error)
+	GetObject(bucket, object string, responseHeaders map[string]string, ranges ...int64) (*api.GetObjectResult, error)
+	BasicGetObject(bucket, object string) (*api.GetObjectResult, error)
+	BasicGetObjectToFile(bucket, object, filePath string) error
+	GetObjectMeta(bucket, object string) (*api.GetObjectMetaResult, error)
+	SelectObject(bucket, object string, args *api.SelectObjectArgs) (*api.SelectObjectResult, error)
+	FetchObject(bucket, object, source string, args *api.FetchObjectArgs) (*api.FetchObjectResult, error)
+	BasicFetchObject(bucket, object, source string) (*api.FetchObjectResult, error)
+	SimpleFetchObject(bucket, object, source, mode, storageClass string) (*api.FetchObjectResult, error)
+	AppendObject(bucket, object string, content *bce.Body, args *api.AppendObjectArgs) (*api.AppendObjectResult, error)
+	SimpleAppendObject(bucket, object string, content *bce.Body, offset int64) (*api.AppendObjectResult, error)
+	SimpleAppendObjectFromString(bucket, object, content string, offset int64) (*api.AppendObjectResult, error)
+	SimpleAppendObjectFromFile(bucket, object, filePath string, offset int64) (*api.AppendObjectResult, error)
+	DeleteObject(bucket, object string) error
+	DeleteMultipleObjects(bucket string, objectListStream *bce.Body) (*api.DeleteMultipleObjectsResult, error)
+	DeleteMultipleObjectsFromString(bucket, objectListString string) (*api.DeleteMultipleObjectsResult, error)
+	DeleteMultipleObjectsFromStruct(bucket string, objectListStruct *api.DeleteMultipleObjectsArgs) (*api.DeleteMultipleObjectsResult, error)
+	DeleteMultipleObjectsFromKeyList(bucket string, keyList []string) (*api.DeleteMultipleObjectsResult, error)
+	DeleteObjectsInBatches(bucket string, keyList []string) (*api.DeleteObjectsReport, error)
+	InitiateMultipartUpload(bucket, object, contentType string, args *api.InitiateMultipartUploadArgs) (*api.InitiateMultipartUploadResult, error)
+	BasicInitiateMultipartUpload(bucket, object string) (*api.InitiateMultipartUploadResult, error)
+	UploadPart(bucket, object, uploadId string, partNumber int, content *bce.Body, args *api.UploadPartArgs) (string, error)
+	BasicUploadPart(bucket, object, uploadId string, partNumber int, content *bce.Body) (string, error)
+	UploadPartFromBytes(bucket, object, uploadId string, partNumber int, content []byte, args *api.UploadPartArgs) (string, error)
+	UploadPartCopy(bucket, object, srcBucket, srcObject, uploadId string, partNumber int, args *api.UploadPartCopyArgs) (*api.CopyObjectResult, error)
+	BasicUploadPartCopy(bucket, object, srcBucket, srcObject, uploadId string, partNumber int) (*api.CopyObjectResult, error)
+	CompleteMultipartUpload(bucket, object, uploadId string, body *bce.Body, args *api.CompleteMultipartUploadArgs) (*api.CompleteMultipartUploadResult, error)
+	CompleteMultipartUploadFromStruct(bucket, object, uploadId string, args *api.CompleteMultipartUploadArgs) (*api.CompleteMultipartUploadResult, error)
+	AbortMultipartUpload(bucket, object, uploadId string) error
+	ListParts(bucket, object, uploadId string, args *api.ListPartsArgs) (*api.ListPartsResult, error)
+	BasicListParts(bucket, object, uploadId string) (*api.ListPartsResult, error)
+	ListMultipartUploads(bucket string, args *api.ListMultipartUploadsArgs) (*api.ListMultipartUploadsResult, error)
+	BasicListMultipartUploads(bucket string) (*api.ListMultipartUploadsResult, error)
+	UploadSuperFile(bucket, object, fileName, storageClass string) error
+	DownloadSuperFile(bucket, object, fileName string) (err error)
+	GeneratePresignedUrl(bucket, object string, expireInSeconds int, method string, headers, params map[string]string) string
+	GeneratePresignedUrlPathStyle(bucket, object string, expireInSeconds int, method string, headers, params map[string]string) string
+	BasicGeneratePresignedUrl(bucket, object string, expireInSeconds int) string
+	GeneratePostPolicyForm(policy *api.PostPolicy) (*api.PostPolicyForm, error)
+	BasicGeneratePostPolicyForm(bucket, keyPrefix string, maxSize int64, expireInSeconds int) (*api.PostPolicyForm, error)
+	PutObjectAcl(bucket, object string, aclBody *bce.Body) error
+	PutObjectAclFromCanned(bucket, object, cannedAcl string) error
+	PutObjectAclGrantRead(bucket, object string, ids ...string) error
+	PutObjectAclGrantFullControl(bucket, object string, ids ...string) error
+	PutObjectAclFromFile(bucket, object, aclFile string) error
+	PutObjectAclFromString(bucket, object, aclString string) error
+	PutObjectAclFromStruct(bucket, object string, aclObj *api.PutObjectAclArgs) error
+	GetObjectAcl(bucket, object string) (*api.GetObjectAclResult, error)
+	DeleteObjectAcl(bucket, object string) error
+	PutObjectTagging(bucket, object string, tags map[string]string) error
+	PutObjectTaggingFromStruct(bucket, object string, args *api.PutObjectTaggingArgs) error
+	GetObjectTagging(bucket, object string) (*api.GetObjectTaggingResult, error)
+	DeleteObjectTagging(bucket, object string) error
+	ListObjectsByTags(bucket string, args *api.ListObjectsArgs, tags map[string]string) (*api.ListObjectsResult, error)
+	RestoreObject(bucket string, object string, restoreDays int, restoreTier string) error
+	SetObjectStorageClass(bucket, object, storageClass string) (*api.CopyObjectResult, error)
+	PutBucketTrash(bucket string, trashReq api.PutBucketTrashReq) error
+	GetBucketTrash(bucket string) (*api.GetBucketTrashResult, error)
+	DeleteBucketTrash(bucket string) error
+	PutBucketNotification(bucket string, putBucketNotificationReq api.PutBucketNotificationReq) error
+	GetBucketNotification(bucket string) (*api.PutBucketNotificationReq, error)
+	DeleteBucketNotification(bucket string) error
+	ParallelUpload(bucket string, object string, filename string, contentType string, args *api.InitiateMultipartUploadArgs) (*api.CompleteMultipartUploadResult, error)
+	ParallelCopy(srcBucketName string, srcObjectName string, destBucketName string, destObjectName string, args *api.MultiCopyObjectArgs, srcClient *Client) (*api.CompleteMultipartUploadResult, error)
+	PutSymlink(bucket string, object string, symlinkKey string, symlinkArgs *api.PutSymlinkArgs) error
+	GetSymlink(bucket string, object string) (string, error)
+	GetSymlinkMeta(bucket string, object string) (*api.GetSymlinkResult, error)
+	PutLiveChannel(bucket, channel string, args *api.PutLiveChannelArgs) (*api.PutLiveChannelResult, error)
+	GetLiveChannel(bucket, channel string) (*api.GetLiveChannelResult, error)
+	ListLiveChannel(bucket string, args *api.ListLiveChannelArgs) (*api.ListLiveChannelResult, error)
+	DeleteLiveChannel(bucket, channel string) error
+	PutLiveChannelStatus(bucket, channel, status string) error
+	GetLiveChannelStatus(bucket, channel string) (*api.GetLiveChannelStatusResult, error)
+	GetLiveChannelHistory(bucket, channel string) (*api.GetLiveChannelHistoryResult, error)
+	PostVodPlaylist(bucket, channel, playlistName string, startTime, endTime int64) error
+	GetVodPlaylist(bucket, channel string, startTime, endTime int64) (io.ReadCloser, error)
+	PutBucketQuota(bucket string, quota *api.BucketQuota) error
+	GetBucketQuota(bucket string) (*api.BucketQuota, error)
+	DeleteBucketQuota(bucket string) error
+	GetBucketStatistics(bucket string, args *api.GetBucketStatisticsArgs) (*api.GetBucketStatisticsResult, error)
+	GetObjectWithConditions(bucket, object string, conditions *api.GetObjectConditions, ranges ...int64) (*api.GetObjectResult, error)
+	GetObjectRange(bucket, object string, start, end int64) (*api.GetObjectRangeResult, error)
+	CopyObjectLarge(bucket, object, srcBucket, srcObject string, args *api.CopyObjectLargeArgs) (*api.CopyObjectResult, error)
+	SetObjectMeta(bucket, object string, args *api.SetObjectMetaArgs) (*api.CopyObjectResult, error)
+	ResolveSymlink(bucket, object string) (string, string, error)
+	GetObjectFollowSymlink(bucket, object string, responseHeaders map[string]string, ranges ...int64) (*api.GetObjectResult, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
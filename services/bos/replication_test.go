@@ -0,0 +1,36 @@
+package bos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+func TestPutBucketReplicationFromStruct(t *testing.T) {
+	var sent api.PutBucketReplicationArgs
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClient("ak", "sk", server.URL)
+
+	conf := &api.PutBucketReplicationArgs{
+		Status:      api.STATUS_ENABLED,
+		Resource:    []string{"bucket/*"},
+		Destination: &api.BucketReplicationDescriptor{Bucket: "dest"},
+	}
+	if err := client.PutBucketReplicationFromStruct("bucket", conf, "rule"); err != nil {
+		t.Fatal(err)
+	}
+	if sent.Id != "rule" {
+		t.Errorf("the default id is not sent: %q", sent.Id)
+	}
+	if conf.Id != "" {
+		t.Errorf("the caller's config is changed: %q", conf.Id)
+	}
+}
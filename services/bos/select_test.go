@@ -0,0 +1,91 @@
+package bos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+// selectFrame - encode the select message of the message-type header and the payload
+func selectFrame(messageType, payload string) []byte {
+	headers := &bytes.Buffer{}
+	headers.WriteByte(byte(len(api.SELECT_MESSAGE_TYPE_HEADER)))
+	headers.WriteString(api.SELECT_MESSAGE_TYPE_HEADER)
+	binary.Write(headers, binary.BigEndian, uint16(len(messageType)))
+	headers.WriteString(messageType)
+	return rawSelectFrame(uint32(8+headers.Len()+len(payload)+4), uint32(headers.Len()),
+		append(headers.Bytes(), payload...), true)
+}
+
+// rawSelectFrame - encode the select message with the given lengths and the data of the headers
+// and the payload, the crc32 is 0 if not computed
+func rawSelectFrame(totalLen, headersLen uint32, data []byte, withCrc32 bool) []byte {
+	frame := make([]byte, 8, 8+len(data)+4)
+	binary.BigEndian.PutUint32(frame[0:4], totalLen)
+	binary.BigEndian.PutUint32(frame[4:8], headersLen)
+	frame = append(frame, data...)
+	var sum uint32
+	if withCrc32 {
+		sum = crc32.ChecksumIEEE(frame)
+	}
+	return append(frame, byte(sum>>24), byte(sum>>16), byte(sum>>8), byte(sum))
+}
+
+func newSelectReader(frames ...[]byte) *api.SelectObjectReader {
+	return api.NewSelectObjectReader(ioutil.NopCloser(bytes.NewReader(bytes.Join(frames, nil))), "")
+}
+
+func TestSelectObjectReader(t *testing.T) {
+	reader := newSelectReader(
+		selectFrame(api.SELECT_MESSAGE_RECORDS, "a,1\nb,"),
+		selectFrame(api.SELECT_MESSAGE_CONTINUE, strings.Repeat("\x00", 16)),
+		selectFrame(api.SELECT_MESSAGE_RECORDS, "2\nc,3"),
+		selectFrame(api.SELECT_MESSAGE_END, ""))
+	var records []string
+	if err := reader.ReadRecords(func(record string) error {
+		records = append(records, record)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(records, "|"); got != "a,1\n|b,2\n|c,3" {
+		t.Errorf("records: got %q", got)
+	}
+}
+
+func TestSelectObjectReaderMalformed(t *testing.T) {
+	corrupt := selectFrame(api.SELECT_MESSAGE_RECORDS, "a,1\n")
+	corrupt[len(corrupt)-5]++
+	cases := []struct {
+		name  string
+		frame []byte
+	}{
+		{"huge headers length", rawSelectFrame(100, 0xFFFFFFF8, make([]byte, 88), false)},
+		{"headers longer than message", rawSelectFrame(100, 89, make([]byte, 88), false)},
+		{"too short", rawSelectFrame(11, 0, nil, false)},
+		{"too long", rawSelectFrame(api.SELECT_MAX_MESSAGE_LEN+1, 0, nil, false)},
+		{"truncated", selectFrame(api.SELECT_MESSAGE_RECORDS, "a,1\n")[:20]},
+		{"crc32 mismatch", corrupt},
+		{"invalid headers", rawSelectFrame(20, 8, []byte{200, 'a', 'b', 'c', 0, 0, 0, 0}, false)},
+		{"short continuation", selectFrame(api.SELECT_MESSAGE_CONTINUE, "1234")},
+		{"unknown type", selectFrame("Unknown", "")},
+		{"missing end", nil},
+	}
+	for _, c := range cases {
+		_, err := newSelectReader(c.frame).Next()
+		if err == nil {
+			t.Errorf("%s: expected error", c.name)
+			continue
+		}
+		if _, ok := err.(*bce.BceClientError); !ok && c.name != "truncated" &&
+			c.name != "missing end" {
+			t.Errorf("%s: got %T %v, expected the client error", c.name, err, err)
+		}
+	}
+}
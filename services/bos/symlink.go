@@ -0,0 +1,108 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// symlink.go - resolve the symlink objects to the final target objects
+
+package bos
+
+import (
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+const (
+	// OBJECT_TYPE_SYMLINK is the object type of the object created by the PutSymlink
+	OBJECT_TYPE_SYMLINK = "Symlink"
+
+	// MAX_SYMLINK_DEPTH is the max number of the symlinks followed to resolve an object
+	MAX_SYMLINK_DEPTH = 16
+)
+
+// ResolveSymlink - follow the symlink chain from the given object to the final target object,
+// the given object itself is returned if it is not a symlink
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the object
+// RETURNS:
+//     - string: the bucket of the final target object
+//     - string: the name of the final target object
+//     - error: any error if it occurs, or the symlink chain loops or exceeds MAX_SYMLINK_DEPTH
+func (c *Client) ResolveSymlink(bucket, object string) (string, string, error) {
+	meta, err := api.GetObjectMeta(c, bucket, object)
+	if err != nil {
+		return "", "", err
+	}
+	if meta.ObjectType != OBJECT_TYPE_SYMLINK {
+		return bucket, object, nil
+	}
+	return c.followSymlink(bucket, object)
+}
+
+// GetObjectFollowSymlink - get the given object like the GetObject, if the object is a symlink
+// the content of the final target object is returned instead of the symlink itself
+//
+// PARAMS:
+//     - bucket: the name of the bucket
+//     - object: the name of the object
+//     - responseHeaders: the optional response headers to get the given object
+//     - ranges: the optional range start and end to get the given object
+// RETURNS:
+//     - *api.GetObjectResult: result struct of the final target object, the "Body" is an
+//       unbuffered stream and must be closed by the caller after reading
+//     - error: any error if it occurs
+func (c *Client) GetObjectFollowSymlink(bucket, object string, responseHeaders map[string]string,
+	ranges ...int64) (*api.GetObjectResult, error) {
+	res, err := api.GetObject(c, bucket, object, responseHeaders, ranges...)
+	if err != nil || res.ObjectType != OBJECT_TYPE_SYMLINK {
+		return res, err
+	}
+	res.Body.Close()
+	targetBucket, target, err := c.followSymlink(bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	return api.GetObject(c, targetBucket, target, responseHeaders, ranges...)
+}
+
+func (c *Client) followSymlink(bucket, object string) (string, string, error) {
+	visited := map[string]bool{bucket + "/" + object: true}
+	for depth := 0; depth < MAX_SYMLINK_DEPTH; depth++ {
+		link, err := api.GetObjectSymlinkMeta(c, bucket, object)
+		if err != nil {
+			return "", "", err
+		}
+		if len(link.Bucket) != 0 {
+			bucket = link.Bucket
+		}
+		object = link.Target
+		if visited[bucket+"/"+object] {
+			return "", "", bce.NewBceClientError(
+				fmt.Sprintf("symlink loop detected at %s/%s", bucket, object))
+		}
+		visited[bucket+"/"+object] = true
+
+		meta, err := api.GetObjectMeta(c, bucket, object)
+		if err != nil {
+			return "", "", err
+		}
+		if meta.ObjectType != OBJECT_TYPE_SYMLINK {
+			return bucket, object, nil
+		}
+	}
+	return "", "", bce.NewBceClientError(
+		fmt.Sprintf("too many levels of symlinks, max depth is %d", MAX_SYMLINK_DEPTH))
+}
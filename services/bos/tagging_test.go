@@ -0,0 +1,164 @@
+package bos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+func TestPutObjectTaggingValidation(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+	client, _ := NewClient("ak", "sk", server.URL)
+
+	tooMany := make(map[string]string)
+	for i := 0; i <= api.MAX_OBJECT_TAG_NUM; i++ {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+	duplicated := &api.PutObjectTaggingArgs{TagSet: []api.ObjectTagSetType{{TagInfo: []api.ObjectTagType{
+		{Key: "key", Value: "1"}, {Key: "key", Value: "2"}}}}}
+	cases := []struct {
+		name string
+		args *api.PutObjectTaggingArgs
+	}{
+		{"too many tags", api.NewPutObjectTaggingArgs(tooMany)},
+		{"empty key", api.NewPutObjectTaggingArgs(map[string]string{"": "value"})},
+		{"long key", api.NewPutObjectTaggingArgs(map[string]string{
+			strings.Repeat("k", api.MAX_OBJECT_TAG_KEY_LEN+1): "value"})},
+		{"long value", api.NewPutObjectTaggingArgs(map[string]string{
+			"key": strings.Repeat("值", api.MAX_OBJECT_TAG_VALUE_LEN+1)})},
+		{"duplicated key", duplicated},
+	}
+	for _, c := range cases {
+		if err := client.PutObjectTaggingFromStruct("bucket", "object", c.args); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("the invalid tags are sent by %d requests", requests)
+	}
+
+	maxTags := make(map[string]string)
+	for i := 0; i < api.MAX_OBJECT_TAG_NUM; i++ {
+		maxTags[fmt.Sprintf("key%d", i)] = strings.Repeat("值", api.MAX_OBJECT_TAG_VALUE_LEN)
+	}
+	maxTags[strings.Repeat("k", api.MAX_OBJECT_TAG_KEY_LEN)] = ""
+	delete(maxTags, "key0")
+	if err := client.PutObjectTagging("bucket", "object", maxTags); err != nil {
+		t.Errorf("the tags within the limits: %v", err)
+	}
+}
+
+func TestListObjectsByTags(t *testing.T) {
+	tags := map[string][]api.ObjectTagType{
+		"a": {{Key: "project", Value: "demo"}, {Key: "env", Value: "prod"}},
+		"b": {{Key: "project", Value: "demo"}, {Key: "env", Value: "test"}},
+		"c": {{Key: "project", Value: "other"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+		if _, ok := r.URL.Query()["tagging"]; !ok {
+			json.NewEncoder(w).Encode(&api.ListObjectsResult{Name: "bucket", MaxKeys: 4,
+				IsTruncated: true, Contents: []api.ObjectSummaryType{
+					{Key: "a"}, {Key: "b"}, {Key: "c"}, {Key: "untagged"}}})
+			return
+		}
+		tagInfo, ok := tags[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"NoSuchTagSet","message":"no tags","requestId":"1"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(&api.GetObjectTaggingResult{
+			TagSet: []api.ObjectTagSetType{{TagInfo: tagInfo}}})
+	}))
+	defer server.Close()
+	client, _ := NewClient("ak", "sk", server.URL)
+	client.Config.Retry = bce.NewNoRetryPolicy()
+	client.MaxParallel = 2
+
+	cases := []struct {
+		tags     map[string]string
+		expected string
+	}{
+		{map[string]string{"project": "demo"}, "a,b"},
+		{map[string]string{"project": "demo", "env": "prod"}, "a"},
+		{map[string]string{"env": ""}, "a,b"},
+		{map[string]string{"owner": ""}, ""},
+		{nil, "a,b,c,untagged"},
+	}
+	for _, c := range cases {
+		res, err := client.ListObjectsByTags("bucket", nil, c.tags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys := make([]string, 0, len(res.Contents))
+		for _, object := range res.Contents {
+			keys = append(keys, object.Key)
+		}
+		if got := strings.Join(keys, ","); got != c.expected {
+			t.Errorf("%v: got %q, expected %q", c.tags, got, c.expected)
+		}
+		if !res.IsTruncated || (c.tags != nil && res.NextMarker != "untagged") {
+			t.Errorf("%v: the next page is lost: %v %q", c.tags, res.IsTruncated, res.NextMarker)
+		}
+	}
+}
+
+func TestPutObjectFromStreamMultipartTags(t *testing.T) {
+	var tagging, crc32cFlag, crc32 string
+	var parts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && query.Get("uploadId") == "":
+			tagging = r.Header.Get("x-bce-tagging")
+			w.Write([]byte(`{"bucket":"bucket","key":"object","uploadId":"upload"}`))
+		case r.Method == http.MethodPut:
+			atomic.AddInt32(&parts, 1)
+			w.Header().Set("ETag", "etag")
+		case r.Method == http.MethodPost:
+			crc32cFlag = r.Header.Get("x-bce-content-crc32c-flag")
+			crc32 = r.Header.Get("x-bce-content-crc32")
+			w.Write([]byte(`{"bucket":"bucket","key":"object","eTag":"etag"}`))
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClient("ak", "sk", server.URL)
+	client.Config.Retry = bce.NewNoRetryPolicy()
+	client.MultipartSize = MIN_MULTIPART_SIZE
+
+	// larger than a part to fall back to the multipart upload
+	content := strings.Repeat("x", MULTIPART_ALIGN+1)
+	args := &api.PutObjectArgs{Tags: map[string]string{"b": "2", "a": "1"},
+		ContentCrc32: "123", ContentCrc32cFlag: true}
+	if _, err := client.PutObjectFromStream("bucket", "object", strings.NewReader(content),
+		args); err != nil {
+		t.Fatal(err)
+	}
+	if parts != 2 {
+		t.Errorf("the stream is uploaded by %d parts", parts)
+	}
+	if tagging != "a=1&b=2" || crc32cFlag != "true" || crc32 != "123" {
+		t.Errorf("got the tagging %q, the crc32c flag %q and the crc32 %q", tagging, crc32cFlag,
+			crc32)
+	}
+
+	args.Tags = map[string]string{"": "empty key"}
+	if _, err := client.PutObjectFromStream("bucket", "object", strings.NewReader(content),
+		args); err == nil {
+		t.Errorf("the invalid tags: expected error")
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// download.go - define the download task of the transfer manager
+
+package transfer
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+// DownloadInput defines the object to download and the local file to store it.
+type DownloadInput struct {
+	Bucket   string
+	Object   string
+	FileName string
+
+	// OnProgress overrides the progress callback of the manager for this file
+	OnProgress ProgressFunc
+}
+
+// Download - start the task to download the object by the concurrent range gets, the local file
+// is removed if the task fails or is canceled
+//
+// PARAMS:
+//     - ctx: the context to cancel the task
+//     - input: the object to download
+// RETURNS:
+//     - *Task: the download task
+func (m *Manager) Download(ctx context.Context, input *DownloadInput) *Task {
+	onProgress := input.OnProgress
+	if onProgress == nil {
+		onProgress = m.options.OnProgress
+	}
+	task, ctx := newTask(ctx, input.Bucket, input.Object, input.FileName, onProgress)
+	go func() {
+		if err := acquire(ctx, m.files); err != nil {
+			task.finish(ctx, err)
+			return
+		}
+		err := m.download(ctx, task, input)
+		<-m.files
+		task.finish(ctx, err)
+	}()
+	return task
+}
+
+// DownloadAll - start the tasks to download the objects
+//
+// PARAMS:
+//     - ctx: the context to cancel the tasks
+//     - inputs: the objects to download
+// RETURNS:
+//     - []*Task: the download tasks in the order of the inputs
+func (m *Manager) DownloadAll(ctx context.Context, inputs []*DownloadInput) []*Task {
+	tasks := make([]*Task, 0, len(inputs))
+	for _, input := range inputs {
+		tasks = append(tasks, m.Download(ctx, input))
+	}
+	return tasks
+}
+
+func (m *Manager) download(ctx context.Context, task *Task, input *DownloadInput) (err error) {
+	meta, err := m.client.GetObjectMeta(input.Bucket, input.Object)
+	if err != nil {
+		return err
+	}
+	size := meta.ContentLength
+	file, err := os.OpenFile(input.FileName, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(input.FileName)
+		}
+	}()
+	task.start(size)
+	if size == 0 {
+		return nil
+	}
+
+	partSize := m.partSize(size)
+	partNum := int((size + partSize - 1) / partSize)
+	parts := make([]int, partNum)
+	for i := range parts {
+		parts[i] = i
+	}
+	return m.runParts(ctx, task, parts, func(ctx context.Context, index int) error {
+		rangeStart := int64(index) * partSize
+		rangeEnd := rangeStart + partLength(index, size, partSize) - 1
+		res, err := m.client.GetObject(input.Bucket, input.Object, nil, rangeStart, rangeEnd)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		reader := &partReader{ctx: ctx, reader: res.Body, task: task, limiter: m.limiter}
+		writer := &offsetWriter{file: file, offset: rangeStart}
+		n, err := io.Copy(writer, reader)
+		if err == nil && n != rangeEnd-rangeStart+1 {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			reader.rollback()
+			if writer.err == nil {
+				// The broken response stream is retryable while the local write error is not
+				return &bce.BceClientError{Message: "read the object part failed: " + err.Error(),
+					Cause: err}
+			}
+			return err
+		}
+		log.Debugf("download part %d of %s/%s success, offset: %d, size: %d", index+1,
+			input.Bucket, input.Object, rangeStart, n)
+		return nil
+	})
+}
+
+// offsetWriter defines the writer to write the part to the file at its offset.
+type offsetWriter struct {
+	file   *os.File
+	offset int64
+	err    error
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.file.WriteAt(p, w.offset)
+	w.offset += int64(n)
+	w.err = err
+	return n, err
+}
@@ -0,0 +1,326 @@
+package transfer
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+const testBucket = "bucket"
+
+type fakeObject struct {
+	content      []byte
+	lastModified time.Time
+}
+
+// fakeBOS is the in-memory BOS server of one bucket serving the apis used by the transfer
+// manager in the path style
+type fakeBOS struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	objects  map[string]*fakeObject
+	uploads  map[string]map[int][]byte
+	aborted  []string
+	uploaded []int // the part numbers uploaded in order
+
+	// failPart fails the upload of the part number with the status code for the given times
+	failPart   map[int]int
+	failStatus int
+	// failDelete fails the deletion of the keys by the multiple objects deletion
+	failDelete map[string]bool
+	// failDeleteBatch fails the whole request of the multiple objects deletion containing the key
+	failDeleteBatch string
+	// listPageSize is the max number of the parts or objects of one list response
+	listPageSize int
+}
+
+// newFakeBOS - start the fake server and create the client without retry to visit it, the
+// server should be closed by the caller
+func newFakeBOS(t *testing.T) (*fakeBOS, *bos.Client) {
+	fake := &fakeBOS{
+		objects:      make(map[string]*fakeObject),
+		uploads:      make(map[string]map[int][]byte),
+		failPart:     make(map[int]int),
+		failStatus:   http.StatusInternalServerError,
+		failDelete:   make(map[string]bool),
+		listPageSize: 2,
+	}
+	fake.server = httptest.NewServer(fake)
+	client, err := bos.NewClient("ak", "sk", fake.server.URL)
+	if err != nil {
+		fake.server.Close()
+		t.Fatal(err)
+	}
+	client.Config.Retry = bce.NewNoRetryPolicy()
+	return fake, client
+}
+
+func (f *fakeBOS) put(key string, content []byte, lastModified time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[key] = &fakeObject{content, lastModified.UTC().Truncate(time.Second)}
+}
+
+func (f *fakeBOS) get(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	object, ok := f.objects[key]
+	if !ok {
+		return nil, false
+	}
+	return object.content, true
+}
+
+func (f *fakeBOS) keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func etagOf(content []byte) string {
+	sum := md5.Sum(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"code":%q,"message":"fake error","requestId":"fake"}`, code)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (f *fakeBOS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	parts := strings.SplitN(path, "/", 2)
+	if parts[0] != testBucket {
+		writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	query := r.URL.Query()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(parts) == 1 || len(parts[1]) == 0 {
+		switch {
+		case r.Method == http.MethodGet:
+			f.listObjects(w, query)
+		case r.Method == http.MethodPost && hasParam(query, "delete"):
+			f.deleteObjects(w, body)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		}
+		return
+	}
+	key := parts[1]
+	hasUploadId := hasParam(query, "uploadId")
+	switch {
+	case r.Method == http.MethodPost && hasParam(query, "uploads"):
+		uploadId := fmt.Sprintf("upload-%d", len(f.uploads)+1)
+		f.uploads[uploadId] = make(map[int][]byte)
+		writeJSON(w, &api.InitiateMultipartUploadResult{Bucket: testBucket, Key: key,
+			UploadId: uploadId})
+	case r.Method == http.MethodPut && hasUploadId:
+		f.uploadPart(w, query, body)
+	case r.Method == http.MethodGet && hasUploadId:
+		f.listParts(w, key, query)
+	case r.Method == http.MethodPost && hasUploadId:
+		f.completeUpload(w, key, query, body)
+	case r.Method == http.MethodDelete && hasUploadId:
+		f.aborted = append(f.aborted, query.Get("uploadId"))
+		delete(f.uploads, query.Get("uploadId"))
+	case r.Method == http.MethodPut:
+		f.objects[key] = &fakeObject{body, time.Now().UTC().Truncate(time.Second)}
+		w.Header().Set("ETag", etagOf(body))
+	case r.Method == http.MethodHead || r.Method == http.MethodGet:
+		f.getObject(w, r, key)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (f *fakeBOS) uploadPart(w http.ResponseWriter, query url.Values, body []byte) {
+	parts, ok := f.uploads[query.Get("uploadId")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+	partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+	if f.failPart[partNumber] > 0 {
+		f.failPart[partNumber]--
+		writeError(w, f.failStatus, "FakeError")
+		return
+	}
+	parts[partNumber] = body
+	f.uploaded = append(f.uploaded, partNumber)
+	w.Header().Set("ETag", etagOf(body))
+}
+
+func (f *fakeBOS) listParts(w http.ResponseWriter, key string, query url.Values) {
+	parts, ok := f.uploads[query.Get("uploadId")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+	marker, _ := strconv.Atoi(query.Get("partNumberMarker"))
+	numbers := make([]int, 0, len(parts))
+	for n := range parts {
+		if n > marker {
+			numbers = append(numbers, n)
+		}
+	}
+	sort.Ints(numbers)
+	result := &api.ListPartsResult{Bucket: testBucket, Key: key, PartNumberMarker: marker,
+		MaxParts: f.listPageSize}
+	if len(numbers) > f.listPageSize {
+		numbers = numbers[:f.listPageSize]
+		result.IsTruncated = true
+		result.NextPartNumberMarker = numbers[len(numbers)-1]
+	}
+	for _, n := range numbers {
+		result.Parts = append(result.Parts, api.ListPartType{PartNumber: n,
+			ETag: etagOf(parts[n]), Size: len(parts[n])})
+	}
+	writeJSON(w, result)
+}
+
+func (f *fakeBOS) completeUpload(w http.ResponseWriter, key string,
+	query url.Values, body []byte) {
+	parts, ok := f.uploads[query.Get("uploadId")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+	args := &api.CompleteMultipartUploadArgs{}
+	if err := json.Unmarshal(body, args); err != nil {
+		writeError(w, http.StatusBadRequest, "MalformedJSON")
+		return
+	}
+	content := make([]byte, 0)
+	for i, p := range args.Parts {
+		data, ok := parts[p.PartNumber]
+		if !ok || p.PartNumber != i+1 || p.ETag != etagOf(data) {
+			writeError(w, http.StatusBadRequest, "InvalidPart")
+			return
+		}
+		content = append(content, data...)
+	}
+	delete(f.uploads, query.Get("uploadId"))
+	f.objects[key] = &fakeObject{content, time.Now().UTC().Truncate(time.Second)}
+	writeJSON(w, &api.CompleteMultipartUploadResult{Bucket: testBucket, Key: key,
+		ETag: "multipart-etag"})
+}
+
+func (f *fakeBOS) getObject(w http.ResponseWriter, r *http.Request, key string) {
+	object, ok := f.objects[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+	content := object.content
+	w.Header().Set("ETag", etagOf(content))
+	w.Header().Set("Last-Modified", object.lastModified.Format(http.TimeFormat))
+	status := http.StatusOK
+	if rangeHeader := r.Header.Get("Range"); len(rangeHeader) != 0 {
+		var start, end int
+		fmt.Sscanf(rangeHeader, "bytes=%d-%d", &start, &end)
+		if end >= len(content) {
+			end = len(content) - 1
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(content)))
+		content = content[start : end+1]
+		status = http.StatusPartialContent
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.WriteHeader(status)
+	if r.Method == http.MethodGet {
+		w.Write(content)
+	}
+}
+
+func (f *fakeBOS) listObjects(w http.ResponseWriter, query url.Values) {
+	prefix, marker := query.Get("prefix"), query.Get("marker")
+	keys := make([]string, 0)
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) && key > marker {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	result := &api.ListObjectsResult{Name: testBucket, Prefix: prefix, Marker: marker,
+		MaxKeys: f.listPageSize}
+	if len(keys) > f.listPageSize {
+		keys = keys[:f.listPageSize]
+		result.IsTruncated = true
+		result.NextMarker = keys[len(keys)-1]
+	}
+	for _, key := range keys {
+		object := f.objects[key]
+		result.Contents = append(result.Contents, api.ObjectSummaryType{
+			Key:          key,
+			LastModified: object.lastModified.Format("2006-01-02T15:04:05Z"),
+			ETag:         etagOf(object.content),
+			Size:         len(object.content),
+		})
+	}
+	writeJSON(w, result)
+}
+
+func (f *fakeBOS) deleteObjects(w http.ResponseWriter, body []byte) {
+	args := &api.DeleteMultipleObjectsArgs{}
+	if err := json.Unmarshal(body, args); err != nil {
+		writeError(w, http.StatusBadRequest, "MalformedJSON")
+		return
+	}
+	for _, object := range args.Objects {
+		if object.Key == f.failDeleteBatch {
+			writeError(w, http.StatusServiceUnavailable, "ServiceUnavailable")
+			return
+		}
+	}
+	result := &api.DeleteMultipleObjectsResult{}
+	for _, object := range args.Objects {
+		if f.failDelete[object.Key] {
+			result.Errors = append(result.Errors, api.DeleteObjectResult{Key: object.Key,
+				Code: "AccessDenied", Message: "fake error"})
+			continue
+		}
+		delete(f.objects, object.Key)
+	}
+	if len(result.Errors) == 0 {
+		return
+	}
+	writeJSON(w, result)
+}
+
+func hasParam(query url.Values, key string) bool {
+	_, ok := query[key]
+	return ok
+}
@@ -0,0 +1,410 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// sync.go - synchronize the local directory and the objects under the prefix like the rsync
+
+package transfer
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+	"github.com/baidubce/bce-sdk-go/util"
+)
+
+// SYNC_LIST_MAX_KEYS is the page size of listing the objects to sync
+const SYNC_LIST_MAX_KEYS = 1000
+
+// SyncOptions defines the options of the directory sync, the zero values are the defaults.
+type SyncOptions struct {
+	// Checksum compares the MD5 of the local file with the ETag of the object instead of the
+	// modification time. The ETags of the objects uploaded by the multipart upload are not the
+	// MD5 of the content, so these objects are always transferred.
+	Checksum bool
+
+	// Delete removes the objects of the upload, or the local files of the download, which do
+	// not exist in the source
+	Delete bool
+
+	// DryRun reports the files to transfer and to delete without changing anything
+	DryRun bool
+
+	// Exclude skips the files whose paths relative to the directory, separated by "/", match
+	// any of the patterns of the path.Match, the excluded files are neither synced nor deleted
+	Exclude []string
+}
+
+// SyncFailure defines the file failed to transfer or to delete and the reason.
+type SyncFailure struct {
+	Path string
+	Err  error
+}
+
+// SyncSummary defines the result of the directory sync, the paths are relative to the directory
+// and separated by "/".
+type SyncSummary struct {
+	Transferred      []string
+	Deleted          []string
+	Skipped          int
+	TransferredBytes int64
+	Failed           []SyncFailure
+}
+
+// syncEntry defines a local file or an object to compare
+type syncEntry struct {
+	path    string // the local file name or the object key
+	size    int64
+	modTime time.Time
+	etag    string
+}
+
+// SyncUpload - upload the files of the local directory recursively to the objects under the
+// prefix, the file is uploaded if the object does not exist, or has the different size, or is
+// older than the file (or has the different ETag if the Checksum is set)
+//
+// PARAMS:
+//     - ctx: the context to cancel the sync
+//     - localDir: the local directory
+//     - bucket: the bucket name
+//     - prefix: the prefix of the objects, "/" is appended if it is not empty
+//     - opts: the options of the sync, nil to use the defaults
+// RETURNS:
+//     - *SyncSummary: the files transferred, deleted, skipped and failed
+//     - error: nil if all files are synced otherwise the specific error
+func (m *Manager) SyncUpload(ctx context.Context, localDir, bucket, prefix string,
+	opts *SyncOptions) (*SyncSummary, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	prefix = syncPrefix(prefix)
+	locals, err := listLocalFiles(localDir, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	remotes, err := m.listSyncObjects(bucket, prefix, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &SyncSummary{}
+	rels := make([]string, 0)
+	inputs := make([]*UploadInput, 0)
+	for _, rel := range sortedKeys(locals) {
+		local := locals[rel]
+		if remote, ok := remotes[rel]; ok {
+			same, err := sameEntry(local, remote, opts.Checksum, false)
+			if err != nil {
+				summary.Failed = append(summary.Failed, SyncFailure{Path: rel, Err: err})
+				continue
+			}
+			if same {
+				summary.Skipped++
+				continue
+			}
+		}
+		rels = append(rels, rel)
+		inputs = append(inputs, &UploadInput{Bucket: bucket, Object: prefix + rel, FileName: local.path})
+	}
+	if opts.DryRun {
+		summary.Transferred = rels
+	} else {
+		m.waitSync(summary, rels, m.UploadAll(ctx, inputs), func(i int) error { return nil },
+			func(i int) int64 { return locals[rels[i]].size })
+	}
+
+	if opts.Delete {
+		keys := make([]string, 0)
+		deleted := make(map[string]string)
+		for _, rel := range sortedKeys(remotes) {
+			if _, ok := locals[rel]; !ok {
+				keys = append(keys, remotes[rel].path)
+				deleted[remotes[rel].path] = rel
+			}
+		}
+		if opts.DryRun || len(keys) == 0 {
+			for _, key := range keys {
+				summary.Deleted = append(summary.Deleted, deleted[key])
+			}
+		} else {
+			report, err := m.client.DeleteObjectsInBatches(bucket, keys)
+			if report != nil {
+				for _, key := range report.Deleted {
+					summary.Deleted = append(summary.Deleted, deleted[key])
+				}
+				for _, failed := range report.Failed {
+					summary.Failed = append(summary.Failed, SyncFailure{Path: deleted[failed.Key],
+						Err: &bce.BceServiceError{Code: failed.Code, Message: failed.Message}})
+				}
+			} else if err != nil {
+				return summary, err
+			}
+		}
+	}
+	return summary, summary.err()
+}
+
+// SyncDownload - download the objects under the prefix recursively to the files of the local
+// directory, the object is downloaded if the file does not exist, or has the different size, or
+// is older than the object (or has the different MD5 if the Checksum is set). The modification
+// time of the downloaded file is set to the last modified time of the object.
+//
+// PARAMS:
+//     - ctx: the context to cancel the sync
+//     - bucket: the bucket name
+//     - prefix: the prefix of the objects, "/" is appended if it is not empty
+//     - localDir: the local directory, which is created if not exists
+//     - opts: the options of the sync, nil to use the defaults
+// RETURNS:
+//     - *SyncSummary: the files transferred, deleted, skipped and failed
+//     - error: nil if all files are synced otherwise the specific error
+func (m *Manager) SyncDownload(ctx context.Context, bucket, prefix, localDir string,
+	opts *SyncOptions) (*SyncSummary, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	prefix = syncPrefix(prefix)
+	if !opts.DryRun {
+		if err := os.MkdirAll(localDir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	locals, err := listLocalFiles(localDir, opts.Exclude)
+	if err != nil && !(opts.DryRun && os.IsNotExist(err)) {
+		return nil, err
+	}
+	remotes, err := m.listSyncObjects(bucket, prefix, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &SyncSummary{}
+	rels := make([]string, 0)
+	inputs := make([]*DownloadInput, 0)
+	for _, rel := range sortedKeys(remotes) {
+		remote := remotes[rel]
+		fileName, err := syncLocalPath(localDir, rel)
+		if err != nil {
+			summary.Failed = append(summary.Failed, SyncFailure{Path: rel, Err: err})
+			continue
+		}
+		if local, ok := locals[rel]; ok {
+			same, err := sameEntry(local, remote, opts.Checksum, true)
+			if err != nil {
+				summary.Failed = append(summary.Failed, SyncFailure{Path: rel, Err: err})
+				continue
+			}
+			if same {
+				summary.Skipped++
+				continue
+			}
+		}
+		if !opts.DryRun {
+			if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+				summary.Failed = append(summary.Failed, SyncFailure{Path: rel, Err: err})
+				continue
+			}
+		}
+		rels = append(rels, rel)
+		inputs = append(inputs, &DownloadInput{Bucket: bucket, Object: remote.path, FileName: fileName})
+	}
+	if opts.DryRun {
+		summary.Transferred = rels
+	} else {
+		m.waitSync(summary, rels, m.DownloadAll(ctx, inputs), func(i int) error {
+			modTime := remotes[rels[i]].modTime
+			if modTime.IsZero() {
+				return nil
+			}
+			return os.Chtimes(inputs[i].FileName, modTime, modTime)
+		}, func(i int) int64 { return remotes[rels[i]].size })
+	}
+
+	if opts.Delete {
+		for _, rel := range sortedKeys(locals) {
+			if _, ok := remotes[rel]; ok {
+				continue
+			}
+			if !opts.DryRun {
+				if err := os.Remove(locals[rel].path); err != nil {
+					summary.Failed = append(summary.Failed, SyncFailure{Path: rel, Err: err})
+					continue
+				}
+			}
+			summary.Deleted = append(summary.Deleted, rel)
+		}
+	}
+	return summary, summary.err()
+}
+
+// waitSync waits for the tasks of the sync and adds their results to the summary
+func (m *Manager) waitSync(summary *SyncSummary, rels []string, tasks []*Task,
+	onSuccess func(i int) error, size func(i int) int64) {
+	for i, task := range tasks {
+		err := task.Wait()
+		if err == nil {
+			err = onSuccess(i)
+		}
+		if err != nil {
+			summary.Failed = append(summary.Failed, SyncFailure{Path: rels[i], Err: err})
+			continue
+		}
+		summary.Transferred = append(summary.Transferred, rels[i])
+		summary.TransferredBytes += size(i)
+	}
+}
+
+func (s *SyncSummary) err() error {
+	if len(s.Failed) == 0 {
+		return nil
+	}
+	return bce.NewBceClientError(fmt.Sprintf("%d files failed to sync, the first one %s: %v",
+		len(s.Failed), s.Failed[0].Path, s.Failed[0].Err))
+}
+
+// listSyncObjects lists the objects under the prefix keyed by the paths relative to the prefix
+func (m *Manager) listSyncObjects(bucket, prefix string,
+	exclude []string) (map[string]*syncEntry, error) {
+	result := make(map[string]*syncEntry)
+	args := &api.ListObjectsArgs{Prefix: prefix, MaxKeys: SYNC_LIST_MAX_KEYS}
+	for {
+		res, err := m.client.ListObjects(bucket, args)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range res.Contents {
+			rel := strings.TrimPrefix(object.Key, prefix)
+			// The keys ending with "/" are the directory placeholders
+			if len(rel) == 0 || strings.HasSuffix(rel, "/") || excluded(rel, exclude) {
+				continue
+			}
+			entry := &syncEntry{path: object.Key, size: int64(object.Size),
+				etag: strings.ToLower(strings.Trim(object.ETag, "\""))}
+			if modTime, err := util.ParseISO8601Date(object.LastModified); err == nil {
+				entry.modTime = modTime
+			}
+			result[rel] = entry
+		}
+		if !res.IsTruncated || len(res.Contents) == 0 {
+			return result, nil
+		}
+		args.Marker = res.NextMarker
+		if len(args.Marker) == 0 {
+			args.Marker = res.Contents[len(res.Contents)-1].Key
+		}
+	}
+}
+
+// listLocalFiles lists the regular files of the directory keyed by the paths relative to it
+func listLocalFiles(localDir string, exclude []string) (map[string]*syncEntry, error) {
+	result := make(map[string]*syncEntry)
+	err := filepath.Walk(localDir, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(localDir, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if excluded(rel, exclude) {
+			return nil
+		}
+		result[rel] = &syncEntry{path: name, size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	return result, err
+}
+
+// sameEntry checks whether the local file and the object are the same, the source is newer if
+// it is modified after the destination in the precision of the seconds of the object
+func sameEntry(local, remote *syncEntry, checksum, download bool) (bool, error) {
+	if local.size != remote.size {
+		return false, nil
+	}
+	if checksum {
+		sum, err := fileMD5(local.path)
+		if err != nil {
+			return false, err
+		}
+		return sum == remote.etag, nil
+	}
+	localTime := local.modTime.Truncate(time.Second)
+	if download {
+		return !remote.modTime.After(localTime), nil
+	}
+	return !localTime.After(remote.modTime), nil
+}
+
+func fileMD5(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// syncLocalPath gets the local file name of the object, the keys escaping the directory by the
+// ".." are rejected
+func syncLocalPath(localDir, rel string) (string, error) {
+	fileName := filepath.Join(localDir, filepath.FromSlash(rel))
+	inside, err := filepath.Rel(localDir, fileName)
+	if err != nil || inside == ".." || strings.HasPrefix(inside, ".."+string(filepath.Separator)) {
+		return "", bce.NewBceClientError("the object key escapes the local directory: " + rel)
+	}
+	return fileName, nil
+}
+
+func syncPrefix(prefix string) string {
+	if len(prefix) != 0 && !strings.HasSuffix(prefix, "/") {
+		return prefix + "/"
+	}
+	return prefix
+}
+
+func excluded(rel string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(entries map[string]*syncEntry) []string {
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,305 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+)
+
+type syncFile struct {
+	path    string
+	content string
+	modTime time.Time
+}
+
+func writeSyncFiles(t *testing.T, dir string, files []syncFile) {
+	for _, f := range files {
+		name := filepath.Join(dir, filepath.FromSlash(f.path))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(f.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(name, f.modTime, f.modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func failedPaths(summary *SyncSummary) []string {
+	paths := make([]string, 0, len(summary.Failed))
+	for _, f := range summary.Failed {
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+func TestSameEntry(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := []struct {
+		name          string
+		local, remote *syncEntry
+		download      bool
+		expected      bool
+	}{
+		{"different size", &syncEntry{size: 1, modTime: now}, &syncEntry{size: 2, modTime: now}, false, false},
+		{"upload older file", &syncEntry{modTime: now}, &syncEntry{modTime: now.Add(time.Hour)}, false, true},
+		{"upload newer file", &syncEntry{modTime: now.Add(time.Hour)}, &syncEntry{modTime: now}, false, false},
+		{"upload in the same second", &syncEntry{modTime: now.Add(500 * time.Millisecond)},
+			&syncEntry{modTime: now}, false, true},
+		{"download older object", &syncEntry{modTime: now.Add(time.Hour)}, &syncEntry{modTime: now}, true, true},
+		{"download newer object", &syncEntry{modTime: now}, &syncEntry{modTime: now.Add(time.Hour)}, true, false},
+	}
+	for _, c := range cases {
+		got, err := sameEntry(c.local, c.remote, false, c.download)
+		if err != nil || got != c.expected {
+			t.Errorf("%s: got %v and %v, expected %v", c.name, got, err, c.expected)
+		}
+	}
+}
+
+func TestSyncLocalPath(t *testing.T) {
+	dir := filepath.FromSlash("/data/dir")
+	if name, err := syncLocalPath(dir, "a/b.txt"); err != nil ||
+		name != filepath.Join(dir, "a", "b.txt") {
+		t.Errorf("got %q and %v", name, err)
+	}
+	for _, rel := range []string{"../escape", "a/../../escape", ".."} {
+		if _, err := syncLocalPath(dir, rel); err == nil {
+			t.Errorf("%s: expected error", rel)
+		}
+	}
+	if syncPrefix("dir") != "dir/" || syncPrefix("dir/") != "dir/" || syncPrefix("") != "" {
+		t.Errorf("sync prefix is not normalized")
+	}
+}
+
+func TestSyncUpload(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Now().Truncate(time.Second)
+	old := now.Add(-time.Hour)
+	writeSyncFiles(t, dir, []syncFile{
+		{"new.txt", "new", now},
+		{"same.txt", "same", old},
+		{"newer.txt", "newer", now},
+		{"resized.txt", "resized", old},
+		{"sub/nested.txt", "nested", now},
+		{"skip.log", "skip", now},
+	})
+	fake.put("prefix/same.txt", []byte("same"), now)
+	fake.put("prefix/newer.txt", []byte("older"), old)
+	fake.put("prefix/resized.txt", []byte("old"), now)
+	fake.put("prefix/removed.txt", []byte("removed"), now)
+	fake.put("prefix/keep.log", []byte("keep"), now)
+	fake.put("prefix/sub/", nil, now)
+	fake.put("other/new.txt", []byte("other"), now)
+
+	m := newTestManager(client, nil)
+	opts := &SyncOptions{Delete: true, DryRun: true, Exclude: []string{"*.log"}}
+	expected := []string{"new.txt", "newer.txt", "resized.txt", "sub/nested.txt"}
+	before := fake.keys()
+	summary, err := m.SyncUpload(context.Background(), dir, testBucket, "prefix", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(summary.Transferred, expected) || summary.Skipped != 1 ||
+		!reflect.DeepEqual(summary.Deleted, []string{"removed.txt"}) {
+		t.Errorf("dry run: got %+v", summary)
+	}
+	if !reflect.DeepEqual(fake.keys(), before) {
+		t.Errorf("dry run changes the objects: %v", fake.keys())
+	}
+
+	opts.DryRun = false
+	summary, err = m.SyncUpload(context.Background(), dir, testBucket, "prefix", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(summary.Transferred, expected) || summary.Skipped != 1 ||
+		summary.TransferredBytes != int64(len("new")+len("newer")+len("resized")+len("nested")) {
+		t.Errorf("sync: got %+v", summary)
+	}
+	if got, _ := fake.get("prefix/newer.txt"); string(got) != "newer" {
+		t.Errorf("newer.txt: got %q", got)
+	}
+	if got, _ := fake.get("prefix/sub/nested.txt"); string(got) != "nested" {
+		t.Errorf("sub/nested.txt: got %q", got)
+	}
+	if _, ok := fake.get("prefix/removed.txt"); ok {
+		t.Errorf("removed.txt is not deleted")
+	}
+	for _, key := range []string{"prefix/keep.log", "prefix/sub/", "other/new.txt"} {
+		if _, ok := fake.get(key); !ok {
+			t.Errorf("%s should not be deleted", key)
+		}
+	}
+	if _, ok := fake.get("prefix/skip.log"); ok {
+		t.Errorf("the excluded file is uploaded")
+	}
+}
+
+func TestSyncUploadChecksum(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Now().Truncate(time.Second)
+	writeSyncFiles(t, dir, []syncFile{
+		{"touched.txt", "content", now},
+		{"changed.txt", "changed", now.Add(-time.Hour)},
+	})
+	fake.put("touched.txt", []byte("content"), now.Add(-time.Hour))
+	fake.put("changed.txt", []byte("content"), now)
+
+	m := newTestManager(client, nil)
+	summary, err := m.SyncUpload(context.Background(), dir, testBucket, "",
+		&SyncOptions{Checksum: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(summary.Transferred, []string{"changed.txt"}) || summary.Skipped != 1 {
+		t.Errorf("checksum: got %+v", summary)
+	}
+}
+
+func TestSyncUploadDeleteFailure(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	writeSyncFiles(t, dir, []syncFile{{"local.txt", "local", time.Now()}})
+	for _, key := range []string{"a.txt", "b.txt", "c.txt"} {
+		fake.put(key, []byte(key), time.Now())
+	}
+	fake.failDelete["b.txt"] = true
+
+	m := newTestManager(client, nil)
+	summary, err := m.SyncUpload(context.Background(), dir, testBucket, "",
+		&SyncOptions{Delete: true})
+	if err == nil || !strings.Contains(err.Error(), "b.txt") {
+		t.Errorf("error: got %v", err)
+	}
+	if !reflect.DeepEqual(summary.Transferred, []string{"local.txt"}) ||
+		!reflect.DeepEqual(summary.Deleted, []string{"a.txt", "c.txt"}) ||
+		!reflect.DeepEqual(failedPaths(summary), []string{"b.txt"}) {
+		t.Errorf("summary: got %+v", summary)
+	}
+	if e, ok := summary.Failed[0].Err.(*bce.BceServiceError); !ok || e.Code != "AccessDenied" {
+		t.Errorf("failure: got %v", summary.Failed[0].Err)
+	}
+	if !reflect.DeepEqual(fake.keys(), []string{"b.txt", "local.txt"}) {
+		t.Errorf("objects: got %v", fake.keys())
+	}
+}
+
+func TestSyncDownload(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	localDir := filepath.Join(dir, "local")
+
+	now := time.Now().Truncate(time.Second)
+	old := now.Add(-time.Hour)
+	writeSyncFiles(t, localDir, []syncFile{
+		{"same.txt", "same", now},
+		{"stale.txt", "stale", old},
+		{"removed.txt", "removed", now},
+		{"keep.log", "keep", now},
+	})
+	fake.put("prefix/same.txt", []byte("same"), old)
+	fake.put("prefix/stale.txt", []byte("fresh"), now)
+	fake.put("prefix/sub/new.txt", []byte("new"), old)
+	fake.put("prefix/../escape.txt", []byte("escape"), now)
+
+	m := newTestManager(client, nil)
+	summary, err := m.SyncDownload(context.Background(), testBucket, "prefix", localDir,
+		&SyncOptions{Delete: true, Exclude: []string{"*.log"}})
+	if err == nil {
+		t.Errorf("the escaping key should fail")
+	}
+	if !reflect.DeepEqual(summary.Transferred, []string{"stale.txt", "sub/new.txt"}) ||
+		!reflect.DeepEqual(summary.Deleted, []string{"removed.txt"}) || summary.Skipped != 1 ||
+		!reflect.DeepEqual(failedPaths(summary), []string{"../escape.txt"}) {
+		t.Errorf("summary: got %+v", summary)
+	}
+	if got, _ := ioutil.ReadFile(filepath.Join(localDir, "stale.txt")); string(got) != "fresh" {
+		t.Errorf("stale.txt: got %q", got)
+	}
+	info, err := os.Stat(filepath.Join(localDir, "sub", "new.txt"))
+	if err != nil || !info.ModTime().Equal(old) {
+		t.Errorf("the modification time of sub/new.txt is not set: %v", err)
+	}
+	for _, name := range []string{"keep.log", "same.txt"} {
+		if _, err := os.Stat(filepath.Join(localDir, name)); err != nil {
+			t.Errorf("%s should be kept: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape.txt")); !os.IsNotExist(err) {
+		t.Errorf("the escaping key is downloaded")
+	}
+
+	// dry run of the directory not created yet
+	summary, err = m.SyncDownload(context.Background(), testBucket, "prefix",
+		filepath.Join(dir, "not-exist"), &SyncOptions{DryRun: true})
+	if len(summary.Transferred) != 3 {
+		t.Errorf("dry run: got %+v and %v", summary, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "not-exist")); !os.IsNotExist(err) {
+		t.Errorf("dry run creates the directory")
+	}
+}
+
+func TestDeleteObjectsInBatches(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+
+	keys := make([]string, 2500)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%04d", i)
+		fake.put(keys[i], nil, time.Now())
+	}
+	fake.failDelete["key-0001"] = true
+	fake.failDeleteBatch = "key-1500" // the second batch fails
+	client.MaxParallel = 2
+
+	report, err := client.DeleteObjectsInBatches(testBucket, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Deleted) != 1499 || len(report.Failed) != 1001 {
+		t.Fatalf("report: got %d deleted and %d failed", len(report.Deleted), len(report.Failed))
+	}
+	codes := make(map[string]int)
+	for _, f := range report.Failed {
+		codes[f.Code]++
+	}
+	if codes["AccessDenied"] != 1 || codes["ServiceUnavailable"] != 1000 {
+		t.Errorf("codes: got %v", codes)
+	}
+	if report.Deleted[0] != "key-0000" || report.Deleted[1] != "key-0002" {
+		t.Errorf("deleted: got %v", report.Deleted[:2])
+	}
+	if remaining := fake.keys(); len(remaining) != 1001 {
+		t.Errorf("remaining: got %d", len(remaining))
+	}
+
+	if _, err := client.DeleteObjectsInBatches(testBucket, nil); err == nil {
+		t.Errorf("empty key list: expected error")
+	}
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// task.go - define the transfer task with its progress, pause and resume
+
+package transfer
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Task defines one upload or download task of the manager.
+type Task struct {
+	mu         sync.Mutex
+	progress   Progress
+	uploadId   string
+	paused     bool
+	resumed    chan struct{}
+	onProgress ProgressFunc
+	callbackMu sync.Mutex
+
+	transferred int64
+	cancel      context.CancelFunc
+	done        chan struct{}
+	err         error
+}
+
+func newTask(ctx context.Context, bucket, object, fileName string,
+	onProgress ProgressFunc) (*Task, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	t := &Task{
+		progress: Progress{
+			Bucket:   bucket,
+			Object:   object,
+			FileName: fileName,
+			Status:   TASK_STATUS_WAITING,
+		},
+		onProgress: onProgress,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	return t, ctx
+}
+
+// Progress - get the current progress of the task
+//
+// RETURNS:
+//     - Progress: the snapshot of the progress
+func (t *Task) Progress() Progress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p := t.progress
+	p.TransferredBytes = atomic.LoadInt64(&t.transferred)
+	return p
+}
+
+// UploadId - get the multipart upload id of the upload task, which can be set to the UploadInput
+// to resume the upload later. It is empty before the multipart upload is initiated or if the
+// file is uploaded by a single put.
+//
+// RETURNS:
+//     - string: the upload id
+func (t *Task) UploadId() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.uploadId
+}
+
+// Pause - pause the task, the transferring parts are finished but no more part is started
+// until the task is resumed.
+func (t *Task) Pause() {
+	t.mu.Lock()
+	if t.paused || t.isFinished() {
+		t.mu.Unlock()
+		return
+	}
+	t.paused = true
+	t.resumed = make(chan struct{})
+	t.progress.Status = TASK_STATUS_PAUSED
+	t.mu.Unlock()
+	t.notify()
+}
+
+// Resume - resume the paused task
+func (t *Task) Resume() {
+	t.mu.Lock()
+	if !t.paused {
+		t.mu.Unlock()
+		return
+	}
+	t.paused = false
+	close(t.resumed)
+	if !t.isFinished() {
+		t.progress.Status = TASK_STATUS_RUNNING
+	}
+	t.mu.Unlock()
+	t.notify()
+}
+
+// Cancel - cancel the task, the transferring parts are interrupted
+func (t *Task) Cancel() {
+	t.cancel()
+}
+
+// Done - get the channel which is closed when the task finishes
+//
+// RETURNS:
+//     - <-chan struct{}: the done channel
+func (t *Task) Done() <-chan struct{} {
+	return t.done
+}
+
+// Wait - wait for the task to finish
+//
+// RETURNS:
+//     - error: nil if the task is completed otherwise the specific error
+func (t *Task) Wait() error {
+	<-t.done
+	return t.err
+}
+
+func (t *Task) isFinished() bool {
+	switch t.progress.Status {
+	case TASK_STATUS_COMPLETED, TASK_STATUS_FAILED, TASK_STATUS_CANCELED:
+		return true
+	}
+	return false
+}
+
+// waitResumed - block while the task is paused, returns the error if the context is done first
+func (t *Task) waitResumed(ctx context.Context) error {
+	t.mu.Lock()
+	if !t.paused {
+		t.mu.Unlock()
+		return ctx.Err()
+	}
+	resumed := t.resumed
+	t.mu.Unlock()
+	select {
+	case <-resumed:
+		return ctx.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *Task) setUploadId(uploadId string) {
+	t.mu.Lock()
+	t.uploadId = uploadId
+	t.mu.Unlock()
+}
+
+func (t *Task) start(total int64) {
+	t.mu.Lock()
+	t.progress.TotalBytes = total
+	if !t.paused {
+		t.progress.Status = TASK_STATUS_RUNNING
+	}
+	t.mu.Unlock()
+	t.notify()
+}
+
+// add - add the transferred bytes, the negative value rolls back the bytes of the failed part
+func (t *Task) add(n int64) {
+	atomic.AddInt64(&t.transferred, n)
+	t.notify()
+}
+
+func (t *Task) finish(ctx context.Context, err error) {
+	t.mu.Lock()
+	switch {
+	case err == nil:
+		t.progress.Status = TASK_STATUS_COMPLETED
+	case ctx.Err() == context.Canceled:
+		t.progress.Status = TASK_STATUS_CANCELED
+	default:
+		t.progress.Status = TASK_STATUS_FAILED
+	}
+	t.err = err
+	t.mu.Unlock()
+	t.notify()
+	t.cancel()
+	close(t.done)
+}
+
+func (t *Task) notify() {
+	if t.onProgress == nil {
+		return
+	}
+	p := t.Progress()
+	t.callbackMu.Lock()
+	defer t.callbackMu.Unlock()
+	t.onProgress(p)
+}
+
+// partReader defines the reader of one part which counts the transferred bytes of the task and
+// limits the bandwidth.
+type partReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	task    *Task
+	limiter *rateLimiter
+	read    int64
+}
+
+func (r *partReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if len(p) > MAX_READ_CHUNK {
+		p = p[:MAX_READ_CHUNK]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.wait(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+		r.read += int64(n)
+		r.task.add(int64(n))
+	}
+	return n, err
+}
+
+func (r *partReader) Close() error {
+	if c, ok := r.reader.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// rollback - remove the counted bytes of the failed part from the task
+func (r *partReader) rollback() {
+	if r.read != 0 {
+		r.task.add(-r.read)
+		r.read = 0
+	}
+}
@@ -0,0 +1,245 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// transfer.go - define the transfer manager to upload and download many files concurrently
+
+// Package transfer implements the high-level manager of BOS uploads and downloads. The files are
+// split into parts which are transferred concurrently, all tasks of the manager share the limits
+// of the concurrent files, the concurrent parts and the bandwidth. Each task reports its
+// progress by the callback, can be paused and resumed, and the failed parts are retried.
+package transfer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+const (
+	DEFAULT_PARALLEL      = 10
+	DEFAULT_FILE_PARALLEL = 3
+	DEFAULT_PART_RETRY    = 3
+
+	TASK_STATUS_WAITING   = "waiting"
+	TASK_STATUS_RUNNING   = "running"
+	TASK_STATUS_PAUSED    = "paused"
+	TASK_STATUS_COMPLETED = "completed"
+	TASK_STATUS_FAILED    = "failed"
+	TASK_STATUS_CANCELED  = "canceled"
+)
+
+// Progress defines the progress of one transfer task.
+type Progress struct {
+	Bucket           string
+	Object           string
+	FileName         string
+	Status           string
+	TotalBytes       int64
+	TransferredBytes int64
+}
+
+// ProgressFunc defines the callback of the progress, it is called by the transferring goroutine
+// every time some bytes are transferred or the status changes, so it should return quickly. The
+// calls of the same task are serialized.
+type ProgressFunc func(p Progress)
+
+// Options defines the options of the transfer manager, the zero values are set to defaults.
+type Options struct {
+	// PartSize is the size of each part, default is the MultipartSize of the client. It should
+	// not be changed between the runs of the resumed upload.
+	PartSize int64
+
+	// Parallel is the max number of the parts transferred concurrently by all tasks
+	Parallel int
+
+	// FileParallel is the max number of the files transferred concurrently
+	FileParallel int
+
+	// PartRetry is the times to retry the failed part, negative value means no retry
+	PartRetry int
+
+	// BandwidthLimit is the max bytes per second of all tasks, zero means no limit
+	BandwidthLimit int64
+
+	// LeavePartsOnError keeps the uploaded parts of the failed or canceled multipart upload
+	// instead of aborting it, so that it can be resumed later by the UploadId of the task.
+	LeavePartsOnError bool
+
+	// OnProgress is the progress callback of the tasks which do not set their own one
+	OnProgress ProgressFunc
+}
+
+// Manager defines the transfer manager which schedules the upload and download tasks.
+type Manager struct {
+	client  *bos.Client
+	options Options
+	limiter *rateLimiter
+	parts   chan struct{}
+	files   chan struct{}
+}
+
+// NewManager - create the transfer manager
+//
+// PARAMS:
+//     - client: the BOS client to send the requests
+//     - options: the options of the manager, nil to use the defaults
+// RETURNS:
+//     - *Manager: the transfer manager
+func NewManager(client *bos.Client, options *Options) *Manager {
+	opts := Options{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.PartSize <= 0 {
+		opts.PartSize = client.MultipartSize
+	}
+	if opts.PartSize < bos.MIN_MULTIPART_SIZE {
+		opts.PartSize = bos.MIN_MULTIPART_SIZE
+	}
+	if opts.Parallel <= 0 {
+		opts.Parallel = DEFAULT_PARALLEL
+	}
+	if opts.FileParallel <= 0 {
+		opts.FileParallel = DEFAULT_FILE_PARALLEL
+	}
+	if opts.PartRetry == 0 {
+		opts.PartRetry = DEFAULT_PART_RETRY
+	} else if opts.PartRetry < 0 {
+		opts.PartRetry = 0
+	}
+	return &Manager{
+		client:  client,
+		options: opts,
+		limiter: newRateLimiter(opts.BandwidthLimit),
+		parts:   make(chan struct{}, opts.Parallel),
+		files:   make(chan struct{}, opts.FileParallel),
+	}
+}
+
+// partSize - get the part size of the file which is aligned to 1MB and keeps the number of the
+// parts no more than MAX_PART_NUMBER
+func (m *Manager) partSize(size int64) int64 {
+	partSize := m.options.PartSize
+	if partSize >= bos.MULTIPART_ALIGN {
+		partSize = (partSize + bos.MULTIPART_ALIGN - 1) / bos.MULTIPART_ALIGN * bos.MULTIPART_ALIGN
+	}
+	if (size+partSize-1)/partSize > bos.MAX_PART_NUMBER {
+		partSize = (size + bos.MAX_PART_NUMBER - 1) / bos.MAX_PART_NUMBER
+		partSize = (partSize + bos.MULTIPART_ALIGN - 1) / bos.MULTIPART_ALIGN * bos.MULTIPART_ALIGN
+	}
+	return partSize
+}
+
+// acquire - take a slot of the given pool, returns the error if the context is done first
+func acquire(ctx context.Context, pool chan struct{}) error {
+	select {
+	case pool <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// runParts - transfer the parts concurrently by the shared part pool, the remaining parts are
+// canceled once any part fails after retries
+//
+// PARAMS:
+//     - ctx: the context of the task
+//     - task: the task to wait for being resumed before each part
+//     - parts: the part indexes to transfer
+//     - fn: the function to transfer one part by its index
+// RETURNS:
+//     - error: the first error of the parts
+func (m *Manager) runParts(ctx context.Context, task *Task, parts []int,
+	fn func(ctx context.Context, index int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	for _, index := range parts {
+		if err := task.waitResumed(ctx); err != nil {
+			fail(err)
+			break
+		}
+		if err := acquire(ctx, m.parts); err != nil {
+			fail(err)
+			break
+		}
+		// The slot may be acquired together with the cancellation by the failed part
+		if err := ctx.Err(); err != nil {
+			<-m.parts
+			fail(err)
+			break
+		}
+		wg.Add(1)
+		go func(index int) {
+			defer func() {
+				<-m.parts
+				wg.Done()
+			}()
+			var err error
+			for attempt := 0; attempt <= m.options.PartRetry; attempt++ {
+				err = fn(ctx, index)
+				if err == nil || ctx.Err() != nil || !isRetryable(err) || attempt == m.options.PartRetry {
+					break
+				}
+				log.Warnf("transfer part %d failed, retry for %d time(s): %v", index, attempt+1, err)
+			}
+			if err != nil {
+				fail(err)
+			}
+		}(index)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// isRetryable - check whether the failed part should be retried, the client errors of sending
+// the request and the server side errors are retryable while the local file errors are not
+func isRetryable(err error) bool {
+	switch e := err.(type) {
+	case *bce.BceClientError:
+		return true
+	case *bce.BceServiceError:
+		return e.StatusCode >= 500 || e.StatusCode == 408 || e.StatusCode == 429
+	}
+	return false
+}
+
+// WaitAll - wait for all the tasks to finish
+//
+// PARAMS:
+//     - tasks: the tasks to wait
+// RETURNS:
+//     - error: the first error of the tasks in order
+func WaitAll(tasks ...*Task) error {
+	var firstErr error
+	for _, t := range tasks {
+		if err := t.Wait(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,439 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos"
+)
+
+const testPartSize = bos.MIN_MULTIPART_SIZE
+
+func newTestManager(client *bos.Client, options *Options) *Manager {
+	if options == nil {
+		options = &Options{}
+	}
+	options.PartSize = testPartSize
+	return NewManager(client, options)
+}
+
+func writeTestFile(t *testing.T, dir, name string, size int) ([]byte, string) {
+	content := make([]byte, size)
+	rand.New(rand.NewSource(int64(size))).Read(content)
+	fileName := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(fileName, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return content, fileName
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "bos-transfer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestPartSize(t *testing.T) {
+	client, _ := bos.NewClient("ak", "sk", "bj.bcebos.com")
+	cases := []struct {
+		partSize, size, expected int64
+	}{
+		{testPartSize, 10 * testPartSize, testPartSize},
+		{5*bos.MULTIPART_ALIGN + 1, 100 * bos.MULTIPART_ALIGN, 6 * bos.MULTIPART_ALIGN},
+		{bos.MULTIPART_ALIGN, 20000 * bos.MULTIPART_ALIGN, 2 * bos.MULTIPART_ALIGN},
+		{1, 10 * testPartSize, testPartSize},
+	}
+	for _, c := range cases {
+		m := NewManager(client, &Options{PartSize: c.partSize})
+		got := m.partSize(c.size)
+		if got != c.expected {
+			t.Errorf("part size of %d/%d: got %d, expected %d", c.partSize, c.size, got, c.expected)
+		}
+		if (c.size+got-1)/got > bos.MAX_PART_NUMBER {
+			t.Errorf("part size of %d/%d: too many parts", c.partSize, c.size)
+		}
+	}
+}
+
+func TestPartLength(t *testing.T) {
+	expected := []int64{4, 4, 2}
+	for i, e := range expected {
+		if got := partLength(i, 10, 4); got != e {
+			t.Errorf("part %d: got %d, expected %d", i, got, e)
+		}
+	}
+	if got := partLength(0, 4, 4); got != 4 {
+		t.Errorf("single part: got %d", got)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{bce.NewBceClientError("connection reset"), true},
+		{&bce.BceServiceError{StatusCode: 500}, true},
+		{&bce.BceServiceError{StatusCode: 429}, true},
+		{&bce.BceServiceError{StatusCode: 408}, true},
+		{&bce.BceServiceError{StatusCode: 403}, false},
+		{errors.New("local file error"), false},
+	}
+	for _, c := range cases {
+		if got := isRetryable(c.err); got != c.expected {
+			t.Errorf("%v: got %v, expected %v", c.err, got, c.expected)
+		}
+	}
+}
+
+func TestRunPartsRetry(t *testing.T) {
+	client, _ := bos.NewClient("ak", "sk", "bj.bcebos.com")
+	m := NewManager(client, &Options{PartRetry: 2})
+	task, ctx := newTask(context.Background(), testBucket, "object", "", nil)
+
+	var mu sync.Mutex
+	attempts := make(map[int]int)
+	err := m.runParts(ctx, task, []int{0, 1, 2}, func(ctx context.Context, index int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[index]++
+		if index == 1 && attempts[index] <= 2 {
+			return &bce.BceServiceError{StatusCode: 500}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts[0] != 1 || attempts[1] != 3 || attempts[2] != 1 {
+		t.Errorf("attempts: got %v", attempts)
+	}
+
+	// the retries are exhausted
+	attempts = make(map[int]int)
+	err = m.runParts(ctx, task, []int{0}, func(ctx context.Context, index int) error {
+		attempts[index]++
+		return &bce.BceServiceError{StatusCode: 503}
+	})
+	if e, ok := err.(*bce.BceServiceError); !ok || e.StatusCode != 503 {
+		t.Errorf("exhausted retries: got %v", err)
+	}
+	if attempts[0] != 3 {
+		t.Errorf("exhausted retries: got %d attempts", attempts[0])
+	}
+}
+
+func TestRunPartsError(t *testing.T) {
+	client, _ := bos.NewClient("ak", "sk", "bj.bcebos.com")
+	m := NewManager(client, &Options{Parallel: 2})
+	task, ctx := newTask(context.Background(), testBucket, "object", "", nil)
+
+	localErr := errors.New("local file error")
+	var calls, canceled int32
+	err := m.runParts(ctx, task, []int{0, 1, 2, 3, 4}, func(ctx context.Context, index int) error {
+		atomic.AddInt32(&calls, 1)
+		if index == 1 {
+			return localErr
+		}
+		// the other parts are interrupted by the failed one
+		<-ctx.Done()
+		atomic.AddInt32(&canceled, 1)
+		return ctx.Err()
+	})
+	if err != localErr {
+		t.Errorf("error: got %v, expected the error of the failed part", err)
+	}
+	if calls != 2 || canceled != 1 {
+		t.Errorf("calls: got %d, canceled: got %d, expected 2 and 1", calls, canceled)
+	}
+	if ctx.Err() != nil {
+		t.Errorf("the context of the task should not be canceled by the failed part")
+	}
+	if len(m.parts) != 0 {
+		t.Errorf("the part slots are not released: %d", len(m.parts))
+	}
+}
+
+func TestRunPartsPause(t *testing.T) {
+	client, _ := bos.NewClient("ak", "sk", "bj.bcebos.com")
+	m := NewManager(client, nil)
+	task, ctx := newTask(context.Background(), testBucket, "object", "", nil)
+	task.start(0)
+	task.Pause()
+	if status := task.Progress().Status; status != TASK_STATUS_PAUSED {
+		t.Fatalf("status: got %s", status)
+	}
+
+	var calls int32
+	fn := func(ctx context.Context, index int) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	done := make(chan error, 1)
+	go func() { done <- m.runParts(ctx, task, []int{0, 1}, fn) }()
+	select {
+	case err := <-done:
+		t.Fatalf("the paused task finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	task.Resume()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("calls after resume: got %d", calls)
+	}
+
+	// cancel the paused task
+	task.Pause()
+	go func() { done <- m.runParts(ctx, task, []int{0, 1}, fn) }()
+	time.Sleep(20 * time.Millisecond)
+	task.Cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("cancel the paused task: got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls after cancel: got %d", calls)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	if err := newRateLimiter(0).wait(context.Background(), 1<<20); err != nil {
+		t.Errorf("nil limiter: got %v", err)
+	}
+
+	limiter := newRateLimiter(64 * 1024)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.wait(context.Background(), MAX_READ_CHUNK); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the first chunk is sent at once and the next two are delayed by 0.5s each
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("elapsed: got %v, expected about 1s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if err := limiter.wait(ctx, MAX_READ_CHUNK); err != context.DeadlineExceeded {
+		t.Errorf("wait with the context done: got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("wait with the context done: elapsed %v", elapsed)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	var mu sync.Mutex
+	var statuses []string
+	m := newTestManager(client, &Options{OnProgress: func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(statuses) == 0 || statuses[len(statuses)-1] != p.Status {
+			statuses = append(statuses, p.Status)
+		}
+	}})
+	small, smallName := writeTestFile(t, dir, "small", testPartSize/2)
+	large, largeName := writeTestFile(t, dir, "large", testPartSize*5/2)
+	fake.failPart[2] = 1 // retried
+
+	tasks := m.UploadAll(context.Background(), []*UploadInput{
+		{Bucket: testBucket, Object: "dir/small", FileName: smallName},
+		{Bucket: testBucket, Object: "dir/large", FileName: largeName},
+	})
+	if err := WaitAll(tasks...); err != nil {
+		t.Fatal(err)
+	}
+	for key, content := range map[string][]byte{"dir/small": small, "dir/large": large} {
+		if got, ok := fake.get(key); !ok || !bytes.Equal(got, content) {
+			t.Errorf("content of %s: got %d bytes, expected %d", key, len(got), len(content))
+		}
+	}
+	if len(tasks[0].UploadId()) != 0 || len(tasks[1].UploadId()) == 0 {
+		t.Errorf("upload id: got %q and %q", tasks[0].UploadId(), tasks[1].UploadId())
+	}
+	p := tasks[1].Progress()
+	if p.Status != TASK_STATUS_COMPLETED || p.TotalBytes != int64(len(large)) ||
+		p.TransferredBytes != int64(len(large)) {
+		t.Errorf("progress: got %+v", p)
+	}
+	if len(fake.uploaded) != 3 {
+		t.Errorf("uploaded parts: got %v", fake.uploaded)
+	}
+	if statuses[len(statuses)-1] != TASK_STATUS_COMPLETED {
+		t.Errorf("statuses: got %v", statuses)
+	}
+}
+
+func TestUploadResume(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+	fake.listPageSize = 1
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	content, fileName := writeTestFile(t, dir, "file", testPartSize*7/2)
+
+	res, err := client.InitiateMultipartUpload(testBucket, "file", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// part 1 and 3 are uploaded while part 2 is broken with the wrong size
+	for _, p := range []struct {
+		number     int
+		start, end int
+	}{{1, 0, testPartSize}, {2, testPartSize, testPartSize + 1}, {3, 2 * testPartSize, 3 * testPartSize}} {
+		body, _ := bce.NewBodyFromBytes(content[p.start:p.end])
+		if _, err := client.BasicUploadPart(testBucket, "file", res.UploadId, p.number, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fake.uploaded = nil
+
+	m := newTestManager(client, nil)
+	task := m.Upload(context.Background(), &UploadInput{Bucket: testBucket, Object: "file",
+		FileName: fileName, UploadId: res.UploadId})
+	if err := task.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.uploaded) != 2 || fake.uploaded[0]+fake.uploaded[1] != 6 {
+		t.Errorf("uploaded parts: got %v, expected 2 and 4", fake.uploaded)
+	}
+	if got, _ := fake.get("file"); !bytes.Equal(got, content) {
+		t.Errorf("content: got %d bytes, expected %d", len(got), len(content))
+	}
+	if p := task.Progress(); p.TransferredBytes != int64(len(content)) {
+		t.Errorf("transferred bytes: got %d, expected %d", p.TransferredBytes, len(content))
+	}
+}
+
+func TestUploadError(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	_, fileName := writeTestFile(t, dir, "file", testPartSize*3)
+
+	fake.failStatus = 403
+	fake.failPart[2] = 10
+	m := newTestManager(client, nil)
+	task := m.Upload(context.Background(), &UploadInput{Bucket: testBucket, Object: "file",
+		FileName: fileName})
+	err := task.Wait()
+	if e, ok := err.(*bce.BceServiceError); !ok || e.StatusCode != 403 {
+		t.Fatalf("error: got %v", err)
+	}
+	if fake.failPart[2] != 9 {
+		t.Errorf("the non-retryable part is retried %d times", 9-fake.failPart[2])
+	}
+	if p := task.Progress(); p.Status != TASK_STATUS_FAILED || p.TransferredBytes > 2*testPartSize {
+		t.Errorf("progress: got %+v", p)
+	}
+	if len(fake.aborted) != 1 || fake.aborted[0] != task.UploadId() {
+		t.Errorf("aborted: got %v, expected %s", fake.aborted, task.UploadId())
+	}
+	if _, ok := fake.get("file"); ok {
+		t.Errorf("the failed upload is completed")
+	}
+
+	// keep the parts to resume
+	fake.aborted = nil
+	m = newTestManager(client, &Options{LeavePartsOnError: true})
+	task = m.Upload(context.Background(), &UploadInput{Bucket: testBucket, Object: "file",
+		FileName: fileName})
+	if err := task.Wait(); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(fake.aborted) != 0 || len(task.UploadId()) == 0 {
+		t.Errorf("aborted: got %v, upload id: %q", fake.aborted, task.UploadId())
+	}
+
+	// the missing file is not retried
+	task = m.Upload(context.Background(), &UploadInput{Bucket: testBucket, Object: "missing",
+		FileName: filepath.Join(dir, "missing")})
+	if err := task.Wait(); !os.IsNotExist(err) {
+		t.Errorf("missing file: got %v", err)
+	}
+}
+
+func TestUploadCancel(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	_, fileName := writeTestFile(t, dir, "file", testPartSize*3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m := newTestManager(client, nil)
+	task := m.Upload(ctx, &UploadInput{Bucket: testBucket, Object: "file", FileName: fileName})
+	if err := task.Wait(); err != context.Canceled {
+		t.Errorf("error: got %v", err)
+	}
+	if status := task.Progress().Status; status != TASK_STATUS_CANCELED {
+		t.Errorf("status: got %s", status)
+	}
+	if len(fake.keys()) != 0 {
+		t.Errorf("objects: got %v", fake.keys())
+	}
+}
+
+func TestDownload(t *testing.T) {
+	fake, client := newFakeBOS(t)
+	defer fake.server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	content := make([]byte, testPartSize*5/2)
+	rand.Read(content)
+	fake.put("object", content, time.Now())
+	fake.put("empty", nil, time.Now())
+
+	m := newTestManager(client, nil)
+	tasks := m.DownloadAll(context.Background(), []*DownloadInput{
+		{Bucket: testBucket, Object: "object", FileName: filepath.Join(dir, "object")},
+		{Bucket: testBucket, Object: "empty", FileName: filepath.Join(dir, "empty")},
+	})
+	if err := WaitAll(tasks...); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ioutil.ReadFile(filepath.Join(dir, "object")); !bytes.Equal(got, content) {
+		t.Errorf("content: got %d bytes, expected %d", len(got), len(content))
+	}
+	if info, err := os.Stat(filepath.Join(dir, "empty")); err != nil || info.Size() != 0 {
+		t.Errorf("empty object: got %v", err)
+	}
+	if p := tasks[0].Progress(); p.TransferredBytes != int64(len(content)) ||
+		p.Status != TASK_STATUS_COMPLETED {
+		t.Errorf("progress: got %+v", p)
+	}
+
+	// the local file of the failed download is removed
+	fileName := filepath.Join(dir, "missing")
+	task := m.Download(context.Background(), &DownloadInput{Bucket: testBucket,
+		Object: "missing", FileName: fileName})
+	if e, ok := task.Wait().(*bce.BceServiceError); !ok || e.StatusCode != 404 {
+		t.Errorf("missing object: got %v", task.Wait())
+	}
+	if _, err := os.Stat(fileName); !os.IsNotExist(err) {
+		t.Errorf("the file of the failed download exists: %v", err)
+	}
+}
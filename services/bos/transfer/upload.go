@@ -0,0 +1,240 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// upload.go - define the upload task of the transfer manager
+
+package transfer
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+// UploadInput defines the file to upload and the optional arguments.
+type UploadInput struct {
+	Bucket      string
+	Object      string
+	FileName    string
+	ContentType string
+
+	// Args is the optional arguments of the object
+	Args *api.InitiateMultipartUploadArgs
+
+	// UploadId resumes the unfinished multipart upload, the uploaded parts are skipped
+	UploadId string
+
+	// OnProgress overrides the progress callback of the manager for this file
+	OnProgress ProgressFunc
+}
+
+// Upload - start the task to upload the local file, the file smaller than the part size is
+// uploaded by a single put and others by the multipart upload
+//
+// PARAMS:
+//     - ctx: the context to cancel the task
+//     - input: the file to upload
+// RETURNS:
+//     - *Task: the upload task
+func (m *Manager) Upload(ctx context.Context, input *UploadInput) *Task {
+	onProgress := input.OnProgress
+	if onProgress == nil {
+		onProgress = m.options.OnProgress
+	}
+	task, ctx := newTask(ctx, input.Bucket, input.Object, input.FileName, onProgress)
+	go func() {
+		if err := acquire(ctx, m.files); err != nil {
+			task.finish(ctx, err)
+			return
+		}
+		err := m.upload(ctx, task, input)
+		<-m.files
+		task.finish(ctx, err)
+	}()
+	return task
+}
+
+// UploadAll - start the tasks to upload the local files
+//
+// PARAMS:
+//     - ctx: the context to cancel the tasks
+//     - inputs: the files to upload
+// RETURNS:
+//     - []*Task: the upload tasks in the order of the inputs
+func (m *Manager) UploadAll(ctx context.Context, inputs []*UploadInput) []*Task {
+	tasks := make([]*Task, 0, len(inputs))
+	for _, input := range inputs {
+		tasks = append(tasks, m.Upload(ctx, input))
+	}
+	return tasks
+}
+
+func (m *Manager) upload(ctx context.Context, task *Task, input *UploadInput) error {
+	file, err := os.Open(input.FileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	task.start(size)
+	if err := task.waitResumed(ctx); err != nil {
+		return err
+	}
+	partSize := m.partSize(size)
+	if size <= partSize && len(input.UploadId) == 0 {
+		return m.putObject(ctx, task, input, file, size)
+	}
+	return m.multipartUpload(ctx, task, input, file, size, partSize)
+}
+
+func (m *Manager) putObject(ctx context.Context, task *Task, input *UploadInput,
+	file *os.File, size int64) error {
+	args := &api.PutObjectArgs{ContentType: input.ContentType}
+	if input.Args != nil {
+		args.CacheControl = input.Args.CacheControl
+		args.ContentDisposition = input.Args.ContentDisposition
+		args.Expires = input.Args.Expires
+		args.StorageClass = input.Args.StorageClass
+	}
+	var err error
+	for attempt := 0; attempt <= m.options.PartRetry; attempt++ {
+		var body *bce.Body
+		if body, err = bce.NewBodyFromSectionFile(file, 0, size); err != nil {
+			return err
+		}
+		reader := &partReader{ctx: ctx, reader: body.Stream(), task: task, limiter: m.limiter}
+		body.SetStream(reader)
+		if _, err = m.client.PutObject(input.Bucket, input.Object, body, args); err == nil {
+			return nil
+		}
+		reader.rollback()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !isRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (m *Manager) multipartUpload(ctx context.Context, task *Task, input *UploadInput,
+	file *os.File, size, partSize int64) error {
+	partNum := int((size + partSize - 1) / partSize)
+	if partNum == 0 {
+		partNum = 1
+	}
+	etags := make([]string, partNum)
+
+	// Initiate the multipart upload or skip the uploaded parts of the resumed one
+	uploadId := input.UploadId
+	if len(uploadId) == 0 {
+		res, err := m.client.InitiateMultipartUpload(input.Bucket, input.Object,
+			input.ContentType, input.Args)
+		if err != nil {
+			return err
+		}
+		uploadId = res.UploadId
+	} else if err := m.listUploadedParts(input, uploadId, size, partSize, etags); err != nil {
+		return err
+	}
+	task.setUploadId(uploadId)
+
+	parts := make([]int, 0, partNum)
+	for i := range etags {
+		if len(etags[i]) == 0 {
+			parts = append(parts, i)
+		} else {
+			task.add(partLength(i, size, partSize))
+		}
+	}
+	// The body of each part is built under the lock since it seeks the shared file
+	var mu sync.Mutex
+	err := m.runParts(ctx, task, parts, func(ctx context.Context, index int) error {
+		mu.Lock()
+		body, err := bce.NewBodyFromSectionFile(file, int64(index)*partSize,
+			partLength(index, size, partSize))
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+		reader := &partReader{ctx: ctx, reader: body.Stream(), task: task, limiter: m.limiter}
+		body.SetStream(reader)
+		etag, err := m.client.BasicUploadPart(input.Bucket, input.Object, uploadId, index+1, body)
+		if err != nil {
+			reader.rollback()
+			return err
+		}
+		mu.Lock()
+		etags[index] = etag
+		mu.Unlock()
+		log.Debugf("upload part %d of %s/%s success, etag: %s", index+1,
+			input.Bucket, input.Object, etag)
+		return nil
+	})
+	if err == nil {
+		completeArgs := &api.CompleteMultipartUploadArgs{Parts: make([]api.UploadInfoType, partNum)}
+		for i, etag := range etags {
+			completeArgs.Parts[i] = api.UploadInfoType{PartNumber: i + 1, ETag: etag}
+		}
+		_, err = m.client.CompleteMultipartUploadFromStruct(input.Bucket, input.Object,
+			uploadId, completeArgs)
+	}
+	if err != nil && !m.options.LeavePartsOnError {
+		m.client.AbortMultipartUpload(input.Bucket, input.Object, uploadId)
+	}
+	return err
+}
+
+// listUploadedParts - fill the etags of the uploaded parts whose size is expected
+func (m *Manager) listUploadedParts(input *UploadInput, uploadId string,
+	size, partSize int64, etags []string) error {
+	args := &api.ListPartsArgs{}
+	for {
+		res, err := m.client.ListParts(input.Bucket, input.Object, uploadId, args)
+		if err != nil {
+			return err
+		}
+		for _, p := range res.Parts {
+			index := p.PartNumber - 1
+			if index < 0 || index >= len(etags) {
+				continue
+			}
+			if int64(p.Size) == partLength(index, size, partSize) {
+				etags[index] = p.ETag
+			}
+		}
+		if !res.IsTruncated {
+			return nil
+		}
+		args.PartNumberMarker = strconv.Itoa(res.NextPartNumberMarker)
+	}
+}
+
+// partLength - get the length of the part by its index
+func partLength(index int, size, partSize int64) int64 {
+	if left := size - int64(index)*partSize; left < partSize {
+		return left
+	}
+	return partSize
+}
@@ -558,7 +558,7 @@ func (c *Client) GetTask(args *GetTaskArgs) (*GetTaskResp, error) {
 	}
 
 	switch args.TaskType {
-	case types.TaskTypeInstanceGroupReplicas:
+	case types.TaskTypeInstanceGroupReplicas, types.TaskTypeClusterUpgrade:
 	default:
 		return nil, fmt.Errorf("unsupported taskType")
 	}
@@ -583,7 +583,7 @@ func (c *Client) ListTasks(args *ListTasksArgs) (*ListTaskResp, error) {
 	}
 
 	switch args.TaskType {
-	case types.TaskTypeInstanceGroupReplicas:
+	case types.TaskTypeInstanceGroupReplicas, types.TaskTypeClusterUpgrade:
 		if args.TargetID == "" {
 			return nil, fmt.Errorf("targetID is empty")
 		}
@@ -603,6 +603,40 @@ func (c *Client) ListTasks(args *ListTasksArgs) (*ListTaskResp, error) {
 	return result, err
 }
 
+// 升级集群 K8S 版本，返回的任务类型为 TaskTypeClusterUpgrade
+func (c *Client) UpgradeCluster(args *UpgradeClusterArgs) (*CreateTaskResp, error) {
+	if args == nil || args.Request == nil {
+		return nil, fmt.Errorf("args is nil")
+	}
+	if args.ClusterID == "" {
+		return nil, fmt.Errorf("clusterID is empty")
+	}
+	if args.Request.K8SVersion == "" {
+		return nil, fmt.Errorf("k8sVersion is empty")
+	}
+	switch args.Request.Target {
+	case UpgradeTargetMaster:
+		if len(args.Request.InstanceGroupIDs) != 0 {
+			return nil, fmt.Errorf("instanceGroupIDs is only supported by the node upgrade")
+		}
+	case UpgradeTargetNode:
+	default:
+		return nil, fmt.Errorf("unsupported upgrade target: %s", args.Request.Target)
+	}
+	if args.Request.MaxUnavailable < 0 {
+		return nil, fmt.Errorf("maxUnavailable should not be negative")
+	}
+
+	result := &CreateTaskResp{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getClusterUpgradeURI(args.ClusterID)).
+		WithBody(args.Request).
+		WithResult(result).
+		Do()
+	return result, err
+}
+
 func (c *Client) GetInstanceCRD(args *GetInstanceCRDArgs) (*GetInstanceCRDResponse, error) {
 	if args == nil {
 		return nil, fmt.Errorf("args is nil")
@@ -63,6 +63,8 @@ const (
 	REQUEST_TASK_URL = "/task"
 
 	REQUEST_TASK_LIST_URL = "/tasks"
+
+	REQUEST_UPGRADE_URL = "/upgrade"
 )
 
 var _ Interface = &Client{}
@@ -167,6 +169,10 @@ func getKubeconfigURI(clusterID string, kubeConfigType KubeConfigType) string {
 	return URI_PREFIX + fmt.Sprintf(REQUEST_KUBECONFIG, clusterID, kubeConfigType)
 }
 
+func getClusterUpgradeURI(clusterID string) string {
+	return URI_PREFIX + REQUEST_CLUSTER_URL + "/" + clusterID + REQUEST_UPGRADE_URL
+}
+
 func getTaskWithIDURI(taskType types.TaskType, taskID string) string {
 	return URI_PREFIX + REQUEST_TASK_URL + "/" + string(taskType) + "/" + taskID
 }
@@ -0,0 +1,228 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// lifecycle.go - 等待集群、节点组和任务的 waiter，及创建集群后创建节点组的编排
+
+package v2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/cce/v2/types"
+)
+
+// ClusterTask - 获取等待集群运行的异步任务，结果为最后一次查询的 *Cluster
+//
+// PARAMS:
+//     - clusterID: 集群 ID
+// RETURNS:
+//     - waiter.Task: 集群 running 时成功，create_failed、delete_failed 或 upgrade_failed 时失败
+func (c *Client) ClusterTask(clusterID string) waiter.Task {
+	return waiter.NewTask(clusterID, func(ctx context.Context) (interface{}, waiter.State, error) {
+		result, err := c.GetCluster(clusterID)
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		if result.Cluster == nil || result.Cluster.Status == nil {
+			return nil, waiter.StateRetry, nil
+		}
+		switch phase := result.Cluster.Status.ClusterPhase; phase {
+		case types.ClusterPhaseRunning:
+			return result.Cluster, waiter.StateSuccess, nil
+		case types.ClusterPhaseCreateFailed, types.ClusterPhaseDeleteFailed,
+			types.ClusterPhaseUpgradeFailed, types.ClusterPhaseDeleted:
+			return result.Cluster, waiter.StateFailure,
+				fmt.Errorf("the cluster %s is in the %s phase", clusterID, phase)
+		}
+		return result.Cluster, waiter.StateRetry, nil
+	}, nil)
+}
+
+// InstanceGroupTask - 获取等待节点组节点 Ready 的异步任务，结果为最后一次查询的 *InstanceGroup
+//
+// PARAMS:
+//     - clusterID: 集群 ID
+//     - instanceGroupID: 节点组 ID
+//     - replicas: 期望 Ready 的节点数，不大于 0 时使用节点组的 Replicas
+// RETURNS:
+//     - waiter.Task: Ready 的节点数达到期望值时成功，节点组因创建失败暂停时失败
+func (c *Client) InstanceGroupTask(clusterID, instanceGroupID string, replicas int) waiter.Task {
+	return waiter.NewTask(instanceGroupID, func(ctx context.Context) (interface{}, waiter.State, error) {
+		result, err := c.GetInstanceGroup(&GetInstanceGroupArgs{
+			ClusterID:       clusterID,
+			InstanceGroupID: instanceGroupID,
+		})
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		group := result.InstanceGroup
+		if group == nil || group.Spec == nil || group.Status == nil {
+			return nil, waiter.StateRetry, nil
+		}
+		expected := replicas
+		if expected <= 0 {
+			expected = group.Spec.Replicas
+		}
+		if group.Status.ReadyReplicas >= expected {
+			return group, waiter.StateSuccess, nil
+		}
+		if pause := group.Status.Pause; pause != nil && pause.Paused {
+			return group, waiter.StateFailure,
+				fmt.Errorf("the instance group %s is paused: %s", instanceGroupID, pause.Reason)
+		}
+		return group, waiter.StateRetry, nil
+	}, nil)
+}
+
+// TaskWaiter - 获取等待 CCE 任务完成的异步任务，结果为最后一次查询的 *types.Task
+//
+// PARAMS:
+//     - args: 任务类型和任务 ID
+// RETURNS:
+//     - waiter.Task: 任务 Done 时成功，Aborted 时失败
+func (c *Client) TaskWaiter(args *GetTaskArgs) waiter.Task {
+	taskArgs := *args
+	return waiter.NewTask(args.TaskID, func(ctx context.Context) (interface{}, waiter.State, error) {
+		result, err := c.GetTask(&taskArgs)
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		if result.Task == nil {
+			return nil, waiter.StateRetry, nil
+		}
+		switch result.Task.Phase {
+		case types.TaskPhaseDone:
+			return result.Task, waiter.StateSuccess, nil
+		case types.TaskPhaseAborted:
+			return result.Task, waiter.StateFailure, fmt.Errorf("the %s task %s is aborted: %s",
+				taskArgs.TaskType, taskArgs.TaskID, result.Task.ErrMessage)
+		}
+		return result.Task, waiter.StateRetry, nil
+	}, nil)
+}
+
+// WaitClusterRunning - 按 waiter 的默认退避策略轮询集群直到 running
+//
+// PARAMS:
+//     - ctx: 取消等待或设置超时的 context
+//     - clusterID: 集群 ID
+// RETURNS:
+//     - *Cluster: 最后一次查询的集群
+//     - error: 集群失败时为 *waiter.FailureError，ctx 结束时为 *waiter.TimeoutError，否则为查询错误
+func (c *Client) WaitClusterRunning(ctx context.Context, clusterID string) (*Cluster, error) {
+	task := c.ClusterTask(clusterID)
+	err := task.Wait(ctx)
+	cluster, _ := task.Result().(*Cluster)
+	return cluster, err
+}
+
+// WaitInstanceGroupReady - 按 waiter 的默认退避策略轮询节点组直到 Ready 的节点数达到期望值
+//
+// PARAMS:
+//     - ctx: 取消等待或设置超时的 context
+//     - clusterID: 集群 ID
+//     - instanceGroupID: 节点组 ID
+//     - replicas: 期望 Ready 的节点数，不大于 0 时使用节点组的 Replicas
+// RETURNS:
+//     - *InstanceGroup: 最后一次查询的节点组
+//     - error: 节点组暂停时为 *waiter.FailureError，ctx 结束时为 *waiter.TimeoutError，否则为查询错误
+func (c *Client) WaitInstanceGroupReady(ctx context.Context, clusterID, instanceGroupID string,
+	replicas int) (*InstanceGroup, error) {
+	task := c.InstanceGroupTask(clusterID, instanceGroupID, replicas)
+	err := task.Wait(ctx)
+	group, _ := task.Result().(*InstanceGroup)
+	return group, err
+}
+
+// WaitTask - 按 waiter 的默认退避策略轮询 CCE 任务直到结束，如扩缩容和升级任务
+//
+// PARAMS:
+//     - ctx: 取消等待或设置超时的 context
+//     - args: 任务类型和任务 ID
+// RETURNS:
+//     - *types.Task: 最后一次查询的任务
+//     - error: 任务 Aborted 时为 *waiter.FailureError，ctx 结束时为 *waiter.TimeoutError，否则为查询错误
+func (c *Client) WaitTask(ctx context.Context, args *GetTaskArgs) (*types.Task, error) {
+	if args == nil {
+		return nil, fmt.Errorf("args is nil")
+	}
+	task := c.TaskWaiter(args)
+	err := task.Wait(ctx)
+	result, _ := task.Result().(*types.Task)
+	return result, err
+}
+
+// CreateClusterWithInstanceGroups - 创建集群，等待集群 running 后依次创建节点组，
+// 可选等待各节点组的节点全部 Ready
+//
+// PARAMS:
+//     - ctx: 取消等待或设置超时的 context
+//     - args: 集群和节点组的参数
+// RETURNS:
+//     - *CreateClusterWithInstanceGroupsResult: 已创建的集群和节点组 ID，出错时也返回已创建的部分
+//     - error: 创建或等待的错误，已创建的资源不会被回滚
+func (c *Client) CreateClusterWithInstanceGroups(ctx context.Context,
+	args *CreateClusterWithInstanceGroupsArgs) (*CreateClusterWithInstanceGroupsResult, error) {
+	if args == nil || args.CreateClusterRequest == nil {
+		return nil, fmt.Errorf("args is nil")
+	}
+	for i, spec := range args.InstanceGroups {
+		if spec == nil {
+			return nil, fmt.Errorf("instance group %d is nil", i)
+		}
+		if spec.InstanceGroupName == "" {
+			return nil, fmt.Errorf("instance group %d: instanceGroupName is empty", i)
+		}
+		if spec.Replicas < 0 {
+			return nil, fmt.Errorf("instance group %s: replicas should not be negative",
+				spec.InstanceGroupName)
+		}
+	}
+
+	created, err := c.CreateCluster(&CreateClusterArgs{CreateClusterRequest: args.CreateClusterRequest})
+	if err != nil {
+		return nil, err
+	}
+	result := &CreateClusterWithInstanceGroupsResult{ClusterID: created.ClusterID}
+	if len(args.InstanceGroups) == 0 {
+		return result, nil
+	}
+	if _, err := c.WaitClusterRunning(ctx, result.ClusterID); err != nil {
+		return result, err
+	}
+
+	for _, spec := range args.InstanceGroups {
+		groupSpec := *spec
+		groupSpec.ClusterID = result.ClusterID
+		res, err := c.CreateInstanceGroup(&CreateInstanceGroupArgs{
+			ClusterID: result.ClusterID,
+			Request:   &CreateInstanceGroupRequest{InstanceGroupSpec: groupSpec},
+		})
+		if err != nil {
+			return result, fmt.Errorf("create instance group %s failed: %v",
+				spec.InstanceGroupName, err)
+		}
+		result.InstanceGroupIDs = append(result.InstanceGroupIDs, res.InstanceGroupID)
+	}
+	if args.WaitInstanceGroupsReady {
+		for _, id := range result.InstanceGroupIDs {
+			if _, err := c.WaitInstanceGroupReady(ctx, result.ClusterID, id, 0); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, nil
+}
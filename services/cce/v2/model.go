@@ -13,9 +13,11 @@ CCE V2 版本 GO SDK, Interface 定义
 package v2
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
 	"github.com/baidubce/bce-sdk-go/services/cce/v2/types"
 	"github.com/baidubce/bce-sdk-go/services/vpc"
 )
@@ -31,9 +33,24 @@ type Interface interface {
 	GetInstance(args *GetInstanceArgs) (*GetInstanceResponse, error)
 	DeleteInstances(args *DeleteInstancesArgs) (*DeleteInstancesResponse, error)
 	ListInstancesByPage(args *ListInstancesByPageArgs) (*ListInstancesResponse, error)
+	UpdateInstance(args *UpdateInstanceArgs) (*UpdateInstancesResponse, error)
 	CreateScaleUpInstanceGroupTask(args *CreateScaleUpInstanceGroupTaskArgs) (*CreateTaskResp, error)
 	CreateScaleDownInstanceGroupTask(args *CreateScaleDownInstanceGroupTaskArgs) (*CreateTaskResp, error)
 
+	CreateInstanceGroup(args *CreateInstanceGroupArgs) (*CreateInstanceGroupResponse, error)
+	ListInstanceGroups(args *ListInstanceGroupsArgs) (*ListInstanceGroupResponse, error)
+	ListInstancesByInstanceGroupID(args *ListInstanceByInstanceGroupIDArgs) (*ListInstancesByInstanceGroupIDResponse, error)
+	GetInstanceGroup(args *GetInstanceGroupArgs) (*GetInstanceGroupResponse, error)
+	UpdateInstanceGroupReplicas(args *UpdateInstanceGroupReplicasArgs) (*UpdateInstanceGroupReplicasResponse, error)
+	UpdateInstanceGroupClusterAutoscalerSpec(args *UpdateInstanceGroupClusterAutoscalerSpecArgs) (*UpdateInstanceGroupClusterAutoscalerSpecResponse, error)
+	DeleteInstanceGroup(args *DeleteInstanceGroupArgs) (*DeleteInstanceGroupResponse, error)
+
+	CreateAutoscaler(args *CreateAutoscalerArgs) (*CreateAutoscalerResponse, error)
+	GetAutoscaler(args *GetAutoscalerArgs) (*GetAutoscalerResponse, error)
+	UpdateAutoscaler(args *UpdateAutoscalerArgs) (*UpdateAutoscalerResponse, error)
+
+	GetKubeConfig(args *GetKubeConfigArgs) (*GetKubeConfigResponse, error)
+
 	GetClusterQuota() (*GetQuotaResponse, error)
 	GetClusterNodeQuota(clusterID string) (*GetQuotaResponse, error)
 
@@ -45,6 +62,18 @@ type Interface interface {
 	GetTask(args *GetTaskArgs) (*GetTaskResp, error)
 	ListTasks(args *ListTasksArgs) (*ListTaskResp, error)
 
+	UpgradeCluster(args *UpgradeClusterArgs) (*CreateTaskResp, error)
+	CreateClusterWithInstanceGroups(ctx context.Context, args *CreateClusterWithInstanceGroupsArgs) (*CreateClusterWithInstanceGroupsResult, error)
+
+	ClusterTask(clusterID string) waiter.Task
+	InstanceGroupTask(clusterID, instanceGroupID string, replicas int) waiter.Task
+	TaskWaiter(args *GetTaskArgs) waiter.Task
+	WaitClusterRunning(ctx context.Context, clusterID string) (*Cluster, error)
+	WaitInstanceGroupReady(ctx context.Context, clusterID, instanceGroupID string, replicas int) (*InstanceGroup, error)
+	WaitTask(ctx context.Context, args *GetTaskArgs) (*types.Task, error)
+
+	GetClusterCRD(args *GetClusterCRDArgs) (*GetClusterCRDResponse, error)
+	UpdateClusterCRD(args *UpdateClusterCRDArgs) (*CommonResponse, error)
 	GetInstanceCRD(args *GetInstanceCRDArgs) (*GetInstanceCRDResponse, error)
 	UpdateInstanceCRD(args *UpdateInstanceCRDRequest) (*CommonResponse, error)
 }
@@ -835,6 +864,50 @@ type ListTasksArgs struct {
 	PageSize int
 }
 
+// UpgradeTarget - 集群升级的对象
+type UpgradeTarget string
+
+const (
+	// UpgradeTargetMaster 升级 Master 组件，需先于 Node 升级
+	UpgradeTargetMaster UpgradeTarget = "master"
+
+	// UpgradeTargetNode 升级节点组中节点的 kubelet 等组件
+	UpgradeTargetNode UpgradeTarget = "node"
+)
+
+type UpgradeClusterArgs struct {
+	ClusterID string
+	Request   *UpgradeClusterRequest
+}
+
+// UpgradeClusterRequest - 升级集群 K8S 版本 request
+type UpgradeClusterRequest struct {
+	K8SVersion types.K8SVersion `json:"k8sVersion"`
+	Target     UpgradeTarget    `json:"target"`
+
+	// 升级 Node 时指定节点组，为空时升级集群内全部节点组
+	InstanceGroupIDs []string `json:"instanceGroupIDs,omitempty"`
+
+	// 升级 Node 的方式，Rolling 逐个升级，Concurrency 按 MaxUnavailable 并发升级
+	UpdatePolicy   types.UpdatePolicy `json:"updatePolicy,omitempty"`
+	MaxUnavailable int                `json:"maxUnavailable,omitempty"`
+}
+
+// CreateClusterWithInstanceGroupsArgs - 创建集群并在集群运行后创建节点组
+type CreateClusterWithInstanceGroupsArgs struct {
+	CreateClusterRequest *CreateClusterRequest
+	InstanceGroups       []*types.InstanceGroupSpec
+
+	// 为 true 时等待各节点组的节点全部 Ready
+	WaitInstanceGroupsReady bool
+}
+
+// CreateClusterWithInstanceGroupsResult - 已创建的集群和节点组，失败时包含失败前已创建的部分
+type CreateClusterWithInstanceGroupsResult struct {
+	ClusterID        string
+	InstanceGroupIDs []string
+}
+
 type GetTaskResp struct {
 	CommonResponse
 	Task *types.Task `json:"task"`
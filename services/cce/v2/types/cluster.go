@@ -281,6 +281,12 @@ const (
 
 	// ClusterPhaseDeleteFailed 集群删除失败
 	ClusterPhaseDeleteFailed ClusterPhase = "delete_failed"
+
+	// ClusterPhaseUpgrading 集群正在升级
+	ClusterPhaseUpgrading ClusterPhase = "upgrading"
+
+	// ClusterPhaseUpgradeFailed 集群升级失败
+	ClusterPhaseUpgradeFailed ClusterPhase = "upgrade_failed"
 )
 
 // AuthenticateMode - 认证类型
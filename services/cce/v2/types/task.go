@@ -12,6 +12,7 @@ import "time"
 
 const (
 	TaskTypeInstanceGroupReplicas TaskType = "InstanceGroupReplicas"
+	TaskTypeClusterUpgrade        TaskType = "ClusterUpgrade"
 )
 
 const (
@@ -3,6 +3,8 @@ package cdn
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"testing"
 	"time"
 
@@ -832,6 +834,24 @@ func TestGetMultiDomainLog(t *testing.T) {
 	checkClientErr(t, "GetMultiDomainLog", err)
 }
 
+func TestOpenDomainLogs(t *testing.T) {
+	endTs := time.Now().Unix()
+	startTs := endTs - 24*60*60
+	reader, err := testCli.OpenDomainLogs(testAuthorityDomain, api.TimeInterval{
+		StartTime: util.FormatISO8601Date(startTs),
+		EndTime:   util.FormatISO8601Date(endTs),
+	}, true)
+	checkClientErr(t, "OpenDomainLogs", err)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	n, err := io.Copy(ioutil.Discard, reader)
+	t.Logf("log size: %d", n)
+	checkClientErr(t, "OpenDomainLogs", err)
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Test function about query statistics.
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the CDN client
+
+package cdn
+
+import (
+	"context"
+	"io"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/cdn/api"
+)
+
+// Interface defines all the operations of the CDN client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	SendCustomRequest(method string, urlPath string, params, reqHeaders map[string]string, bodyObj interface{}, respObj interface{}) error
+	ListDomains(marker string) ([]string, string, error)
+	GetDomainStatus(status string, rule string) ([]api.DomainStatus, error)
+	IsValidDomain(domain string) (*api.DomainValidInfo, error)
+	CreateDomain(domain string, originInit *api.OriginInit) (*api.DomainCreatedInfo, error)
+	EnableDomain(domain string) error
+	DisableDomain(domain string) error
+	DeleteDomain(domain string) error
+	GetIpInfo(ip string, action string) (*api.IpInfo, error)
+	GetIpListInfo(ips []string, action string) ([]api.IpInfo, error)
+	GetBackOriginNodes() ([]api.BackOriginNode, error)
+	GetDomainConfig(domain string) (*api.DomainConfig, error)
+	SetDomainOrigin(domain string, origins []api.OriginPeer, defaultHost string) error
+	SetOriginProtocol(domain string, originProtocol string) error
+	GetOriginProtocol(domain string) (string, error)
+	SetDomainSeo(domain string, seoSwitch *api.SeoSwitch) error
+	GetDomainSeo(domain string) (*api.SeoSwitch, error)
+	GetCacheTTL(domain string) ([]api.CacheTTL, error)
+	SetCacheTTL(domain string, cacheTTLs []api.CacheTTL) error
+	SetRefererACL(domain string, blackList []string, whiteList []string, isAllowEmpty bool) error
+	GetRefererACL(domain string) (*api.RefererACL, error)
+	SetIpACL(domain string, blackList []string, whiteList []string) error
+	GetIpACL(domain string) (*api.IpACL, error)
+	SetUaACL(domain string, blackList []string, whiteList []string) error
+	GetUaACL(domain string) (*api.UaACL, error)
+	SetLimitRate(domain string, limitRate int) error
+	SetTrafficLimit(domain string, trafficLimit *api.TrafficLimit) error
+	GetTrafficLimit(domain string) (*api.TrafficLimit, error)
+	SetDomainHttps(domain string, httpsConfig *api.HTTPSConfig) error
+	GetDomainHttps(domain string) (*api.HTTPSConfig, error)
+	PutCert(domain string, userCert *api.UserCertificate, httpsEnabled string) (certId string, err error)
+	GetCert(domain string) (certDetail *api.CertificateDetail, err error)
+	DeleteCert(domain string) error
+	SetOCSP(domain string, enabled bool) error
+	GetOCSP(domain string) (bool, error)
+	SetDomainRequestAuth(domain string, requestAuth *api.RequestAuth) error
+	SetFollowProtocol(domain string, isFollowProtocol bool) error
+	SetHttpHeader(domain string, httpHeaders []api.HttpHeader) error
+	GetHttpHeader(domain string) ([]api.HttpHeader, error)
+	SetErrorPage(domain string, errorPages []api.ErrorPage) error
+	GetErrorPage(domain string) ([]api.ErrorPage, error)
+	SetCacheShared(domain string, config *api.CacheShared) error
+	GetCacheShared(domain string) (*api.CacheShared, error)
+	SetMediaDrag(domain string, mediaDragConf *api.MediaDragConf) error
+	GetMediaDrag(domain string) (*api.MediaDragConf, error)
+	SetFileTrim(domain string, fileTrim bool) error
+	GetFileTrim(domain string) (bool, error)
+	SetIPv6(domain string, enabled bool) error
+	GetIPv6(domain string) (bool, error)
+	SetQUIC(domain string, enabled bool) error
+	GetQUIC(domain string) (bool, error)
+	SetOfflineMode(domain string, enabled bool) error
+	GetOfflineMode(domain string) (bool, error)
+	SetMobileAccess(domain string, distinguishClient bool) error
+	GetMobileAccess(domain string) (bool, error)
+	SetClientIp(domain string, clientIp *api.ClientIp) error
+	GetClientIp(domain string) (*api.ClientIp, error)
+	SetRetryOrigin(domain string, retryOrigin *api.RetryOrigin) error
+	GetRetryOrigin(domain string) (*api.RetryOrigin, error)
+	SetAccessLimit(domain string, accessLimit *api.AccessLimit) error
+	GetAccessLimit(domain string) (*api.AccessLimit, error)
+	SetCacheUrlArgs(domain string, cacheFullUrl *api.CacheUrlArgs) error
+	GetCacheUrlArgs(domain string) (*api.CacheUrlArgs, error)
+	SetCors(domain string, isAllow bool, originList []string) error
+	GetCors(domain string) (*api.CorsCfg, error)
+	SetRangeSwitch(domain string, enabled bool) error
+	GetRangeSwitch(domain string) (bool, error)
+	SetContentEncoding(domain string, enabled bool, encodingType string) error
+	GetContentEncoding(domain string) (string, error)
+	Purge(tasks []api.PurgeTask) (api.PurgedId, error)
+	GetPurgedStatus(queryData *api.CStatusQueryData) (*api.PurgedStatus, error)
+	Prefetch(tasks []api.PrefetchTask) (api.PrefetchId, error)
+	GetPrefetchStatus(queryData *api.CStatusQueryData) (*api.PrefetchStatus, error)
+	GetQuota() (*api.QuotaDetail, error)
+	GetCacheOpRecords(queryData *api.CRecordQueryData) (*api.RecordDetails, error)
+	EnableDsa() error
+	DisableDsa() error
+	ListDsaDomains() ([]api.DSADomain, error)
+	SetDsaConfig(domain string, dsaConfig *api.DSAConfig) error
+	GetDomainLog(domain string, timeInterval api.TimeInterval) ([]api.LogEntry, error)
+	GetMultiDomainLog(queryData *api.LogQueryData) ([]api.LogEntry, error)
+	GetAvgSpeed(queryCondition *api.QueryCondition) ([]api.AvgSpeedDetail, error)
+	GetAvgSpeedByRegion(queryCondition *api.QueryCondition, prov string, isp string) ([]api.AvgSpeedRegionDetail, error)
+	GetPv(queryCondition *api.QueryCondition, level string) ([]api.PvDetail, error)
+	GetSrcPv(queryCondition *api.QueryCondition) ([]api.PvDetail, error)
+	GetPvByRegion(queryCondition *api.QueryCondition, prov string, isp string) ([]api.PvRegionDetail, error)
+	GetUv(queryCondition *api.QueryCondition) ([]api.UvDetail, error)
+	GetFlow(queryCondition *api.QueryCondition, level string) ([]api.FlowDetail, error)
+	GetFlowByProtocol(queryCondition *api.QueryCondition, protocol string) ([]api.FlowDetail, error)
+	GetFlowByRegion(queryCondition *api.QueryCondition, prov string, isp string) ([]api.FlowRegionDetail, error)
+	GetSrcFlow(queryCondition *api.QueryCondition) ([]api.FlowDetail, error)
+	GetRealHit(queryCondition *api.QueryCondition) ([]api.HitDetail, error)
+	GetPvHit(queryCondition *api.QueryCondition) ([]api.HitDetail, error)
+	GetHttpCode(queryCondition *api.QueryCondition) ([]api.HttpCodeDetail, error)
+	GetSrcHttpCode(queryCondition *api.QueryCondition) ([]api.HttpCodeDetail, error)
+	GetHttpCodeByRegion(queryCondition *api.QueryCondition, prov string, isp string) ([]api.HttpCodeRegionDetail, error)
+	GetTopNUrls(queryCondition *api.QueryCondition, httpCode string) ([]api.TopNDetail, error)
+	GetTopNReferers(queryCondition *api.QueryCondition, httpCode string) ([]api.TopNDetail, error)
+	GetTopNDomains(queryCondition *api.QueryCondition, httpCode string) ([]api.TopNDetail, error)
+	GetError(queryCondition *api.QueryCondition) ([]api.ErrorDetail, error)
+	GetPeak95Bandwidth(startTime, endTime string, domains, tags []string) (string, int64, error)
+	DownloadLog(entry *api.LogEntry, decompress bool) (io.ReadCloser, error)
+	OpenDomainLogs(domain string, timeInterval api.TimeInterval, decompress bool) (io.ReadCloser, error)
+	WaitPurged(ctx context.Context, id api.PurgedId) error
+	WaitPrefetched(ctx context.Context, id api.PrefetchId) error
+	PurgeTask(id api.PurgedId) waiter.Task
+	PrefetchTask(id api.PrefetchId) waiter.Task
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
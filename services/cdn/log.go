@@ -0,0 +1,148 @@
+package cdn
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/baidubce/bce-sdk-go/services/cdn/api"
+)
+
+// DownloadLog - download the content of a log file returned by the GetDomainLog
+//
+// PARAMS:
+//     - entry: the log file to download
+//     - decompress: decompress the content if it is gzip compressed
+// RETURNS:
+//     - io.ReadCloser: the streaming content of the log file, which should be closed by the caller
+//     - error: nil if success otherwise the specific error
+func (cli *Client) DownloadLog(entry *api.LogEntry, decompress bool) (io.ReadCloser, error) {
+	if entry == nil || entry.LogBase == nil || entry.Url == "" {
+		return nil, errors.New("the url of the log file is empty")
+	}
+	resp, err := cli.GetUrl(entry.Url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		resp.Body().Close()
+		return nil, fmt.Errorf("download log file %s failed: %s", entry.Name, resp.StatusText())
+	}
+	if !decompress {
+		return resp.Body(), nil
+	}
+	return newLogReader(resp.Body())
+}
+
+// OpenDomainLogs - list the hourly log files of the domain in the time interval, and read them
+// one by one in time order as a single stream, the files are downloaded only when reading them
+//
+// PARAMS:
+//     - domain: the specified domain
+//     - timeInterval: the specified time interval
+//     - decompress: decompress the content of the gzip compressed log files
+// RETURNS:
+//     - io.ReadCloser: the concatenated content of the log files, which should be closed
+//     - error: nil if success otherwise the specific error
+func (cli *Client) OpenDomainLogs(domain string, timeInterval api.TimeInterval,
+	decompress bool) (io.ReadCloser, error) {
+	entries, err := cli.GetDomainLog(domain, timeInterval)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return logStartTime(entries[i]) < logStartTime(entries[j])
+	})
+	return &domainLogReader{cli: cli, entries: entries, decompress: decompress}, nil
+}
+
+func logStartTime(entry api.LogEntry) string {
+	if entry.TimeInterval == nil {
+		return ""
+	}
+	return entry.StartTime
+}
+
+// gzipLogReader closes both the gzip reader and the underlying body
+type gzipLogReader struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (r *gzipLogReader) Close() error {
+	r.Reader.Close()
+	return r.body.Close()
+}
+
+// bufferedLogReader reads the peeked content of the uncompressed body
+type bufferedLogReader struct {
+	*bufio.Reader
+	body io.Closer
+}
+
+func (r *bufferedLogReader) Close() error {
+	return r.body.Close()
+}
+
+// newLogReader - decompress the body if it starts with the gzip magic number
+func newLogReader(body io.ReadCloser) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(body)
+	magic, _ := buffered.Peek(2)
+	if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return &bufferedLogReader{buffered, body}, nil
+	}
+	gzReader, err := gzip.NewReader(buffered)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	return &gzipLogReader{gzReader, body}, nil
+}
+
+// domainLogReader downloads and reads the log files one after another
+type domainLogReader struct {
+	cli        *Client
+	entries    []api.LogEntry
+	decompress bool
+	current    io.ReadCloser
+}
+
+func (r *domainLogReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.entries) == 0 {
+				return 0, io.EOF
+			}
+			current, err := r.cli.DownloadLog(&r.entries[0], r.decompress)
+			if err != nil {
+				return 0, err
+			}
+			r.current = current
+			r.entries = r.entries[1:]
+		}
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (r *domainLogReader) Close() error {
+	r.entries = nil
+	if r.current != nil {
+		err := r.current.Close()
+		r.current = nil
+		return err
+	}
+	return nil
+}
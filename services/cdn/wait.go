@@ -0,0 +1,127 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/cdn/api"
+)
+
+// The status of the purge and prefetch tasks
+const (
+	CACHE_TASK_STATUS_IN_PROGRESS = "in-progress"
+	CACHE_TASK_STATUS_COMPLETED   = "completed"
+	CACHE_TASK_STATUS_FAILED      = "failed"
+)
+
+// WaitPurged - poll the purge task with the default backoff of the waiter until all the urls are
+// purged or any of them is failed
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - id: the purge task ID returned by the Purge
+// RETURNS:
+//     - error: nil if completed, *waiter.FailureError if any url is failed, *waiter.TimeoutError
+//       if the context is done, otherwise the query error
+func (cli *Client) WaitPurged(ctx context.Context, id api.PurgedId) error {
+	return cli.PurgeTask(id).Wait(ctx)
+}
+
+// WaitPrefetched - poll the prefetch task with the default backoff of the waiter until all the
+// urls are prefetched or any of them is failed
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - id: the prefetch task ID returned by the Prefetch
+// RETURNS:
+//     - error: nil if completed, *waiter.FailureError if any url is failed, *waiter.TimeoutError
+//       if the context is done, otherwise the query error
+func (cli *Client) WaitPrefetched(ctx context.Context, id api.PrefetchId) error {
+	return cli.PrefetchTask(id).Wait(ctx)
+}
+
+// PurgeTask - get the async task of the purge, the result of the task is the []string of the
+// statuses of the urls
+//
+// PARAMS:
+//     - id: the purge task ID returned by the Purge
+// RETURNS:
+//     - waiter.Task: the purge task
+func (cli *Client) PurgeTask(id api.PurgedId) waiter.Task {
+	return newCacheTask(string(id), func() ([]string, error) {
+		var statuses []string
+		queryData := &api.CStatusQueryData{Id: string(id)}
+		for {
+			result, err := cli.GetPurgedStatus(queryData)
+			if err != nil {
+				return nil, err
+			}
+			for _, detail := range result.Details {
+				statuses = append(statuses, cacheTaskStatus(detail.CachedDetail))
+			}
+			if !result.IsTruncated {
+				return statuses, nil
+			}
+			queryData.Marker = result.NextMarker
+		}
+	})
+}
+
+// PrefetchTask - get the async task of the prefetch, the result of the task is the []string of
+// the statuses of the urls
+//
+// PARAMS:
+//     - id: the prefetch task ID returned by the Prefetch
+// RETURNS:
+//     - waiter.Task: the prefetch task
+func (cli *Client) PrefetchTask(id api.PrefetchId) waiter.Task {
+	return newCacheTask(string(id), func() ([]string, error) {
+		var statuses []string
+		queryData := &api.CStatusQueryData{Id: string(id)}
+		for {
+			result, err := cli.GetPrefetchStatus(queryData)
+			if err != nil {
+				return nil, err
+			}
+			for _, detail := range result.Details {
+				statuses = append(statuses, cacheTaskStatus(detail.CachedDetail))
+			}
+			if !result.IsTruncated {
+				return statuses, nil
+			}
+			queryData.Marker = result.NextMarker
+		}
+	})
+}
+
+func cacheTaskStatus(detail *api.CachedDetail) string {
+	if detail == nil {
+		return ""
+	}
+	return detail.Status
+}
+
+// newCacheTask - create the task which is finished when all the statuses of the urls are completed
+func newCacheTask(id string, query func() ([]string, error)) waiter.Task {
+	return waiter.NewTask(id, func(ctx context.Context) (interface{}, waiter.State, error) {
+		statuses, err := query()
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		for _, status := range statuses {
+			if status == CACHE_TASK_STATUS_FAILED {
+				return statuses, waiter.StateFailure, fmt.Errorf("the cache task is failed")
+			}
+		}
+		for _, status := range statuses {
+			if status != CACHE_TASK_STATUS_COMPLETED {
+				return statuses, waiter.StateRetry, nil
+			}
+		}
+		if len(statuses) == 0 {
+			return statuses, waiter.StateRetry, nil
+		}
+		return statuses, waiter.StateSuccess, nil
+	}, nil)
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// bind.go - upload the certificate and bind it to the CDN domain or BLB HTTPS listener
+
+package cert
+
+import (
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/services/blb"
+	"github.com/baidubce/bce-sdk-go/services/cdn"
+	cdnapi "github.com/baidubce/bce-sdk-go/services/cdn/api"
+)
+
+// CreateCertAndBindCDN - upload the certificate and enable the HTTPS of the CDN domain with it,
+// the other HTTPS settings of the domain are kept. The uploaded certificate is deleted if it
+// fails to bind.
+//
+// PARAMS:
+//     - cdnClient: the client of the CDN service
+//     - domain: the CDN domain to bind the certificate
+//     - args: the arguments to create a cert
+// RETURNS:
+//     - *CreateCertResult: the result of create Cert, contains new Cert's ID
+//     - error: nil if success, *BindCertError if failed to bind, otherwise the specific error
+func (c *Client) CreateCertAndBindCDN(cdnClient cdn.Interface, domain string,
+	args *CreateCertArgs) (*CreateCertResult, error) {
+	if cdnClient == nil {
+		return nil, fmt.Errorf("unset cdn client")
+	}
+	if domain == "" {
+		return nil, fmt.Errorf("unset domain")
+	}
+	current, err := cdnClient.GetDomainHttps(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.CreateCert(args)
+	if err != nil {
+		return nil, err
+	}
+	config := &cdnapi.HTTPSConfig{}
+	if current != nil {
+		*config = *current
+	}
+	config.Enabled = true
+	config.CertId = result.CertId
+	if err := cdnClient.SetDomainHttps(domain, config); err != nil {
+		return nil, c.rollbackCert(result.CertId, "cdn domain "+domain, err)
+	}
+	return result, nil
+}
+
+// CreateCertAndBindBLB - upload the certificate and set it as the certificate of the HTTPS
+// listener of the BLB instead of the current ones, the other settings of the listener are kept.
+// The uploaded certificate is deleted if it fails to bind.
+//
+// PARAMS:
+//     - blbClient: the client of the BLB service
+//     - blbId: LoadBalancer's ID
+//     - listenerPort: the port of the HTTPS listener
+//     - args: the arguments to create a cert
+// RETURNS:
+//     - *CreateCertResult: the result of create Cert, contains new Cert's ID
+//     - error: nil if success, *BindCertError if failed to bind, otherwise the specific error
+func (c *Client) CreateCertAndBindBLB(blbClient blb.Interface, blbId string, listenerPort uint16,
+	args *CreateCertArgs) (*CreateCertResult, error) {
+	if blbClient == nil {
+		return nil, fmt.Errorf("unset blb client")
+	}
+	if blbId == "" || listenerPort == 0 {
+		return nil, fmt.Errorf("unset blbId or listener port")
+	}
+	listeners, err := blbClient.DescribeHTTPSListeners(blbId,
+		&blb.DescribeListenerArgs{ListenerPort: listenerPort})
+	if err != nil {
+		return nil, err
+	}
+	if len(listeners.ListenerList) == 0 {
+		return nil, fmt.Errorf("https listener %d of blb %s not found", listenerPort, blbId)
+	}
+
+	result, err := c.CreateCert(args)
+	if err != nil {
+		return nil, err
+	}
+	err = blbClient.UpdateHTTPSListener(blbId, &blb.UpdateHTTPSListenerArgs{
+		ListenerPort: listenerPort,
+		CertIds:      []string{result.CertId},
+	})
+	if err != nil {
+		return nil, c.rollbackCert(result.CertId,
+			fmt.Sprintf("https listener %d of blb %s", listenerPort, blbId), err)
+	}
+	return result, nil
+}
+
+// BindCertError is returned if the uploaded certificate fails to bind, Err is the error of
+// binding and RollbackErr is the error of deleting the certificate, nil if it is deleted.
+type BindCertError struct {
+	CertId      string
+	Target      string
+	Err         error
+	RollbackErr error
+}
+
+func (e *BindCertError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("bind cert %s to %s failed: %v, and delete the cert failed: %v",
+			e.CertId, e.Target, e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("bind cert %s to %s failed and the cert is deleted: %v",
+		e.CertId, e.Target, e.Err)
+}
+
+// rollbackCert - delete the certificate failed to bind
+//
+// PARAMS:
+//     - id: the specific cert's ID
+//     - target: the description of the binding target
+//     - cause: the error of binding
+// RETURNS:
+//     - error: the *BindCertError
+func (c *Client) rollbackCert(id, target string, cause error) error {
+	return &BindCertError{CertId: id, Target: target, Err: cause, RollbackErr: c.DeleteCert(id)}
+}
@@ -2,12 +2,17 @@ package cert
 
 import (
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
 	"testing"
 
+	"github.com/baidubce/bce-sdk-go/services/cdn"
+	cdnapi "github.com/baidubce/bce-sdk-go/services/cdn/api"
 	"github.com/baidubce/bce-sdk-go/util/log"
 )
 
@@ -122,3 +127,50 @@ func TestClient_DeleteCert(t *testing.T) {
 	err := CERT_CLIENT.DeleteCert(CERT_ID)
 	ExpectEqual(t.Errorf, nil, err)
 }
+
+type fakeCdnClient struct {
+	cdn.Interface
+	config *cdnapi.HTTPSConfig
+	err    error
+}
+
+func (f *fakeCdnClient) GetDomainHttps(domain string) (*cdnapi.HTTPSConfig, error) {
+	return &cdnapi.HTTPSConfig{Http2Enabled: true}, nil
+}
+
+func (f *fakeCdnClient) SetDomainHttps(domain string, config *cdnapi.HTTPSConfig) error {
+	f.config = config
+	return f.err
+}
+
+func TestCreateCertAndBindCDN(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"certName":"test","certId":"cert-test"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClient("ak", "sk", server.URL)
+	args := &CreateCertArgs{CertName: "test", CertServerData: "server", CertPrivateData: "private"}
+
+	fake := &fakeCdnClient{}
+	result, err := client.CreateCertAndBindCDN(fake, "www.example.com", args)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, "cert-test", result.CertId)
+	ExpectEqual(t.Errorf, true, fake.config.Enabled)
+	ExpectEqual(t.Errorf, true, fake.config.Http2Enabled)
+	ExpectEqual(t.Errorf, "cert-test", fake.config.CertId)
+	ExpectEqual(t.Errorf, 0, len(deleted))
+
+	fake.err = errors.New("bind failed")
+	_, err = client.CreateCertAndBindCDN(fake, "www.example.com", args)
+	bindErr, ok := err.(*BindCertError)
+	ExpectEqual(t.Errorf, true, ok)
+	ExpectEqual(t.Errorf, fake.err, bindErr.Err)
+	ExpectEqual(t.Errorf, nil, bindErr.RollbackErr)
+	ExpectEqual(t.Errorf, []string{getCertUriWithId("cert-test")}, deleted)
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the CERT client
+
+package cert
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/blb"
+	"github.com/baidubce/bce-sdk-go/services/cdn"
+)
+
+// Interface defines all the operations of the CERT client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateCertAndBindCDN(cdnClient cdn.Interface, domain string, args *CreateCertArgs) (*CreateCertResult, error)
+	CreateCertAndBindBLB(blbClient blb.Interface, blbId string, listenerPort uint16, args *CreateCertArgs) (*CreateCertResult, error)
+	CreateCert(args *CreateCertArgs) (*CreateCertResult, error)
+	UpdateCertName(id string, args *UpdateCertNameArgs) error
+	ListCerts() (*ListCertResult, error)
+	GetCertMeta(id string) (*CertificateMeta, error)
+	DeleteCert(id string) error
+	UpdateCertData(id string, args *UpdateCertDataArgs) error
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
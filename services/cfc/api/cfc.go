@@ -227,6 +227,58 @@ func DeleteReservedConcurrentExecutions(cli bce.Client, args *DeleteReservedConc
 }
 
 func Invocations(cli bce.Client, args *InvocationsArgs) (*InvocationsResult, error) {
+	req, err := newInvocationsRequest(args)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	defer resp.Body().Close()
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	body, err := ioutil.ReadAll(resp.Body())
+	if err != nil {
+		return nil, err
+	}
+	result := &InvocationsResult{
+		Payload: string(body),
+	}
+	if err := parseInvocationsHeaders(resp, &result.FunctionError, &result.LogResult); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// InvokeStream - invoke the function and return the response payload as a stream, which
+// avoids buffering the whole payload in memory. The caller must close the returned Payload.
+func InvokeStream(cli bce.Client, args *InvocationsArgs) (*InvokeStreamResult, error) {
+	req, err := newInvocationsRequest(args)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &InvokeStreamResult{
+		Payload: resp.ParseStreamBody(),
+	}
+	if err := parseInvocationsHeaders(resp, &result.FunctionError, &result.LogResult); err != nil {
+		result.Payload.Close()
+		return nil, err
+	}
+	return result, nil
+}
+
+func newInvocationsRequest(args *InvocationsArgs) (*bce.BceRequest, error) {
 	if err := args.Validate(); err != nil {
 		return nil, err
 	}
@@ -273,34 +325,23 @@ func Invocations(cli bce.Client, args *InvocationsArgs) (*InvocationsResult, err
 		req.SetBody(requestBody)
 	}
 
-	resp := &bce.BceResponse{}
-	if err := cli.SendRequest(req, resp); err != nil {
-		return nil, err
-	}
-	defer resp.Body().Close()
-	if resp.IsFail() {
-		return nil, resp.ServiceError()
-	}
-	body, err := ioutil.ReadAll(resp.Body())
-	if err != nil {
-		return nil, err
-	}
-	result := &InvocationsResult{
-		Payload: string(body),
-	}
+	return req, nil
+}
+
+func parseInvocationsHeaders(resp *bce.BceResponse, functionError, logResult *string) error {
 	errorStr := resp.Header("x-bce-function-error")
 	if len(errorStr) > 0 {
-		result.FunctionError = errorStr
+		*functionError = errorStr
 	}
-	logResult := resp.Header("x-bce-log-result")
-	if len(logResult) > 0 {
-		decodeBytes, err := base64.StdEncoding.DecodeString(string(logResult))
+	logStr := resp.Header("x-bce-log-result")
+	if len(logStr) > 0 {
+		decodeBytes, err := base64.StdEncoding.DecodeString(logStr)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		result.LogResult = string(decodeBytes)
+		*logResult = string(decodeBytes)
 	}
-	return result, nil
+	return nil
 }
 
 func ListVersionsByFunction(cli bce.Client, args *ListVersionsByFunctionArgs) (*ListVersionsByFunctionResult, error) {
@@ -16,6 +16,8 @@ package api
 
 import (
 	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
 )
 
 type InvocationType string
@@ -141,6 +143,12 @@ type InvocationsResult struct {
 	LogResult     string
 }
 
+type InvokeStreamResult struct {
+	Payload       *bce.StreamBody
+	FunctionError string
+	LogResult     string
+}
+
 type GetFunctionArgs struct {
 	FunctionName string
 	Qualifier    string
@@ -96,6 +96,17 @@ func (c *Client) Invoke(args *api.InvocationsArgs) (*api.InvocationsResult, erro
 	return api.Invocations(c, args)
 }
 
+// InvokeStream - invoke a cfc function and return the payload as a stream without buffering
+//
+// PARAMS:
+//     - args: the arguments to invocation cfc function
+// RETURNS:
+//     - *api.InvokeStreamResult: the result whose Payload must be closed by the caller
+//     - error: nil if success otherwise the specific error
+func (c *Client) InvokeStream(args *api.InvocationsArgs) (*api.InvokeStreamResult, error) {
+	return api.InvokeStream(c, args)
+}
+
 // ListFunctions - list all functions with the specific parameters
 //
 // PARAMS:
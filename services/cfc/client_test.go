@@ -1,6 +1,8 @@
 package cfc
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -621,3 +623,71 @@ func TestDeleteEventSource(t *testing.T) {
 		t.Fatalf("err (%v)", err)
 	}
 }
+
+func TestPackageDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sdktest-deploy")
+	if err != nil {
+		t.Fatalf("err (%v)", err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "lib"), 0755)
+	os.MkdirAll(filepath.Join(dir, ".git"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "index.py"), []byte("def handler(event, context):\n    return 'Hello World'\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "lib", "util.py"), []byte("x = 1\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "lib", "util.pyc"), []byte("x"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref"), 0644)
+
+	zipFile, err := PackageDirectory(dir, []string{".git", "*.pyc"})
+	if err != nil {
+		t.Fatalf("err (%v)", err)
+	}
+	again, _ := PackageDirectory(dir, []string{".git", "*.pyc"})
+	if ComputeCodeSha256(zipFile) != ComputeCodeSha256(again) {
+		t.Fatalf("the sha256 of the same code changed")
+	}
+	reader, err := zip.NewReader(bytes.NewReader(zipFile), int64(len(zipFile)))
+	if err != nil {
+		t.Fatalf("err (%v)", err)
+	}
+	var names []string
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	if fmt.Sprint(names) != "[index.py lib/util.py]" {
+		t.Fatalf("unexpected packaged files %v", names)
+	}
+}
+
+func TestDeployFunction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sdktest-deploy")
+	if err != nil {
+		t.Fatalf("err (%v)", err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "index.py"), []byte("def handler(event, context):\n    return 'Hello World'\n"), 0644)
+
+	config := &DeployConfig{
+		FunctionName: FunctionName01 + "-deploy",
+		Handler:      "index.handler",
+		Runtime:      "python3",
+		MemorySize:   128,
+		Timeout:      3,
+		Publish:      true,
+		Aliases:      []string{AliasName01},
+	}
+	res, err := CfcClient.DeployFunction(dir, config)
+	if err != nil {
+		t.Fatalf("err (%v)", err)
+	}
+	if !res.Created || len(res.Aliases) != 1 || res.Aliases[0].FunctionVersion != res.Version {
+		t.Fatalf("unexpected deploy result %+v", res)
+	}
+	res, err = CfcClient.DeployFunction(dir, config)
+	if err != nil {
+		t.Fatalf("err (%v)", err)
+	}
+	if res.Created || res.CodeUpdated {
+		t.Fatalf("unexpected deploy result %+v", res)
+	}
+	CfcClient.DeleteFunction(&api.DeleteFunctionArgs{FunctionName: config.FunctionName})
+}
@@ -0,0 +1,332 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+
+// deploy.go - package the code directory and deploy it as the function in one call
+
+package cfc
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/cfc/api"
+)
+
+const (
+	LATEST_VERSION = "$LATEST"
+
+	// MAX_ZIP_FILE_SIZE is the max size of the zipped code uploaded directly
+	MAX_ZIP_FILE_SIZE = 50 << 20
+)
+
+// zipModTime is set to all the entries so that the unchanged code has the same CodeSha256
+var zipModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// DeployConfig defines the configuration of the function to be deployed by DeployFunction.
+//
+// The configuration of the existing function is updated with the given fields. The Aliases are
+// created or updated to the published version, or $LATEST if Publish is false. The Excludes are
+// the patterns of filepath.Match matching the relative path or the base name of the files and
+// directories not packaged, eg: ".git", "*.pyc" or "tests/*".
+type DeployConfig struct {
+	FunctionName       string
+	Handler            string
+	Runtime            string
+	MemorySize         int
+	Timeout            int
+	Description        string
+	Environment        *api.Environment
+	VpcConfig          *api.VpcConfig
+	LogType            string
+	LogBosDir          string
+	Publish            bool
+	VersionDescription string
+	Aliases            []string
+	Excludes           []string
+}
+
+// DeployResult defines the result of DeployFunction.
+type DeployResult struct {
+	Function    *api.Function
+	CodeSha256  string
+	Created     bool
+	CodeUpdated bool
+	Version     string
+	Aliases     []*api.Alias
+}
+
+// PackageDirectory - zip the files under the directory with the paths relative to it
+//
+// PARAMS:
+//     - dir: the code directory
+//     - excludes: the patterns of the files and directories not packaged
+// RETURNS:
+//     - []byte: the zip file content, which is the same for the same files
+//     - error: nil if success otherwise the specific error
+func PackageDirectory(dir string, excludes []string) ([]byte, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	var files []string
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if isExcluded(filepath.ToSlash(rel), excludes) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no file to package in %s", dir)
+	}
+	sort.Strings(files)
+
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	for _, rel := range files {
+		if err := addZipFile(writer, filepath.Join(dir, rel), filepath.ToSlash(rel)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func isExcluded(rel string, excludes []string) bool {
+	base := rel[strings.LastIndex(rel, "/")+1:]
+	for _, pattern := range excludes {
+		pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func addZipFile(writer *zip.Writer, path, name string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	header.Modified = zipModTime
+	w, err := writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// ComputeCodeSha256 - compute the CodeSha256 of the zipped code in the same format as the service
+//
+// PARAMS:
+//     - zipFile: the zip file content
+// RETURNS:
+//     - string: the base64 encoded sha256 digest
+func ComputeCodeSha256(zipFile []byte) string {
+	sum := sha256.Sum256(zipFile)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// DeployFunction - package the code directory and deploy it: create the function if it does not
+// exist, otherwise update the code if it is changed and the configuration, then publish the
+// version and point the aliases to it if they are configured
+//
+// PARAMS:
+//     - dir: the code directory
+//     - config: the configuration of the function
+// RETURNS:
+//     - *DeployResult: the deployed function, version and aliases
+//     - error: nil if success otherwise the specific error
+func (c *Client) DeployFunction(dir string, config *DeployConfig) (*DeployResult, error) {
+	if config == nil || len(config.FunctionName) == 0 {
+		return nil, fmt.Errorf("the function name of the deploy config should be set")
+	}
+	zipFile, err := PackageDirectory(dir, config.Excludes)
+	if err != nil {
+		return nil, err
+	}
+	if len(zipFile) > MAX_ZIP_FILE_SIZE {
+		return nil, fmt.Errorf("the zipped code of %d bytes exceeds the limit of %d bytes",
+			len(zipFile), MAX_ZIP_FILE_SIZE)
+	}
+	result := &DeployResult{CodeSha256: ComputeCodeSha256(zipFile), Version: LATEST_VERSION}
+
+	existing, err := c.GetFunction(&api.GetFunctionArgs{FunctionName: config.FunctionName})
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if existing == nil {
+		created, err := c.CreateFunction(&api.CreateFunctionArgs{
+			Code:         &api.CodeFile{ZipFile: zipFile},
+			FunctionName: config.FunctionName,
+			Handler:      config.Handler,
+			Runtime:      config.Runtime,
+			MemorySize:   config.MemorySize,
+			Timeout:      config.Timeout,
+			Description:  config.Description,
+			Environment:  config.Environment,
+			VpcConfig:    config.VpcConfig,
+			LogType:      config.LogType,
+			LogBosDir:    config.LogBosDir,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Function = (*api.Function)(created)
+		result.Created, result.CodeUpdated = true, true
+	} else {
+		if existing.Configuration.CodeSha256 != result.CodeSha256 {
+			if _, err := c.UpdateFunctionCode(&api.UpdateFunctionCodeArgs{
+				FunctionName: config.FunctionName,
+				ZipFile:      zipFile,
+			}); err != nil {
+				return nil, err
+			}
+			result.CodeUpdated = true
+		}
+		// Keep the current settings which are not given by the config
+		current := existing.Configuration
+		args := &api.UpdateFunctionConfigurationArgs{
+			FunctionName: config.FunctionName,
+			Timeout:      config.Timeout,
+			MemorySize:   config.MemorySize,
+			Description:  stringOr(config.Description, current.Description),
+			Handler:      stringOr(config.Handler, current.Handler),
+			Runtime:      stringOr(config.Runtime, current.Runtime),
+			Environment:  config.Environment,
+			VpcConfig:    config.VpcConfig,
+			LogType:      stringOr(config.LogType, current.LogType),
+			LogBosDir:    stringOr(config.LogBosDir, current.LogBosDir),
+		}
+		if args.Environment == nil {
+			args.Environment = current.Environment
+		}
+		if args.VpcConfig == nil {
+			args.VpcConfig = current.VpcConfig
+		}
+		updated, err := c.UpdateFunctionConfiguration(args)
+		if err != nil {
+			return nil, err
+		}
+		result.Function = (*api.Function)(updated)
+	}
+
+	if config.Publish {
+		published, err := c.PublishVersion(&api.PublishVersionArgs{
+			FunctionName: config.FunctionName,
+			Description:  config.VersionDescription,
+			CodeSha256:   result.CodeSha256,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Version = published.Version
+	}
+
+	for _, name := range config.Aliases {
+		alias, err := c.pointAlias(config.FunctionName, name, result.Version)
+		if err != nil {
+			return nil, err
+		}
+		result.Aliases = append(result.Aliases, alias)
+	}
+	return result, nil
+}
+
+// pointAlias - create the alias of the version or update it to the version if it exists
+func (c *Client) pointAlias(functionName, aliasName, version string) (*api.Alias, error) {
+	_, err := c.GetAlias(&api.GetAliasArgs{FunctionName: functionName, AliasName: aliasName})
+	if err != nil {
+		if !isNotFound(err) {
+			return nil, err
+		}
+		created, err := c.CreateAlias(&api.CreateAliasArgs{
+			FunctionName:    functionName,
+			FunctionVersion: version,
+			Name:            aliasName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return (*api.Alias)(created), nil
+	}
+	updated, err := c.UpdateAlias(&api.UpdateAliasArgs{
+		FunctionName:    functionName,
+		AliasName:       aliasName,
+		FunctionVersion: version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return (*api.Alias)(updated), nil
+}
+
+func stringOr(value, current string) string {
+	if len(value) == 0 {
+		return current
+	}
+	return value
+}
+
+func isNotFound(err error) bool {
+	e, ok := err.(*bce.BceServiceError)
+	return ok && e.StatusCode == http.StatusNotFound
+}
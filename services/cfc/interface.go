@@ -0,0 +1,70 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the CFC client
+
+package cfc
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/cfc/api"
+)
+
+// Interface defines all the operations of the CFC client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	Invocations(args *api.InvocationsArgs) (*api.InvocationsResult, error)
+	Invoke(args *api.InvocationsArgs) (*api.InvocationsResult, error)
+	InvokeStream(args *api.InvocationsArgs) (*api.InvokeStreamResult, error)
+	ListFunctions(args *api.ListFunctionsArgs) (*api.ListFunctionsResult, error)
+	GetFunction(args *api.GetFunctionArgs) (*api.GetFunctionResult, error)
+	CreateFunction(args *api.CreateFunctionArgs) (*api.CreateFunctionResult, error)
+	DeleteFunction(args *api.DeleteFunctionArgs) error
+	UpdateFunctionCode(args *api.UpdateFunctionCodeArgs) (*api.UpdateFunctionCodeResult, error)
+	GetFunctionConfiguration(args *api.GetFunctionConfigurationArgs) (*api.GetFunctionConfigurationResult, error)
+	UpdateFunctionConfiguration(args *api.UpdateFunctionConfigurationArgs) (*api.UpdateFunctionConfigurationResult, error)
+	ListVersionsByFunction(args *api.ListVersionsByFunctionArgs) (*api.ListVersionsByFunctionResult, error)
+	PublishVersion(args *api.PublishVersionArgs) (*api.PublishVersionResult, error)
+	ListAliases(args *api.ListAliasesArgs) (*api.ListAliasesResult, error)
+	CreateAlias(args *api.CreateAliasArgs) (*api.CreateAliasResult, error)
+	GetAlias(args *api.GetAliasArgs) (*api.GetAliasResult, error)
+	UpdateAlias(args *api.UpdateAliasArgs) (*api.UpdateAliasResult, error)
+	DeleteAlias(args *api.DeleteAliasArgs) error
+	ListTriggers(args *api.ListTriggersArgs) (*api.ListTriggersResult, error)
+	CreateTrigger(args *api.CreateTriggerArgs) (*api.CreateTriggerResult, error)
+	UpdateTrigger(args *api.UpdateTriggerArgs) (*api.UpdateTriggerResult, error)
+	DeleteTrigger(args *api.DeleteTriggerArgs) error
+	SetReservedConcurrentExecutions(args *api.ReservedConcurrentExecutionsArgs) error
+	DeleteReservedConcurrentExecutions(args *api.DeleteReservedConcurrentExecutionsArgs) error
+	ListEventSource(args *api.ListEventSourceArgs) (*api.ListEventSourceResult, error)
+	GetEventSource(args *api.GetEventSourceArgs) (*api.GetEventSourceResult, error)
+	UpdateEventSource(args *api.UpdateEventSourceArgs) (*api.UpdateEventSourceResult, error)
+	CreateEventSource(args *api.CreateEventSourceArgs) (*api.CreateEventSourceResult, error)
+	DeleteEventSource(args *api.DeleteEventSourceArgs) error
+	DeployFunction(dir string, config *DeployConfig) (*DeployResult, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
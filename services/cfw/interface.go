@@ -0,0 +1,50 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the CFW client
+
+package cfw
+
+// Interface defines all the operations of the CFW client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	BindCfw(cfwId string, body *BindCfwRequest) error
+	CreateCfw(body *CreateCfwRequest) (*CreateCfwResponse, error)
+	CreateCfwRule(cfwId string, body *CreateCfwRuleRequest) error
+	DeleteCfw(cfwId string) error
+	DeleteCfwRule(cfwId string, body *DeleteCfwRuleRequest) error
+	DisableCfw(cfwId string, body *DisableCfwRequest) error
+	EnableCfw(cfwId string, body *EnableCfwRequest) error
+	GetCfw(cfwId string) (*GetCfwResponse, error)
+	ListCfw(listCfwArgs *ListCfwArgs) (*ListCfwResponse, error)
+	ListInstance(body *ListInstanceRequest) (*ListInstanceResponse, error)
+	UnbindCfw(cfwId string, body *UnbindCfwRequest) error
+	UpdateCfw(cfwId string, body *UpdateCfwRequest) error
+	UpdateCfwRule(cfwId string, cfwRuleId string, body *UpdateCfwRuleRequest) error
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the DCC client
+
+package dcc
+
+// Interface defines all the operations of the DCC client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	ListDedicatedHosts(args *ListDedicatedHostArgs) (list *ListDedicatedHostResult, err error)
+	GetDedicatedHostDetail(hostID string) (ret *GetDedicatedHostDetailResult, err error)
+	PurchaseReserved(hostID string, args *PurchaseReservedArgs) (err error)
+	Create(args *CreateArgs) (ret *CreateResult, err error)
+	BindTag(dccID string, args *BindTagArgs) (err error)
+	UnbindTag(dccID string, args *BindTagArgs) (err error)
+	CreateInstance(args *CreateInstanceArgs) (ret *CreateInstanceResult, err error)
+	ModityInstance(instanceID string, args *ModityInstanceArgs) (err error)
+	BindTagforInstance(instanceID string, args *BindTagArgs) error
+	UnbindTagforInstance(instanceID string, args *BindTagArgs) error
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the DDC client
+
+package ddc
+
+// Interface defines all the operations of the DDC client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateInstance(args *CreateInstanceArgs) (*CreateResult, error)
+	CreateRds(args *CreateRdsArgs) (*CreateResult, error)
+	SupplyVpcInfo(newArgs *CreateInstanceArgs, args *CreateRdsArgs) (*CreateInstanceArgs, error)
+	SupplyZoneAndSubnetInfo(newArgs *CreateInstanceArgs, args *CreateRdsArgs) (*CreateInstanceArgs, error)
+	UnDefaultVpcInfo(newArgs *CreateInstanceArgs, args *CreateRdsArgs) (*CreateInstanceArgs, error)
+	CreateReadReplica(args *CreateReadReplicaArgs) (*CreateResult, error)
+	UpdateRoGroup(roGroupId string, args *UpdateRoGroupArgs) error
+	UpdateRoGroupReplicaWeight(roGroupId string, args *UpdateRoGroupWeightArgs) error
+	ReBalanceRoGroup(roGroupId string) error
+	CreateDeploySet(poolId string, args *CreateDeployRequest) error
+	UpdateDeploySet(poolId string, deployId string, args *UpdateDeployRequest) error
+	ListRds(marker *ListRdsArgs) (*ListRdsResult, error)
+	GetDdcDetail(instanceId string) (*InstanceModelResult, error)
+	GetDetail(instanceId string) (*Instance, error)
+	DeleteRds(instanceIds string) error
+	RebootInstance(instanceId string, args *RebootArgs) error
+	UpdateInstanceName(instanceId string, args *UpdateInstanceNameArgs) error
+	GetBackupList(instanceId string) (*GetBackupListResult, error)
+	GetZoneList() (*GetZoneListResult, error)
+	ListSubnets(args *ListSubnetsArgs) (*ListSubnetsResult, error)
+	ListPool(marker *Marker) (*ListPoolResult, error)
+	ListDeploySets(poolId string, marker *Marker) (*ListDeploySetResult, error)
+	DeleteDeploySet(poolId string, deploySetId string) error
+	GetDeploySet(poolId string, deploySetId string) (*DeploySet, error)
+	GetSecurityIps(instanceId string) (*GetSecurityIpsResult, error)
+	UpdateSecurityIps(instacneId string, args *UpdateSecurityIpsArgs) error
+	ListParameters(instanceId string) (*ListParametersResult, error)
+	UpdateParameter(instanceId string, args *UpdateParameterArgs) error
+	CreateBackup(instanceId string) error
+	GetBackupDetail(instanceId string, snapshotId string) (*BackupDetailResult, error)
+	ModifyBackupPolicy(instanceId string, args *BackupPolicy) error
+	GetBinlogList(instanceId string, datetime string) (*BinlogListResult, error)
+	GetBinlogDetail(instanceId string, binlog string) (*BinlogDetailResult, error)
+	SwitchInstance(instanceId string, args *SwitchArgs) error
+	CreateDatabase(instanceId string, args *CreateDatabaseArgs) error
+	DeleteDatabase(instanceId, dbName string) error
+	UpdateDatabaseRemark(instanceId string, dbName string, args *UpdateDatabaseRemarkArgs) error
+	GetDatabase(instanceId, dbName string) (*Database, error)
+	ListDatabase(instanceId string) (*ListDatabaseResult, error)
+	CreateAccount(instanceId string, args *CreateAccountArgs) error
+	DeleteAccount(instanceId, accountName string) error
+	UpdateAccountPassword(instanceId string, accountName string, args *UpdateAccountPasswordArgs) error
+	UpdateAccountDesc(instanceId string, accountName string, args *UpdateAccountDescArgs) error
+	UpdateAccountPrivileges(instanceId string, accountName string, args *UpdateAccountPrivilegesArgs) error
+	GetAccount(instanceId, accountName string) (*Account, error)
+	ListAccount(instanceId string) (*ListAccountResult, error)
+	ListRoGroup(instanceId string) (*ListRoGroupResult, error)
+	ListVpc() (*[]VpcVo, error)
+	GetMaintainTime(instanceId string) (*MaintainTime, error)
+	UpdateMaintainTime(instanceId string, args *MaintainTime) error
+	ListRecycleInstances(marker *Marker) (*RecyclerInstanceList, error)
+	RecoverRecyclerInstances(instanceIds []string) error
+	DeleteRecyclerInstances(instanceIds []string) error
+	ListSecurityGroupByVpcId(vpcId string) (*[]SecurityGroup, error)
+	ListSecurityGroupByInstanceId(instanceId string) (*ListSecurityGroupResult, error)
+	BindSecurityGroups(args *SecurityGroupArgs) error
+	UnBindSecurityGroups(args *SecurityGroupArgs) error
+	ReplaceSecurityGroups(args *SecurityGroupArgs) error
+	ListLogByInstanceId(instanceId string, args *ListLogArgs) (*[]Log, error)
+	GetLogById(instanceId, logId string, args *GetLogArgs) (*LogDetail, error)
+	LazyDropCreateHardLink(instanceId, dbName, tableName string) error
+	LazyDropDeleteHardLink(instanceId, dbName, tableName string) error
+	ResizeRds(instanceId string, args *ResizeRdsArgs) error
+	ModifySyncMode(instanceId string, args *ModifySyncModeArgs) error
+	GetDisk(instanceId string) (*Disk, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the DDC v2 client
+
+package ddcrds
+
+import (
+	"github.com/baidubce/bce-sdk-go/auth"
+	"github.com/baidubce/bce-sdk-go/bce"
+)
+
+// Interface defines all the operations of the DDC v2 client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	Config(config *bce.BceClientConfiguration)
+	ConfigEndpoint(endPoint string)
+	ConfigRegion(region string)
+	ConfigRetry(policy bce.RetryPolicy)
+	ConfigSignOption(option *auth.SignOptions)
+	ConfigSignOptionHeadersToSign(header map[string]struct{})
+	ConfigSignOptionExpireSeconds(seconds int)
+	ConfigCredentials(credentials *auth.BceCredentials)
+	ConfigProxyUrl(proxyUrl string)
+	ConfigConnectionTimeoutInMillis(millis int)
+	CreateRds(args *CreateRdsArgs, productType string) (*CreateResult, error)
+	CreateReadReplica(args *CreateReadReplicaArgs) (*CreateResult, error)
+	UpdateRoGroup(roGroupId string, args *UpdateRoGroupArgs, productType string) error
+	UpdateRoGroupReplicaWeight(roGroupId string, args *UpdateRoGroupWeightArgs, productType string) error
+	ReBalanceRoGroup(roGroupId, productType string) error
+	CreateRdsProxy(args *CreateRdsProxyArgs) (*CreateResult, error)
+	ListPage(args *ListPageArgs) (*ListPageResult, error)
+	ListRds(marker *ListRdsArgs) (*ListRdsResult, error)
+	GetDetail(instanceId string) (*Instance, error)
+	DeleteRds(instanceIds string) error
+	ResizeRds(instanceId string, args *ResizeRdsArgs) (*OrderIdResponse, error)
+	CreateAccount(instanceId string, args *CreateAccountArgs) error
+	ListAccount(instanceId string) (*ListAccountResult, error)
+	GetAccount(instanceId, accountName string) (*Account, error)
+	DeleteAccount(instanceId, accountName string) error
+	RebootInstance(instanceId string) error
+	RebootInstanceWithArgs(instanceId string, args *RebootArgs) (*MaintainTaskIdResult, error)
+	UpdateInstanceName(instanceId string, args *UpdateInstanceNameArgs) error
+	ModifySyncMode(instanceId string, args *ModifySyncModeArgs) error
+	ModifyEndpoint(instanceId string, args *ModifyEndpointArgs) error
+	ModifyPublicAccess(instanceId string, args *ModifyPublicAccessArgs) error
+	GetBackupList(instanceId string, args *GetBackupListArgs) (*GetBackupListResult, error)
+	GetZoneList(productType string) (*GetZoneListResult, error)
+	ListSubnets(args *ListSubnetsArgs, productType string) (*ListSubnetsResult, error)
+	GetSecurityIps(instanceId string) (*GetSecurityIpsResult, error)
+	UpdateSecurityIps(instanceId, Etag string, args *UpdateSecurityIpsArgs) error
+	ListParameters(instanceId string) (*ListParametersResult, error)
+	UpdateParameter(instanceId, Etag string, args *UpdateParameterArgs) (*ProducedMaintainTaskResult, error)
+	CreateDeploySet(poolId string, args *CreateDeployRequest) (*CreateDeployResult, error)
+	UpdateDeploySet(poolId, deployId string, args *UpdateDeployRequest) error
+	ListDeploySets(poolId string, marker *Marker) (*ListDeploySetResult, error)
+	ListPool(marker *Marker, productType string) (*ListPoolResult, error)
+	GetDeploySet(poolId string, deploySetId string) (*DeploySet, error)
+	DeleteDeploySet(poolId string, deploySetId string) error
+	CreateBackup(instanceId string) error
+	ModifyBackupPolicy(instanceId string, args *BackupPolicy) error
+	GetBackupDetail(instanceId string, snapshotId string) (*BackupDetailResult, error)
+	GetBinlogList(instanceId string, datetime string) (*BinlogListResult, error)
+	GetBinlogDetail(instanceId string, binlog string) (*BinlogDetailResult, error)
+	SwitchInstance(instanceId string, args *SwitchArgs) (*ProducedMaintainTaskResult, error)
+	CreateDatabase(instanceId string, args *CreateDatabaseArgs) error
+	DeleteDatabase(instanceId, dbName string) error
+	UpdateDatabaseRemark(instanceId string, dbName string, args *UpdateDatabaseRemarkArgs) error
+	GetDatabase(instanceId, dbName string) (*Database, error)
+	ListDatabase(instanceId string) (*ListDatabaseResult, error)
+	GetTableAmount(args *GetTableAmountArgs) (*TableAmountResult, error)
+	GetDatabaseDiskUsage(instanceId, dbName string) (*DatabaseDiskUsageResult, error)
+	GetRecoverableDateTime(instanceId string) (*GetRecoverableDateTimeResult, error)
+	RecoverToSourceInstanceByDatetime(instanceId string, args *RecoverInstanceArgs) (*MaintainTaskIdResult, error)
+	UpdateAccountPassword(instanceId string, accountName string, args *UpdateAccountPasswordArgs) error
+	UpdateAccountDesc(instanceId string, accountName string, args *UpdateAccountDescArgs) error
+	UpdateAccountPrivileges(instanceId string, accountName string, args *UpdateAccountPrivilegesArgs) error
+	ListRoGroup(instanceId string) (*ListRoGroupResult, error)
+	ListVpc(productType string) (*[]VpcVo, error)
+	AutoRenew(args *AutoRenewArgs, productType string) error
+	GetMaintainTime(instanceId string) (*MaintainTime, error)
+	UpdateMaintainTime(instanceId string, args *MaintainTime) error
+	ListRecycleInstances(marker *Marker, productType string) (*RecyclerInstanceList, error)
+	RecoverRecyclerInstances(instanceIds []string) (*OrderIdResponse, error)
+	DeleteRecyclerInstances(instanceIds []string) error
+	ListSecurityGroupByVpcId(vpcId string) (*[]SecurityGroup, error)
+	ListSecurityGroupByInstanceId(instanceId string) (*ListSecurityGroupResult, error)
+	BindSecurityGroups(args *SecurityGroupArgs) error
+	UnBindSecurityGroups(args *SecurityGroupArgs) error
+	ReplaceSecurityGroups(args *SecurityGroupArgs) error
+	ListLogByInstanceId(instanceId string, args *ListLogArgs) (*[]Log, error)
+	GetLogById(instanceId, logId string, args *GetLogArgs) (*LogDetail, error)
+	LazyDropCreateHardLink(instanceId, dbName, tableName string) error
+	LazyDropDeleteHardLink(instanceId, dbName, tableName string) (*MaintainTaskIdResult, error)
+	GetMachineInfo(instanceId string) (*MachineInfo, error)
+	GetDisk(instanceId string) (*Disk, error)
+	GetResidual(poolId string) (*GetResidualResult, error)
+	GetFlavorCapacity(poolId string, args *GetFlavorCapacityArgs) (*GetFlavorCapacityResult, error)
+	KillSession(instanceId string, args *KillSessionArgs) (*KillSessionResult, error)
+	GetKillSessionTask(instanceId string, taskId int) (*GetKillSessionTaskResult, error)
+	GetMaintainTaskList(args *GetMaintainTaskListArgs) (*ListMaintainTaskResult, error)
+	GetMaintainTaskDetail(taskIds string) (*MaintainTaskDetailList, error)
+	ExecuteMaintainTaskImmediately(taskId string) error
+	CancelMaintainTask(taskId string) error
+	GetAccessLog(date string) (*AccessLog, error)
+	GetErrorLogs(args *GetErrorLogsArgs) (*ErrorLogsResponse, error)
+	GetSlowLogs(args *GetSlowLogsArgs) (*SlowLogsResponse, error)
+	GetInstanceBackupStatus(instanceId string) (*GetBackupStatusResponse, error)
+	InstanceVersionRollBack(instanceId string, args *InstanceVersionRollBackArg) (*MaintainTaskIdResult, error)
+	InstanceVersionUpgrade(instanceId string, args *InstanceVersionUpgradeArg) (*MaintainTaskIdResult, error)
+	GetInstanceSyncDelay(instanceId string) (*InstanceSyncDelayResponse, error)
+	InstanceSyncDelayReplication(instanceId string, args *InstanceSyncDelayReplicationArg) (*InstanceSyncDelayReplicationResponse, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
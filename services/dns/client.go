@@ -0,0 +1,63 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// client.go - define the client for Cloud DNS service
+
+// Package dns defines the Cloud DNS services of BCE, which manage the public zones and their
+// record sets.
+package dns
+
+import "github.com/baidubce/bce-sdk-go/bce"
+
+const (
+	URI_PREFIX = bce.URI_PREFIX + "v1"
+
+	DEFAULT_ENDPOINT = "dns.baidubce.com"
+
+	REQUEST_ZONE_URL = "/dns/zone"
+
+	REQUEST_RECORD_URL = "/record"
+)
+
+// Client of DNS service is a kind of BceClient, so derived from BceClient
+type Client struct {
+	*bce.BceClient
+}
+
+func NewClient(ak, sk, endPoint string) (*Client, error) {
+	if len(endPoint) == 0 {
+		endPoint = DEFAULT_ENDPOINT
+	}
+	client, err := bce.NewBceClientWithAkSk(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{client}, nil
+}
+
+func getZoneUri() string {
+	return URI_PREFIX + REQUEST_ZONE_URL
+}
+
+func getZoneUriWithName(zoneName string) string {
+	return URI_PREFIX + REQUEST_ZONE_URL + "/" + zoneName
+}
+
+func getRecordUri(zoneName string) string {
+	return URI_PREFIX + REQUEST_ZONE_URL + "/" + zoneName + REQUEST_RECORD_URL
+}
+
+func getRecordUriWithId(zoneName, recordId string) string {
+	return URI_PREFIX + REQUEST_ZONE_URL + "/" + zoneName + REQUEST_RECORD_URL + "/" + recordId
+}
@@ -0,0 +1,145 @@
+package dns
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/util"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+var (
+	DNS_CLIENT *Client
+	RECORD_ID  string
+
+	// set this value before start test
+	ZONE_NAME = "sdk-test.com"
+)
+
+// For security reason, ak/sk should not hard write here.
+type Conf struct {
+	AK       string
+	SK       string
+	Endpoint string
+}
+
+func init() {
+	_, f, _, _ := runtime.Caller(0)
+	conf := filepath.Join(filepath.Dir(f), "config.json")
+	fp, err := os.Open(conf)
+	if err != nil {
+		log.Fatal("config json file of ak/sk not given:", conf)
+		os.Exit(1)
+	}
+	decoder := json.NewDecoder(fp)
+	confObj := &Conf{}
+	decoder.Decode(confObj)
+
+	DNS_CLIENT, _ = NewClient(confObj.AK, confObj.SK, confObj.Endpoint)
+	log.SetLogLevel(log.WARN)
+}
+
+// ExpectEqual is the helper function for test each case
+func ExpectEqual(alert func(format string, args ...interface{}),
+	expected interface{}, actual interface{}) bool {
+	expectedValue, actualValue := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	equal := false
+	switch {
+	case expected == nil && actual == nil:
+		return true
+	case expected != nil && actual == nil:
+		equal = expectedValue.IsNil()
+	case expected == nil && actual != nil:
+		equal = actualValue.IsNil()
+	default:
+		if actualType := reflect.TypeOf(actual); actualType != nil {
+			if expectedValue.IsValid() && expectedValue.Type().ConvertibleTo(actualType) {
+				equal = reflect.DeepEqual(expectedValue.Convert(actualType).Interface(), actual)
+			}
+		}
+	}
+	if !equal {
+		_, file, line, _ := runtime.Caller(1)
+		alert("%s:%d: missmatch, expect %v but %v", file, line, expected, actual)
+		return false
+	}
+	return true
+}
+
+func TestRecordArgsCheck(t *testing.T) {
+	cases := []struct {
+		args  *RecordArgs
+		valid bool
+	}{
+		{&RecordArgs{Rr: "www", Type: RECORD_TYPE_A, Value: "1.2.3.4"}, true},
+		{&RecordArgs{Rr: "www", Type: RECORD_TYPE_A, Value: "::1"}, false},
+		{&RecordArgs{Rr: "www", Type: RECORD_TYPE_AAAA, Value: "2400:da00::1"}, true},
+		{&RecordArgs{Rr: "www", Type: RECORD_TYPE_AAAA, Value: "1.2.3.4"}, false},
+		{&RecordArgs{Rr: "@", Type: RECORD_TYPE_MX, Value: "mx.sdk-test.com"}, false},
+		{&RecordArgs{Rr: "@", Type: RECORD_TYPE_MX, Value: "mx.sdk-test.com", Priority: 10}, true},
+		{&RecordArgs{Rr: "www", Type: RECORD_TYPE_CNAME, Value: "a.b.com", Weight: 101}, false},
+		{&RecordArgs{Rr: "www", Type: "SRV", Value: "a.b.com"}, false},
+		{&RecordArgs{Type: RECORD_TYPE_TXT, Value: "v=spf1"}, false},
+	}
+	for i, c := range cases {
+		err := c.args.Check()
+		if ExpectEqual(t.Errorf, c.valid, err == nil) == false {
+			t.Logf("case %d: %v", i, err)
+		}
+	}
+}
+
+func TestClient_CreateZone(t *testing.T) {
+	err := DNS_CLIENT.CreateZone(&CreateZoneArgs{Name: ZONE_NAME, ClientToken: getClientToken()})
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_ListAllZones(t *testing.T) {
+	_, err := DNS_CLIENT.ListAllZones(ZONE_NAME)
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	args := &RecordArgs{
+		Rr:          "www",
+		Type:        RECORD_TYPE_A,
+		Value:       "1.2.3.4",
+		Ttl:         300,
+		Line:        LINE_TELECOM,
+		Weight:      50,
+		ClientToken: getClientToken(),
+	}
+	result, err := DNS_CLIENT.CreateRecord(ZONE_NAME, args)
+	ExpectEqual(t.Errorf, nil, err)
+	if err == nil {
+		RECORD_ID = result.Id
+	}
+}
+
+func TestClient_ListAllRecords(t *testing.T) {
+	_, err := DNS_CLIENT.ListAllRecords(ZONE_NAME, "www")
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_DisableRecord(t *testing.T) {
+	err := DNS_CLIENT.DisableRecord(ZONE_NAME, RECORD_ID, getClientToken())
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	err := DNS_CLIENT.DeleteRecord(ZONE_NAME, RECORD_ID, getClientToken())
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_DeleteZone(t *testing.T) {
+	err := DNS_CLIENT.DeleteZone(ZONE_NAME, getClientToken())
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func getClientToken() string {
+	return util.NewUUID()
+}
@@ -0,0 +1,319 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// dns.go - the zone and record APIs definition supported by the DNS service
+
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+// CreateZone - create a public zone
+//
+// PARAMS:
+//     - args: the arguments to create a zone
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) CreateZone(args *CreateZoneArgs) error {
+	if args == nil || len(args.Name) == 0 {
+		return fmt.Errorf("please set the zone name")
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getZoneUri()).
+		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithBody(args).
+		Do()
+}
+
+// ListZone - list the zones with the specific parameters
+//
+// PARAMS:
+//     - args: the arguments to list zones, the Name filters the zones by name
+// RETURNS:
+//     - *ListZoneResult: the zones of this page and the marker of the next page
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListZone(args *ListZoneArgs) (*ListZoneResult, error) {
+	if args == nil {
+		args = &ListZoneArgs{}
+	}
+	if args.MaxKeys <= 0 || args.MaxKeys > MAX_LIST_KEYS {
+		args.MaxKeys = MAX_LIST_KEYS
+	}
+
+	result := &ListZoneResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getZoneUri()).
+		WithQueryParamFilter("name", args.Name).
+		WithQueryParamFilter("marker", args.Marker).
+		WithQueryParamFilter("maxKeys", strconv.Itoa(args.MaxKeys)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// ListAllZones - list all the zones by following the markers
+//
+// PARAMS:
+//     - name: the zone name to filter the zones, empty to list all
+// RETURNS:
+//     - []Zone: all the matched zones
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListAllZones(name string) ([]Zone, error) {
+	args := &ListZoneArgs{Name: name}
+	var zones []Zone
+	for {
+		res, err := c.ListZone(args)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, res.Zones...)
+		if !res.IsTruncated || len(res.NextMarker) == 0 {
+			return zones, nil
+		}
+		args.Marker = res.NextMarker
+	}
+}
+
+// DeleteZone - delete a zone and all its records
+//
+// PARAMS:
+//     - zoneName: the name of the zone
+//     - clientToken: optional parameter, an Idempotent Token
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) DeleteZone(zoneName, clientToken string) error {
+	if len(zoneName) == 0 {
+		return fmt.Errorf("please set the zone name")
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.DELETE).
+		WithURL(getZoneUriWithName(zoneName)).
+		WithQueryParamFilter("clientToken", clientToken).
+		Do()
+}
+
+// CreateRecord - create a record in the zone
+//
+// PARAMS:
+//     - zoneName: the name of the zone
+//     - args: the arguments of the record, with the line and weight for the routing
+// RETURNS:
+//     - *CreateRecordResult: the id of the new record
+//     - error: nil if success otherwise the specific error
+func (c *Client) CreateRecord(zoneName string, args *RecordArgs) (*CreateRecordResult, error) {
+	if len(zoneName) == 0 {
+		return nil, fmt.Errorf("please set the zone name")
+	}
+	if err := args.Check(); err != nil {
+		return nil, err
+	}
+
+	result := &CreateRecordResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getRecordUri(zoneName)).
+		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithBody(args).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// ListRecord - list the records of the zone with the specific parameters
+//
+// PARAMS:
+//     - zoneName: the name of the zone
+//     - args: the arguments to list records, the Rr and Id filter the records
+// RETURNS:
+//     - *ListRecordResult: the records of this page and the marker of the next page
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListRecord(zoneName string, args *ListRecordArgs) (*ListRecordResult, error) {
+	if len(zoneName) == 0 {
+		return nil, fmt.Errorf("please set the zone name")
+	}
+	if args == nil {
+		args = &ListRecordArgs{}
+	}
+	if args.MaxKeys <= 0 || args.MaxKeys > MAX_LIST_KEYS {
+		args.MaxKeys = MAX_LIST_KEYS
+	}
+
+	result := &ListRecordResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getRecordUri(zoneName)).
+		WithQueryParamFilter("rr", args.Rr).
+		WithQueryParamFilter("id", args.Id).
+		WithQueryParamFilter("marker", args.Marker).
+		WithQueryParamFilter("maxKeys", strconv.Itoa(args.MaxKeys)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// ListAllRecords - list all the records of the zone by following the markers
+//
+// PARAMS:
+//     - zoneName: the name of the zone
+//     - rr: the host record to filter the records, empty to list all
+// RETURNS:
+//     - []Record: all the matched records
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListAllRecords(zoneName, rr string) ([]Record, error) {
+	args := &ListRecordArgs{Rr: rr}
+	var records []Record
+	for {
+		res, err := c.ListRecord(zoneName, args)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, res.Records...)
+		if !res.IsTruncated || len(res.NextMarker) == 0 {
+			return records, nil
+		}
+		args.Marker = res.NextMarker
+	}
+}
+
+// UpdateRecord - update a record of the zone
+//
+// PARAMS:
+//     - zoneName: the name of the zone
+//     - recordId: the id of the record
+//     - args: the new arguments of the record
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) UpdateRecord(zoneName, recordId string, args *RecordArgs) error {
+	if len(zoneName) == 0 || len(recordId) == 0 {
+		return fmt.Errorf("please set the zone name and the record id")
+	}
+	if err := args.Check(); err != nil {
+		return err
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.PUT).
+		WithURL(getRecordUriWithId(zoneName, recordId)).
+		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithBody(args).
+		Do()
+}
+
+// EnableRecord - enable a paused record to answer the queries again
+//
+// PARAMS:
+//     - zoneName: the name of the zone
+//     - recordId: the id of the record
+//     - clientToken: optional parameter, an Idempotent Token
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) EnableRecord(zoneName, recordId, clientToken string) error {
+	return c.switchRecord(zoneName, recordId, clientToken, "enable")
+}
+
+// DisableRecord - pause a record without deleting it
+//
+// PARAMS:
+//     - zoneName: the name of the zone
+//     - recordId: the id of the record
+//     - clientToken: optional parameter, an Idempotent Token
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) DisableRecord(zoneName, recordId, clientToken string) error {
+	return c.switchRecord(zoneName, recordId, clientToken, "disable")
+}
+
+func (c *Client) switchRecord(zoneName, recordId, clientToken, action string) error {
+	if len(zoneName) == 0 || len(recordId) == 0 {
+		return fmt.Errorf("please set the zone name and the record id")
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.PUT).
+		WithURL(getRecordUriWithId(zoneName, recordId)).
+		WithQueryParamFilter("clientToken", clientToken).
+		WithQueryParam(action, "").
+		Do()
+}
+
+// DeleteRecord - delete a record of the zone
+//
+// PARAMS:
+//     - zoneName: the name of the zone
+//     - recordId: the id of the record
+//     - clientToken: optional parameter, an Idempotent Token
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) DeleteRecord(zoneName, recordId, clientToken string) error {
+	if len(zoneName) == 0 || len(recordId) == 0 {
+		return fmt.Errorf("please set the zone name and the record id")
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.DELETE).
+		WithURL(getRecordUriWithId(zoneName, recordId)).
+		WithQueryParamFilter("clientToken", clientToken).
+		Do()
+}
+
+// Check - validate the record arguments by the record type before sending the request
+//
+// RETURNS:
+//     - error: nil if valid otherwise the specific error
+func (args *RecordArgs) Check() error {
+	if args == nil {
+		return fmt.Errorf("please set the record arguments")
+	}
+	if len(args.Rr) == 0 || len(args.Value) == 0 {
+		return fmt.Errorf("please set the rr and the value of the record")
+	}
+	switch args.Type {
+	case RECORD_TYPE_A:
+		if ip := net.ParseIP(args.Value); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("the value of the A record should be an IPv4 address: %s", args.Value)
+		}
+	case RECORD_TYPE_AAAA:
+		if ip := net.ParseIP(args.Value); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("the value of the AAAA record should be an IPv6 address: %s", args.Value)
+		}
+	case RECORD_TYPE_MX:
+		if args.Priority <= 0 {
+			return fmt.Errorf("please set the priority of the MX record")
+		}
+	case RECORD_TYPE_CNAME, RECORD_TYPE_TXT:
+	default:
+		return fmt.Errorf("unsupported record type: %s", args.Type)
+	}
+	if args.Ttl < 0 {
+		return fmt.Errorf("the ttl of the record should not be negative: %d", args.Ttl)
+	}
+	if args.Weight < 0 || args.Weight > MAX_RECORD_WEIGHT {
+		return fmt.Errorf("the weight of the record should be in [0, %d]: %d",
+			MAX_RECORD_WEIGHT, args.Weight)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// model.go - definitions of the request arguments and results data structure model
+
+package dns
+
+const (
+	RECORD_TYPE_A     = "A"
+	RECORD_TYPE_AAAA  = "AAAA"
+	RECORD_TYPE_CNAME = "CNAME"
+	RECORD_TYPE_TXT   = "TXT"
+	RECORD_TYPE_MX    = "MX"
+)
+
+// the resolution lines of the geo routing, the records of the same name and type answer the
+// queries from the matched line, and the ones of the LINE_DEFAULT answer all the others
+const (
+	LINE_DEFAULT = "default"
+	LINE_TELECOM = "ct"
+	LINE_UNICOM  = "cnc"
+	LINE_MOBILE  = "cmnet"
+	LINE_EDU     = "edu"
+)
+
+const (
+	RECORD_STATUS_RUNNING = "running"
+	RECORD_STATUS_PAUSE   = "pause"
+
+	MAX_RECORD_WEIGHT = 100
+	MAX_LIST_KEYS     = 1000
+)
+
+type CreateZoneArgs struct {
+	Name        string `json:"name"`
+	ClientToken string `json:"-"`
+}
+
+type ListZoneArgs struct {
+	Name    string
+	Marker  string
+	MaxKeys int
+}
+
+type ListZoneResult struct {
+	Marker      string `json:"marker"`
+	IsTruncated bool   `json:"isTruncated"`
+	NextMarker  string `json:"nextMarker"`
+	MaxKeys     int    `json:"maxKeys"`
+	Zones       []Zone `json:"zones"`
+}
+
+type Zone struct {
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	ProductVersion string `json:"productVersion"`
+	CreateTime     string `json:"createTime"`
+	ExpireTime     string `json:"expireTime"`
+}
+
+// RecordArgs defines the record set to be created or updated. The Line selects the geo routing
+// line, and the records of the same Rr, Type and Line share the queries by their Weight.
+type RecordArgs struct {
+	Rr          string `json:"rr"`
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	Ttl         int    `json:"ttl,omitempty"`
+	Line        string `json:"line,omitempty"`
+	Weight      int    `json:"weight,omitempty"`
+	Priority    int    `json:"priority,omitempty"`
+	Description string `json:"description,omitempty"`
+	ClientToken string `json:"-"`
+}
+
+type CreateRecordResult struct {
+	Id string `json:"id"`
+}
+
+type ListRecordArgs struct {
+	Rr      string
+	Id      string
+	Marker  string
+	MaxKeys int
+}
+
+type ListRecordResult struct {
+	Marker      string   `json:"marker"`
+	IsTruncated bool     `json:"isTruncated"`
+	NextMarker  string   `json:"nextMarker"`
+	MaxKeys     int      `json:"maxKeys"`
+	Records     []Record `json:"records"`
+}
+
+type Record struct {
+	Id          string `json:"id"`
+	Rr          string `json:"rr"`
+	Status      string `json:"status"`
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	Ttl         int    `json:"ttl"`
+	Line        string `json:"line"`
+	Weight      int    `json:"weight"`
+	Priority    int    `json:"priority"`
+	Description string `json:"description"`
+}
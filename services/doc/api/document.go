@@ -20,8 +20,11 @@ package api
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 
+	"github.com/baidubce/bce-sdk-go/auth"
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/http"
 )
@@ -68,6 +71,44 @@ func RegisterDocument(cli bce.Client, regParam *RegDocumentParam) (*RegDocumentR
 	return result, nil
 }
 
+// GetDocumentByReference - get the document by the reference id given when registering, which is
+// used to find the document whose registration result is lost, eg: by the network failures
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - referenceId: the reference id of the document
+//     - queryParam: enable/disable https of cover url, nil means DefaultQueryDocumentParam
+// RETURNS:
+//     - *QueryDocumentResp: the document, DocExceptions.NoSuchDocument error if not registered
+//     - error: the return error if any occurs
+func GetDocumentByReference(cli bce.Client, referenceId string,
+	queryParam *QueryDocumentParam) (*QueryDocumentResp, error) {
+	if err := CheckReferenceId(referenceId); err != nil {
+		return nil, err
+	}
+	if queryParam == nil {
+		queryParam = DefaultQueryDocumentParam()
+	}
+	req := &bce.BceRequest{}
+	req.SetUri("/v2/document")
+	req.SetParam("referenceId", referenceId)
+	req.SetParam("https", strconv.FormatBool(queryParam.Https))
+	req.SetMethod(http.GET)
+	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &QueryDocumentResp{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // PublishDocument - publish document
 //
 // PARAMS:
@@ -98,17 +139,18 @@ func PublishDocument(cli bce.Client, documentId string) error {
 // PARAMS:
 //     - cli: the client agent which can perform sending request
 //     - documentId: id of document in doc service
-//     - queryParam: enable/disable https of coverl url
+//     - queryParam: enable/disable https of coverl url, nil means DefaultQueryDocumentParam
 // RETURNS:
 //     - *QueryDocumentResp
 //     - error: the return error if any occurs
 func QueryDocument(cli bce.Client, documentId string, queryParam *QueryDocumentParam) (*QueryDocumentResp, error) {
+	if queryParam == nil {
+		queryParam = DefaultQueryDocumentParam()
+	}
 	req := &bce.BceRequest{}
 	urlPath := fmt.Sprintf("/v2/document/%s", documentId)
 	req.SetUri(urlPath)
-	if queryParam != nil {
-		req.SetParam("https", strconv.FormatBool(queryParam.Https))
-	}
+	req.SetParam("https", strconv.FormatBool(queryParam.Https))
 	req.SetMethod(http.GET)
 	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
 	resp := &bce.BceResponse{}
@@ -130,18 +172,38 @@ func QueryDocument(cli bce.Client, documentId string, queryParam *QueryDocumentP
 // PARAMS:
 //     - cli: the client agent which can perform sending request
 //     - documentId: id of document in doc service
-//     - readParam: expiration time of the doc's html
+//     - readParam: expiration time of the doc's html and the access control of the token,
+//       such as the watermark, allowed referrer domains and readable page range, nil means
+//       DefaultReadDocumentParam
 // RETURNS:
 //     - *ReadDocumentResp
 //     - error: the return error if any occurs
 func ReadDocument(cli bce.Client, documentId string, readParam *ReadDocumentParam) (*ReadDocumentResp, error) {
+	if readParam == nil {
+		readParam = DefaultReadDocumentParam()
+	}
+	if err := readParam.Check(); err != nil {
+		return nil, err
+	}
 	req := &bce.BceRequest{}
 	urlPath := fmt.Sprintf("/v2/document/%s", documentId)
 	req.SetUri(urlPath)
 	req.SetParam("read", "")
-	if readParam != nil {
+	if readParam.ExpireInSeconds > 0 {
 		req.SetParam("expireInSeconds", strconv.FormatInt(readParam.ExpireInSeconds, 10))
 	}
+	if len(readParam.Watermark) != 0 {
+		req.SetParam("watermark", readParam.Watermark)
+	}
+	if len(readParam.AllowedDomains) != 0 {
+		req.SetParam("allowedDomains", strings.Join(readParam.AllowedDomains, ","))
+	}
+	if readParam.PageStart > 0 {
+		req.SetParam("pageStart", strconv.FormatInt(readParam.PageStart, 10))
+	}
+	if readParam.PageEnd > 0 {
+		req.SetParam("pageEnd", strconv.FormatInt(readParam.PageEnd, 10))
+	}
 	req.SetMethod(http.GET)
 	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
 	resp := &bce.BceResponse{}
@@ -188,6 +250,229 @@ func GetImages(cli bce.Client, documentId string) (*GetImagesResp, error) {
 	return result, nil
 }
 
+// GetImagesWithParam - get the list of images generated by the document conversion, the image
+// urls are presigned with the given expiration so that they can be shared without the AK/SK
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - documentId: id of document in doc service
+//     - param: the expiration of the image urls, nil means the urls returned by the service
+// RETURNS:
+//     - *GetImagesResp: the images with the presigned urls
+//     - error: the return error if any occurs
+func GetImagesWithParam(cli bce.Client, documentId string, param *GetImagesParam) (*GetImagesResp, error) {
+	if err := param.Check(); err != nil {
+		return nil, err
+	}
+	result, err := GetImages(cli, documentId)
+	if err != nil || param == nil || param.ExpireInSeconds == 0 {
+		return result, err
+	}
+	for i := range result.Images {
+		signedUrl, err := presignImageUrl(cli, result.Images[i].Url, "", param.ExpireInSeconds)
+		if err != nil {
+			return nil, err
+		}
+		result.Images[i].Url = signedUrl
+	}
+	return result, nil
+}
+
+// GetHtmlArchive - get the converted HTML of the published h5 document as a zip archive stream
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - documentId: id of document in doc service
+// RETURNS:
+//     - *bce.StreamBody: the zip archive stream which must be closed by the caller
+//     - error: the return error if any occurs
+func GetHtmlArchive(cli bce.Client, documentId string) (*bce.StreamBody, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(fmt.Sprintf("/v2/document/%s", documentId))
+	req.SetParam("getHtml", "")
+	req.SetParam("format", "zip")
+	req.SetMethod(http.GET)
+
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	return resp.ParseStreamBody(), nil
+}
+
+// GetHtmlFiles - get the BOS location of the files of the converted HTML of the h5 document
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - documentId: id of document in doc service
+// RETURNS:
+//     - *GetHtmlFilesResp: the bucket and the objects of the HTML files
+//     - error: the return error if any occurs
+func GetHtmlFiles(cli bce.Client, documentId string) (*GetHtmlFilesResp, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(fmt.Sprintf("/v2/document/%s", documentId))
+	req.SetParam("getHtmlFiles", "")
+	req.SetMethod(http.GET)
+	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
+
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &GetHtmlFilesResp{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetText - get the text extracted from the pages of the document by the conversion, which is
+// only available for the published document whose conversion produces the text
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - documentId: id of document in doc service
+//     - param: the page range of the text, nil means DefaultGetTextParam of all pages
+// RETURNS:
+//     - *GetTextResp: the texts of the pages in order
+//     - error: the return error if any occurs
+func GetText(cli bce.Client, documentId string, param *GetTextParam) (*GetTextResp, error) {
+	if param == nil {
+		param = DefaultGetTextParam()
+	}
+	if err := param.Check(); err != nil {
+		return nil, err
+	}
+	req := &bce.BceRequest{}
+	req.SetUri(fmt.Sprintf("/v2/document/%s", documentId))
+	req.SetParam("getText", "")
+	if param.PageStart > 0 {
+		req.SetParam("pageStart", strconv.Itoa(param.PageStart))
+	}
+	if param.PageEnd > 0 {
+		req.SetParam("pageEnd", strconv.Itoa(param.PageEnd))
+	}
+	req.SetMethod(http.GET)
+	req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
+
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &GetTextResp{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetThumbnails - get the thumbnail urls of the document pages, the thumbnails are generated by
+// the image processing of BOS from the page images of the document converted to image
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - documentId: id of document in doc service
+//     - param: the size, page range and expiration of the thumbnails
+// RETURNS:
+//     - []ThumbnailResp: the thumbnails ordered by the page index
+//     - error: the return error if any occurs
+func GetThumbnails(cli bce.Client, documentId string, param *ThumbnailParam) ([]ThumbnailResp, error) {
+	if param == nil {
+		return nil, errors.New("the thumbnail param cannot be nil")
+	}
+	if err := param.Check(); err != nil {
+		return nil, err
+	}
+	images, err := GetImages(cli, documentId)
+	if err != nil {
+		return nil, err
+	}
+	process := "image/resize,m_lfit"
+	if param.Width > 0 {
+		process += ",w_" + strconv.Itoa(param.Width)
+	}
+	if param.Height > 0 {
+		process += ",h_" + strconv.Itoa(param.Height)
+	}
+	result := make([]ThumbnailResp, 0, len(images.Images))
+	for _, image := range images.Images {
+		if image.PageIndex < param.PageStart || (param.PageEnd != 0 && image.PageIndex > param.PageEnd) {
+			continue
+		}
+		thumbnailUrl, err := presignImageUrl(cli, image.Url, process, param.ExpireInSeconds)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ThumbnailResp{
+			PageIndex: image.PageIndex,
+			Width:     param.Width,
+			Height:    param.Height,
+			Url:       thumbnailUrl,
+		})
+	}
+	return result, nil
+}
+
+// presignImageUrl - add the image process parameter to the image url if given and presign it if
+// the expiration is positive, otherwise the signature issued by the service is kept
+func presignImageUrl(cli bce.Client, imageUrl, process string, expireInSeconds int) (string, error) {
+	u, err := url.Parse(imageUrl)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	if process != "" {
+		query.Set("x-bce-process", process)
+	}
+	conf := cli.GetBceClientConfig()
+	if expireInSeconds <= 0 || conf == nil {
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+	credentials := conf.Credentials
+	if conf.CredentialsProvider != nil {
+		if credentials, err = conf.CredentialsProvider.GetCredentials(); err != nil {
+			return "", err
+		}
+	}
+	if credentials == nil {
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+
+	query.Del("authorization")
+	query.Del(http.BCE_SECURITY_TOKEN)
+	if len(credentials.SessionToken) != 0 {
+		query.Set(http.BCE_SECURITY_TOKEN, credentials.SessionToken)
+	}
+	req := &bce.BceRequest{}
+	req.SetMethod(http.GET)
+	req.SetHost(u.Host)
+	req.SetUri(u.Path)
+	req.SetHeader(http.HOST, u.Host)
+	for k := range query {
+		req.SetParam(k, query.Get(k))
+	}
+	option := auth.SignOptions{
+		HeadersToSign: map[string]struct{}{"host": {}},
+		ExpireSeconds: expireInSeconds,
+	}
+	signer := &auth.BceV1Signer{}
+	signer.Sign(&req.Request, credentials, &option)
+	query.Set("authorization", req.Header(http.AUTHORIZATION))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
 // DeleteDocument - delete document in doc service
 //
 // PARAMS:
@@ -216,11 +501,14 @@ func DeleteDocument(cli bce.Client, documentId string) error {
 //
 // PARAMS:
 //     - cli: the client agent which can perform sending request
-//     - param: the optional arguments to list documents
+//     - param: the optional arguments to list documents, nil means DefaultListDocumentsParam
 // RETURNS:
 //     - *ListDocumentsResp: the result docments list structure
 //     - error: nil if ok otherwise the specific error
 func ListDocuments(cli bce.Client, listParam *ListDocumentsParam) (*ListDocumentsResp, error) {
+	if listParam == nil {
+		listParam = DefaultListDocumentsParam()
+	}
 	err := listParam.Check()
 	if err != nil {
 		return nil, err
@@ -238,6 +526,10 @@ func ListDocuments(cli bce.Client, listParam *ListDocumentsParam) (*ListDocument
 	if listParam.MaxSize != 0 {
 		req.SetParam("maxSize", strconv.FormatInt(listParam.MaxSize, 10))
 	}
+	if listParam.Order != "" {
+		req.SetParam("orderBy", "createTime")
+		req.SetParam("order", listParam.Order)
+	}
 
 	resp := &bce.BceResponse{}
 	if err := cli.SendRequest(req, resp); err != nil {
@@ -250,5 +542,14 @@ func ListDocuments(cli bce.Client, listParam *ListDocumentsParam) (*ListDocument
 	if err := resp.ParseJsonBody(result); err != nil {
 		return nil, err
 	}
+	if listParam.HasClientFilter() {
+		docs := make([]DocumentResp, 0, len(result.Docs))
+		for i := range result.Docs {
+			if listParam.Match(&result.Docs[i]) {
+				docs = append(docs, result.Docs[i])
+			}
+		}
+		result.Docs = docs
+	}
 	return result, nil
 }
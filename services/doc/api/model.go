@@ -17,62 +17,113 @@
 package api
 
 import (
-	"encoding/json"
-	"errors"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/util"
 )
 
 type StatusType string
 
+// MAX_WATERMARK_LENGTH is the max character count of the watermark of the read token
+const MAX_WATERMARK_LENGTH = 64
+
+// MAX_REFERENCE_ID_LENGTH is the max character count of the reference id of the document
+const MAX_REFERENCE_ID_LENGTH = 64
+
+// domainPattern matches the domain name with the optional leading wildcard label
+var domainPattern = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?\.)+[a-zA-Z]{2,}$`)
+
+// referenceIdPattern matches the reference id of the document
+var referenceIdPattern = regexp.MustCompile(`^[a-zA-Z0-9._:-]+$`)
+
 const (
 	DOC_TARGET_H5    = "h5"
 	DOC_TARGET_IMAGE = "image"
 
-	DOC_PUBLIC  = "PUBLIC"
-	DOC_PRIVATE = "PRIVATE"
+	DOC_PUBLIC   = "PUBLIC"
+	DOC_PRIVATE  = "PRIVATE"
+	DOC_BOS_EDIT = "bosEdit" // the source file is stored in the bucket owned by the user
 
 	DOC_STATUS_UPLOADING  StatusType = "UPLOADING"
 	DOC_STATUS_PROCESSING StatusType = "PROCESSING"
 	DOC_STATUS_PUBLISHED  StatusType = "PUBLISHED"
 	DOC_STATUS_FAILED     StatusType = "FAILED"
+
+	// the orders of the create time to list documents, the server default is used if not set
+	DOC_ORDER_ASC  = "asc"
+	DOC_ORDER_DESC = "desc"
 )
 
 type RegDocumentParam struct {
-	Title        string `json:"title"`        // must
-	Format       string `json:"format"`       // must，doc, docx, ppt, pptx, xls, xlsx, vsd, pot, pps, rtf, wps, et, dps, pdf, txt, epub
-	TargetType   string `json:"targetType"`   // h5|image, default: h5
-	Notification string `json:"notification"` // notification
-	Access       string `json:"access"`       // PUBLIC|PRIVATE, default: PUBLIC
+	Title        string `json:"title"`                  // must
+	Format       string `json:"format"`                 // must，doc, docx, ppt, pptx, xls, xlsx, vsd, pot, pps, rtf, wps, et, dps, pdf, txt, epub
+	TargetType   string `json:"targetType"`             // h5|image, default: h5
+	Notification string `json:"notification,omitempty"` // notification, 为空时不能传该参数，否则请求会报错
+	Access       string `json:"access"`                 // PUBLIC|PRIVATE|bosEdit, default: PUBLIC
+
+	// Bucket and Object specify the location in the bucket owned by the user to upload the source
+	// file to instead of the bucket of the DOC service, so that the source file is kept with the
+	// user's own lifecycle policies. The Bucket is required by the bosEdit access and the Object is
+	// generated by the service if it is empty. The bucket should authorize the DOC service to read.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+
+	// MD5 is the hex encoded MD5 digest of the source file, the service verifies the uploaded
+	// source file with it when publishing and fails the document if they are not the same.
+	MD5 string `json:"md5,omitempty"`
+
+	// ReferenceId is the unique id of the document given by the caller, eg: the id in the caller's
+	// own system. Registering with the same ReferenceId again returns the document registered at
+	// first instead of creating a new one, so that the registration can be retried safely after
+	// the network failures. It is rejected with the DocExceptions.ReferenceIdConflict error if
+	// the title or format is not the same as the registered document.
+	ReferenceId string `json:"referenceId,omitempty"`
+}
+
+// DOC_FORMATS are the document formats supported by the DOC service
+var DOC_FORMATS = []string{"doc", "docx", "ppt", "pptx", "xls", "xlsx", "vsd", "pot", "pps",
+	"rtf", "wps", "et", "dps", "pdf", "txt", "epub"}
+
+// Check - check the fields of the register param, the empty TargetType and Access use the default
+func (d *RegDocumentParam) Check() error {
+	v := &bce.Validator{}
+	v.Required("title", d.Title)
+	v.Required("format", d.Format)
+	v.OneOf("format", strings.ToLower(d.Format), DOC_FORMATS...)
+	v.OneOf("targetType", d.TargetType, DOC_TARGET_H5, DOC_TARGET_IMAGE)
+	v.OneOf("access", d.Access, DOC_PUBLIC, DOC_PRIVATE, DOC_BOS_EDIT)
+	v.Check(d.Access != DOC_BOS_EDIT || d.Bucket != "", "bucket", "is required by the bosEdit access")
+	v.Check(d.Object == "" || d.Bucket != "", "bucket", "is required if the object is given")
+	v.Check(d.MD5 == "" || isHexMD5(d.MD5), "md5", "should be 32 hex characters")
+	checkReferenceId(v, d.ReferenceId)
+	return v.Err()
 }
 
 // String - 格式化为json格式
 func (d *RegDocumentParam) String() (string, error) {
-	if d.Title == "" || d.Format == "" {
-		return "", errors.New("tile and format cannot be empty")
+	if err := d.Check(); err != nil {
+		return "", err
 	}
 	if d.TargetType == "" || (d.TargetType != DOC_TARGET_H5 && d.TargetType != DOC_TARGET_IMAGE) {
 		d.TargetType = DOC_TARGET_H5
 	}
-	if d.Access != DOC_PUBLIC && d.Access != DOC_PRIVATE {
+	if d.Access != DOC_PUBLIC && d.Access != DOC_PRIVATE && d.Access != DOC_BOS_EDIT {
 		d.Access = DOC_PUBLIC
 	}
 
-	j, e := json.Marshal(d)
+	j, e := bce.MarshalJSON(d)
 	if e != nil {
 		return "", e
 	}
-
-	// 如果 notification 为空，则去掉该参数，不然请求会报错
-	if d.Notification == "" {
-		var m map[string]string
-		json.Unmarshal(j, &m)
-		delete(m, "notification")
-		j, _ = json.Marshal(m)
-	}
-
 	return string(j), nil
 }
 
-// RegDocumentResp - 注册文档请求响应
+// RegDocumentResp - 注册文档请求响应, the Bucket and Object are the location to upload the source
+// file to, which are the ones given by the RegDocumentParam if the user-owned bucket is used
 type RegDocumentResp struct {
 	DocumentId  string `json:"documentId"`
 	Bucket      string `json:"bucket"`
@@ -80,6 +131,20 @@ type RegDocumentResp struct {
 	BosEndpoint string `json:"bosEndpoint"`
 }
 
+// GetHtmlFilesResp defines the BOS location of the files of the converted HTML, which can be
+// archived as a self-contained copy of the document
+type GetHtmlFilesResp struct {
+	BosEndpoint string         `json:"bosEndpoint"`
+	Bucket      string         `json:"bucket"`
+	Files       []HtmlFileResp `json:"files"`
+}
+
+type HtmlFileResp struct {
+	Path   string `json:"path"`   // the relative path in the bundle, eg: index.html, page/1.html
+	Object string `json:"object"` // the object name in the bucket
+	Size   int64  `json:"size"`
+}
+
 type GetImagesResp struct {
 	Images []ImageResp `json:"images"`
 }
@@ -89,24 +154,184 @@ type ImageResp struct {
 	Url       string `json:"url"`
 }
 
+// GetImagesParam - the parameters to get the images of the document pages
+type GetImagesParam struct {
+	ExpireInSeconds int // presign the urls with the given expiration if it is positive
+}
+
+// Check - check the parameters of the images, nil is valid
+func (p *GetImagesParam) Check() error {
+	if p == nil {
+		return nil
+	}
+	v := &bce.Validator{}
+	v.Check(p.ExpireInSeconds >= 0, "expireInSeconds", "should not be negative")
+	return v.Err()
+}
+
+// ThumbnailParam - the parameters to get the thumbnails of the document pages
+type ThumbnailParam struct {
+	Width           int   // the max width of the thumbnail, 0 means scaled by the height
+	Height          int   // the max height of the thumbnail, 0 means scaled by the width
+	PageStart       int64 // the first page index to get, 0 means from the first page
+	PageEnd         int64 // the last page index to get, 0 means to the last page
+	ExpireInSeconds int   // presign the urls with the given expiration if it is positive
+}
+
+// Check - check the parameters of the thumbnails
+func (p *ThumbnailParam) Check() error {
+	v := &bce.Validator{}
+	v.Check(p.Width >= 0 && p.Height >= 0 && p.Width+p.Height > 0, "width/height",
+		"at least one should be positive and neither should be negative")
+	v.Check(p.PageStart >= 0, "pageStart", "should not be negative")
+	v.Check(p.PageEnd >= 0 && (p.PageEnd == 0 || p.PageEnd >= p.PageStart), "pageEnd",
+		"should not be negative or less than pageStart")
+	return v.Err()
+}
+
+// ThumbnailResp - the thumbnail of a document page, the image is scaled proportionally to fit
+// the width and height
+type ThumbnailResp struct {
+	PageIndex int64
+	Width     int
+	Height    int
+	Url       string
+}
+
+// GetTextParam defines the page range of the text to get, the PageEnd 0 means the last page
+type GetTextParam struct {
+	PageStart int // the first page index from 1, 0 means the first page
+	PageEnd   int // the last page index included
+}
+
+// DefaultGetTextParam - the param used by the GetText if nil is given, which gets all the pages
+func DefaultGetTextParam() *GetTextParam {
+	return &GetTextParam{}
+}
+
+// Check - check the page range of the text, nil is valid
+func (p *GetTextParam) Check() error {
+	if p == nil {
+		return nil
+	}
+	v := &bce.Validator{}
+	v.Check(p.PageStart >= 0, "pageStart", "should not be negative")
+	v.Check(p.PageEnd >= 0 && (p.PageEnd == 0 || p.PageEnd >= p.PageStart), "pageEnd",
+		"should not be negative or less than pageStart")
+	return v.Err()
+}
+
+// PageText defines the text extracted from a page of the document
+type PageText struct {
+	PageIndex int    `json:"pageIndex"`
+	Text      string `json:"text"`
+}
+
+// GetTextResp defines the result of the GetText
+type GetTextResp struct {
+	DocumentId string     `json:"documentId"`
+	Texts      []PageText `json:"texts"`
+}
+
 type QueryDocumentParam struct {
 	Https bool
 }
 
+// DefaultQueryDocumentParam - the param used by the QueryDocument and GetDocumentByReference if
+// nil is given, which gets the cover url by http
+func DefaultQueryDocumentParam() *QueryDocumentParam {
+	return &QueryDocumentParam{}
+}
+
 type QueryDocumentResp struct {
 	DocumentId   string            `json:"documentId"`
 	Title        string            `json:"title"`
 	Format       string            `json:"format"`
 	TargetType   string            `json:"targetType"`
 	Status       string            `json:"status"`
+	SubStatus    string            `json:"subStatus"` // the detail status during PROCESSING
+	Progress     int               `json:"progress"`  // the conversion progress percentage 0-100
 	UploadInfo   UploadInfoResp    `json:"uploadInfo"`
 	PublishInfo  PublishInfoResp   `json:"publishInfo"`
 	Notification string            `json:"notification"`
 	Access       string            `json:"access"`
 	CreateTime   string            `json:"createTime"`
+	ReferenceId  string            `json:"referenceId"`
 	Error        DocumentErrorResp `json:"error"`
 }
 
+// IsFinished - check whether the conversion of the document is finished, published or failed
+func (d *QueryDocumentResp) IsFinished() bool {
+	return d.Status == string(DOC_STATUS_PUBLISHED) || d.Status == string(DOC_STATUS_FAILED)
+}
+
+// Err - get the conversion error of the failed document
+//
+// RETURNS:
+//     - error: *ConversionError if the document is failed otherwise nil
+func (d *QueryDocumentResp) Err() error {
+	if d.Status != string(DOC_STATUS_FAILED) {
+		return nil
+	}
+	return &ConversionError{DocumentId: d.DocumentId, Code: d.Error.Code, Message: d.Error.Message}
+}
+
+// ConversionError defines the error of the document failed to convert, the Code is one of the
+// error codes of the DOC service, see the predicates of the doc package such as IsFileTooLarge
+type ConversionError struct {
+	DocumentId string
+	Code       string
+	Message    string
+}
+
+func (e *ConversionError) Error() string {
+	return "document " + e.DocumentId + " conversion failed: [Code: " + e.Code +
+		"; Message: " + e.Message + "]"
+}
+
+// ChecksumMismatchError defines the error of the uploaded source file whose MD5 digest is not the
+// same as the one given when registering, the Actual is empty if it is detected by the service
+type ChecksumMismatchError struct {
+	DocumentId string
+	Expected   string
+	Actual     string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return "document " + e.DocumentId + " source file checksum mismatch: [Expected: " +
+		e.Expected + "; Actual: " + e.Actual + "]"
+}
+
+func checkReferenceId(v *bce.Validator, referenceId string) {
+	if referenceId == "" {
+		return
+	}
+	v.MaxLength("referenceId", referenceId, MAX_REFERENCE_ID_LENGTH)
+	v.Check(referenceIdPattern.MatchString(referenceId), "referenceId",
+		"should only contain letters, digits and ._:-")
+}
+
+// CheckReferenceId - check the reference id of the document
+//
+// PARAMS:
+//     - referenceId: the reference id given when registering
+// RETURNS:
+//     - error: nil if valid otherwise the *bce.ValidationError
+func CheckReferenceId(referenceId string) error {
+	v := &bce.Validator{}
+	v.Required("referenceId", referenceId)
+	checkReferenceId(v, referenceId)
+	return v.Err()
+}
+
+func isHexMD5(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
 type UploadInfoResp struct {
 	Bucket      string `json:"bucket"`
 	Object      string `json:"object"`
@@ -125,8 +350,37 @@ type DocumentErrorResp struct {
 	Message string `json:"message"`
 }
 
+// ReadDocumentParam defines the expiration and the access control of the read token, which
+// are enforced by the viewer for the documents read with the token
 type ReadDocumentParam struct {
 	ExpireInSeconds int64
+	Watermark       string   // the text watermark rendered on every page, at most 64 characters
+	AllowedDomains  []string // the referrer domains allowed to embed the viewer, eg: "*.example.com"
+	PageStart       int64    // the first readable page index from 1, 0 means the first page
+	PageEnd         int64    // the last readable page index included, 0 means the last page
+}
+
+// DefaultReadDocumentParam - the param used by the ReadDocument if nil is given, which gets the
+// token with the default expiration of the service, without watermark and access control
+func DefaultReadDocumentParam() *ReadDocumentParam {
+	return &ReadDocumentParam{}
+}
+
+// Check - check the expiration and access control of the read token, nil is valid
+func (p *ReadDocumentParam) Check() error {
+	if p == nil {
+		return nil
+	}
+	v := &bce.Validator{}
+	v.Check(p.ExpireInSeconds >= 0, "expireInSeconds", "should not be negative")
+	v.MaxLength("watermark", p.Watermark, MAX_WATERMARK_LENGTH)
+	for _, domain := range p.AllowedDomains {
+		v.Check(domainPattern.MatchString(domain), "allowedDomains", "invalid domain: "+domain)
+	}
+	v.Check(p.PageStart >= 0, "pageStart", "should not be negative")
+	v.Check(p.PageEnd >= 0 && (p.PageEnd == 0 || p.PageEnd >= p.PageStart), "pageEnd",
+		"should not be negative or less than pageStart")
+	return v.Err()
 }
 
 type ReadDocumentResp struct {
@@ -138,26 +392,94 @@ type ReadDocumentResp struct {
 	ExpireTime string `json:"expireTime"`
 }
 
+// ExpireAt - parse the expiration time of the read token
+func (r *ReadDocumentResp) ExpireAt() (time.Time, error) {
+	return parseDocTime(r.ExpireTime)
+}
+
+// ListDocumentsParam defines the arguments to list documents. Status, Marker, MaxSize and Order
+// are handled by the server. The DOC service does not support the other filters, so they are applied
+// on the client side to the documents of the returned page, in which case a page may contain less
+// documents than MaxSize even if it is truncated, and NextMarker should be used to continue.
 type ListDocumentsParam struct {
 	Status  StatusType
 	Marker  string
 	MaxSize int64
+	Order   string // the order of the create time, DOC_ORDER_ASC or DOC_ORDER_DESC
+
+	// client side filters
+	TitlePrefix    string    // only the documents whose title has the prefix
+	TitleKeyword   string    // only the documents whose title contains the keyword, case insensitive
+	Format         string    // only the documents of the format, eg: pdf
+	CreateTimeFrom time.Time // only the documents created at or after the time if not zero
+	CreateTimeTo   time.Time // only the documents created before the time if not zero
+}
+
+// DefaultListDocumentsParam - the param used by the ListDocuments if nil is given, which lists
+// the first page of the documents in any status with the page size and order of the service
+func DefaultListDocumentsParam() *ListDocumentsParam {
+	return &ListDocumentsParam{}
 }
 
+// Check - check the arguments to list documents, nil is valid
 func (l *ListDocumentsParam) Check() error {
-	switch l.Status {
-	case DOC_STATUS_UPLOADING:
-	case DOC_STATUS_FAILED:
-	case DOC_STATUS_PROCESSING:
-	case DOC_STATUS_PUBLISHED:
-	case "":
-	default:
-		return errors.New("invalid DOC status")
+	if l == nil {
+		return nil
+	}
+	v := &bce.Validator{}
+	v.OneOf("status", string(l.Status), string(DOC_STATUS_UPLOADING), string(DOC_STATUS_FAILED),
+		string(DOC_STATUS_PROCESSING), string(DOC_STATUS_PUBLISHED))
+	v.Range("maxSize", l.MaxSize, 0, 200)
+	v.OneOf("order", l.Order, DOC_ORDER_ASC, DOC_ORDER_DESC)
+	v.Check(l.CreateTimeFrom.IsZero() || l.CreateTimeTo.IsZero() ||
+		l.CreateTimeFrom.Before(l.CreateTimeTo), "createTime", "invalid range")
+	return v.Err()
+}
+
+// HasClientFilter - whether any client side filter is set
+func (l *ListDocumentsParam) HasClientFilter() bool {
+	if l == nil {
+		return false
+	}
+	return l.TitlePrefix != "" || l.TitleKeyword != "" || l.Format != "" ||
+		!l.CreateTimeFrom.IsZero() || !l.CreateTimeTo.IsZero()
+}
+
+// Match - whether the document matches all the client side filters
+func (l *ListDocumentsParam) Match(doc *DocumentResp) bool {
+	if l == nil {
+		return true
+	}
+	if l.TitlePrefix != "" && !strings.HasPrefix(doc.Title, l.TitlePrefix) {
+		return false
+	}
+	if l.TitleKeyword != "" &&
+		!strings.Contains(strings.ToLower(doc.Title), strings.ToLower(l.TitleKeyword)) {
+		return false
 	}
-	if l.MaxSize > 200 || l.MaxSize < 0 {
-		return errors.New("invalid maxSize")
+	if l.Format != "" && !strings.EqualFold(doc.Format, l.Format) {
+		return false
 	}
-	return nil
+	if !l.CreateTimeFrom.IsZero() || !l.CreateTimeTo.IsZero() {
+		createTime, err := parseDocTime(doc.CreateTime)
+		if err != nil {
+			return false
+		}
+		if !l.CreateTimeFrom.IsZero() && createTime.Before(l.CreateTimeFrom) {
+			return false
+		}
+		if !l.CreateTimeTo.IsZero() && !createTime.Before(l.CreateTimeTo) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseDocTime(str string) (time.Time, error) {
+	if t, err := util.ParseISO8601Date(str); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, str)
 }
 
 type ListDocumentsResp struct {
@@ -165,6 +487,10 @@ type ListDocumentsResp struct {
 	IsTruncated bool           `json:"isTruncated"`
 	NextMarker  string         `json:"nextMarker,omitempty"`
 	Docs        []DocumentResp `json:"documents"`
+
+	// TotalCount is the number of all documents with the Status regardless of the page and the
+	// client side filters, nil if it is not returned by the server
+	TotalCount *int64 `json:"totalCount,omitempty"`
 }
 
 type DocumentResp struct {
@@ -176,5 +502,6 @@ type DocumentResp struct {
 	Notification string            `json:"notification"`
 	Access       string            `json:"access"`
 	CreateTime   string            `json:"createTime"`
+	ReferenceId  string            `json:"referenceId"`
 	Error        DocumentErrorResp `json:"error"`
 }
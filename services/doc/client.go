@@ -18,25 +18,68 @@
 package doc
 
 import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
 	"github.com/baidubce/bce-sdk-go/auth"
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/services/doc/api"
 )
 
 const (
-	DEFAULT_SERVICE_DOMAIN = "doc.bj.baidubce.com"
+	REGION_BJ = "bj"
+	REGION_GZ = "gz"
+	REGION_SU = "su"
+
+	DEFAULT_SERVICE_DOMAIN = "doc." + REGION_BJ + "." + bce.DEFAULT_DOMAIN
 )
 
+var regionPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
 // Client of DOC service is a kind of BceClient, so derived from BceClient
 type Client struct {
 	*bce.BceClient
 }
 
+// WithOptions - copy the client with the configuration overridden by the given options, the
+// calls of the copied client use the overridden settings and the original client is unchanged
+//
+// PARAMS:
+//     - opts: the options such as bce.WithTimeout, bce.WithRetryPolicy, bce.WithHeader and
+//       bce.WithCredentials
+// RETURNS:
+//     - *Client: the copied DOC client
+func (c *Client) WithOptions(opts ...bce.RequestOption) *Client {
+	client := *c
+	client.BceClient = c.BceClient.WithOptions(opts...)
+	return &client
+}
+
+// WithContext - copy the client with the context to cancel the calls or set their deadline, eg:
+// c.WithContext(ctx).QueryDocument(documentId, nil)
+//
+// PARAMS:
+//     - ctx: the context of the calls made by the copied client
+// RETURNS:
+//     - *Client: the copied DOC client
+func (c *Client) WithContext(ctx context.Context) *Client {
+	return c.WithOptions(bce.WithContext(ctx))
+}
+
 // DocClientConfiguration defines the config components structure by user.
+//
+// The Endpoint may be a host with the optional port or a url with the http or https scheme, it
+// takes precedence over the Region which generates the public endpoint "doc.{region}.baidubce.com".
+// EnableHttps switches the endpoint without scheme to https.
 type DocClientConfiguration struct {
-	Ak       string
-	Sk       string
-	Endpoint string
+	Ak          string
+	Sk          string
+	Endpoint    string
+	Region      string
+	EnableHttps bool
 }
 
 // NewClient make the DOC service client with default configuration.
@@ -49,10 +92,28 @@ func NewClient(ak, sk string) (*Client, error) {
 	})
 }
 
+// NewClientWithRegion make the DOC service client of the public endpoint of the region.
+func NewClientWithRegion(ak, sk, region string) (*Client, error) {
+	return NewClientWithConfig(&DocClientConfiguration{
+		Ak:     ak,
+		Sk:     sk,
+		Region: region,
+	})
+}
+
+// NewClientWithConfig make the DOC service client with the credentials, region and endpoint
+// given by the config, an error is returned if the region or endpoint is invalid.
 func NewClientWithConfig(config *DocClientConfiguration) (*Client, error) {
 	var credentials *auth.BceCredentials
 	var err error
-	ak, sk, endpoint := config.Ak, config.Sk, config.Endpoint
+	if config == nil {
+		return nil, fmt.Errorf("the doc client configuration is nil")
+	}
+	ak, sk := config.Ak, config.Sk
+	region, endpoint, err := serviceEndpoint(config)
+	if err != nil {
+		return nil, err
+	}
 	if len(ak) == 0 && len(sk) == 0 { // to support public-read-write request
 		credentials, err = nil, nil
 	} else {
@@ -61,15 +122,12 @@ func NewClientWithConfig(config *DocClientConfiguration) (*Client, error) {
 			return nil, err
 		}
 	}
-	if len(endpoint) == 0 {
-		endpoint = DEFAULT_SERVICE_DOMAIN
-	}
 	defaultSignOptions := &auth.SignOptions{
 		HeadersToSign: auth.DEFAULT_HEADERS_TO_SIGN,
 		ExpireSeconds: auth.DEFAULT_EXPIRE_SECONDS}
 	defaultConf := &bce.BceClientConfiguration{
 		Endpoint:                  endpoint,
-		Region:                    bce.DEFAULT_REGION,
+		Region:                    region,
 		UserAgent:                 bce.DEFAULT_USER_AGENT,
 		Credentials:               credentials,
 		SignOption:                defaultSignOptions,
@@ -82,6 +140,52 @@ func NewClientWithConfig(config *DocClientConfiguration) (*Client, error) {
 	return client, nil
 }
 
+// serviceEndpoint - resolve and validate the region and endpoint of the config
+func serviceEndpoint(config *DocClientConfiguration) (string, string, error) {
+	region := strings.ToLower(strings.TrimSpace(config.Region))
+	if len(region) != 0 && !regionPattern.MatchString(region) {
+		return "", "", fmt.Errorf("invalid doc region: %q", config.Region)
+	}
+	endpoint := strings.TrimSpace(config.Endpoint)
+	if len(endpoint) == 0 {
+		if len(region) == 0 {
+			region = REGION_BJ
+		}
+		endpoint = "doc." + region + "." + bce.DEFAULT_DOMAIN
+	}
+	if len(region) == 0 {
+		region = bce.DEFAULT_REGION
+	}
+
+	scheme := ""
+	if pos := strings.Index(endpoint, "://"); pos != -1 {
+		scheme = strings.ToLower(endpoint[:pos])
+	}
+	switch scheme {
+	case "":
+		if config.EnableHttps {
+			scheme, endpoint = "https", "https://"+endpoint
+		}
+	case "http":
+		if config.EnableHttps {
+			return "", "", fmt.Errorf("https is enabled but the doc endpoint is %q", endpoint)
+		}
+	case "https":
+	default:
+		return "", "", fmt.Errorf("unsupported scheme of the doc endpoint: %q", endpoint)
+	}
+	raw := endpoint
+	if len(scheme) == 0 {
+		raw = "http://" + endpoint
+	}
+	u, err := url.Parse(raw)
+	if err != nil || len(u.Hostname()) == 0 || (len(u.Path) != 0 && u.Path != "/") ||
+		len(u.RawQuery) != 0 || u.User != nil {
+		return "", "", fmt.Errorf("invalid doc endpoint: %q", endpoint)
+	}
+	return region, strings.TrimSuffix(endpoint, "/"), nil
+}
+
 // RegisterDocument - register document in doc service
 //
 // PARAMS:
@@ -100,26 +204,59 @@ func (c *Client) RegisterDocument(regParam *api.RegDocumentParam) (*api.RegDocum
 // RETURNS:
 //     - error: the return error if any occurs
 func (c *Client) PublishDocument(documentId string) error {
+	defer c.invalidateDocumentCache(documentId)
 	return api.PublishDocument(c, documentId)
 }
 
+// GetDocumentByReference - get the document by the reference id given when registering
+//
+// PARAMS:
+//     - referenceId: the reference id of the document
+//     - queryParam: enable/disable https of cover url, nil means DefaultQueryParam
+// RETURNS:
+//     - *api.QueryDocumentResp: the document, check IsNoSuchDocument if not registered
+//     - error: the return error if any occurs
+func (c *Client) GetDocumentByReference(referenceId string,
+	queryParam *api.QueryDocumentParam) (*api.QueryDocumentResp, error) {
+	return api.GetDocumentByReference(c, referenceId, queryParam)
+}
+
 // QueryDocument - query document's status
 //
 // PARAMS:
 //     - documentId: id of document in doc service
-//     - queryParam: enable/disable https of coverl url
+//     - queryParam: enable/disable https of coverl url, nil means DefaultQueryParam
 // RETURNS:
 //     - *api.QueryDocumentResp
 //     - error: the return error if any occurs
 func (c *Client) QueryDocument(documentId string, queryParam *api.QueryDocumentParam) (*api.QueryDocumentResp, error) {
-	return api.QueryDocument(c, documentId, queryParam)
+	key := queryDocumentCacheKey(documentId, queryParam != nil && queryParam.Https)
+	result := &api.QueryDocumentResp{}
+	if bce.GetCachedResult(c, key, result) {
+		return result, nil
+	}
+	result, err := api.QueryDocument(c, documentId, queryParam)
+	if err == nil {
+		bce.SetCachedResult(c, key, result)
+	}
+	return result, err
+}
+
+func queryDocumentCacheKey(documentId string, https bool) string {
+	return fmt.Sprintf("doc:query:%s:%t", documentId, https)
+}
+
+// invalidateDocumentCache - remove the cached query results of the document after it is changed
+func (c *Client) invalidateDocumentCache(documentId string) {
+	bce.InvalidateCachedResult(c, queryDocumentCacheKey(documentId, false))
+	bce.InvalidateCachedResult(c, queryDocumentCacheKey(documentId, true))
 }
 
 // ReadDocument - get document token for client sdk
 //
 // PARAMS:
 //     - documentId: id of document in doc service
-//     - readParam: expiration time of the doc's html
+//     - readParam: expiration time of the doc's html, nil means DefaultReadParam
 // RETURNS:
 //     - *api.ReadDocumentResp
 //     - error: the return error if any occurs
@@ -138,6 +275,30 @@ func (c *Client) GetImages(documentId string) (*api.GetImagesResp, error) {
 	return api.GetImages(c, documentId)
 }
 
+// GetThumbnails - get the thumbnail urls of the document pages with the given size
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+//     - param: the size, page range and expiration of the thumbnails
+// RETURNS:
+//     - []api.ThumbnailResp: the thumbnails ordered by the page index
+//     - error: the return error if any occurs
+func (c *Client) GetThumbnails(documentId string, param *api.ThumbnailParam) ([]api.ThumbnailResp, error) {
+	return api.GetThumbnails(c, documentId, param)
+}
+
+// GetText - get the text extracted from the document pages by the conversion
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+//     - param: the page range of the text, nil means DefaultTextParam of all pages
+// RETURNS:
+//     - *api.GetTextResp: the texts of the pages ordered by the page index
+//     - error: the return error if any occurs
+func (c *Client) GetText(documentId string, param *api.GetTextParam) (*api.GetTextResp, error) {
+	return api.GetText(c, documentId, param)
+}
+
 // DeleteDocument - delete document in doc service
 //
 // PARAMS:
@@ -145,6 +306,7 @@ func (c *Client) GetImages(documentId string) (*api.GetImagesResp, error) {
 // RETURNS:
 //     - error: the return error if any occurs
 func (c *Client) DeleteDocument(documentId string) error {
+	defer c.invalidateDocumentCache(documentId)
 	return api.DeleteDocument(c, documentId)
 }
 
@@ -152,7 +314,7 @@ func (c *Client) DeleteDocument(documentId string) error {
 //
 // PARAMS:
 //     - cli: the client agent which can perform sending request
-//     - param: the optional arguments to list documents
+//     - param: the optional arguments to list documents, nil means DefaultListParam
 // RETURNS:
 //     - *ListDocumentsResp: the result docments list structure
 //     - error: nil if ok otherwise the specific error
@@ -1,23 +1,35 @@
 package doc
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
 	"github.com/baidubce/bce-sdk-go/services/bos"
 	"github.com/baidubce/bce-sdk-go/services/doc/api"
+	"github.com/baidubce/bce-sdk-go/util"
 	"github.com/baidubce/bce-sdk-go/util/log"
 )
 
 var (
 	DOC_CLIENT *Client
 	BOS_CLIENT *bos.Client
+
+	BOS_TEST_BUCKET = "doc-source-test"
 )
 
 // For security reason, ak/sk should not hard write here.
@@ -139,6 +151,17 @@ func TestGetDocImages(t *testing.T) {
 	rRes, err := DOC_CLIENT.GetImages(res.DocumentId)
 	ExpectEqual(t.Errorf, nil, err)
 	t.Logf("%+v", rRes)
+
+	thumbnails, err := DOC_CLIENT.GetThumbnails(res.DocumentId, &api.ThumbnailParam{
+		Width:           200,
+		PageStart:       1,
+		PageEnd:         2,
+		ExpireInSeconds: 600,
+	})
+	ExpectEqual(t.Errorf, nil, err)
+	for _, thumbnail := range thumbnails {
+		t.Logf("%+v", thumbnail)
+	}
 }
 
 func TestListDocs(t *testing.T) {
@@ -170,3 +193,552 @@ func TestDeleteDocs(t *testing.T) {
 	err = DOC_CLIENT.DeleteDocument(res.DocumentId)
 	ExpectEqual(t.Errorf, nil, err)
 }
+
+func TestDocOptionsFacade(t *testing.T) {
+	res, err := DOC_CLIENT.Register("test.txt", "txt", WithTargetType(api.DOC_TARGET_H5))
+	ExpectEqual(t.Errorf, nil, err)
+	t.Logf("%+v", res)
+
+	qRes, err := DOC_CLIENT.Query(res.DocumentId, WithHTTPS(true))
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, string(api.DOC_STATUS_UPLOADING), qRes.Status)
+
+	lRes, err := DOC_CLIENT.List(WithStatus(api.DOC_STATUS_UPLOADING), WithMaxSize(1))
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, 1, len(lRes.Docs))
+
+	err = DOC_CLIENT.DeleteDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestListDocsWithClientFilter(t *testing.T) {
+	listParam := &api.ListDocumentsParam{
+		MaxSize:        200,
+		Format:         "txt",
+		CreateTimeFrom: time.Now().Add(-24 * time.Hour),
+	}
+	res, err := DOC_CLIENT.ListDocuments(listParam)
+	ExpectEqual(t.Errorf, nil, err)
+	for _, doc := range res.Docs {
+		ExpectEqual(t.Errorf, "txt", doc.Format)
+	}
+}
+
+func TestSearchDocuments(t *testing.T) {
+	res, err := DOC_CLIENT.Register("Quarterly Report.txt", "txt")
+	ExpectEqual(t.Errorf, nil, err)
+
+	param := &SearchDocumentsParam{MaxResults: 10}
+	param.Status = api.DOC_STATUS_UPLOADING
+	found := false
+	for !found {
+		sRes, err := DOC_CLIENT.SearchDocuments("quarterly", param)
+		ExpectEqual(t.Errorf, nil, err)
+		if err != nil {
+			break
+		}
+		for _, doc := range sRes.Docs {
+			ExpectEqual(t.Errorf, true, strings.Contains(strings.ToLower(doc.Title), "quarterly"))
+			found = found || doc.DocumentId == res.DocumentId
+		}
+		if !sRes.IsTruncated {
+			break
+		}
+		param.Marker = sRes.NextMarker
+	}
+	ExpectEqual(t.Errorf, true, found)
+
+	_, err = DOC_CLIENT.SearchDocuments("", nil)
+	ExpectEqual(t.Errorf, false, err == nil)
+
+	err = DOC_CLIENT.DeleteDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestWatchProgress(t *testing.T) {
+	res, err := DOC_CLIENT.Register("test.txt", "txt")
+	ExpectEqual(t.Errorf, nil, err)
+	_, err = BOS_CLIENT.PutObjectFromString(res.Bucket, res.Object, "test\nline", nil)
+	ExpectEqual(t.Errorf, nil, err)
+	err = DOC_CLIENT.PublishDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	doc, err := DOC_CLIENT.WatchProgress(ctx, res.DocumentId, func(p *Progress) {
+		t.Logf("%s %s %d%% eta: %v", p.Status, p.SubStatus, p.Percent, p.ETA)
+	})
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, "PUBLISHED", doc.Status)
+}
+
+func TestListDocumentsOrder(t *testing.T) {
+	res, err := DOC_CLIENT.ListDocuments(&api.ListDocumentsParam{MaxSize: 10, Order: api.DOC_ORDER_ASC})
+	ExpectEqual(t.Errorf, nil, err)
+	for i := 1; i < len(res.Docs); i++ {
+		ExpectEqual(t.Errorf, true, res.Docs[i-1].CreateTime <= res.Docs[i].CreateTime)
+	}
+	if res.TotalCount != nil {
+		ExpectEqual(t.Errorf, true, *res.TotalCount >= int64(len(res.Docs)))
+	}
+
+	_, err = DOC_CLIENT.ListDocuments(&api.ListDocumentsParam{Order: "random"})
+	ExpectEqual(t.Errorf, false, err == nil)
+}
+
+func TestWaitDocumentPublished(t *testing.T) {
+	res, err := DOC_CLIENT.Register("test.txt", "txt")
+	ExpectEqual(t.Errorf, nil, err)
+	_, err = BOS_CLIENT.PutObjectFromString(res.Bucket, res.Object, "test\nline", nil)
+	ExpectEqual(t.Errorf, nil, err)
+	err = DOC_CLIENT.PublishDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	doc, err := DOC_CLIENT.WaitDocumentPublished(ctx, res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, "PUBLISHED", doc.Status)
+}
+
+func TestDocumentTask(t *testing.T) {
+	res, err := DOC_CLIENT.Register("test.txt", "txt")
+	ExpectEqual(t.Errorf, nil, err)
+	_, err = BOS_CLIENT.PutObjectFromString(res.Bucket, res.Object, "test\nline", nil)
+	ExpectEqual(t.Errorf, nil, err)
+	err = DOC_CLIENT.PublishDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+
+	task := DOC_CLIENT.DocumentTask(res.DocumentId)
+	ExpectEqual(t.Errorf, res.DocumentId, task.ID())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	err = task.Wait(ctx)
+	ExpectEqual(t.Errorf, nil, err)
+	state, err := task.Poll(ctx)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, waiter.StateSuccess, state)
+	ExpectEqual(t.Errorf, "PUBLISHED", task.Result().(*api.QueryDocumentResp).Status)
+}
+
+func TestSubscribeDocumentEvents(t *testing.T) {
+	_, err := DOC_CLIENT.SubscribeDocumentEvents(context.Background(), "")
+	ExpectEqual(t.Errorf, false, err == nil)
+
+	res, err := DOC_CLIENT.Register("test.txt", "txt")
+	ExpectEqual(t.Errorf, nil, err)
+	_, err = BOS_CLIENT.PutObjectFromString(res.Bucket, res.Object, "test\nline", nil)
+	ExpectEqual(t.Errorf, nil, err)
+	err = DOC_CLIENT.PublishDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	events, err := DOC_CLIENT.SubscribeDocumentEvents(ctx, res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+	var last DocumentEvent
+	for e := range events {
+		t.Logf("%s %s %s %d%%", e.Type, e.Status, e.SubStatus, e.Percent)
+		last = e
+	}
+	ExpectEqual(t.Errorf, DOC_EVENT_PUBLISHED, last.Type)
+	ExpectEqual(t.Errorf, 100, last.Percent)
+}
+
+func TestCreateDocumentFromFile(t *testing.T) {
+	filePath := filepath.Join(os.TempDir(), "test-create.txt")
+	err := ioutil.WriteFile(filePath, []byte("test\nline"), 0644)
+	ExpectEqual(t.Errorf, nil, err)
+	defer os.Remove(filePath)
+
+	res, err := DOC_CLIENT.CreateDocumentFromFile(filePath, "")
+	ExpectEqual(t.Errorf, nil, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	doc, err := DOC_CLIENT.WaitDocumentPublished(ctx, res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, "test-create", doc.Title)
+
+	_, err = DOC_CLIENT.Register("test.txt", "txt", WithMD5("not-md5"))
+	_, ok := err.(*bce.ValidationError)
+	ExpectEqual(t.Errorf, true, ok)
+}
+
+func TestCreateDocumentFromURL(t *testing.T) {
+	_, err := BOS_CLIENT.PutObjectFromString(BOS_TEST_BUCKET, "test-url.txt", "test\nline", nil)
+	ExpectEqual(t.Errorf, nil, err)
+	sourceUrl := BOS_CLIENT.BasicGeneratePresignedUrl(BOS_TEST_BUCKET, "test-url.txt", 600)
+	regRes, err := DOC_CLIENT.CreateDocumentFromURL(sourceUrl, "", "")
+	ExpectEqual(t.Errorf, nil, err)
+	doc, err := DOC_CLIENT.QueryDocument(regRes.DocumentId, nil)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, "test-url", doc.Title)
+}
+
+func TestCreateDocumentFromURLError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow.txt" {
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	// the signature in the query is kept out of the error
+	_, err := DOC_CLIENT.CreateDocumentFromURL(server.URL+"/test.txt?authorization=secret", "", "")
+	ExpectEqual(t.Errorf, true, err != nil)
+	ExpectEqual(t.Errorf, false, strings.Contains(err.Error(), "secret"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = DOC_CLIENT.WithContext(ctx).CreateDocumentFromURL(
+		server.URL+"/slow.txt?authorization=secret", "", "")
+	ExpectEqual(t.Errorf, true, err != nil)
+	ExpectEqual(t.Errorf, false, strings.Contains(err.Error(), "secret"))
+}
+
+func TestQueryDocumentCache(t *testing.T) {
+	// cache on a copy of the client to keep the shared DOC_CLIENT unchanged
+	client := DOC_CLIENT.WithOptions(func(conf *bce.BceClientConfiguration) {
+		conf.Cache = bce.NewMemoryCache()
+		conf.CacheTTL = time.Minute
+	})
+
+	res, err := client.Register("test.txt", "txt")
+	ExpectEqual(t.Errorf, nil, err)
+	qRes, err := client.QueryDocument(res.DocumentId, nil)
+	ExpectEqual(t.Errorf, nil, err)
+	cached := &api.QueryDocumentResp{}
+	ExpectEqual(t.Errorf, true, bce.GetCachedResult(client, queryDocumentCacheKey(res.DocumentId, false), cached))
+	ExpectEqual(t.Errorf, qRes.Status, cached.Status)
+
+	err = client.DeleteDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, false, bce.GetCachedResult(client, queryDocumentCacheKey(res.DocumentId, false), cached))
+	ExpectEqual(t.Errorf, nil, DOC_CLIENT.Config.Cache)
+}
+
+func TestRegDocumentParamCheck(t *testing.T) {
+	err := (&api.RegDocumentParam{Format: "exe", Access: "OPEN"}).Check()
+	vErr, ok := err.(*bce.ValidationError)
+	ExpectEqual(t.Errorf, true, ok)
+	ExpectEqual(t.Errorf, 3, len(vErr.Errors))
+	ExpectEqual(t.Errorf, "title", vErr.Errors[0].Field)
+
+	err = (&api.RegDocumentParam{Title: "test", Format: "txt", ReferenceId: "cms/10086"}).Check()
+	vErr, ok = err.(*bce.ValidationError)
+	ExpectEqual(t.Errorf, true, ok)
+	ExpectEqual(t.Errorf, "referenceId", vErr.Errors[0].Field)
+	err = (&api.RegDocumentParam{Title: "test", Format: "txt", ReferenceId: "cms-article:10086"}).Check()
+	ExpectEqual(t.Errorf, nil, err)
+	_, err = DOC_CLIENT.GetDocumentByReference("", nil)
+	_, ok = err.(*bce.ValidationError)
+	ExpectEqual(t.Errorf, true, ok)
+
+	_, err = DOC_CLIENT.ListDocuments(&api.ListDocumentsParam{MaxSize: 1000})
+	_, ok = err.(*bce.ValidationError)
+	ExpectEqual(t.Errorf, true, ok)
+}
+
+func TestWithOptions(t *testing.T) {
+	cli := DOC_CLIENT.WithOptions(bce.WithTimeout(3*time.Second), bce.WithHeader("x-app-id", "test"))
+	ExpectEqual(t.Errorf, 3000, cli.Config.ConnectionTimeoutInMillis)
+	ExpectEqual(t.Errorf, "test", cli.Config.CustomHeaders["x-app-id"])
+	ExpectEqual(t.Errorf, "", DOC_CLIENT.Config.CustomHeaders["x-app-id"])
+
+	_, err := cli.ListDocuments(&api.ListDocumentsParam{})
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestCopyDocument(t *testing.T) {
+	res, err := DOC_CLIENT.Register("test.txt", "txt", WithTargetType(api.DOC_TARGET_IMAGE))
+	ExpectEqual(t.Errorf, nil, err)
+	_, err = BOS_CLIENT.PutObjectFromString(res.Bucket, res.Object, "test\nline", nil)
+	ExpectEqual(t.Errorf, nil, err)
+	err = DOC_CLIENT.PublishDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+
+	cRes, err := DOC_CLIENT.CopyDocument(res.DocumentId, "copy.txt")
+	ExpectEqual(t.Errorf, nil, err)
+	qRes, err := DOC_CLIENT.QueryDocument(cRes.DocumentId, nil)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, "copy.txt", qRes.Title)
+	ExpectEqual(t.Errorf, api.DOC_TARGET_IMAGE, qRes.TargetType)
+}
+
+func TestWriteHtmlArchive(t *testing.T) {
+	res, err := DOC_CLIENT.Register("test.txt", "txt")
+	ExpectEqual(t.Errorf, nil, err)
+	_, err = BOS_CLIENT.PutObjectFromString(res.Bucket, res.Object, "test\nline", nil)
+	ExpectEqual(t.Errorf, nil, err)
+	err = DOC_CLIENT.PublishDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+	_, err = DOC_CLIENT.WaitDocumentPublished(context.Background(), res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+
+	var buf bytes.Buffer
+	err = DOC_CLIENT.WriteHtmlArchive(res.DocumentId, &buf)
+	ExpectEqual(t.Errorf, nil, err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	ExpectEqual(t.Errorf, nil, err)
+	if err == nil {
+		ExpectEqual(t.Errorf, true, len(zr.File) > 0)
+	}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	doc := &api.QueryDocumentResp{DocumentId: "doc-test", Status: string(api.DOC_STATUS_FAILED),
+		Error: api.DocumentErrorResp{Code: ERR_FILE_TOO_LARGE, Message: "too large"}}
+	err := doc.Err()
+	ExpectEqual(t.Errorf, true, IsFileTooLarge(err))
+	ExpectEqual(t.Errorf, true, IsConversionFailed(err))
+	ExpectEqual(t.Errorf, false, IsUnsupportedFormat(err))
+
+	err = bce.NewBceServiceError(ERR_NO_SUCH_DOCUMENT, "not found", "", 404)
+	ExpectEqual(t.Errorf, true, IsNoSuchDocument(err))
+
+	_, err = DOC_CLIENT.RegisterDocument(&api.RegDocumentParam{Title: "test", Format: "exe"})
+	ExpectEqual(t.Errorf, true, IsUnsupportedFormat(err))
+}
+
+func TestGetText(t *testing.T) {
+	_, err := DOC_CLIENT.GetText("doc-test", &api.GetTextParam{PageStart: 3, PageEnd: 2})
+	_, ok := err.(*bce.ValidationError)
+	ExpectEqual(t.Errorf, true, ok)
+
+	res, err := DOC_CLIENT.ListDocuments(&api.ListDocumentsParam{Status: api.DOC_STATUS_PUBLISHED, MaxSize: 1})
+	ExpectEqual(t.Errorf, nil, err)
+	if len(res.Docs) == 0 {
+		return
+	}
+	documentId := res.Docs[0].DocumentId
+	tRes, err := DOC_CLIENT.GetText(documentId, &api.GetTextParam{PageStart: 1, PageEnd: 1})
+	ExpectEqual(t.Errorf, nil, err)
+	t.Logf("%+v", tRes)
+
+	lastIndex := 0
+	err = DOC_CLIENT.WalkText(documentId, 2, func(page *api.PageText) error {
+		ExpectEqual(t.Errorf, lastIndex+1, page.PageIndex)
+		lastIndex = page.PageIndex
+		return nil
+	})
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestRegisterToUserBucket(t *testing.T) {
+	_, err := DOC_CLIENT.Register("test", "txt", WithAccess(api.DOC_BOS_EDIT))
+	_, ok := err.(*bce.ValidationError)
+	ExpectEqual(t.Errorf, true, ok)
+
+	res, err := DOC_CLIENT.Register("test", "txt", WithAccess(api.DOC_BOS_EDIT),
+		WithBucket(BOS_TEST_BUCKET, "doc/test.txt"))
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, BOS_TEST_BUCKET, res.Bucket)
+	ExpectEqual(t.Errorf, "doc/test.txt", res.Object)
+}
+
+func TestRegisterWithReferenceId(t *testing.T) {
+	referenceId := "sdk-test-" + util.NewUUID()
+	_, err := DOC_CLIENT.GetDocumentByReference(referenceId, nil)
+	ExpectEqual(t.Errorf, true, IsNoSuchDocument(err))
+
+	res, err := DOC_CLIENT.Register("test", "txt", WithReferenceId(referenceId))
+	ExpectEqual(t.Errorf, nil, err)
+	retried, err := DOC_CLIENT.Register("test", "txt", WithReferenceId(referenceId))
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, res.DocumentId, retried.DocumentId)
+	_, err = DOC_CLIENT.Register("test", "pdf", WithReferenceId(referenceId))
+	ExpectEqual(t.Errorf, true, IsReferenceIdConflict(err))
+
+	qRes, err := DOC_CLIENT.GetDocumentByReference(referenceId, nil)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, res.DocumentId, qRes.DocumentId)
+	ExpectEqual(t.Errorf, referenceId, qRes.ReferenceId)
+
+	err = DOC_CLIENT.DeleteDocument(res.DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestTokenCache(t *testing.T) {
+	res, err := DOC_CLIENT.ListDocuments(&api.ListDocumentsParam{Status: api.DOC_STATUS_PUBLISHED, MaxSize: 1})
+	ExpectEqual(t.Errorf, nil, err)
+	if len(res.Docs) == 0 {
+		return
+	}
+	cache := DOC_CLIENT.NewTokenCache(3600, time.Minute)
+	token, err := cache.Get(res.Docs[0].DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+	cached, err := cache.Get(res.Docs[0].DocumentId)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, token, cached)
+}
+
+func TestGetDocumentWithToken(t *testing.T) {
+	res, err := DOC_CLIENT.ListDocuments(&api.ListDocumentsParam{Status: api.DOC_STATUS_PUBLISHED, MaxSize: 1})
+	ExpectEqual(t.Errorf, nil, err)
+	if len(res.Docs) == 0 {
+		return
+	}
+	doc, err := DOC_CLIENT.GetDocumentWithToken(res.Docs[0].DocumentId, 3600)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, string(api.DOC_STATUS_PUBLISHED), doc.Status)
+	ExpectEqual(t.Errorf, true, doc.Token != nil && doc.Token.Token != "")
+}
+
+func TestNewClientWithConfig(t *testing.T) {
+	cases := []struct {
+		conf     DocClientConfiguration
+		region   string
+		endpoint string
+		fail     bool
+	}{
+		{DocClientConfiguration{}, REGION_BJ, DEFAULT_SERVICE_DOMAIN, false},
+		{DocClientConfiguration{Region: REGION_GZ}, REGION_GZ, "doc.gz.baidubce.com", false},
+		{DocClientConfiguration{Region: "SU", EnableHttps: true}, REGION_SU, "https://doc.su.baidubce.com", false},
+		{DocClientConfiguration{Region: REGION_GZ, Endpoint: "10.0.0.1:8080"}, REGION_GZ, "10.0.0.1:8080", false},
+		{DocClientConfiguration{Endpoint: "https://doc.example.com/"}, bce.DEFAULT_REGION, "https://doc.example.com", false},
+		{DocClientConfiguration{Endpoint: "http://doc.example.com", EnableHttps: true}, "", "", true},
+		{DocClientConfiguration{Endpoint: "ftp://doc.example.com"}, "", "", true},
+		{DocClientConfiguration{Endpoint: "doc.example.com/v2"}, "", "", true},
+		{DocClientConfiguration{Region: "bj/../x"}, "", "", true},
+	}
+	for _, c := range cases {
+		cli, err := NewClientWithConfig(&c.conf)
+		if c.fail {
+			ExpectEqual(t.Errorf, true, err != nil)
+			continue
+		}
+		ExpectEqual(t.Errorf, nil, err)
+		ExpectEqual(t.Errorf, c.region, cli.Config.Region)
+		ExpectEqual(t.Errorf, c.endpoint, cli.Config.Endpoint)
+	}
+}
+
+func TestReadDocumentParamCheck(t *testing.T) {
+	param := &api.ReadDocumentParam{
+		Watermark:      "confidential",
+		AllowedDomains: []string{"example.com", "*.example.com"},
+		PageStart:      1,
+		PageEnd:        3,
+	}
+	ExpectEqual(t.Errorf, nil, param.Check())
+
+	param.AllowedDomains = []string{"https://example.com"}
+	param.PageEnd = -1
+	err := param.Check()
+	vErr, ok := err.(*bce.ValidationError)
+	ExpectEqual(t.Errorf, true, ok)
+	ExpectEqual(t.Errorf, 2, len(vErr.Errors))
+
+	res, err := DOC_CLIENT.Register("test.txt", "txt")
+	ExpectEqual(t.Errorf, nil, err)
+	token, err := DOC_CLIENT.Read(res.DocumentId, WithExpiry(time.Hour), WithWatermark("confidential"),
+		WithAllowedDomains("*.example.com"), WithPageRange(1, 2))
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, true, len(token.Token) > 0)
+}
+
+func TestGetUsage(t *testing.T) {
+	usage, err := DOC_CLIENT.GetUsage(&UsageParam{
+		Detail: true,
+		Limits: &UsageLimits{MaxDocuments: 1},
+	})
+	ExpectEqual(t.Errorf, nil, err)
+	var total int64
+	for _, count := range usage.DocumentsByStatus {
+		total += count
+	}
+	ExpectEqual(t.Errorf, usage.Documents, total)
+	if usage.Documents >= 1 {
+		ExpectEqual(t.Errorf, []string{"documents"}, usage.NearLimits(1))
+	}
+}
+
+func TestNilParams(t *testing.T) {
+	var listParam *api.ListDocumentsParam
+	ExpectEqual(t.Errorf, nil, listParam.Check())
+	ExpectEqual(t.Errorf, false, listParam.HasClientFilter())
+	ExpectEqual(t.Errorf, true, listParam.Match(&api.DocumentResp{}))
+	var readParam *api.ReadDocumentParam
+	ExpectEqual(t.Errorf, nil, readParam.Check())
+
+	res, err := DOC_CLIENT.ListDocuments(nil)
+	ExpectEqual(t.Errorf, nil, err)
+	defaultRes, err := DOC_CLIENT.ListDocuments(DefaultListParam())
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, len(defaultRes.Docs), len(res.Docs))
+}
+
+func TestDownloadImages(t *testing.T) {
+	docs, err := DOC_CLIENT.ListDocuments(&api.ListDocumentsParam{Status: api.DOC_STATUS_PUBLISHED})
+	ExpectEqual(t.Errorf, nil, err)
+	for _, doc := range docs.Docs {
+		if doc.TargetType != "image" {
+			continue
+		}
+		images, err := DOC_CLIENT.GetImagesWithParam(doc.DocumentId, &api.GetImagesParam{ExpireInSeconds: 600})
+		ExpectEqual(t.Errorf, nil, err)
+		for _, image := range images.Images {
+			ExpectEqual(t.Errorf, true, strings.Contains(image.Url, "authorization="))
+		}
+
+		dir, err := ioutil.TempDir("", "doc-images")
+		ExpectEqual(t.Errorf, nil, err)
+		defer os.RemoveAll(dir)
+		completed := 0
+		manifest, err := DOC_CLIENT.DownloadImages(context.Background(), doc.DocumentId, dir,
+			&DownloadImagesParam{Parallel: 2, OnComplete: func(*ImagesManifest) { completed++ }})
+		ExpectEqual(t.Errorf, nil, err)
+		ExpectEqual(t.Errorf, 1, completed)
+		ExpectEqual(t.Errorf, len(images.Images), len(manifest.Images))
+		for _, image := range manifest.Images {
+			info, err := os.Stat(filepath.Join(dir, image.File))
+			ExpectEqual(t.Errorf, nil, err)
+			ExpectEqual(t.Errorf, image.Size, info.Size())
+		}
+		_, err = os.Stat(filepath.Join(dir, IMAGES_MANIFEST_NAME))
+		ExpectEqual(t.Errorf, nil, err)
+		return
+	}
+}
+
+func TestGetImageStream(t *testing.T) {
+	docs, err := DOC_CLIENT.ListDocuments(&api.ListDocumentsParam{Status: api.DOC_STATUS_PUBLISHED})
+	ExpectEqual(t.Errorf, nil, err)
+	for _, doc := range docs.Docs {
+		if doc.TargetType != "image" {
+			continue
+		}
+		images, err := DOC_CLIENT.GetImages(doc.DocumentId)
+		ExpectEqual(t.Errorf, nil, err)
+		for _, image := range images.Images {
+			stream, err := DOC_CLIENT.GetImageStream(doc.DocumentId, image.PageIndex)
+			ExpectEqual(t.Errorf, nil, err)
+			data, err := ioutil.ReadAll(stream.Body)
+			stream.Body.Close()
+			ExpectEqual(t.Errorf, nil, err)
+			ExpectEqual(t.Errorf, true, len(data) > 0)
+		}
+		_, err = DOC_CLIENT.GetImageStream(doc.DocumentId, int64(len(images.Images)+1))
+		ExpectEqual(t.Errorf, true, err != nil)
+		return
+	}
+}
+
+func TestConvertWithDeadline(t *testing.T) {
+	doc, err := DOC_CLIENT.ConvertWithDeadline(context.Background(), "./sudoku.pdf", "",
+		time.Now().Add(5*time.Minute))
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, "PUBLISHED", doc.Status)
+
+	_, err = DOC_CLIENT.ConvertWithDeadline(context.Background(), "./sudoku.pdf", "",
+		time.Now().Add(time.Second))
+	ExpectEqual(t.Errorf, true, IsConversionTimeout(err))
+	ExpectEqual(t.Errorf, nil, err.(*ConversionTimeoutError).DeleteErr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = DOC_CLIENT.WithContext(ctx).QueryDocument(doc.DocumentId, nil)
+	ExpectEqual(t.Errorf, true, err != nil)
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// convert.go - convert the document by the deadline and abort the stuck conversion
+
+package doc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// DEFAULT_ABORT_TIMEOUT is the timeout to delete the document not published by the deadline
+const DEFAULT_ABORT_TIMEOUT = 30 * time.Second
+
+// ConversionTimeoutError is returned by the ConvertWithDeadline if the document is not published
+// by the deadline, the document is deleted unless the DeleteErr is not nil
+type ConversionTimeoutError struct {
+	DocumentId string
+	Deadline   time.Time
+	Err        error // the error of the waiting or the context
+	DeleteErr  error // the error of deleting the document if any
+}
+
+func (e *ConversionTimeoutError) Error() string {
+	msg := fmt.Sprintf("document %s is not published by the deadline %s: %v", e.DocumentId,
+		e.Deadline.Format(time.RFC3339), e.Err)
+	if e.DeleteErr != nil {
+		msg += fmt.Sprintf(", and failed to delete it: %v", e.DeleteErr)
+	}
+	return msg
+}
+
+// IsConversionTimeout - check whether the document is not published by the deadline
+func IsConversionTimeout(err error) bool {
+	_, ok := err.(*ConversionTimeoutError)
+	return ok
+}
+
+// ConvertWithDeadline - register the document, upload the source file, publish it and wait for
+// the conversion like the CreateDocumentFromFile and the WaitDocumentPublished. If the document
+// is not published by the deadline or the ctx is done, the document is deleted to abort the
+// conversion, so that no stuck conversion is left behind.
+//
+// PARAMS:
+//     - ctx: the context to cancel the conversion
+//     - filePath: the path of the source file, the format is the extension of it
+//     - title: the title of the document, the file name without the extension if it is empty
+//     - deadline: the time by which the document should be published
+//     - opts: WithTargetType, WithAccess, WithNotification and WithBucket are supported
+// RETURNS:
+//     - *api.QueryDocumentResp: the published document, or the last queried one if failed
+//     - error: nil if published, *ConversionTimeoutError if aborted by the deadline or the ctx,
+//       *waiter.FailureError with the *api.ConversionError if the conversion is failed,
+//       otherwise the error of the registration, uploading or publishing
+func (c *Client) ConvertWithDeadline(ctx context.Context, filePath, title string,
+	deadline time.Time, opts ...Option) (*api.QueryDocumentResp, error) {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	res, err := c.WithContext(ctx).CreateDocumentFromFile(filePath, title, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := c.WaitDocumentPublished(ctx, res.DocumentId)
+	if err == nil || (!waiter.IsTimeout(err) && ctx.Err() == nil) {
+		return doc, err
+	}
+	return doc, &ConversionTimeoutError{
+		DocumentId: res.DocumentId,
+		Deadline:   deadline,
+		Err:        err,
+		DeleteErr:  c.abortDocument(res.DocumentId),
+	}
+}
+
+// abortDocument - delete the document with a new context, since the context of the calls may be
+// done already
+func (c *Client) abortDocument(documentId string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_ABORT_TIMEOUT)
+	defer cancel()
+	err := api.DeleteDocument(c.WithContext(ctx), documentId)
+	if err == nil {
+		c.invalidateDocumentCache(documentId)
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// copy.go - duplicate a document without uploading the source file again
+
+package doc
+
+import (
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	bosapi "github.com/baidubce/bce-sdk-go/services/bos/api"
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// CopyDocument - duplicate the document as a new one, the source file in BOS is copied on the
+// server side to the location of the newly registered document which is then published, so the
+// new document has the same format, target type, access and notification as the source one.
+//
+// PARAMS:
+//     - documentId: the id of the source document, whose source file should have been uploaded
+//     - newTitle: the title of the new document, the title of the source one if it is empty
+// RETURNS:
+//     - *api.RegDocumentResp: id and document location in bos of the new document
+//     - error: the return error if any occurs
+func (c *Client) CopyDocument(documentId, newTitle string) (*api.RegDocumentResp, error) {
+	src, err := api.QueryDocument(c, documentId, nil)
+	if err != nil {
+		return nil, err
+	}
+	if src.Status == string(api.DOC_STATUS_UPLOADING) {
+		return nil, fmt.Errorf("the source file of document %s has not been uploaded", documentId)
+	}
+	if newTitle == "" {
+		newTitle = src.Title
+	}
+
+	regParam := &api.RegDocumentParam{
+		Title:        newTitle,
+		Format:       src.Format,
+		TargetType:   src.TargetType,
+		Access:       src.Access,
+		Notification: src.Notification,
+	}
+	if src.Access == api.DOC_BOS_EDIT {
+		// keep the copied source file in the same user-owned bucket
+		regParam.Bucket = src.UploadInfo.Bucket
+	}
+	res, err := api.RegisterDocument(c, regParam)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := *c.Config
+	conf.Endpoint = res.BosEndpoint
+	bosClient := bce.NewBceClient(&conf, c.Signer)
+	source := fmt.Sprintf("/%s/%s", src.UploadInfo.Bucket, src.UploadInfo.Object)
+	if _, err := bosapi.CopyObject(bosClient, res.Bucket, res.Object, source, nil); err != nil {
+		api.DeleteDocument(c, res.DocumentId)
+		return nil, err
+	}
+
+	if err := c.PublishDocument(res.DocumentId); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
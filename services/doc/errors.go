@@ -0,0 +1,126 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// errors.go - define the error codes of the DOC service and the predicates to check them
+
+package doc
+
+import (
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// The error codes returned by the DOC service apis or set to the failed document
+const (
+	ERR_NO_SUCH_DOCUMENT      = "DocExceptions.NoSuchDocument"
+	ERR_INVALID_STATUS        = "DocExceptions.InvalidDocumentStatus"
+	ERR_UNSUPPORTED_FORMAT    = "DocExceptions.UnsupportedFormat"
+	ERR_FILE_TOO_LARGE        = "DocExceptions.FileTooLarge"
+	ERR_SOURCE_FILE_NOT_FOUND = "DocExceptions.SourceFileNotFound"
+	ERR_ENCRYPTED_DOCUMENT    = "DocExceptions.EncryptedDocument"
+	ERR_CONVERSION_FAILED     = "DocExceptions.ConversionFailed"
+	ERR_CHECKSUM_MISMATCH     = "DocExceptions.ChecksumMismatch"
+	ERR_REFERENCE_ID_CONFLICT = "DocExceptions.ReferenceIdConflict"
+)
+
+// ErrorCode - get the DOC error code of the error
+//
+// PARAMS:
+//     - err: the error returned by the DOC client or api.QueryDocumentResp.Err, including the
+//       *waiter.FailureError returned by WaitDocumentPublished
+// RETURNS:
+//     - string: the error code, empty if it is not an error of the DOC service
+func ErrorCode(err error) string {
+	if failure, ok := err.(*waiter.FailureError); ok {
+		err = failure.Err
+	}
+	switch e := err.(type) {
+	case *bce.BceServiceError:
+		return e.Code
+	case *api.ConversionError:
+		return e.Code
+	}
+	return ""
+}
+
+// IsNoSuchDocument - check whether the document does not exist
+func IsNoSuchDocument(err error) bool {
+	return ErrorCode(err) == ERR_NO_SUCH_DOCUMENT
+}
+
+// IsInvalidStatus - check whether the operation is not allowed in the current document status,
+// eg: publish the document twice
+func IsInvalidStatus(err error) bool {
+	return ErrorCode(err) == ERR_INVALID_STATUS
+}
+
+// IsUnsupportedFormat - check whether the format of the document is not supported, including
+// the format rejected by the client-side validation
+func IsUnsupportedFormat(err error) bool {
+	if vErr, ok := err.(*bce.ValidationError); ok {
+		for _, fieldErr := range vErr.Errors {
+			if fieldErr.Field == "format" {
+				return true
+			}
+		}
+		return false
+	}
+	return ErrorCode(err) == ERR_UNSUPPORTED_FORMAT
+}
+
+// IsFileTooLarge - check whether the source file of the document exceeds the size limit
+func IsFileTooLarge(err error) bool {
+	return ErrorCode(err) == ERR_FILE_TOO_LARGE
+}
+
+// IsSourceFileNotFound - check whether the document is published before the source file is
+// uploaded to the BOS
+func IsSourceFileNotFound(err error) bool {
+	return ErrorCode(err) == ERR_SOURCE_FILE_NOT_FOUND
+}
+
+// IsEncryptedDocument - check whether the document can not be converted for being encrypted
+func IsEncryptedDocument(err error) bool {
+	return ErrorCode(err) == ERR_ENCRYPTED_DOCUMENT
+}
+
+// IsConversionFailed - check whether the conversion of the document is failed for any reason
+func IsConversionFailed(err error) bool {
+	if failure, ok := err.(*waiter.FailureError); ok {
+		err = failure.Err
+	}
+	if _, ok := err.(*api.ConversionError); ok {
+		return true
+	}
+	return ErrorCode(err) == ERR_CONVERSION_FAILED
+}
+
+// IsChecksumMismatch - check whether the MD5 digest of the uploaded source file is not the same
+// as the one given when registering, detected by the client or the service
+func IsChecksumMismatch(err error) bool {
+	if failure, ok := err.(*waiter.FailureError); ok {
+		err = failure.Err
+	}
+	if _, ok := err.(*api.ChecksumMismatchError); ok {
+		return true
+	}
+	return ErrorCode(err) == ERR_CHECKSUM_MISMATCH
+}
+
+// IsReferenceIdConflict - check whether the reference id is already used by another document
+// registered with the different title or format
+func IsReferenceIdConflict(err error) bool {
+	return ErrorCode(err) == ERR_REFERENCE_ID_CONFLICT
+}
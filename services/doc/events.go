@@ -0,0 +1,203 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// events.go - define the helper to subscribe the conversion events of the document
+
+package doc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// DocumentEventType defines the type of the document event
+type DocumentEventType string
+
+const (
+	// DOC_EVENT_SNAPSHOT is the first event with the status of the document when subscribing
+	DOC_EVENT_SNAPSHOT DocumentEventType = "SNAPSHOT"
+	// DOC_EVENT_STATUS_CHANGED is emitted when the status or the sub status is changed
+	DOC_EVENT_STATUS_CHANGED DocumentEventType = "STATUS_CHANGED"
+	// DOC_EVENT_PROGRESS is emitted when only the progress percentage is changed
+	DOC_EVENT_PROGRESS DocumentEventType = "PROGRESS"
+	// DOC_EVENT_PUBLISHED is the last event if the document is published
+	DOC_EVENT_PUBLISHED DocumentEventType = "PUBLISHED"
+	// DOC_EVENT_FAILED is the last event if the conversion is failed, Err is the *api.ConversionError
+	DOC_EVENT_FAILED DocumentEventType = "FAILED"
+	// DOC_EVENT_ERROR is emitted when the query fails, the subscription keeps polling unless the
+	// document does not exist any more
+	DOC_EVENT_ERROR DocumentEventType = "ERROR"
+)
+
+// DOC_EVENT_BUFFER_SIZE is the buffer size of the channel returned by SubscribeDocumentEvents
+const DOC_EVENT_BUFFER_SIZE = 16
+
+// DocumentEvent stands for a status change of the document delivered by SubscribeDocumentEvents,
+// it is safe to be encoded as json and pushed to the browsers directly
+type DocumentEvent struct {
+	Type       DocumentEventType      `json:"type"`
+	DocumentId string                 `json:"documentId"`
+	Status     api.StatusType         `json:"status,omitempty"`
+	SubStatus  string                 `json:"subStatus,omitempty"`
+	Percent    int                    `json:"percent"`
+	ETA        time.Duration          `json:"eta,omitempty"`
+	Time       time.Time              `json:"time"`
+	Err        error                  `json:"-"`
+	Message    string                 `json:"message,omitempty"`
+	Document   *api.QueryDocumentResp `json:"-"`
+}
+
+// IsFinal - check whether the event is the last one of the subscription
+func (e *DocumentEvent) IsFinal() bool {
+	return e.Type == DOC_EVENT_PUBLISHED || e.Type == DOC_EVENT_FAILED ||
+		(e.Type == DOC_EVENT_ERROR && IsNoSuchDocument(e.Err))
+}
+
+// SubscribeDocumentEvents - poll the document status every DEFAULT_WATCH_INTERVAL and deliver the
+// changes over the returned channel, which is closed after the published or failed event, or
+// when the context is done
+//
+// PARAMS:
+//     - ctx: the context to cancel the subscription
+//     - documentId: id of document in doc service
+// RETURNS:
+//     - <-chan DocumentEvent: the events of the document, starting with a DOC_EVENT_SNAPSHOT
+//     - error: the error of the first query, such as the document does not exist
+func (c *Client) SubscribeDocumentEvents(ctx context.Context, documentId string) (<-chan DocumentEvent, error) {
+	return c.SubscribeDocumentEventsWithTrigger(ctx, documentId, nil)
+}
+
+// SubscribeDocumentEventsWithTrigger - the same as SubscribeDocumentEvents, and each value
+// received from the trigger queries the document immediately, so that the handler of the
+// notification callbacks configured by the document can push the changes without the delay
+//
+// PARAMS:
+//     - ctx: the context to cancel the subscription
+//     - documentId: id of document in doc service
+//     - trigger: the channel to wake up the polling, may be nil
+// RETURNS:
+//     - <-chan DocumentEvent: the events of the document, starting with a DOC_EVENT_SNAPSHOT
+//     - error: the error of the first query, such as the document does not exist
+func (c *Client) SubscribeDocumentEventsWithTrigger(ctx context.Context, documentId string,
+	trigger <-chan struct{}) (<-chan DocumentEvent, error) {
+	if len(documentId) == 0 {
+		return nil, fmt.Errorf("documentId should not be empty")
+	}
+	cli := c.WithContext(ctx)
+	doc, err := api.QueryDocument(cli, documentId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan DocumentEvent, DOC_EVENT_BUFFER_SIZE)
+	startTime, startPercent := time.Now(), doc.Progress
+	first := newDocumentEvent(DOC_EVENT_SNAPSHOT, documentId, doc, startTime, startPercent)
+	if t := finalEventType(doc); t != "" {
+		first.Type = t
+	}
+	go func() {
+		defer close(events)
+		send := func(e *DocumentEvent) bool {
+			select {
+			case events <- *e:
+				return !e.IsFinal()
+			case <-ctx.Done():
+				return false
+			}
+		}
+		if !send(first) {
+			return
+		}
+		last := first
+		timer := time.NewTimer(DEFAULT_WATCH_INTERVAL)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			case <-trigger:
+				if !timer.Stop() {
+					<-timer.C
+				}
+			}
+			timer.Reset(DEFAULT_WATCH_INTERVAL)
+
+			doc, err := api.QueryDocument(cli, documentId, nil)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				e := &DocumentEvent{Type: DOC_EVENT_ERROR, DocumentId: documentId,
+					Status: last.Status, SubStatus: last.SubStatus, Percent: last.Percent,
+					Time: time.Now(), Err: err, Message: err.Error()}
+				if !send(e) {
+					return
+				}
+				continue
+			}
+			current := newDocumentEvent(DOC_EVENT_PROGRESS, documentId, doc, startTime, startPercent)
+			if t := finalEventType(doc); t != "" {
+				current.Type = t
+			} else if current.Status != last.Status || current.SubStatus != last.SubStatus {
+				current.Type = DOC_EVENT_STATUS_CHANGED
+			} else if current.Percent == last.Percent {
+				continue
+			}
+			if !send(current) {
+				return
+			}
+			last = current
+		}
+	}()
+	return events, nil
+}
+
+// newDocumentEvent - build the event by the queried document
+func newDocumentEvent(eventType DocumentEventType, documentId string, doc *api.QueryDocumentResp,
+	startTime time.Time, startPercent int) *DocumentEvent {
+	now := time.Now()
+	e := &DocumentEvent{
+		Type:       eventType,
+		DocumentId: documentId,
+		Status:     api.StatusType(doc.Status),
+		SubStatus:  doc.SubStatus,
+		Percent:    doc.Progress,
+		Time:       now,
+		Document:   doc,
+	}
+	if doc.Status == string(api.DOC_STATUS_PUBLISHED) {
+		e.Percent = 100
+	} else if !doc.IsFinished() {
+		e.ETA = estimateRemaining(now.Sub(startTime), startPercent, e.Percent)
+	}
+	if e.Err = doc.Err(); e.Err != nil {
+		e.Message = e.Err.Error()
+	}
+	return e
+}
+
+// finalEventType - get the type of the last event if the conversion is finished, otherwise empty
+func finalEventType(doc *api.QueryDocumentResp) DocumentEventType {
+	switch doc.Status {
+	case string(api.DOC_STATUS_PUBLISHED):
+		return DOC_EVENT_PUBLISHED
+	case string(api.DOC_STATUS_FAILED):
+		return DOC_EVENT_FAILED
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// html.go - archive the converted HTML of a document as a self-contained zip
+
+package doc
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	bosapi "github.com/baidubce/bce-sdk-go/services/bos/api"
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// GetHtmlArchive - get the converted HTML of the published h5 document as a zip archive stream
+//
+// PARAMS:
+//     - documentId: id of the published h5 document
+// RETURNS:
+//     - *bce.StreamBody: the zip archive stream which must be closed by the caller
+//     - error: the return error if any occurs
+func (c *Client) GetHtmlArchive(documentId string) (*bce.StreamBody, error) {
+	return api.GetHtmlArchive(c, documentId)
+}
+
+// GetHtmlFiles - get the BOS location of the files of the converted HTML of the h5 document
+//
+// PARAMS:
+//     - documentId: id of the published h5 document
+// RETURNS:
+//     - *api.GetHtmlFilesResp: the bucket and the objects of the HTML files
+//     - error: the return error if any occurs
+func (c *Client) GetHtmlFiles(documentId string) (*api.GetHtmlFilesResp, error) {
+	return api.GetHtmlFiles(c, documentId)
+}
+
+// WriteHtmlArchive - write the converted HTML of the published h5 document to w as a zip, so
+// that the offline viewers and the archival systems can keep a self-contained copy. The archive
+// built by the service is copied if available, otherwise the archive is rebuilt from the HTML
+// files in BOS, which are read one by one without buffering the whole document.
+//
+// PARAMS:
+//     - documentId: id of the published h5 document
+//     - w: the writer of the zip archive
+// RETURNS:
+//     - error: the return error if any occurs
+func (c *Client) WriteHtmlArchive(documentId string, w io.Writer) error {
+	doc, err := api.QueryDocument(c, documentId, nil)
+	if err != nil {
+		return err
+	}
+	if err := doc.Err(); err != nil {
+		return err
+	}
+	if doc.Status != string(api.DOC_STATUS_PUBLISHED) || doc.TargetType != api.DOC_TARGET_H5 {
+		return fmt.Errorf("document %s is not a published h5 document: status %s, target %s",
+			documentId, doc.Status, doc.TargetType)
+	}
+
+	archive, err := api.GetHtmlArchive(c, documentId)
+	if err == nil {
+		defer archive.Close()
+		_, err = io.Copy(w, archive)
+		return err
+	}
+	if serviceErr, ok := err.(*bce.BceServiceError); !ok || serviceErr.StatusCode != http.StatusNotFound {
+		return err
+	}
+	return c.buildHtmlArchive(documentId, w)
+}
+
+// buildHtmlArchive - rebuild the zip archive from the HTML files of the document in BOS
+func (c *Client) buildHtmlArchive(documentId string, w io.Writer) error {
+	files, err := api.GetHtmlFiles(c, documentId)
+	if err != nil {
+		return err
+	}
+	if len(files.Files) == 0 {
+		return fmt.Errorf("document %s has no converted html files", documentId)
+	}
+
+	conf := *c.Config
+	conf.Endpoint = files.BosEndpoint
+	bosClient := bce.NewBceClient(&conf, c.Signer)
+
+	zw := zip.NewWriter(w)
+	for _, file := range files.Files {
+		name := path.Clean(strings.TrimLeft(file.Path, "/"))
+		if name == "." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("invalid html file path: %s", file.Path)
+		}
+		if err := copyObjectToZip(bosClient, zw, name, files.Bucket, file.Object); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func copyObjectToZip(cli bce.Client, zw *zip.Writer, name, bucket, object string) error {
+	obj, err := bosapi.GetObject(cli, bucket, object, nil)
+	if err != nil {
+		return err
+	}
+	defer obj.Body.Close()
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, obj.Body)
+	return err
+}
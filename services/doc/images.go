@@ -0,0 +1,305 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// images.go - download the page images of the document concurrently with retries
+
+package doc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+	"github.com/baidubce/bce-sdk-go/util"
+)
+
+const (
+	DEFAULT_IMAGES_PARALLEL          = 5
+	DEFAULT_IMAGES_RETRY             = 3
+	DEFAULT_IMAGES_EXPIRE_IN_SECONDS = 1800
+	DEFAULT_IMAGE_EXT                = ".png"
+
+	// IMAGES_MANIFEST_NAME is the file name of the manifest written to the target directory
+	IMAGES_MANIFEST_NAME = "manifest.json"
+)
+
+// DownloadImagesParam - the parameters to download the page images of the document
+type DownloadImagesParam struct {
+	Parallel        int             // the images downloaded at the same time, 0 means default
+	Retry           int             // the retries of each image, 0 means default, negative none
+	ExpireInSeconds int             // the expiration of the presigned image urls, 0 means default
+	Backoff         *waiter.Backoff // the delays between the retries, nil means 1s up to 10s
+
+	// OnComplete is called with the manifest after all the images are done and the manifest is
+	// written, no matter whether some of the images failed
+	OnComplete func(*ImagesManifest)
+}
+
+// ImageFile - the download result of a page image
+type ImageFile struct {
+	PageIndex int64  `json:"pageIndex"`
+	File      string `json:"file"` // the file name in the target directory
+	Size      int64  `json:"size"`
+	Attempts  int    `json:"attempts"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ImagesManifest - the manifest of the downloaded images, which is written to the target
+// directory as IMAGES_MANIFEST_NAME
+type ImagesManifest struct {
+	DocumentId   string      `json:"documentId"`
+	Images       []ImageFile `json:"images"` // ordered by the page index
+	Failed       int         `json:"failed"`
+	CompleteTime string      `json:"completeTime"`
+}
+
+// Err - get the error if any of the images failed
+func (m *ImagesManifest) Err() error {
+	if m.Failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to download %d of %d images of document %s",
+		m.Failed, len(m.Images), m.DocumentId)
+}
+
+// GetImagesWithParam - get the images of the document with the presigned urls
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+//     - param: the expiration of the image urls, nil means the urls returned by the service
+// RETURNS:
+//     - *api.GetImagesResp: the images with the presigned urls
+//     - error: the return error if any occurs
+func (c *Client) GetImagesWithParam(documentId string, param *api.GetImagesParam) (*api.GetImagesResp, error) {
+	return api.GetImagesWithParam(c, documentId, param)
+}
+
+// GetImageStream - get the image of the document page generated by the conversion as a stream
+// without buffering it in memory, the image is read from its url returned by the GetImages
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+//     - pageIndex: the page index of the image, the same as the PageIndex of the GetImages
+// RETURNS:
+//     - *bce.StreamBody: the image stream which must be closed by the caller
+//     - error: the return error if any occurs
+func (c *Client) GetImageStream(documentId string, pageIndex int64) (*bce.StreamBody, error) {
+	images, err := api.GetImages(c, documentId)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images.Images {
+		if image.PageIndex != pageIndex {
+			continue
+		}
+		ctx := c.Config.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		resp, err := c.openImage(ctx, image.Url, fmt.Sprintf("of page %d", pageIndex))
+		if err != nil {
+			return nil, err
+		}
+		return &bce.StreamBody{
+			Body:          resp.Body,
+			ContentLength: resp.ContentLength,
+			ContentType:   resp.Header.Get("Content-Type"),
+			ETag:          strings.Trim(resp.Header.Get("ETag"), "\""),
+		}, nil
+	}
+	return nil, fmt.Errorf("document %s has no image of page %d", documentId, pageIndex)
+}
+
+// DownloadImages - download all the page images of the document to the directory concurrently,
+// each image is retried on failure and saved as page-<index>.<ext>, then the manifest of the
+// results is written to the directory and passed to the OnComplete callback
+//
+// PARAMS:
+//     - ctx: the context to cancel the downloading
+//     - documentId: id of document in doc service
+//     - dir: the target directory, created if not exists
+//     - param: the parallel, retry and expiration, nil means the defaults
+// RETURNS:
+//     - *ImagesManifest: the results of the images, returned even if some of the images failed
+//     - error: the error of listing the images or writing the manifest, or the manifest Err
+func (c *Client) DownloadImages(ctx context.Context, documentId, dir string,
+	param *DownloadImagesParam) (*ImagesManifest, error) {
+	if param == nil {
+		param = &DownloadImagesParam{}
+	}
+	parallel, retry, expire := param.Parallel, param.Retry, param.ExpireInSeconds
+	if parallel <= 0 {
+		parallel = DEFAULT_IMAGES_PARALLEL
+	}
+	if retry == 0 {
+		retry = DEFAULT_IMAGES_RETRY
+	} else if retry < 0 {
+		retry = 0
+	}
+	if expire <= 0 {
+		expire = DEFAULT_IMAGES_EXPIRE_IN_SECONDS
+	}
+	backoff := param.Backoff
+	if backoff == nil {
+		backoff = &waiter.Backoff{Initial: time.Second, Max: 10 * time.Second, Multiplier: 2}
+	}
+
+	images, err := api.GetImagesWithParam(c.WithContext(ctx), documentId,
+		&api.GetImagesParam{ExpireInSeconds: expire})
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	sort.Slice(images.Images, func(i, j int) bool {
+		return images.Images[i].PageIndex < images.Images[j].PageIndex
+	})
+
+	manifest := &ImagesManifest{
+		DocumentId: documentId,
+		Images:     make([]ImageFile, len(images.Images)),
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallel && i < len(images.Images); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range indexes {
+				manifest.Images[k] = c.downloadImage(ctx, images.Images[k], dir, retry, backoff)
+			}
+		}()
+	}
+	for k := range images.Images {
+		indexes <- k
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, image := range manifest.Images {
+		if image.Error != "" {
+			manifest.Failed++
+		}
+	}
+	manifest.CompleteTime = util.FormatISO8601Date(util.NowUTCSeconds())
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, IMAGES_MANIFEST_NAME), raw, 0644); err != nil {
+		return manifest, err
+	}
+	if param.OnComplete != nil {
+		param.OnComplete(manifest)
+	}
+	return manifest, manifest.Err()
+}
+
+// downloadImage - download the image to the directory with retries
+func (c *Client) downloadImage(ctx context.Context, image api.ImageResp, dir string, retry int,
+	backoff *waiter.Backoff) ImageFile {
+	ext := DEFAULT_IMAGE_EXT
+	if u, err := url.Parse(image.Url); err == nil && path.Ext(u.Path) != "" {
+		ext = path.Ext(u.Path)
+	}
+	result := ImageFile{
+		PageIndex: image.PageIndex,
+		File:      fmt.Sprintf("page-%d%s", image.PageIndex, ext),
+	}
+	for {
+		result.Attempts++
+		size, err := c.downloadImageFile(ctx, image.Url, filepath.Join(dir, result.File))
+		if err == nil {
+			result.Size, result.Error = size, ""
+			return result
+		}
+		result.Error = err.Error()
+		if result.Attempts > retry || ctx.Err() != nil {
+			return result
+		}
+		timer := time.NewTimer(backoff.Delay(result.Attempts))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			result.Error = ctx.Err().Error()
+			return result
+		case <-timer.C:
+		}
+	}
+}
+
+// openImage - send the unsigned GET request of the image url by the GetUrl with the http client,
+// proxy and timeout of the client since the url carries the signature, the errors refer to the
+// name of the image instead of the url to keep the signature out of them
+func (c *Client) openImage(ctx context.Context, imageUrl, name string) (*http.Response, error) {
+	resp, err := c.WithContext(ctx).GetUrl(imageUrl)
+	if urlErr, ok := err.(*url.Error); ok {
+		return nil, fmt.Errorf("download the image %s failed: %v", name, urlErr.Err)
+	} else if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		resp.Body().Close()
+		return nil, fmt.Errorf("download the image %s failed: %s", name, resp.StatusText())
+	}
+	return resp.HttpResponse(), nil
+}
+
+// downloadImageFile - download the url to a temporary file and rename it to the target file, so
+// that the target file is either complete or not exists. The errors refer to the file instead of
+// the url to keep the signature of the presigned url out of the manifest.
+func (c *Client) downloadImageFile(ctx context.Context, imageUrl, file string) (int64, error) {
+	name := filepath.Base(file)
+	resp, err := c.openImage(ctx, imageUrl, name)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), name+".tmp")
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(tmp, resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && resp.ContentLength >= 0 && size != resp.ContentLength {
+		err = fmt.Errorf("download the image %s failed: %d of %d bytes received",
+			name, size, resp.ContentLength)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return 0, err
+	}
+	return size, nil
+}
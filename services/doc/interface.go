@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the DOC client
+
+package doc
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// Interface defines all the operations of the DOC client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	RegisterDocument(regParam *api.RegDocumentParam) (*api.RegDocumentResp, error)
+	PublishDocument(documentId string) error
+	GetDocumentByReference(referenceId string, queryParam *api.QueryDocumentParam) (*api.QueryDocumentResp, error)
+	QueryDocument(documentId string, queryParam *api.QueryDocumentParam) (*api.QueryDocumentResp, error)
+	ReadDocument(documentId string, readParam *api.ReadDocumentParam) (*api.ReadDocumentResp, error)
+	GetImages(documentId string) (*api.GetImagesResp, error)
+	GetThumbnails(documentId string, param *api.ThumbnailParam) ([]api.ThumbnailResp, error)
+	GetText(documentId string, param *api.GetTextParam) (*api.GetTextResp, error)
+	DeleteDocument(documentId string) error
+	ListDocuments(listParam *api.ListDocumentsParam) (*api.ListDocumentsResp, error)
+	ConvertWithDeadline(ctx context.Context, filePath, title string, deadline time.Time, opts ...Option) (*api.QueryDocumentResp, error)
+	CopyDocument(documentId, newTitle string) (*api.RegDocumentResp, error)
+	SubscribeDocumentEvents(ctx context.Context, documentId string) (<-chan DocumentEvent, error)
+	SubscribeDocumentEventsWithTrigger(ctx context.Context, documentId string, trigger <-chan struct{}) (<-chan DocumentEvent, error)
+	GetHtmlArchive(documentId string) (*bce.StreamBody, error)
+	GetHtmlFiles(documentId string) (*api.GetHtmlFilesResp, error)
+	WriteHtmlArchive(documentId string, w io.Writer) error
+	GetImagesWithParam(documentId string, param *api.GetImagesParam) (*api.GetImagesResp, error)
+	GetImageStream(documentId string, pageIndex int64) (*bce.StreamBody, error)
+	DownloadImages(ctx context.Context, documentId, dir string, param *DownloadImagesParam) (*ImagesManifest, error)
+	Register(title, format string, opts ...Option) (*api.RegDocumentResp, error)
+	Query(documentId string, opts ...Option) (*api.QueryDocumentResp, error)
+	Read(documentId string, opts ...Option) (*api.ReadDocumentResp, error)
+	List(opts ...Option) (*api.ListDocumentsResp, error)
+	WatchProgress(ctx context.Context, documentId string, callback func(*Progress)) (*api.QueryDocumentResp, error)
+	WaitDocumentPublished(ctx context.Context, documentId string) (*api.QueryDocumentResp, error)
+	DocumentTask(documentId string) waiter.Task
+	GetDocumentWithToken(documentId string, expireInSeconds int64) (*DocumentWithToken, error)
+	SearchDocuments(keyword string, param *SearchDocumentsParam) (*SearchDocumentsResult, error)
+	WalkText(documentId string, batchPages int, fn func(*api.PageText) error) error
+	NewTokenCache(expireInSeconds int64, refreshBefore time.Duration) *TokenCache
+	NewTokenCacheWithParam(param *api.ReadDocumentParam, refreshBefore time.Duration) *TokenCache
+	CreateDocumentFromFile(filePath, title string, opts ...Option) (*api.RegDocumentResp, error)
+	CreateDocumentFromURL(sourceUrl, title, format string, opts ...Option) (*api.RegDocumentResp, error)
+	GetUsage(param *UsageParam) (*Usage, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk string) (Interface, error) {
+	client, err := NewClient(ak, sk)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,294 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// options.go - define the functional options facade of the DOC client
+
+package doc
+
+import (
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// Option defines the functional option to set the optional parameters of the facade methods.
+// The options not used by the called method are ignored.
+type Option func(*options)
+
+type options struct {
+	https        bool
+	expiry       time.Duration
+	watermark    string
+	domains      []string
+	pageStart    int64
+	pageEnd      int64
+	targetType   string
+	access       string
+	notification string
+	bucket       string
+	object       string
+	status       api.StatusType
+	marker       string
+	maxSize      int64
+	order        string
+	titlePrefix  string
+	titleKeyword string
+	format       string
+	createFrom   time.Time
+	createTo     time.Time
+	noCache      bool
+	md5          string
+	referenceId  string
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// WithHTTPS sets whether the returned urls use the https protocol.
+func WithHTTPS(https bool) Option {
+	return func(o *options) { o.https = https }
+}
+
+// WithExpiry sets the expiration duration of the read token.
+func WithExpiry(d time.Duration) Option {
+	return func(o *options) { o.expiry = d }
+}
+
+// WithWatermark sets the text watermark rendered on every page read with the read token.
+func WithWatermark(text string) Option {
+	return func(o *options) { o.watermark = text }
+}
+
+// WithAllowedDomains sets the referrer domains allowed to embed the viewer with the read token.
+func WithAllowedDomains(domains ...string) Option {
+	return func(o *options) { o.domains = domains }
+}
+
+// WithPageRange sets the pages readable with the read token, 0 means the first or last page.
+func WithPageRange(start, end int64) Option {
+	return func(o *options) {
+		o.pageStart = start
+		o.pageEnd = end
+	}
+}
+
+// WithTargetType sets the conversion target type of the registered document, h5 or image.
+func WithTargetType(targetType string) Option {
+	return func(o *options) { o.targetType = targetType }
+}
+
+// WithAccess sets the access of the registered document, PUBLIC, PRIVATE or bosEdit.
+func WithAccess(access string) Option {
+	return func(o *options) { o.access = access }
+}
+
+// WithNotification sets the notification name of the registered document.
+func WithNotification(notification string) Option {
+	return func(o *options) { o.notification = notification }
+}
+
+// WithBucket sets the location in the user-owned bucket to upload the source file of the
+// registered document to, the object is generated by the service if it is empty.
+func WithBucket(bucket, object string) Option {
+	return func(o *options) {
+		o.bucket = bucket
+		o.object = object
+	}
+}
+
+// WithMD5 sets the hex encoded MD5 digest of the source file of the registered document, which
+// is verified by the service when publishing.
+func WithMD5(md5 string) Option {
+	return func(o *options) { o.md5 = md5 }
+}
+
+// WithReferenceId sets the unique reference id of the registered document, registering with the
+// same reference id again returns the same document so that the registration is idempotent.
+func WithReferenceId(referenceId string) Option {
+	return func(o *options) { o.referenceId = referenceId }
+}
+
+// WithStatus sets the document status to list.
+func WithStatus(status api.StatusType) Option {
+	return func(o *options) { o.status = status }
+}
+
+// WithMarker sets the marker to start listing from.
+func WithMarker(marker string) Option {
+	return func(o *options) { o.marker = marker }
+}
+
+// WithMaxSize sets the max number of documents to list.
+func WithMaxSize(maxSize int64) Option {
+	return func(o *options) { o.maxSize = maxSize }
+}
+
+// WithOrder sets the order of the create time to list documents, api.DOC_ORDER_ASC or
+// api.DOC_ORDER_DESC.
+func WithOrder(order string) Option {
+	return func(o *options) { o.order = order }
+}
+
+// WithTitlePrefix sets the title prefix of the documents to list, filtered on client side.
+func WithTitlePrefix(prefix string) Option {
+	return func(o *options) { o.titlePrefix = prefix }
+}
+
+// WithTitleKeyword sets the keyword contained in the title of the documents to list, case
+// insensitive and filtered on client side.
+func WithTitleKeyword(keyword string) Option {
+	return func(o *options) { o.titleKeyword = keyword }
+}
+
+// WithFormat sets the format of the documents to list, filtered on client side.
+func WithFormat(format string) Option {
+	return func(o *options) { o.format = format }
+}
+
+// WithCreateTimeRange sets the [from, to) create time range of the documents to list, filtered
+// on client side. The zero time means no limit.
+func WithCreateTimeRange(from, to time.Time) Option {
+	return func(o *options) {
+		o.createFrom = from
+		o.createTo = to
+	}
+}
+
+// WithNoCache bypasses the cache configured by the Config.Cache and refreshes the cached result.
+func WithNoCache() Option {
+	return func(o *options) { o.noCache = true }
+}
+
+// Register - register document in doc service with functional options
+//
+// PARAMS:
+//     - title: the title of the document
+//     - format: the format of the document, eg: doc, pdf, txt
+//     - opts: WithTargetType, WithAccess, WithNotification, WithBucket, WithMD5 and
+//       WithReferenceId are supported
+// RETURNS:
+//     - *api.RegDocumentResp: id and document location in bos
+//     - error: the return error if any occurs
+func (c *Client) Register(title, format string, opts ...Option) (*api.RegDocumentResp, error) {
+	o := newOptions(opts)
+	return api.RegisterDocument(c, &api.RegDocumentParam{
+		Title:        title,
+		Format:       format,
+		TargetType:   o.targetType,
+		Access:       o.access,
+		Notification: o.notification,
+		Bucket:       o.bucket,
+		Object:       o.object,
+		MD5:          o.md5,
+		ReferenceId:  o.referenceId,
+	})
+}
+
+// Query - query document's status with functional options
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+//     - opts: WithHTTPS and WithNoCache are supported
+// RETURNS:
+//     - *api.QueryDocumentResp
+//     - error: the return error if any occurs
+func (c *Client) Query(documentId string, opts ...Option) (*api.QueryDocumentResp, error) {
+	o := newOptions(opts)
+	queryParam := &api.QueryDocumentParam{Https: o.https}
+	if !o.noCache {
+		return c.QueryDocument(documentId, queryParam)
+	}
+	result, err := api.QueryDocument(c, documentId, queryParam)
+	if err == nil {
+		bce.SetCachedResult(c, queryDocumentCacheKey(documentId, o.https), result)
+	}
+	return result, err
+}
+
+// Read - get document token for client sdk with functional options
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+//     - opts: WithExpiry, WithWatermark, WithAllowedDomains and WithPageRange are supported,
+//       the server default is used if not set
+// RETURNS:
+//     - *api.ReadDocumentResp
+//     - error: the return error if any occurs
+func (c *Client) Read(documentId string, opts ...Option) (*api.ReadDocumentResp, error) {
+	o := newOptions(opts)
+	if o.expiry < 0 {
+		o.expiry = 0
+	}
+	return api.ReadDocument(c, documentId, &api.ReadDocumentParam{
+		ExpireInSeconds: int64(o.expiry / time.Second),
+		Watermark:       o.watermark,
+		AllowedDomains:  o.domains,
+		PageStart:       o.pageStart,
+		PageEnd:         o.pageEnd,
+	})
+}
+
+// List - list documents with functional options
+//
+// PARAMS:
+//     - opts: WithStatus, WithMarker, WithMaxSize, WithOrder, WithTitlePrefix, WithTitleKeyword,
+//       WithFormat and WithCreateTimeRange are supported
+// RETURNS:
+//     - *api.ListDocumentsResp: the result docments list structure
+//     - error: the return error if any occurs
+func (c *Client) List(opts ...Option) (*api.ListDocumentsResp, error) {
+	o := newOptions(opts)
+	return api.ListDocuments(c, &api.ListDocumentsParam{
+		Status:         o.status,
+		Marker:         o.marker,
+		MaxSize:        o.maxSize,
+		Order:          o.order,
+		TitlePrefix:    o.titlePrefix,
+		TitleKeyword:   o.titleKeyword,
+		Format:         o.format,
+		CreateTimeFrom: o.createFrom,
+		CreateTimeTo:   o.createTo,
+	})
+}
+
+// DefaultQueryParam returns the param used by QueryDocument and GetDocumentByReference if nil is
+// given, it can be modified and passed to them explicitly.
+func DefaultQueryParam() *api.QueryDocumentParam {
+	return api.DefaultQueryDocumentParam()
+}
+
+// DefaultReadParam returns the param used by ReadDocument if nil is given, the read token has
+// the default expiration of the service without watermark and access control.
+func DefaultReadParam() *api.ReadDocumentParam {
+	return api.DefaultReadDocumentParam()
+}
+
+// DefaultListParam returns the param used by ListDocuments if nil is given, which lists the first
+// page of the documents in any status.
+func DefaultListParam() *api.ListDocumentsParam {
+	return api.DefaultListDocumentsParam()
+}
+
+// DefaultTextParam returns the param used by GetText if nil is given, which gets all the pages.
+func DefaultTextParam() *api.GetTextParam {
+	return api.DefaultGetTextParam()
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// progress.go - define the helper to watch the conversion progress of the document
+
+package doc
+
+import (
+	"context"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// DEFAULT_WATCH_INTERVAL is the default interval to query the document status by WatchProgress
+const DEFAULT_WATCH_INTERVAL = 3 * time.Second
+
+// Progress stands for a conversion progress update of the document.
+type Progress struct {
+	DocumentId string
+	Status     api.StatusType
+	SubStatus  string
+	Percent    int
+	// ETA is the estimated remaining time calculated by the progress speed since watching, it is
+	// zero if the progress has not changed yet or the conversion is finished
+	ETA      time.Duration
+	Document *api.QueryDocumentResp
+}
+
+// WatchProgress - poll the document status until it is published or failed, and invoke the
+// callback when the status or progress changes
+//
+// PARAMS:
+//     - ctx: the context to cancel watching
+//     - documentId: id of document in doc service
+//     - callback: the function to receive the progress updates, may be nil
+// RETURNS:
+//     - *api.QueryDocumentResp: the last queried document
+//     - error: the query error, or the error of the context if it is done
+func (c *Client) WatchProgress(ctx context.Context, documentId string,
+	callback func(*Progress)) (*api.QueryDocumentResp, error) {
+	var last *Progress
+	startTime, startPercent := time.Time{}, 0
+	op := func(ctx context.Context) (interface{}, error) {
+		doc, err := api.QueryDocument(c.WithContext(ctx), documentId, nil)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		if startTime.IsZero() {
+			startTime, startPercent = now, doc.Progress
+		}
+		current := &Progress{
+			DocumentId: documentId,
+			Status:     api.StatusType(doc.Status),
+			SubStatus:  doc.SubStatus,
+			Percent:    doc.Progress,
+			Document:   doc,
+		}
+		if doc.IsFinished() {
+			if doc.Status == string(api.DOC_STATUS_PUBLISHED) {
+				current.Percent = 100
+			}
+		} else {
+			current.ETA = estimateRemaining(now.Sub(startTime), startPercent, current.Percent)
+		}
+		if callback != nil && (last == nil || last.Status != current.Status ||
+			last.SubStatus != current.SubStatus || last.Percent != current.Percent) {
+			callback(current)
+		}
+		last = current
+		return doc, nil
+	}
+	finished := waiter.Acceptor{
+		State: waiter.StateSuccess,
+		Matcher: func(result interface{}, err error) bool {
+			return err == nil && result.(*api.QueryDocumentResp).IsFinished()
+		},
+	}
+	result, err := waiter.Wait(ctx, op, []waiter.Acceptor{finished}, fixedBackoff())
+	if timeout, ok := err.(*waiter.TimeoutError); ok {
+		err = timeout.Err
+	}
+	doc, _ := result.(*api.QueryDocumentResp)
+	return doc, err
+}
+
+// WaitDocumentPublished - poll the document status with the default backoff of the waiter until
+// it is published or failed
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - documentId: id of document in doc service
+// RETURNS:
+//     - *api.QueryDocumentResp: the last queried document
+//     - error: nil if published, *waiter.FailureError with the *api.ConversionError if failed,
+//       *waiter.TimeoutError if the context is done, otherwise the query error
+func (c *Client) WaitDocumentPublished(ctx context.Context, documentId string) (*api.QueryDocumentResp, error) {
+	task := c.DocumentTask(documentId)
+	err := task.Wait(ctx)
+	doc, _ := task.Result().(*api.QueryDocumentResp)
+	return doc, err
+}
+
+// DocumentTask - get the async task of the document conversion, the result of the task is the
+// *api.QueryDocumentResp of the last query
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+// RETURNS:
+//     - waiter.Task: the task finished when the document is published, or failed with the
+//       *api.ConversionError
+func (c *Client) DocumentTask(documentId string) waiter.Task {
+	return waiter.NewTask(documentId, func(ctx context.Context) (interface{}, waiter.State, error) {
+		doc, err := api.QueryDocument(c.WithContext(ctx), documentId, nil)
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		if err := doc.Err(); err != nil {
+			return doc, waiter.StateFailure, err
+		}
+		if doc.Status == string(api.DOC_STATUS_PUBLISHED) {
+			return doc, waiter.StateSuccess, nil
+		}
+		return doc, waiter.StateRetry, nil
+	}, nil)
+}
+
+// fixedBackoff - poll every DEFAULT_WATCH_INTERVAL
+func fixedBackoff() *waiter.Backoff {
+	return &waiter.Backoff{Initial: DEFAULT_WATCH_INTERVAL, Max: DEFAULT_WATCH_INTERVAL}
+}
+
+// estimateRemaining - estimate the remaining time by the average speed of the progress
+//
+// PARAMS:
+//     - elapsed: the elapsed time since the first progress
+//     - from: the first progress percentage
+//     - to: the current progress percentage
+// RETURNS:
+//     - time.Duration: the estimated remaining time, zero if unknown
+func estimateRemaining(elapsed time.Duration, from, to int) time.Duration {
+	if to <= from || to >= 100 || elapsed <= 0 {
+		return 0
+	}
+	return elapsed * time.Duration(100-to) / time.Duration(to-from)
+}
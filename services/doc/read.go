@@ -0,0 +1,69 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// read.go - get the status and the read token of a document in one call for the page rendering
+
+package doc
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// DocumentWithToken defines the status and metadata of the document with the read token for the
+// front-end reader, the Token is nil if the document is not published
+type DocumentWithToken struct {
+	*api.QueryDocumentResp
+	Token *api.ReadDocumentResp
+}
+
+// GetDocumentWithToken - query the document and get the read token concurrently, so that the
+// page rendering costs one round trip instead of two
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+//     - expireInSeconds: the expiration of the read token, the default of the service if it is 0
+// RETURNS:
+//     - *DocumentWithToken: the document and the read token if it is published
+//     - error: the error of the query, or the error of the read if the document is published
+func (c *Client) GetDocumentWithToken(documentId string, expireInSeconds int64) (*DocumentWithToken, error) {
+	var readParam *api.ReadDocumentParam
+	if expireInSeconds > 0 {
+		readParam = &api.ReadDocumentParam{ExpireInSeconds: expireInSeconds}
+	}
+	type readResult struct {
+		token *api.ReadDocumentResp
+		err   error
+	}
+	readChan := make(chan readResult, 1)
+	go func() {
+		token, err := api.ReadDocument(c, documentId, readParam)
+		readChan <- readResult{token, err}
+	}()
+
+	doc, err := c.QueryDocument(documentId, nil)
+	read := <-readChan
+	if err != nil {
+		return nil, err
+	}
+	result := &DocumentWithToken{QueryDocumentResp: doc}
+	if doc.Status != string(api.DOC_STATUS_PUBLISHED) {
+		// the read token is only available for the published document
+		return result, nil
+	}
+	if read.err != nil {
+		return nil, read.err
+	}
+	result.Token = read.token
+	return result, nil
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// search.go - search the documents by the keyword in the title across the pages of the list
+
+package doc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+const (
+	// the page size of the list when scanning for the search
+	SEARCH_PAGE_SIZE = 200
+
+	// the default max number of the matched documents returned by one search
+	DEFAULT_SEARCH_RESULTS = 100
+)
+
+// SearchDocumentsParam defines the arguments to search documents. The DOC service does not
+// support filtering by title, so the search lists the documents page by page and matches them on
+// client side, the MaxScanned bounds the cost of the search for the users with lots of documents.
+type SearchDocumentsParam struct {
+	// the server side and client side filters besides the keyword, the Marker continues the
+	// previous search with its NextMarker and the MaxSize is the page size, default 200
+	api.ListDocumentsParam
+
+	// stop after the page in which the matched documents reach MaxResults, default 100, the
+	// returned documents may exceed it by less than a page so that no match is skipped
+	MaxResults int
+
+	// stop after scanning MaxScanned documents even if there is not enough matches, 0 for no limit
+	MaxScanned int
+}
+
+// SearchDocumentsResult defines the matched documents of the search. Continue the search with the
+// NextMarker as the Marker of the param if IsTruncated is true.
+type SearchDocumentsResult struct {
+	Docs        []api.DocumentResp
+	IsTruncated bool
+	NextMarker  string
+	Scanned     int // the number of the documents scanned by the search
+}
+
+// SearchDocuments - search the documents whose title contains the keyword case insensitively,
+// the status and create time of the matched documents are returned as listed
+//
+// PARAMS:
+//     - keyword: the keyword contained in the title, should not be empty
+//     - param: the optional arguments to search documents
+// RETURNS:
+//     - *SearchDocumentsResult: the matched documents and the marker to continue the search
+//     - error: the return error if any occurs
+func (c *Client) SearchDocuments(keyword string,
+	param *SearchDocumentsParam) (*SearchDocumentsResult, error) {
+	if strings.TrimSpace(keyword) == "" {
+		return nil, fmt.Errorf("please set the keyword to search")
+	}
+	if param == nil {
+		param = &SearchDocumentsParam{}
+	}
+	maxResults := param.MaxResults
+	if maxResults <= 0 {
+		maxResults = DEFAULT_SEARCH_RESULTS
+	}
+	filter := param.ListDocumentsParam
+	filter.TitleKeyword = keyword
+	if err := filter.Check(); err != nil {
+		return nil, err
+	}
+	// list by the server side filters only and match on client side here to count the scanned
+	listParam := api.ListDocumentsParam{
+		Status:  filter.Status,
+		Marker:  filter.Marker,
+		MaxSize: filter.MaxSize,
+		Order:   filter.Order,
+	}
+	if listParam.MaxSize == 0 {
+		listParam.MaxSize = SEARCH_PAGE_SIZE
+	}
+
+	result := &SearchDocumentsResult{Docs: []api.DocumentResp{}}
+	for {
+		page, err := api.ListDocuments(c, &listParam)
+		if err != nil {
+			return nil, err
+		}
+		result.Scanned += len(page.Docs)
+		for i := range page.Docs {
+			if filter.Match(&page.Docs[i]) {
+				result.Docs = append(result.Docs, page.Docs[i])
+			}
+		}
+		result.IsTruncated = page.IsTruncated && page.NextMarker != ""
+		result.NextMarker = ""
+		if !result.IsTruncated {
+			return result, nil
+		}
+		result.NextMarker = page.NextMarker
+		if len(result.Docs) >= maxResults ||
+			(param.MaxScanned > 0 && result.Scanned >= param.MaxScanned) {
+			return result, nil
+		}
+		listParam.Marker = page.NextMarker
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// text.go - stream the extracted page texts of a document in batches
+
+package doc
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// DEFAULT_TEXT_BATCH_PAGES is the number of pages fetched by a request of the WalkText
+const DEFAULT_TEXT_BATCH_PAGES = 50
+
+// WalkText - fetch the extracted texts of the document pages batch by batch and call the fn for
+// every page in order, so that the large document can be indexed without holding all the texts
+//
+// PARAMS:
+//     - documentId: id of the published document in doc service
+//     - batchPages: the number of pages of every request, DEFAULT_TEXT_BATCH_PAGES if not positive
+//     - fn: the function called for every page, the walking stops and returns its error if any
+// RETURNS:
+//     - error: the error of the requests or the fn if any occurs
+func (c *Client) WalkText(documentId string, batchPages int, fn func(*api.PageText) error) error {
+	if batchPages <= 0 {
+		batchPages = DEFAULT_TEXT_BATCH_PAGES
+	}
+	doc, err := api.QueryDocument(c, documentId, nil)
+	if err != nil {
+		return err
+	}
+	if err := doc.Err(); err != nil {
+		return err
+	}
+	pageCount := doc.PublishInfo.PageCount
+	for start := 1; start <= pageCount; start += batchPages {
+		end := start + batchPages - 1
+		if end > pageCount {
+			end = pageCount
+		}
+		res, err := api.GetText(c, documentId, &api.GetTextParam{PageStart: start, PageEnd: end})
+		if err != nil {
+			return err
+		}
+		for i := range res.Texts {
+			if err := fn(&res.Texts[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// token.go - cache the read tokens of the documents until they are about to expire
+
+package doc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+const (
+	DEFAULT_TOKEN_EXPIRE_SECONDS = 3600
+	DEFAULT_TOKEN_REFRESH_BEFORE = time.Minute
+)
+
+// TokenCache caches the read token of every document and refreshes it when it is about to
+// expire, it is safe for concurrent use and the concurrent Get of the same document waits for
+// one ReadDocument request instead of sending their own.
+type TokenCache struct {
+	client          *Client
+	expireInSeconds int64
+	param           api.ReadDocumentParam
+	refreshBefore   time.Duration
+	now             func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*tokenEntry
+}
+
+type tokenEntry struct {
+	done     chan struct{} // closed when the token is fetched
+	token    *api.ReadDocumentResp
+	expireAt time.Time
+	err      error
+}
+
+// NewTokenCache - create the cache of the read tokens fetched by the client
+//
+// PARAMS:
+//     - expireInSeconds: the expiration of the read tokens, DEFAULT_TOKEN_EXPIRE_SECONDS if it
+//       is not positive
+//     - refreshBefore: the token is refreshed when it expires within the duration, so that the
+//       returned token is valid for at least the duration, DEFAULT_TOKEN_REFRESH_BEFORE if it is
+//       not positive, it should be less than the expiration
+// RETURNS:
+//     - *TokenCache: the token cache
+func (c *Client) NewTokenCache(expireInSeconds int64, refreshBefore time.Duration) *TokenCache {
+	return c.NewTokenCacheWithParam(&api.ReadDocumentParam{ExpireInSeconds: expireInSeconds},
+		refreshBefore)
+}
+
+// NewTokenCacheWithParam - create the cache of the read tokens fetched with the same access
+// control, such as the watermark, allowed domains and page range
+//
+// PARAMS:
+//     - param: the expiration and access control of the read tokens, the expiration is
+//       DEFAULT_TOKEN_EXPIRE_SECONDS if it is not positive
+//     - refreshBefore: the same as NewTokenCache
+// RETURNS:
+//     - *TokenCache: the token cache
+func (c *Client) NewTokenCacheWithParam(param *api.ReadDocumentParam,
+	refreshBefore time.Duration) *TokenCache {
+	var cached api.ReadDocumentParam
+	if param != nil {
+		cached = *param
+		cached.AllowedDomains = append([]string(nil), param.AllowedDomains...)
+	}
+	if cached.ExpireInSeconds <= 0 {
+		cached.ExpireInSeconds = DEFAULT_TOKEN_EXPIRE_SECONDS
+	}
+	if refreshBefore <= 0 {
+		refreshBefore = DEFAULT_TOKEN_REFRESH_BEFORE
+	}
+	return &TokenCache{
+		client:          c,
+		expireInSeconds: cached.ExpireInSeconds,
+		param:           cached,
+		refreshBefore:   refreshBefore,
+		now:             time.Now,
+		entries:         make(map[string]*tokenEntry),
+	}
+}
+
+// Get - get the cached read token of the document, or fetch it if it is not cached or about to
+// expire. The failure is not cached and the next Get fetches again.
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+// RETURNS:
+//     - *api.ReadDocumentResp: the read token, which should not be modified since it is shared
+//     - error: the return error if any occurs
+func (t *TokenCache) Get(documentId string) (*api.ReadDocumentResp, error) {
+	t.mu.Lock()
+	entry, ok := t.entries[documentId]
+	if ok {
+		select {
+		case <-entry.done:
+			if entry.err == nil && t.now().Add(t.refreshBefore).Before(entry.expireAt) {
+				t.mu.Unlock()
+				return entry.token, nil
+			}
+			ok = false // expired or failed, fetch again
+		default:
+			// being fetched by another caller
+		}
+	}
+	if !ok {
+		entry = &tokenEntry{done: make(chan struct{})}
+		t.entries[documentId] = entry
+		t.mu.Unlock()
+		t.fetch(documentId, entry)
+	} else {
+		t.mu.Unlock()
+	}
+
+	<-entry.done
+	return entry.token, entry.err
+}
+
+func (t *TokenCache) fetch(documentId string, entry *tokenEntry) {
+	defer close(entry.done)
+	start := t.now()
+	param := t.param
+	token, err := api.ReadDocument(t.client, documentId, &param)
+	if err != nil {
+		entry.err = err
+		t.mu.Lock()
+		if t.entries[documentId] == entry {
+			delete(t.entries, documentId)
+		}
+		t.mu.Unlock()
+		return
+	}
+	entry.token = token
+	if entry.expireAt, err = token.ExpireAt(); err != nil {
+		// count the expiration from the request time if the service does not return it
+		entry.expireAt = start.Add(time.Duration(t.expireInSeconds) * time.Second)
+	}
+}
+
+// Invalidate - remove the cached read token of the document, eg: after it is deleted or its
+// access is changed
+//
+// PARAMS:
+//     - documentId: id of document in doc service
+func (t *TokenCache) Invalidate(documentId string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, documentId)
+}
+
+// Purge - remove the expired read tokens to release the memory of the documents no longer read
+func (t *TokenCache) Purge() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	for documentId, entry := range t.entries {
+		select {
+		case <-entry.done:
+			if !now.Before(entry.expireAt) {
+				delete(t.entries, documentId)
+			}
+		default:
+		}
+	}
+}
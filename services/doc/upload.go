@@ -0,0 +1,169 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// upload.go - create a document from the local file or the url by the register, upload and publish steps
+
+package doc
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	bosapi "github.com/baidubce/bce-sdk-go/services/bos/api"
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+const (
+	// The error code of the BOS service if the uploaded content does not match the Content-MD5
+	ERR_BOS_BAD_DIGEST = "BadDigest"
+
+	// The max size of the source file downloaded by the CreateDocumentFromURL
+	MAX_URL_SOURCE_SIZE = 100 << 20
+)
+
+// CreateDocumentFromFile - create the document from the local file: register the document with
+// the MD5 digest of the file, upload the file to the BOS and publish the document. The uploaded
+// file is verified with the digest by both the BOS and the DOC service, the mismatch is returned
+// as the *api.ChecksumMismatchError and the registered document is deleted.
+//
+// PARAMS:
+//     - filePath: the path of the source file, the format is the extension of it
+//     - title: the title of the document, the file name without the extension if it is empty
+//     - opts: WithTargetType, WithAccess, WithNotification and WithBucket are supported
+// RETURNS:
+//     - *api.RegDocumentResp: id and document location in bos
+//     - error: the return error if any occurs, use IsChecksumMismatch to check the mismatch
+func (c *Client) CreateDocumentFromFile(filePath, title string, opts ...Option) (*api.RegDocumentResp, error) {
+	body, err := bce.NewBodyFromFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Stream().Close()
+	ext := filepath.Ext(filePath)
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(filePath), ext)
+	}
+	return c.createDocument(body, title, strings.TrimPrefix(ext, "."), opts)
+}
+
+// CreateDocumentFromURL - create the document from the source file downloaded from the http or
+// https url like the CreateDocumentFromFile, the file is buffered in memory instead of the local
+// storage and should not be larger than the MAX_URL_SOURCE_SIZE. The file is downloaded by the
+// GetUrl with the http client, proxy, timeout and context of the client, and the errors refer to
+// the url without the query to keep the signature of the presigned url out of them.
+//
+// PARAMS:
+//     - sourceUrl: the url of the source file, which should be accessible by the GET request
+//     - title: the title of the document, the file name in the url without the extension if it
+//       is empty
+//     - format: the format of the document, the extension of the file name in the url if it is
+//       empty
+//     - opts: WithTargetType, WithAccess, WithNotification and WithBucket are supported
+// RETURNS:
+//     - *api.RegDocumentResp: id and document location in bos
+//     - error: the return error if any occurs, use IsChecksumMismatch to check the mismatch
+func (c *Client) CreateDocumentFromURL(sourceUrl, title, format string, opts ...Option) (*api.RegDocumentResp, error) {
+	u, err := url.Parse(sourceUrl)
+	if urlErr, ok := err.(*url.Error); ok {
+		return nil, fmt.Errorf("invalid source url: %v", urlErr.Err)
+	} else if err != nil {
+		return nil, err
+	}
+	safeUrl := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme of the source url: %s", safeUrl)
+	}
+	name := path.Base(u.Path)
+	ext := path.Ext(name)
+	if title == "" {
+		title = strings.TrimSuffix(name, ext)
+	}
+	if format == "" {
+		format = strings.TrimPrefix(ext, ".")
+	}
+
+	resp, err := c.GetUrl(sourceUrl)
+	if urlErr, ok := err.(*url.Error); ok {
+		return nil, fmt.Errorf("download the source file %s failed: %v", safeUrl, urlErr.Err)
+	} else if err != nil {
+		return nil, err
+	}
+	defer resp.Body().Close()
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("download the source file %s failed: %s", safeUrl, resp.StatusText())
+	}
+	if resp.ContentLength() > MAX_URL_SOURCE_SIZE {
+		return nil, fmt.Errorf("the source file %s is larger than %d bytes", safeUrl, MAX_URL_SOURCE_SIZE)
+	}
+	raw, err := ioutil.ReadAll(io.LimitReader(resp.Body(), MAX_URL_SOURCE_SIZE+1))
+	if err != nil {
+		return nil, fmt.Errorf("download the source file %s failed: %v", safeUrl, err)
+	}
+	if len(raw) > MAX_URL_SOURCE_SIZE {
+		return nil, fmt.Errorf("the source file %s is larger than %d bytes", safeUrl, MAX_URL_SOURCE_SIZE)
+	}
+	body, err := bce.NewBodyFromBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+	return c.createDocument(body, title, format, opts)
+}
+
+// createDocument - register the document with the MD5 digest of the body, upload the body and
+// publish the document
+func (c *Client) createDocument(body *bce.Body, title, format string, opts []Option) (*api.RegDocumentResp, error) {
+	digest, err := base64.StdEncoding.DecodeString(body.ContentMD5())
+	if err != nil {
+		return nil, err
+	}
+	md5 := hex.EncodeToString(digest)
+	res, err := c.Register(title, strings.ToLower(format), append(opts, WithMD5(md5))...)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := *c.Config
+	conf.Endpoint = res.BosEndpoint
+	bosClient := bce.NewBceClient(&conf, c.Signer)
+	etag, err := bosapi.PutObject(bosClient, res.Bucket, res.Object, body, nil)
+	if err != nil {
+		c.abortDocument(res.DocumentId)
+		if e, ok := err.(*bce.BceServiceError); ok && e.Code == ERR_BOS_BAD_DIGEST {
+			return nil, &api.ChecksumMismatchError{DocumentId: res.DocumentId, Expected: md5}
+		}
+		return nil, err
+	}
+	if etag != "" && !strings.EqualFold(etag, md5) {
+		c.abortDocument(res.DocumentId)
+		return nil, &api.ChecksumMismatchError{DocumentId: res.DocumentId, Expected: md5, Actual: etag}
+	}
+
+	if err := c.PublishDocument(res.DocumentId); err != nil {
+		if ctx := c.Config.Context; ctx != nil && ctx.Err() != nil {
+			// The document can not be published by the caller without the id
+			c.abortDocument(res.DocumentId)
+		}
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// usage.go - count the usage of the DOC service and check it against the quota of the account
+
+package doc
+
+import (
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/services/doc/api"
+)
+
+// the page size of the list when counting the usage
+const USAGE_PAGE_SIZE = 200
+
+// UsageLimits defines the quota of the account, which is not returned by the DOC service and
+// should be given by the operators from the console or the contract, 0 means no limit.
+type UsageLimits struct {
+	MaxDocuments    int64
+	MaxStorageBytes int64
+	MaxPages        int64
+}
+
+// UsageParam defines the arguments to count the usage.
+type UsageParam struct {
+	// Detail queries every published document for its size and page count, which sends one more
+	// request for each published document, otherwise StorageBytes and Pages are not counted
+	Detail bool
+
+	// Limits is the quota to check the usage against, may be nil
+	Limits *UsageLimits
+}
+
+// Usage defines the usage of the DOC service counted by listing all the documents, the DOC
+// service does not provide the usage api nor record the conversion time of the documents.
+type Usage struct {
+	Documents         int64
+	DocumentsByStatus map[api.StatusType]int64
+	StorageBytes      int64 // the total size of the published documents, counted if Detail
+	Pages             int64 // the total pages of the published documents, counted if Detail
+	Detailed          bool
+	Limits            *UsageLimits
+}
+
+// NearLimits - get the names of the usages not less than the ratio of their limits
+//
+// PARAMS:
+//     - ratio: the ratio of the limits to alert, eg: 0.8, 1 for the exceeded or reached limits
+// RETURNS:
+//     - []string: "documents", "storage" or "pages", empty if none is near its limit
+func (u *Usage) NearLimits(ratio float64) []string {
+	names := []string{}
+	if u.Limits == nil {
+		return names
+	}
+	check := func(name string, used, limit int64) {
+		if limit > 0 && float64(used) >= ratio*float64(limit) {
+			names = append(names, name)
+		}
+	}
+	check("documents", u.Documents, u.Limits.MaxDocuments)
+	if u.Detailed {
+		check("storage", u.StorageBytes, u.Limits.MaxStorageBytes)
+		check("pages", u.Pages, u.Limits.MaxPages)
+	}
+	return names
+}
+
+// GetUsage - count the documents of all the status by listing them page by page, and the size
+// and pages of the published documents if required
+//
+// PARAMS:
+//     - param: the optional arguments to count the usage
+// RETURNS:
+//     - *Usage: the usage of the account
+//     - error: the return error if any occurs
+func (c *Client) GetUsage(param *UsageParam) (*Usage, error) {
+	if param == nil {
+		param = &UsageParam{}
+	}
+	usage := &Usage{
+		DocumentsByStatus: make(map[api.StatusType]int64),
+		Detailed:          param.Detail,
+		Limits:            param.Limits,
+	}
+	listParam := &api.ListDocumentsParam{MaxSize: USAGE_PAGE_SIZE}
+	for {
+		page, err := api.ListDocuments(c, listParam)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range page.Docs {
+			usage.Documents++
+			usage.DocumentsByStatus[api.StatusType(doc.Status)]++
+			if !param.Detail || doc.Status != string(api.DOC_STATUS_PUBLISHED) {
+				continue
+			}
+			detail, err := api.QueryDocument(c, doc.DocumentId, nil)
+			if err != nil {
+				if IsNoSuchDocument(err) {
+					continue // deleted while counting
+				}
+				return nil, fmt.Errorf("query document %s failed: %v", doc.DocumentId, err)
+			}
+			usage.StorageBytes += int64(detail.PublishInfo.SizeInBytes)
+			usage.Pages += int64(detail.PublishInfo.PageCount)
+		}
+		if !page.IsTruncated || page.NextMarker == "" {
+			return usage, nil
+		}
+		listParam.Marker = page.NextMarker
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the DTS client
+
+package dts
+
+// Interface defines all the operations of the DTS client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateDts(args *CreateDtsArgs) (*CreateDtsResult, error)
+	DeleteDts(taskId string) error
+	GetDetail(taskId string) (*DtsTaskMeta, error)
+	ListDts(args *ListDtsArgs) (*ListDtsResult, error)
+	ListDtsWithPage(args *ListDtsWithPageArgs) (*ListDtsWithPageResult, error)
+	PreCheck(taskId string) (*PreCheckResult, error)
+	GetPreCheck(taskId string) (*GetPreCheckResult, error)
+	SkipPreCheck(taskId string) (*SkipPreCheckResponse, error)
+	ConfigDts(taskId string, args *ConfigArgs) (*ConfigDtsResult, error)
+	StartDts(taskId string) error
+	PauseDts(taskId string) error
+	ShutdownDts(taskId string) error
+	GetSchema(args *GetSchemaArgs) (*GetSchemaResponse, error)
+	UpdateTaskName(taskId string, args *UpdateTaskNameArgs) error
+	ResizeTaskStandard(taskId string, args *ResizeTaskStandardArgs) (*ResizeTaskStandardResponse, error)
+	GetVpcs(region string) (*DtsVpcsResult, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -31,6 +31,10 @@ const (
 	REQUEST_EIP_CLUSTER_URL = "/eipcluster"
 
 	REQUEST_EIP_TP_URL = "/eiptp"
+
+	REQUEST_BANDWIDTH_SCHEDULE_URL = "/eip/bandwidthSchedule"
+
+	REQUEST_BANDWIDTH_STATISTICS_URL = "/eip/bandwidthStatistics"
 )
 
 // Client of EIP service is a kind of BceClient, so derived from BceClient
@@ -79,4 +83,16 @@ func getEipTpUri() string {
 
 func getEipTpUriWithId(id string) string {
 	return URI_PREFIX + REQUEST_EIP_TP_URL + "/" + id
-}
\ No newline at end of file
+}
+
+func getBandwidthScheduleUri() string {
+	return URI_PREFIX + REQUEST_BANDWIDTH_SCHEDULE_URL
+}
+
+func getBandwidthScheduleUriWithId(policyId string) string {
+	return URI_PREFIX + REQUEST_BANDWIDTH_SCHEDULE_URL + "/" + policyId
+}
+
+func getBandwidthStatisticsUri() string {
+	return URI_PREFIX + REQUEST_BANDWIDTH_STATISTICS_URL
+}
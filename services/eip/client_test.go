@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/baidubce/bce-sdk-go/util"
 	"github.com/baidubce/bce-sdk-go/util/log"
@@ -219,6 +220,48 @@ func TestClient_GetEipTp(t *testing.T) {
 	fmt.Println(EIP_CLIENT.GetEipTp("tp-hfI9pKIL58"))
 }
 
+func TestClient_BandwidthSchedule(t *testing.T) {
+	createArgs := &CreateBandwidthScheduleArgs{
+		ResourceId:      EIP_ADDRESS,
+		Name:            "sdk-peak",
+		BandWidthInMbps: 10,
+		StartTime:       "20:00",
+		EndTime:         "23:00",
+		WeekDays:        []int{1, 2, 3, 4, 5},
+		ClientToken:     getClientToken(),
+	}
+	result, err := EIP_CLIENT.CreateBandwidthSchedule(createArgs)
+	ExpectEqual(t.Errorf, nil, err)
+
+	updateArgs := &UpdateBandwidthScheduleArgs{
+		BandWidthInMbps: 20,
+		StartTime:       "22:00",
+		EndTime:         "06:00",
+		ClientToken:     getClientToken(),
+	}
+	err = EIP_CLIENT.UpdateBandwidthSchedule(result.PolicyId, updateArgs)
+	ExpectEqual(t.Errorf, nil, err)
+
+	listResult, err := EIP_CLIENT.ListBandwidthSchedule(&ListBandwidthScheduleArgs{ResourceId: EIP_ADDRESS})
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, 1, len(listResult.PolicyList))
+
+	err = EIP_CLIENT.DeleteBandwidthSchedule(result.PolicyId, getClientToken())
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_GetBandwidthStatistics(t *testing.T) {
+	end := time.Now().UTC()
+	args := &GetBandwidthStatisticsArgs{
+		ResourceId: EIP_ADDRESS,
+		StartTime:  end.Add(-time.Hour).Format(STATISTICS_TIME_LAYOUT),
+		EndTime:    end.Format(STATISTICS_TIME_LAYOUT),
+	}
+	result, err := EIP_CLIENT.GetBandwidthStatistics(args)
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, DEFAULT_STATISTICS_PERIOD, result.Period)
+}
+
 func getClientToken() string {
 	return util.NewUUID()
 }
@@ -43,7 +43,7 @@ func (c *Client) CreateEip(args *CreateEipArgs) (*CreateEipResult, error) {
 	err := bce.NewRequestBuilder(c).
 		WithMethod(http.POST).
 		WithURL(getEipUri()).
-		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithClientToken(args.ClientToken).
 		WithBody(args).
 		WithResult(result).
 		Do()
@@ -391,7 +391,7 @@ func (c *Client) CreateEipTp(args *CreateEipTpArgs) (*CreateEipTpResult, error)
 	err := bce.NewRequestBuilder(c).
 		WithMethod(http.POST).
 		WithURL(getEipTpUri()).
-		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithClientToken(args.ClientToken).
 		WithBody(args).
 		WithResult(result).
 		Do()
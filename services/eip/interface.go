@@ -0,0 +1,62 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the EIP client
+
+package eip
+
+// Interface defines all the operations of the EIP client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateEip(args *CreateEipArgs) (*CreateEipResult, error)
+	ResizeEip(eip string, args *ResizeEipArgs) error
+	BindEip(eip string, args *BindEipArgs) error
+	UnBindEip(eip, clientToken string) error
+	DeleteEip(eip, clientToken string) error
+	OptionalDeleteEip(eip string, clientToken string, releaseToRecycle bool) error
+	ListEip(args *ListEipArgs) (*ListEipResult, error)
+	ListRecycleEip(args *ListRecycleEipArgs) (*ListRecycleEipResult, error)
+	RestoreRecycleEip(eip string, clientToken string) error
+	DeleteRecycleEip(eip string, clientToken string) error
+	PurchaseReservedEip(eip string, args *PurchaseReservedEipArgs) error
+	StartAutoRenew(eip string, args *StartAutoRenewArgs) error
+	StopAutoRenew(eip string, clientToken string) error
+	ListEipCluster(args *ListEipArgs) (*ListClusterResult, error)
+	GetEipCluster(clusterId string) (*ClusterDetail, error)
+	DirectEip(eip, clientToken string) error
+	UnDirectEip(eip, clientToken string) error
+	CreateEipTp(args *CreateEipTpArgs) (*CreateEipTpResult, error)
+	ListEipTp(args *ListEipTpArgs) (*ListEipTpResult, error)
+	GetEipTp(id string) (*EipTpDetail, error)
+	CreateBandwidthSchedule(args *CreateBandwidthScheduleArgs) (*CreateBandwidthScheduleResult, error)
+	UpdateBandwidthSchedule(policyId string, args *UpdateBandwidthScheduleArgs) error
+	ListBandwidthSchedule(args *ListBandwidthScheduleArgs) (*ListBandwidthScheduleResult, error)
+	DeleteBandwidthSchedule(policyId, clientToken string) error
+	GetBandwidthStatistics(args *GetBandwidthStatisticsArgs) (*BandwidthStatisticsResult, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
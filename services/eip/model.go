@@ -207,3 +207,72 @@ type EipTpDetail struct {
 	ExpireTime   string `json:"expireTime,omitempty"`
 	CreateTime   string `json:"createTime,omitempty"`
 }
+
+type CreateBandwidthScheduleArgs struct {
+	ResourceId      string `json:"resourceId"`
+	Name            string `json:"name,omitempty"`
+	BandWidthInMbps int    `json:"bandwidthInMbps"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	WeekDays        []int  `json:"weekDays,omitempty"`
+	ClientToken     string `json:"-"`
+}
+
+type CreateBandwidthScheduleResult struct {
+	PolicyId string `json:"policyId"`
+}
+
+type UpdateBandwidthScheduleArgs struct {
+	Name            string `json:"name,omitempty"`
+	BandWidthInMbps int    `json:"bandwidthInMbps"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	WeekDays        []int  `json:"weekDays,omitempty"`
+	ClientToken     string `json:"-"`
+}
+
+type ListBandwidthScheduleArgs struct {
+	ResourceId string
+	Marker     string
+	MaxKeys    int
+}
+
+type ListBandwidthScheduleResult struct {
+	Marker      string                   `json:"marker"`
+	MaxKeys     int                      `json:"maxKeys"`
+	NextMarker  string                   `json:"nextMarker"`
+	IsTruncated bool                     `json:"isTruncated"`
+	PolicyList  []BandwidthScheduleModel `json:"policyList"`
+}
+
+type BandwidthScheduleModel struct {
+	PolicyId        string `json:"policyId"`
+	ResourceId      string `json:"resourceId"`
+	Name            string `json:"name"`
+	BandWidthInMbps int    `json:"bandwidthInMbps"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	WeekDays        []int  `json:"weekDays"`
+	Status          string `json:"status"`
+	CreateTime      string `json:"createTime"`
+}
+
+type GetBandwidthStatisticsArgs struct {
+	ResourceId string
+	StartTime  string
+	EndTime    string
+	Period     int
+}
+
+type BandwidthStatisticsResult struct {
+	ResourceId string               `json:"resourceId"`
+	Period     int                  `json:"period"`
+	DataPoints []BandwidthDataPoint `json:"dataPoints"`
+}
+
+type BandwidthDataPoint struct {
+	Timestamp          string  `json:"timestamp"`
+	InBandwidthInMbps  float64 `json:"inBandwidthInMbps"`
+	OutBandwidthInMbps float64 `json:"outBandwidthInMbps"`
+	BandWidthInMbps    int     `json:"bandwidthInMbps"`
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+
+// schedule.go - the bandwidth scheduled scaling APIs definition supported by the EIP service
+package eip
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+const (
+	// the layout of the StartTime and EndTime of the bandwidth schedule policy
+	SCHEDULE_TIME_LAYOUT = "15:04"
+
+	// the layout of the StartTime and EndTime of the bandwidth statistics in UTC
+	STATISTICS_TIME_LAYOUT = "2006-01-02T15:04:05Z"
+
+	DEFAULT_STATISTICS_PERIOD = 300
+)
+
+// CreateBandwidthSchedule - create a policy to change the bandwidth of the EIP or the EIP group
+// in the daily time window, the bandwidth is restored after the window ends
+//
+// PARAMS:
+//     - args: the arguments to create a bandwidth schedule policy
+// RETURNS:
+//     - *CreateBandwidthScheduleResult: the result of create policy, contains the policy id
+//     - error: nil if success otherwise the specific error
+func (c *Client) CreateBandwidthSchedule(args *CreateBandwidthScheduleArgs) (*CreateBandwidthScheduleResult, error) {
+	if args == nil {
+		return nil, fmt.Errorf("please set create bandwidth schedule argments")
+	}
+	if len(args.ResourceId) == 0 {
+		return nil, fmt.Errorf("please set resourceId argment")
+	}
+	if err := checkBandwidthSchedule(args.BandWidthInMbps, args.StartTime, args.EndTime, args.WeekDays); err != nil {
+		return nil, err
+	}
+
+	result := &CreateBandwidthScheduleResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getBandwidthScheduleUri()).
+		WithClientToken(args.ClientToken).
+		WithBody(args).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// UpdateBandwidthSchedule - update the bandwidth schedule policy
+//
+// PARAMS:
+//     - policyId: the specific policy id
+//     - args: the arguments to update the bandwidth schedule policy
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) UpdateBandwidthSchedule(policyId string, args *UpdateBandwidthScheduleArgs) error {
+	if len(policyId) == 0 {
+		return fmt.Errorf("please set policyId argment")
+	}
+	if args == nil {
+		return fmt.Errorf("please set update bandwidth schedule argments")
+	}
+	if err := checkBandwidthSchedule(args.BandWidthInMbps, args.StartTime, args.EndTime, args.WeekDays); err != nil {
+		return err
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.PUT).
+		WithURL(getBandwidthScheduleUriWithId(policyId)).
+		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithBody(args).
+		Do()
+}
+
+// ListBandwidthSchedule - list the bandwidth schedule policies
+//
+// PARAMS:
+//     - args: the arguments to list the policies, the ResourceId is optional to filter the
+//       policies of the EIP or the EIP group
+// RETURNS:
+//     - *ListBandwidthScheduleResult: the result of listing the policies
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListBandwidthSchedule(args *ListBandwidthScheduleArgs) (*ListBandwidthScheduleResult, error) {
+	if args == nil {
+		args = &ListBandwidthScheduleArgs{}
+	}
+	if args.MaxKeys <= 0 || args.MaxKeys > 1000 {
+		args.MaxKeys = 1000
+	}
+
+	result := &ListBandwidthScheduleResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getBandwidthScheduleUri()).
+		WithQueryParamFilter("resourceId", args.ResourceId).
+		WithQueryParamFilter("marker", args.Marker).
+		WithQueryParamFilter("maxKeys", strconv.Itoa(args.MaxKeys)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// DeleteBandwidthSchedule - delete the bandwidth schedule policy
+//
+// PARAMS:
+//     - policyId: the specific policy id
+//     - clientToken: optional parameter, an Idempotent Token
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) DeleteBandwidthSchedule(policyId, clientToken string) error {
+	if len(policyId) == 0 {
+		return fmt.Errorf("please set policyId argment")
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.DELETE).
+		WithURL(getBandwidthScheduleUriWithId(policyId)).
+		WithQueryParamFilter("clientToken", clientToken).
+		Do()
+}
+
+// GetBandwidthStatistics - get the inbound and outbound bandwidth usage of the EIP or the EIP
+// group in the time range
+//
+// PARAMS:
+//     - args: the arguments to get the statistics, the StartTime and EndTime are in the layout
+//       of STATISTICS_TIME_LAYOUT, the Period in seconds is DEFAULT_STATISTICS_PERIOD if not set
+// RETURNS:
+//     - *BandwidthStatisticsResult: the data points of the bandwidth usage
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetBandwidthStatistics(args *GetBandwidthStatisticsArgs) (*BandwidthStatisticsResult, error) {
+	if args == nil {
+		return nil, fmt.Errorf("please set get bandwidth statistics argments")
+	}
+	if len(args.ResourceId) == 0 {
+		return nil, fmt.Errorf("please set resourceId argment")
+	}
+	start, err := time.Parse(STATISTICS_TIME_LAYOUT, args.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid startTime %q: %v", args.StartTime, err)
+	}
+	end, err := time.Parse(STATISTICS_TIME_LAYOUT, args.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endTime %q: %v", args.EndTime, err)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("endTime should be after startTime")
+	}
+	period := args.Period
+	if period <= 0 {
+		period = DEFAULT_STATISTICS_PERIOD
+	}
+
+	result := &BandwidthStatisticsResult{}
+	err = bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getBandwidthStatisticsUri()).
+		WithQueryParam("resourceId", args.ResourceId).
+		WithQueryParam("startTime", args.StartTime).
+		WithQueryParam("endTime", args.EndTime).
+		WithQueryParam("period", strconv.Itoa(period)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// checkBandwidthSchedule - check the bandwidth, the daily time window and the week days
+// (1 for Monday to 7 for Sunday, empty for every day) of the schedule policy
+func checkBandwidthSchedule(bandwidth int, startTime, endTime string, weekDays []int) error {
+	if bandwidth <= 0 {
+		return fmt.Errorf("bandwidthInMbps should be positive")
+	}
+	start, err := time.Parse(SCHEDULE_TIME_LAYOUT, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid startTime %q, should be HH:mm", startTime)
+	}
+	end, err := time.Parse(SCHEDULE_TIME_LAYOUT, endTime)
+	if err != nil {
+		return fmt.Errorf("invalid endTime %q, should be HH:mm", endTime)
+	}
+	if start.Equal(end) {
+		return fmt.Errorf("startTime and endTime should not be the same")
+	}
+	for _, day := range weekDays {
+		if day < 1 || day > 7 {
+			return fmt.Errorf("invalid week day %d, should be 1 to 7", day)
+		}
+	}
+	return nil
+}
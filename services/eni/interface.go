@@ -0,0 +1,54 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the ENI client
+
+package eni
+
+// Interface defines all the operations of the ENI client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateEni(args *CreateEniArgs) (*CreateEniResult, error)
+	UpdateEni(args *UpdateEniArgs) error
+	DeleteEni(args *DeleteEniArgs) error
+	ListEni(args *ListEniArgs) (*ListEniResult, error)
+	GetEniDetail(eniId string) (*Eni, error)
+	AddPrivateIp(args *EniPrivateIpArgs) (*AddPrivateIpResult, error)
+	BatchAddPrivateIp(args *EniBatchPrivateIpArgs) (*BatchAddPrivateIpResult, error)
+	BatchAddPrivateIpCrossSubnet(args *EniBatchAddPrivateIpCrossSubnetArgs) (*BatchAddPrivateIpResult, error)
+	DeletePrivateIp(args *EniPrivateIpArgs) error
+	BatchDeletePrivateIp(args *EniBatchPrivateIpArgs) error
+	AttachEniInstance(args *EniInstance) error
+	DetachEniInstance(args *EniInstance) error
+	BindEniPublicIp(args *BindEniPublicIpArgs) error
+	UnBindEniPublicIp(args *UnBindEniPublicIpArgs) error
+	UpdateEniSecurityGroup(args *UpdateEniSecurityGroupArgs) error
+	UpdateEniEnterpriseSecurityGroup(args *UpdateEniEnterpriseSecurityGroupArgs) error
+	GetEniQuota(args *EniQuoteArgs) (*EniQuoteInfo, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
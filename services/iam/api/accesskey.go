@@ -0,0 +1,107 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// accesskey.go - the access key APIs definition supported by the IAM service
+
+package api
+
+import (
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+func CreateAccessKey(cli bce.Client, userName string) (*CreateAccessKeyResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getAccessKeyUri(userName))
+	req.SetMethod(http.POST)
+
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	jsonBody := &CreateAccessKeyResult{}
+	if err := resp.ParseJsonBody(jsonBody); err != nil {
+		return nil, err
+	}
+	return jsonBody, nil
+}
+
+func DisableAccessKey(cli bce.Client, userName, accessKeyId string) (*UpdateAccessKeyResult, error) {
+	return updateAccessKey(cli, userName, accessKeyId, "disable")
+}
+
+func EnableAccessKey(cli bce.Client, userName, accessKeyId string) (*UpdateAccessKeyResult, error) {
+	return updateAccessKey(cli, userName, accessKeyId, "enable")
+}
+
+func updateAccessKey(cli bce.Client, userName, accessKeyId, action string) (*UpdateAccessKeyResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getAccessKeyUri(userName) + "/" + accessKeyId)
+	req.SetParam(action, "")
+	req.SetMethod(http.PUT)
+
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	jsonBody := &UpdateAccessKeyResult{}
+	if err := resp.ParseJsonBody(jsonBody); err != nil {
+		return nil, err
+	}
+	return jsonBody, nil
+}
+
+func DeleteAccessKey(cli bce.Client, userName, accessKeyId string) error {
+	req := &bce.BceRequest{}
+	req.SetUri(getAccessKeyUri(userName) + "/" + accessKeyId)
+	req.SetMethod(http.DELETE)
+
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return err
+	}
+	if resp.IsFail() {
+		return resp.ServiceError()
+	}
+	return nil
+}
+
+func ListAccessKey(cli bce.Client, userName string) (*ListAccessKeyResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(getAccessKeyUri(userName))
+	req.SetMethod(http.GET)
+
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	jsonBody := &ListAccessKeyResult{}
+	if err := resp.ParseJsonBody(jsonBody); err != nil {
+		return nil, err
+	}
+	return jsonBody, nil
+}
+
+func getAccessKeyUri(userName string) string {
+	return getUserUri(userName) + URI_ACCESSKEY
+}
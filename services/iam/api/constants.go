@@ -15,11 +15,15 @@
 package api
 
 const (
-	URI_PREFIX = "/v1"
-	URI_USER   = "/user"
-	URI_GROUP  = "/group"
-	URI_POLICY = "/policy"
+	URI_PREFIX    = "/v1"
+	URI_USER      = "/user"
+	URI_GROUP     = "/group"
+	URI_POLICY    = "/policy"
+	URI_ACCESSKEY = "/accesskey"
 
 	POLICY_TYPE_SYSTEM = "System"
 	POLICY_TYPE_CUSTOM = "Custom"
+
+	POLICY_EFFECT_ALLOW = "Allow"
+	POLICY_EFFECT_DENY  = "Deny"
 )
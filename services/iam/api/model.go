@@ -14,7 +14,11 @@
 
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 type UserModel struct {
 	Id          string    `json:"id"`
@@ -104,6 +108,52 @@ type Acl struct {
 	AccessControlList []AclEntry `json:"accessControlList"`
 }
 
+// NewAcl - create an empty policy document to add the entries by Allow and Deny
+func NewAcl() *Acl {
+	return &Acl{AccessControlList: []AclEntry{}}
+}
+
+// Allow - add an entry allowing the permissions on the resources of the service in the region,
+// the region can be "*" for all regions and the resources can be "*" for all resources
+func (a *Acl) Allow(service, region string, permissions, resources []string) *Acl {
+	return a.addEntry(POLICY_EFFECT_ALLOW, service, region, permissions, resources)
+}
+
+// Deny - add an entry denying the permissions on the resources of the service in the region
+func (a *Acl) Deny(service, region string, permissions, resources []string) *Acl {
+	return a.addEntry(POLICY_EFFECT_DENY, service, region, permissions, resources)
+}
+
+func (a *Acl) addEntry(effect, service, region string, permissions, resources []string) *Acl {
+	a.AccessControlList = append(a.AccessControlList, AclEntry{
+		Service:    service,
+		Region:     region,
+		Permission: permissions,
+		Resource:   resources,
+		Effect:     effect,
+	})
+	return a
+}
+
+// Document - marshal the policy document to set the Document of the CreatePolicyArgs
+func (a *Acl) Document() (string, error) {
+	if len(a.AccessControlList) == 0 {
+		return "", errors.New("the policy document should contain at least one entry")
+	}
+	for _, entry := range a.AccessControlList {
+		if entry.Service == "" || entry.Region == "" || len(entry.Permission) == 0 ||
+			len(entry.Resource) == 0 {
+			return "", errors.New("the service, region, permission and resource of the policy " +
+				"entry should not be empty")
+		}
+	}
+	document, err := json.Marshal(a)
+	if err != nil {
+		return "", err
+	}
+	return string(document), nil
+}
+
 type PolicyModel struct {
 	Id          string    `json:"id"`
 	Name        string    `json:"name"`
@@ -150,3 +200,20 @@ type DetachPolicyFromGroupArgs struct {
 	PolicyName string `json:"policyName"`
 	PolicyType string `json:"policyType,omitempty"`
 }
+
+type AccessKeyModel struct {
+	Id           string    `json:"id"`
+	Secret       string    `json:"secret"`
+	CreateTime   time.Time `json:"createTime"`
+	LastUsedTime time.Time `json:"lastUsedTime"`
+	Enabled      bool      `json:"enabled"`
+	Description  string    `json:"description"`
+}
+
+type CreateAccessKeyResult AccessKeyModel
+
+type UpdateAccessKeyResult AccessKeyModel
+
+type ListAccessKeyResult struct {
+	AccessKeys []AccessKeyModel `json:"accessKeys"`
+}
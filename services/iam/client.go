@@ -194,6 +194,40 @@ func (c *Client) ListGroupAttachedPolicies(name string) (*api.ListPolicyResult,
 	return api.ListGroupAttachedPolicies(c, name)
 }
 
+func (c *Client) CreateAccessKey(userName string) (*api.CreateAccessKeyResult, error) {
+	return api.CreateAccessKey(c, userName)
+}
+
+func (c *Client) DisableAccessKey(userName, accessKeyId string) (*api.UpdateAccessKeyResult, error) {
+	return api.DisableAccessKey(c, userName, accessKeyId)
+}
+
+func (c *Client) EnableAccessKey(userName, accessKeyId string) (*api.UpdateAccessKeyResult, error) {
+	return api.EnableAccessKey(c, userName, accessKeyId)
+}
+
+func (c *Client) DeleteAccessKey(userName, accessKeyId string) error {
+	return api.DeleteAccessKey(c, userName, accessKeyId)
+}
+
+func (c *Client) ListAccessKey(userName string) (*api.ListAccessKeyResult, error) {
+	return api.ListAccessKey(c, userName)
+}
+
+// RotateAccessKey - create a new access key for the user and disable the old one, the old access
+// key should be deleted by DeleteAccessKey after the applications switch to the new one, or be
+// enabled again by EnableAccessKey to roll back
+func (c *Client) RotateAccessKey(userName, oldAccessKeyId string) (*api.CreateAccessKeyResult, error) {
+	newKey, err := api.CreateAccessKey(c, userName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := api.DisableAccessKey(c, userName, oldAccessKeyId); err != nil {
+		return newKey, err
+	}
+	return newKey, nil
+}
+
 func NewBodyFromStruct(args interface{}) (*bce.Body, error) {
 	jsonBytes, err := json.Marshal(args)
 	if err != nil {
@@ -375,3 +375,37 @@ func TestGroupAttachDetachPolicy(t *testing.T) {
 	err = IAM_CLIENT.DeleteGroup(groupName)
 	ExpectEqual(t.Errorf, err, nil)
 }
+
+func TestPolicyDocumentBuilder(t *testing.T) {
+	_, err := api.NewAcl().Document()
+	ExpectEqual(t.Errorf, false, err == nil)
+
+	document, err := api.NewAcl().Allow("bos", "bj", []string{"ListBucket"}, []string{"*"}).Document()
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, getPolicyDocument(), document)
+}
+
+func TestCreateRotateDeleteAccessKey(t *testing.T) {
+	userName := "test_sdk_go_accesskey"
+	_, err := IAM_CLIENT.CreateUser(&api.CreateUserArgs{Name: userName})
+	ExpectEqual(t.Errorf, err, nil)
+
+	oldKey, err := IAM_CLIENT.CreateAccessKey(userName)
+	ExpectEqual(t.Errorf, err, nil)
+	newKey, err := IAM_CLIENT.RotateAccessKey(userName, oldKey.Id)
+	ExpectEqual(t.Errorf, err, nil)
+
+	listRes, err := IAM_CLIENT.ListAccessKey(userName)
+	ExpectEqual(t.Errorf, err, nil)
+	ExpectEqual(t.Errorf, 2, len(listRes.AccessKeys))
+	for _, key := range listRes.AccessKeys {
+		ExpectEqual(t.Errorf, key.Id == newKey.Id, key.Enabled)
+	}
+
+	for _, key := range listRes.AccessKeys {
+		err = IAM_CLIENT.DeleteAccessKey(userName, key.Id)
+		ExpectEqual(t.Errorf, err, nil)
+	}
+	err = IAM_CLIENT.DeleteUser(userName)
+	ExpectEqual(t.Errorf, err, nil)
+}
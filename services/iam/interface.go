@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the IAM client
+
+package iam
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/iam/api"
+)
+
+// Interface defines all the operations of the IAM client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateUser(args *api.CreateUserArgs) (*api.CreateUserResult, error)
+	GetUser(name string) (*api.GetUserResult, error)
+	UpdateUser(name string, args *api.UpdateUserArgs) (*api.UpdateUserResult, error)
+	DeleteUser(name string) error
+	ListUser() (*api.ListUserResult, error)
+	UpdateUserLoginProfile(name string, args *api.UpdateUserLoginProfileArgs) (*api.UpdateUserLoginProfileResult, error)
+	GetUserLoginProfile(name string) (*api.GetUserLoginProfileResult, error)
+	DeleteUserLoginProfile(name string) error
+	CreateGroup(args *api.CreateGroupArgs) (*api.CreateGroupResult, error)
+	GetGroup(name string) (*api.GetGroupResult, error)
+	UpdateGroup(name string, args *api.UpdateGroupArgs) (*api.UpdateGroupResult, error)
+	DeleteGroup(name string) error
+	ListGroup() (*api.ListGroupResult, error)
+	AddUserToGroup(userName string, groupName string) error
+	DeleteUserFromGroup(userName string, groupName string) error
+	ListUsersInGroup(name string) (*api.ListUsersInGroupResult, error)
+	ListGroupsForUser(name string) (*api.ListGroupsForUserResult, error)
+	CreatePolicy(args *api.CreatePolicyArgs) (*api.CreatePolicyResult, error)
+	GetPolicy(name, policyType string) (*api.GetPolicyResult, error)
+	DeletePolicy(name string) error
+	ListPolicy(nameFilter, policyType string) (*api.ListPolicyResult, error)
+	AttachPolicyToUser(args *api.AttachPolicyToUserArgs) error
+	DetachPolicyFromUser(args *api.DetachPolicyFromUserArgs) error
+	ListUserAttachedPolicies(name string) (*api.ListPolicyResult, error)
+	AttachPolicyToGroup(args *api.AttachPolicyToGroupArgs) error
+	DetachPolicyFromGroup(args *api.DetachPolicyFromGroupArgs) error
+	ListGroupAttachedPolicies(name string) (*api.ListPolicyResult, error)
+	CreateAccessKey(userName string) (*api.CreateAccessKeyResult, error)
+	DisableAccessKey(userName, accessKeyId string) (*api.UpdateAccessKeyResult, error)
+	EnableAccessKey(userName, accessKeyId string) (*api.UpdateAccessKeyResult, error)
+	DeleteAccessKey(userName, accessKeyId string) error
+	ListAccessKey(userName string) (*api.ListAccessKeyResult, error)
+	RotateAccessKey(userName, oldAccessKeyId string) (*api.CreateAccessKeyResult, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk string) (Interface, error) {
+	client, err := NewClient(ak, sk)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the LOCALDNS client
+
+package localDns
+
+// Interface defines all the operations of the LOCALDNS client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	AddRecord(zoneId string, body *AddRecordRequest) (*AddRecordResponse, error)
+	DeletePrivateZone(zoneId string, clientToken string) error
+	CreatePrivateZone(body *CreatePrivateZoneRequest) (*CreatePrivateZoneResponse, error)
+	BindVpc(zoneId string, body *BindVpcRequest) error
+	DeleteRecord(recordId string, clientToken string) error
+	DisableRecord(recordId string, clientToken string) error
+	EnableRecord(recordId string, clientToken string) error
+	GetPrivateZone(zoneId string) (*GetPrivateZoneResponse, error)
+	ListPrivateZone(request *ListPrivateZoneRequest) (*ListPrivateZoneResponse, error)
+	ListRecord(zoneId string) (*ListRecordResponse, error)
+	UnbindVpc(zoneId string, body *UnbindVpcRequest) error
+	UpdateRecord(recordId string, body *UpdateRecordRequest) error
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
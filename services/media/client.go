@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// client.go - define the client for Media Cloud Transcoding service
+
+// Package media defines the Media Cloud Transcoding (MCT) services of BCE, which transcode the
+// audio and video files in BOS by the pipelines with the presets and capture the thumbnails.
+package media
+
+import "github.com/baidubce/bce-sdk-go/bce"
+
+const (
+	URI_PREFIX = bce.URI_PREFIX + "v3"
+
+	DEFAULT_ENDPOINT = "media." + bce.DEFAULT_REGION + ".baidubce.com"
+
+	REQUEST_PIPELINE_URL = "/pipeline"
+
+	REQUEST_PRESET_URL = "/preset"
+
+	REQUEST_TRANSCODING_JOB_URL = "/job/transcoding"
+
+	REQUEST_THUMBNAIL_JOB_URL = "/job/thumbnail"
+)
+
+// Client of MCT service is a kind of BceClient, so derived from BceClient
+type Client struct {
+	*bce.BceClient
+}
+
+func NewClient(ak, sk, endPoint string) (*Client, error) {
+	if len(endPoint) == 0 {
+		endPoint = DEFAULT_ENDPOINT
+	}
+	client, err := bce.NewBceClientWithAkSk(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{client}, nil
+}
+
+func getPipelineUri() string {
+	return URI_PREFIX + REQUEST_PIPELINE_URL
+}
+
+func getPipelineUriWithName(pipelineName string) string {
+	return URI_PREFIX + REQUEST_PIPELINE_URL + "/" + pipelineName
+}
+
+func getPresetUri() string {
+	return URI_PREFIX + REQUEST_PRESET_URL
+}
+
+func getPresetUriWithName(presetName string) string {
+	return URI_PREFIX + REQUEST_PRESET_URL + "/" + presetName
+}
+
+func getTranscodingJobUri() string {
+	return URI_PREFIX + REQUEST_TRANSCODING_JOB_URL
+}
+
+func getTranscodingJobUriWithId(jobId string) string {
+	return URI_PREFIX + REQUEST_TRANSCODING_JOB_URL + "/" + jobId
+}
+
+func getThumbnailJobUri() string {
+	return URI_PREFIX + REQUEST_THUMBNAIL_JOB_URL
+}
+
+func getThumbnailJobUriWithId(jobId string) string {
+	return URI_PREFIX + REQUEST_THUMBNAIL_JOB_URL + "/" + jobId
+}
@@ -0,0 +1,200 @@
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+var (
+	MEDIA_CLIENT *Client
+	JOB_ID       string
+
+	// set these values before start test
+	PIPELINE_NAME = "sdk-test-pipeline"
+	PRESET_NAME   = "sdk-test-preset"
+	SOURCE_BUCKET = "sdk-test-source"
+	TARGET_BUCKET = "sdk-test-target"
+	SOURCE_KEY    = "video/test.mp4"
+)
+
+// For security reason, ak/sk should not hard write here.
+type Conf struct {
+	AK       string
+	SK       string
+	Endpoint string
+}
+
+func init() {
+	_, f, _, _ := runtime.Caller(0)
+	conf := filepath.Join(filepath.Dir(f), "config.json")
+	fp, err := os.Open(conf)
+	if err != nil {
+		log.Fatal("config json file of ak/sk not given:", conf)
+		os.Exit(1)
+	}
+	decoder := json.NewDecoder(fp)
+	confObj := &Conf{}
+	decoder.Decode(confObj)
+
+	MEDIA_CLIENT, _ = NewClient(confObj.AK, confObj.SK, confObj.Endpoint)
+	log.SetLogLevel(log.WARN)
+}
+
+// ExpectEqual is the helper function for test each case
+func ExpectEqual(alert func(format string, args ...interface{}),
+	expected interface{}, actual interface{}) bool {
+	expectedValue, actualValue := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	equal := false
+	switch {
+	case expected == nil && actual == nil:
+		return true
+	case expected != nil && actual == nil:
+		equal = expectedValue.IsNil()
+	case expected == nil && actual != nil:
+		equal = actualValue.IsNil()
+	default:
+		if actualType := reflect.TypeOf(actual); actualType != nil {
+			if expectedValue.IsValid() && expectedValue.Type().ConvertibleTo(actualType) {
+				equal = reflect.DeepEqual(expectedValue.Convert(actualType).Interface(), actual)
+			}
+		}
+	}
+	if !equal {
+		_, file, line, _ := runtime.Caller(1)
+		alert("%s:%d: missmatch, expect %v but %v", file, line, expected, actual)
+		return false
+	}
+	return true
+}
+
+func TestArgsCheck(t *testing.T) {
+	cases := []struct {
+		args interface {
+			Check() error
+		}
+		valid bool
+	}{
+		{&CreatePipelineArgs{PipelineName: "p", SourceBucket: "s", TargetBucket: "t"}, true},
+		{&CreatePipelineArgs{PipelineName: "p", SourceBucket: "s"}, false},
+		{&CreatePipelineArgs{PipelineName: "p", SourceBucket: "s", TargetBucket: "t",
+			Config: &PipelineConfig{Capacity: MAX_PIPELINE_CAPACITY + 1}}, false},
+		{&Preset{PresetName: "p", Container: CONTAINER_MP4,
+			Video: &Video{Codec: VIDEO_CODEC_H264, BitRateInBps: 1024000}}, true},
+		{&Preset{PresetName: "p", Container: "avi"}, false},
+		{&Preset{PresetName: "p", Container: CONTAINER_HLS, Video: &Video{Codec: "vp9"}}, false},
+		{&Preset{PresetName: "p", Container: CONTAINER_MP3, Audio: &Audio{Channels: -1}}, false},
+		{&CreateTranscodingJobArgs{PipelineName: "p", Source: TranscodingSource{SourceKey: "a"},
+			Target: TranscodingTarget{TargetKey: "b", PresetName: "p"}}, true},
+		{&CreateTranscodingJobArgs{PipelineName: "p", Source: TranscodingSource{SourceKey: "a"},
+			Target: TranscodingTarget{TargetKey: "b"}}, false},
+		{&CreateThumbnailJobArgs{PipelineName: "p", Source: ThumbnailSource{Key: "a"}}, true},
+		{&CreateThumbnailJobArgs{PipelineName: "p", Source: ThumbnailSource{Key: "a"},
+			Capture: &ThumbnailCapture{Mode: CAPTURE_MODE_MANUAL}}, false},
+		{&CreateThumbnailJobArgs{PipelineName: "p", Source: ThumbnailSource{Key: "a"},
+			Capture: &ThumbnailCapture{Mode: CAPTURE_MODE_MANUAL, StartTimeInSecond: 10,
+				EndTimeInSecond: 5, IntervalInSecond: 1}}, false},
+		{&CreateThumbnailJobArgs{PipelineName: "p", Source: ThumbnailSource{Key: "a"},
+			Target: &ThumbnailTarget{Format: "bmp"}}, false},
+	}
+	for i, c := range cases {
+		err := c.args.Check()
+		if ExpectEqual(t.Errorf, c.valid, err == nil) == false {
+			t.Logf("case %d: %v", i, err)
+		}
+	}
+}
+
+func TestJobStatus(t *testing.T) {
+	job := &TranscodingJob{JobId: "job-1", JobStatus: JOB_STATUS_RUNNING}
+	ExpectEqual(t.Errorf, false, job.IsFinished())
+	ExpectEqual(t.Errorf, nil, job.Err())
+	ExpectEqual(t.Errorf, waiter.StateRetry, jobState(job.JobStatus))
+
+	job.JobStatus = JOB_STATUS_FAILED
+	job.Error = &JobError{Code: "SourceNotFound", Message: "the source does not exist"}
+	ExpectEqual(t.Errorf, true, job.IsFinished())
+	err, ok := job.Err().(*JobFailedError)
+	ExpectEqual(t.Errorf, true, ok)
+	if ok {
+		ExpectEqual(t.Errorf, "SourceNotFound", err.Code)
+	}
+}
+
+func TestClient_CreatePipeline(t *testing.T) {
+	err := MEDIA_CLIENT.CreatePipeline(&CreatePipelineArgs{
+		PipelineName: PIPELINE_NAME,
+		SourceBucket: SOURCE_BUCKET,
+		TargetBucket: TARGET_BUCKET,
+		Config:       &PipelineConfig{Capacity: DEFAULT_PIPELINE_CAPACITY},
+	})
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_CreatePreset(t *testing.T) {
+	err := MEDIA_CLIENT.CreatePreset(&Preset{
+		PresetName: PRESET_NAME,
+		Container:  CONTAINER_MP4,
+		Audio:      &Audio{BitRateInBps: 128000},
+		Video:      &Video{Codec: VIDEO_CODEC_H264, BitRateInBps: 1024000, MaxWidthInPixel: 1280},
+	})
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_CreateTranscodingJob(t *testing.T) {
+	result, err := MEDIA_CLIENT.CreateTranscodingJob(&CreateTranscodingJobArgs{
+		PipelineName: PIPELINE_NAME,
+		Source:       TranscodingSource{SourceKey: SOURCE_KEY},
+		Target:       TranscodingTarget{TargetKey: "video/test-720p.mp4", PresetName: PRESET_NAME},
+	})
+	ExpectEqual(t.Errorf, nil, err)
+	if err == nil {
+		JOB_ID = result.JobId
+	}
+}
+
+func TestClient_WaitTranscodingJob(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	_, err := MEDIA_CLIENT.WaitTranscodingJob(ctx, JOB_ID)
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_CreateThumbnailJob(t *testing.T) {
+	result, err := MEDIA_CLIENT.CreateThumbnailJob(&CreateThumbnailJobArgs{
+		PipelineName: PIPELINE_NAME,
+		Source:       ThumbnailSource{Key: SOURCE_KEY},
+		Target:       &ThumbnailTarget{KeyPrefix: "thumbnail/test-", Format: THUMBNAIL_FORMAT_JPG},
+		Capture:      &ThumbnailCapture{Mode: CAPTURE_MODE_AUTO},
+	})
+	ExpectEqual(t.Errorf, nil, err)
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		_, err = MEDIA_CLIENT.WaitThumbnailJob(ctx, result.JobId)
+		ExpectEqual(t.Errorf, nil, err)
+	}
+}
+
+func TestClient_ListTranscodingJobs(t *testing.T) {
+	_, err := MEDIA_CLIENT.ListTranscodingJobs(PIPELINE_NAME)
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_DeletePreset(t *testing.T) {
+	err := MEDIA_CLIENT.DeletePreset(PRESET_NAME)
+	ExpectEqual(t.Errorf, nil, err)
+}
+
+func TestClient_DeletePipeline(t *testing.T) {
+	err := MEDIA_CLIENT.DeletePipeline(PIPELINE_NAME)
+	ExpectEqual(t.Errorf, nil, err)
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the MEDIA client
+
+package media
+
+import (
+	"context"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+)
+
+// Interface defines all the operations of the MEDIA client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateTranscodingJob(args *CreateTranscodingJobArgs) (*CreateJobResult, error)
+	ListTranscodingJobs(pipelineName string) (*ListTranscodingJobsResult, error)
+	GetTranscodingJob(jobId string) (*TranscodingJob, error)
+	CreateThumbnailJob(args *CreateThumbnailJobArgs) (*CreateJobResult, error)
+	ListThumbnailJobs(pipelineName string) (*ListThumbnailJobsResult, error)
+	GetThumbnailJob(jobId string) (*ThumbnailJob, error)
+	CreatePipeline(args *CreatePipelineArgs) error
+	ListPipelines() (*ListPipelinesResult, error)
+	GetPipeline(pipelineName string) (*Pipeline, error)
+	DeletePipeline(pipelineName string) error
+	CreatePreset(preset *Preset) error
+	ListPresets() (*ListPresetsResult, error)
+	GetPreset(presetName string) (*Preset, error)
+	UpdatePreset(preset *Preset) error
+	DeletePreset(presetName string) error
+	TranscodingJobTask(jobId string) waiter.Task
+	ThumbnailJobTask(jobId string) waiter.Task
+	WaitTranscodingJob(ctx context.Context, jobId string) (*TranscodingJob, error)
+	WaitThumbnailJob(ctx context.Context, jobId string) (*ThumbnailJob, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
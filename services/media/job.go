@@ -0,0 +1,166 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// job.go - the transcoding and thumbnail job APIs definition supported by the MCT service
+
+package media
+
+import (
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+// CreateTranscodingJob - create a job to transcode the source object with the preset
+//
+// PARAMS:
+//     - args: the arguments to create a transcoding job
+// RETURNS:
+//     - *CreateJobResult: the ID of the created job
+//     - error: nil if success otherwise the specific error
+func (c *Client) CreateTranscodingJob(args *CreateTranscodingJobArgs) (*CreateJobResult, error) {
+	if args == nil {
+		return nil, fmt.Errorf("please set the create transcoding job argument")
+	}
+	if err := args.Check(); err != nil {
+		return nil, err
+	}
+
+	result := &CreateJobResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getTranscodingJobUri()).
+		WithBody(args).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// ListTranscodingJobs - list the transcoding jobs of the pipeline
+//
+// PARAMS:
+//     - pipelineName: the name of the pipeline
+// RETURNS:
+//     - *ListTranscodingJobsResult: the jobs of the pipeline
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListTranscodingJobs(pipelineName string) (*ListTranscodingJobsResult, error) {
+	if len(pipelineName) == 0 {
+		return nil, fmt.Errorf("please set the pipeline name")
+	}
+
+	result := &ListTranscodingJobsResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getTranscodingJobUri()).
+		WithQueryParam("pipelineName", pipelineName).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// GetTranscodingJob - get the status and progress of the transcoding job
+//
+// PARAMS:
+//     - jobId: the ID of the job
+// RETURNS:
+//     - *TranscodingJob: the job
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetTranscodingJob(jobId string) (*TranscodingJob, error) {
+	if len(jobId) == 0 {
+		return nil, fmt.Errorf("please set the job id")
+	}
+
+	result := &TranscodingJob{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getTranscodingJobUriWithId(jobId)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// CreateThumbnailJob - create a job to capture the thumbnails of the source video
+//
+// PARAMS:
+//     - args: the arguments to create a thumbnail job
+// RETURNS:
+//     - *CreateJobResult: the ID of the created job
+//     - error: nil if success otherwise the specific error
+func (c *Client) CreateThumbnailJob(args *CreateThumbnailJobArgs) (*CreateJobResult, error) {
+	if args == nil {
+		return nil, fmt.Errorf("please set the create thumbnail job argument")
+	}
+	if err := args.Check(); err != nil {
+		return nil, err
+	}
+
+	result := &CreateJobResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getThumbnailJobUri()).
+		WithBody(args).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// ListThumbnailJobs - list the thumbnail jobs of the pipeline
+//
+// PARAMS:
+//     - pipelineName: the name of the pipeline
+// RETURNS:
+//     - *ListThumbnailJobsResult: the jobs of the pipeline
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListThumbnailJobs(pipelineName string) (*ListThumbnailJobsResult, error) {
+	if len(pipelineName) == 0 {
+		return nil, fmt.Errorf("please set the pipeline name")
+	}
+
+	result := &ListThumbnailJobsResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getThumbnailJobUri()).
+		WithQueryParam("pipelineName", pipelineName).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// GetThumbnailJob - get the status and the captured thumbnails of the thumbnail job
+//
+// PARAMS:
+//     - jobId: the ID of the job
+// RETURNS:
+//     - *ThumbnailJob: the job
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetThumbnailJob(jobId string) (*ThumbnailJob, error) {
+	if len(jobId) == 0 {
+		return nil, fmt.Errorf("please set the job id")
+	}
+
+	result := &ThumbnailJob{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getThumbnailJobUriWithId(jobId)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
@@ -0,0 +1,360 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// model.go - definitions of the request arguments and results data structure model
+
+package media
+
+import (
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+)
+
+const (
+	JOB_STATUS_PENDING   = "PENDING"
+	JOB_STATUS_RUNNING   = "RUNNING"
+	JOB_STATUS_SUCCESS   = "SUCCESS"
+	JOB_STATUS_FAILED    = "FAILED"
+	JOB_STATUS_CANCELLED = "CANCELLED"
+
+	PIPELINE_STATUS_ACTIVE   = "ACTIVE"
+	PIPELINE_STATUS_INACTIVE = "INACTIVE"
+
+	CONTAINER_MP4  = "mp4"
+	CONTAINER_FLV  = "flv"
+	CONTAINER_HLS  = "hls"
+	CONTAINER_MP3  = "mp3"
+	CONTAINER_M4A  = "m4a"
+	CONTAINER_DASH = "dash"
+
+	VIDEO_CODEC_H264 = "h264"
+	VIDEO_CODEC_H265 = "h265"
+
+	SIZING_POLICY_KEEP    = "keep"
+	SIZING_POLICY_SHRINK  = "shrinkToFit"
+	SIZING_POLICY_STRETCH = "stretch"
+
+	THUMBNAIL_FORMAT_JPG = "jpg"
+	THUMBNAIL_FORMAT_PNG = "png"
+	THUMBNAIL_FORMAT_GIF = "gif"
+
+	CAPTURE_MODE_AUTO   = "auto"
+	CAPTURE_MODE_MANUAL = "manual"
+	CAPTURE_MODE_SPLIT  = "split"
+
+	// the capacity of the pipeline, which is the max number of the jobs running concurrently
+	DEFAULT_PIPELINE_CAPACITY = 20
+	MAX_PIPELINE_CAPACITY     = 100
+)
+
+// PipelineConfig defines the configuration of the pipeline.
+type PipelineConfig struct {
+	Capacity int `json:"capacity,omitempty"`
+}
+
+// CreatePipelineArgs defines the arguments to create a pipeline, which transcodes the sources in
+// the SourceBucket to the TargetBucket.
+type CreatePipelineArgs struct {
+	PipelineName string          `json:"pipelineName"`
+	Description  string          `json:"description,omitempty"`
+	SourceBucket string          `json:"sourceBucket"`
+	TargetBucket string          `json:"targetBucket"`
+	Config       *PipelineConfig `json:"config,omitempty"`
+}
+
+// Check - check the arguments of the pipeline
+func (args *CreatePipelineArgs) Check() error {
+	v := &bce.Validator{}
+	v.Required("pipelineName", args.PipelineName)
+	v.Required("sourceBucket", args.SourceBucket)
+	v.Required("targetBucket", args.TargetBucket)
+	if args.Config != nil {
+		v.Range("capacity", int64(args.Config.Capacity), 0, MAX_PIPELINE_CAPACITY)
+	}
+	return v.Err()
+}
+
+// JobStatusCount defines the count of the jobs of the pipeline in each status.
+type JobStatusCount struct {
+	Total   int `json:"total"`
+	Success int `json:"success"`
+	Running int `json:"running"`
+	Pending int `json:"pending"`
+	Failed  int `json:"failed"`
+}
+
+type Pipeline struct {
+	PipelineName         string         `json:"pipelineName"`
+	Description          string         `json:"description"`
+	SourceBucket         string         `json:"sourceBucket"`
+	TargetBucket         string         `json:"targetBucket"`
+	Config               PipelineConfig `json:"config"`
+	State                string         `json:"state"`
+	CreateTime           string         `json:"createTime"`
+	TranscodingJobStatus JobStatusCount `json:"transcodingJobStatus"`
+	ThumbnailJobStatus   JobStatusCount `json:"thumbnailJobStatus"`
+}
+
+type ListPipelinesResult struct {
+	Pipelines []Pipeline `json:"pipelines"`
+}
+
+// Clip defines the time range of the source to transcode.
+type Clip struct {
+	StartTimeInSecond int `json:"startTimeInSecond,omitempty"`
+	DurationInSecond  int `json:"durationInSecond,omitempty"`
+}
+
+// Audio defines the audio encoding parameters of the preset.
+type Audio struct {
+	BitRateInBps   int `json:"bitRateInBps,omitempty"`
+	SampleRateInHz int `json:"sampleRateInHz,omitempty"`
+	Channels       int `json:"channels,omitempty"`
+}
+
+// Video defines the video encoding parameters of the preset.
+type Video struct {
+	Codec            string `json:"codec,omitempty"`
+	BitRateInBps     int    `json:"bitRateInBps,omitempty"`
+	MaxFrameRate     int    `json:"maxFrameRate,omitempty"`
+	MaxWidthInPixel  int    `json:"maxWidthInPixel,omitempty"`
+	MaxHeightInPixel int    `json:"maxHeightInPixel,omitempty"`
+	SizingPolicy     string `json:"sizingPolicy,omitempty"`
+}
+
+// Preset defines the transcoding template, the Transmux only changes the container without
+// re-encoding the streams, in which case the Audio and Video are ignored.
+type Preset struct {
+	PresetName  string `json:"presetName"`
+	Description string `json:"description,omitempty"`
+	Container   string `json:"container"`
+	Transmux    bool   `json:"transmux,omitempty"`
+	Clip        *Clip  `json:"clip,omitempty"`
+	Audio       *Audio `json:"audio,omitempty"`
+	Video       *Video `json:"video,omitempty"`
+	State       string `json:"state,omitempty"`
+	CreateTime  string `json:"createTime,omitempty"`
+}
+
+// Check - check the parameters of the preset
+func (p *Preset) Check() error {
+	v := &bce.Validator{}
+	v.Required("presetName", p.PresetName)
+	v.Required("container", p.Container)
+	v.OneOf("container", p.Container, CONTAINER_MP4, CONTAINER_FLV, CONTAINER_HLS,
+		CONTAINER_MP3, CONTAINER_M4A, CONTAINER_DASH)
+	if p.Video != nil {
+		v.OneOf("video.codec", p.Video.Codec, VIDEO_CODEC_H264, VIDEO_CODEC_H265)
+		v.OneOf("video.sizingPolicy", p.Video.SizingPolicy, SIZING_POLICY_KEEP,
+			SIZING_POLICY_SHRINK, SIZING_POLICY_STRETCH)
+		v.Check(p.Video.BitRateInBps >= 0 && p.Video.MaxFrameRate >= 0 &&
+			p.Video.MaxWidthInPixel >= 0 && p.Video.MaxHeightInPixel >= 0, "video",
+			"should not be negative")
+	}
+	if p.Audio != nil {
+		v.Check(p.Audio.BitRateInBps >= 0 && p.Audio.SampleRateInHz >= 0 && p.Audio.Channels >= 0,
+			"audio", "should not be negative")
+	}
+	return v.Err()
+}
+
+type ListPresetsResult struct {
+	Presets []Preset `json:"presets"`
+}
+
+// TranscodingSource defines the source object of the transcoding job in the source bucket.
+type TranscodingSource struct {
+	SourceKey string `json:"sourceKey"`
+}
+
+// TranscodingTarget defines the target object of the transcoding job in the target bucket.
+type TranscodingTarget struct {
+	TargetKey  string `json:"targetKey"`
+	PresetName string `json:"presetName"`
+}
+
+// CreateTranscodingJobArgs defines the arguments to create a transcoding job.
+type CreateTranscodingJobArgs struct {
+	PipelineName string            `json:"pipelineName"`
+	Source       TranscodingSource `json:"source"`
+	Target       TranscodingTarget `json:"target"`
+}
+
+// Check - check the arguments of the transcoding job
+func (args *CreateTranscodingJobArgs) Check() error {
+	v := &bce.Validator{}
+	v.Required("pipelineName", args.PipelineName)
+	v.Required("source.sourceKey", args.Source.SourceKey)
+	v.Required("target.targetKey", args.Target.TargetKey)
+	v.Required("target.presetName", args.Target.PresetName)
+	return v.Err()
+}
+
+type CreateJobResult struct {
+	JobId string `json:"jobId"`
+}
+
+// JobError defines the reason of the failed job.
+type JobError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type TranscodingJob struct {
+	JobId        string            `json:"jobId"`
+	PipelineName string            `json:"pipelineName"`
+	Source       TranscodingSource `json:"source"`
+	Target       TranscodingTarget `json:"target"`
+	JobStatus    string            `json:"jobStatus"`
+	Progress     int               `json:"progress"` // the percentage 0-100 of the running job
+	StartTime    string            `json:"startTime"`
+	EndTime      string            `json:"endTime"`
+	Error        *JobError         `json:"error,omitempty"`
+}
+
+// IsFinished - check whether the job is finished, succeeded, failed or cancelled
+func (j *TranscodingJob) IsFinished() bool {
+	return isJobFinished(j.JobStatus)
+}
+
+// Err - get the error of the failed or cancelled job
+//
+// RETURNS:
+//   - error: *JobFailedError if the job is failed or cancelled otherwise nil
+func (j *TranscodingJob) Err() error {
+	return jobErr(j.JobId, j.JobStatus, j.Error)
+}
+
+type ListTranscodingJobsResult struct {
+	Jobs []TranscodingJob `json:"jobs"`
+}
+
+// ThumbnailSource defines the source video of the thumbnail job.
+type ThumbnailSource struct {
+	Key string `json:"key"`
+}
+
+// ThumbnailTarget defines the format, size and the object name prefix of the thumbnails, the
+// objects are named as KeyPrefix + the sequence number + the extension of the format.
+type ThumbnailTarget struct {
+	KeyPrefix     string `json:"keyPrefix,omitempty"`
+	Format        string `json:"format,omitempty"`
+	SizingPolicy  string `json:"sizingPolicy,omitempty"`
+	WidthInPixel  int    `json:"widthInPixel,omitempty"`
+	HeightInPixel int    `json:"heightInPixel,omitempty"`
+}
+
+// ThumbnailCapture defines how to capture the thumbnails: the auto mode captures by the duration
+// of the video, the manual mode captures every IntervalInSecond in the time range and the split
+// mode captures FrameNumber frames evenly.
+type ThumbnailCapture struct {
+	Mode              string `json:"mode,omitempty"`
+	StartTimeInSecond int    `json:"startTimeInSecond,omitempty"`
+	EndTimeInSecond   int    `json:"endTimeInSecond,omitempty"`
+	IntervalInSecond  int    `json:"intervalInSecond,omitempty"`
+	FrameNumber       int    `json:"frameNumber,omitempty"`
+}
+
+// CreateThumbnailJobArgs defines the arguments to create a thumbnail job.
+type CreateThumbnailJobArgs struct {
+	PipelineName string            `json:"pipelineName"`
+	Source       ThumbnailSource   `json:"source"`
+	Target       *ThumbnailTarget  `json:"target,omitempty"`
+	Capture      *ThumbnailCapture `json:"capture,omitempty"`
+}
+
+// Check - check the arguments of the thumbnail job
+func (args *CreateThumbnailJobArgs) Check() error {
+	v := &bce.Validator{}
+	v.Required("pipelineName", args.PipelineName)
+	v.Required("source.key", args.Source.Key)
+	if args.Target != nil {
+		v.OneOf("target.format", args.Target.Format, THUMBNAIL_FORMAT_JPG, THUMBNAIL_FORMAT_PNG,
+			THUMBNAIL_FORMAT_GIF)
+		v.OneOf("target.sizingPolicy", args.Target.SizingPolicy, SIZING_POLICY_KEEP,
+			SIZING_POLICY_SHRINK, SIZING_POLICY_STRETCH)
+	}
+	if c := args.Capture; c != nil {
+		v.OneOf("capture.mode", c.Mode, CAPTURE_MODE_AUTO, CAPTURE_MODE_MANUAL, CAPTURE_MODE_SPLIT)
+		v.Check(c.StartTimeInSecond >= 0 && (c.EndTimeInSecond == 0 ||
+			c.EndTimeInSecond > c.StartTimeInSecond), "capture",
+			"the end time should be greater than the start time")
+		v.Check(c.Mode != CAPTURE_MODE_MANUAL || c.IntervalInSecond > 0, "capture.intervalInSecond",
+			"is required by the manual mode")
+		v.Check(c.Mode != CAPTURE_MODE_SPLIT || c.FrameNumber > 0, "capture.frameNumber",
+			"is required by the split mode")
+	}
+	return v.Err()
+}
+
+type ThumbnailJob struct {
+	JobId        string           `json:"jobId"`
+	PipelineName string           `json:"pipelineName"`
+	Source       ThumbnailSource  `json:"source"`
+	Target       ThumbnailTarget  `json:"target"`
+	Capture      ThumbnailCapture `json:"capture"`
+	JobStatus    string           `json:"jobStatus"`
+	StartTime    string           `json:"startTime"`
+	EndTime      string           `json:"endTime"`
+	Keys         []string         `json:"keys"` // the object names of the captured thumbnails
+	Error        *JobError        `json:"error,omitempty"`
+}
+
+// IsFinished - check whether the job is finished, succeeded, failed or cancelled
+func (j *ThumbnailJob) IsFinished() bool {
+	return isJobFinished(j.JobStatus)
+}
+
+// Err - get the error of the failed or cancelled job
+//
+// RETURNS:
+//   - error: *JobFailedError if the job is failed or cancelled otherwise nil
+func (j *ThumbnailJob) Err() error {
+	return jobErr(j.JobId, j.JobStatus, j.Error)
+}
+
+type ListThumbnailJobsResult struct {
+	Jobs []ThumbnailJob `json:"thumbnails"`
+}
+
+// JobFailedError defines the error of the failed or cancelled job.
+type JobFailedError struct {
+	JobId   string
+	Status  string
+	Code    string
+	Message string
+}
+
+func (e *JobFailedError) Error() string {
+	return fmt.Sprintf("media job %s is %s: [Code: %s; Message: %s]", e.JobId, e.Status,
+		e.Code, e.Message)
+}
+
+func isJobFinished(status string) bool {
+	return status == JOB_STATUS_SUCCESS || status == JOB_STATUS_FAILED ||
+		status == JOB_STATUS_CANCELLED
+}
+
+func jobErr(jobId, status string, jobError *JobError) error {
+	if status != JOB_STATUS_FAILED && status != JOB_STATUS_CANCELLED {
+		return nil
+	}
+	err := &JobFailedError{JobId: jobId, Status: status}
+	if jobError != nil {
+		err.Code = jobError.Code
+		err.Message = jobError.Message
+	}
+	return err
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// pipeline.go - the pipeline and preset APIs definition supported by the MCT service
+
+package media
+
+import (
+	"fmt"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+// CreatePipeline - create a pipeline to transcode the sources from the source bucket
+//
+// PARAMS:
+//     - args: the arguments to create a pipeline
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) CreatePipeline(args *CreatePipelineArgs) error {
+	if args == nil {
+		return fmt.Errorf("please set the create pipeline argument")
+	}
+	if err := args.Check(); err != nil {
+		return err
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getPipelineUri()).
+		WithBody(args).
+		Do()
+}
+
+// ListPipelines - list all the pipelines of the user
+//
+// RETURNS:
+//     - *ListPipelinesResult: the pipelines
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListPipelines() (*ListPipelinesResult, error) {
+	result := &ListPipelinesResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getPipelineUri()).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// GetPipeline - get the detail of the pipeline
+//
+// PARAMS:
+//     - pipelineName: the name of the pipeline
+// RETURNS:
+//     - *Pipeline: the pipeline and the count of its jobs
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetPipeline(pipelineName string) (*Pipeline, error) {
+	if len(pipelineName) == 0 {
+		return nil, fmt.Errorf("please set the pipeline name")
+	}
+
+	result := &Pipeline{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getPipelineUriWithName(pipelineName)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// DeletePipeline - delete the pipeline, which should have no pending or running job
+//
+// PARAMS:
+//     - pipelineName: the name of the pipeline
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) DeletePipeline(pipelineName string) error {
+	if len(pipelineName) == 0 {
+		return fmt.Errorf("please set the pipeline name")
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.DELETE).
+		WithURL(getPipelineUriWithName(pipelineName)).
+		Do()
+}
+
+// CreatePreset - create a custom preset of the transcoding parameters
+//
+// PARAMS:
+//     - preset: the preset to create
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) CreatePreset(preset *Preset) error {
+	if preset == nil {
+		return fmt.Errorf("please set the preset")
+	}
+	if err := preset.Check(); err != nil {
+		return err
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getPresetUri()).
+		WithBody(preset).
+		Do()
+}
+
+// ListPresets - list all the presets, including the system presets and the custom ones
+//
+// RETURNS:
+//     - *ListPresetsResult: the presets
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListPresets() (*ListPresetsResult, error) {
+	result := &ListPresetsResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getPresetUri()).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// GetPreset - get the detail of the preset
+//
+// PARAMS:
+//     - presetName: the name of the preset
+// RETURNS:
+//     - *Preset: the preset
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetPreset(presetName string) (*Preset, error) {
+	if len(presetName) == 0 {
+		return nil, fmt.Errorf("please set the preset name")
+	}
+
+	result := &Preset{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.GET).
+		WithURL(getPresetUriWithName(presetName)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// UpdatePreset - update the custom preset, the jobs created before are not affected
+//
+// PARAMS:
+//     - preset: the preset with the new parameters, the PresetName selects the preset to update
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) UpdatePreset(preset *Preset) error {
+	if preset == nil {
+		return fmt.Errorf("please set the preset")
+	}
+	if err := preset.Check(); err != nil {
+		return err
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.PUT).
+		WithURL(getPresetUriWithName(preset.PresetName)).
+		WithBody(preset).
+		Do()
+}
+
+// DeletePreset - delete the custom preset
+//
+// PARAMS:
+//     - presetName: the name of the preset
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) DeletePreset(presetName string) error {
+	if len(presetName) == 0 {
+		return fmt.Errorf("please set the preset name")
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.DELETE).
+		WithURL(getPresetUriWithName(presetName)).
+		Do()
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// wait.go - wait for the transcoding and thumbnail jobs to finish
+
+package media
+
+import (
+	"context"
+
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+)
+
+// TranscodingJobTask - get the async task of the transcoding job, the result of the task is the
+// *TranscodingJob of the last query which holds the progress
+//
+// PARAMS:
+//     - jobId: the job ID returned by the CreateTranscodingJob
+// RETURNS:
+//     - waiter.Task: the task finished when the job succeeds, or failed with the *JobFailedError
+//       if the job fails or is cancelled
+func (c *Client) TranscodingJobTask(jobId string) waiter.Task {
+	return waiter.NewTask(jobId, func(ctx context.Context) (interface{}, waiter.State, error) {
+		job, err := c.GetTranscodingJob(jobId)
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		return job, jobState(job.JobStatus), job.Err()
+	}, nil)
+}
+
+// ThumbnailJobTask - get the async task of the thumbnail job, the result of the task is the
+// *ThumbnailJob of the last query which holds the captured thumbnails
+//
+// PARAMS:
+//     - jobId: the job ID returned by the CreateThumbnailJob
+// RETURNS:
+//     - waiter.Task: the task finished when the job succeeds, or failed with the *JobFailedError
+//       if the job fails or is cancelled
+func (c *Client) ThumbnailJobTask(jobId string) waiter.Task {
+	return waiter.NewTask(jobId, func(ctx context.Context) (interface{}, waiter.State, error) {
+		job, err := c.GetThumbnailJob(jobId)
+		if err != nil {
+			return nil, waiter.StateRetry, err
+		}
+		return job, jobState(job.JobStatus), job.Err()
+	}, nil)
+}
+
+// WaitTranscodingJob - poll the transcoding job with the default backoff of the waiter until it
+// is finished
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - jobId: the job ID returned by the CreateTranscodingJob
+// RETURNS:
+//     - *TranscodingJob: the last queried job
+//     - error: nil if the job succeeds, *waiter.FailureError if it fails or is cancelled,
+//       *waiter.TimeoutError if the context is done, otherwise the query error
+func (c *Client) WaitTranscodingJob(ctx context.Context, jobId string) (*TranscodingJob, error) {
+	task := c.TranscodingJobTask(jobId)
+	err := task.Wait(ctx)
+	job, _ := task.Result().(*TranscodingJob)
+	return job, err
+}
+
+// WaitThumbnailJob - poll the thumbnail job with the default backoff of the waiter until it is
+// finished
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - jobId: the job ID returned by the CreateThumbnailJob
+// RETURNS:
+//     - *ThumbnailJob: the last queried job
+//     - error: nil if the job succeeds, *waiter.FailureError if it fails or is cancelled,
+//       *waiter.TimeoutError if the context is done, otherwise the query error
+func (c *Client) WaitThumbnailJob(ctx context.Context, jobId string) (*ThumbnailJob, error) {
+	task := c.ThumbnailJobTask(jobId)
+	err := task.Wait(ctx)
+	job, _ := task.Result().(*ThumbnailJob)
+	return job, err
+}
+
+func jobState(status string) waiter.State {
+	switch status {
+	case JOB_STATUS_SUCCESS:
+		return waiter.StateSuccess
+	case JOB_STATUS_FAILED, JOB_STATUS_CANCELLED:
+		return waiter.StateFailure
+	}
+	return waiter.StateRetry
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the MMS client
+
+package mms
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/mms/api"
+)
+
+// Interface defines all the operations of the MMS client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	InsertVideo(lib string, args *api.BaseRequest) (*api.BaseResponse, error)
+	GetInsertVideoResult(lib, source string) (*api.BaseResponse, error)
+	GetInsertVideoResultById(libId, mediaId string) (*api.BaseResponse, error)
+	DeleteVideo(lib, source string) (*api.BaseResponse, error)
+	DeleteVideoById(libId, mediaId string) (*api.BaseResponse, error)
+	InsertImage(lib string, args *api.BaseRequest) (*api.BaseResponse, error)
+	DeleteImage(lib, source string) (*api.BaseResponse, error)
+	DeleteImageById(libId, mediaId string) (*api.BaseResponse, error)
+	SearchImageByImage(lib string, args *api.BaseRequest) (*api.SearchTaskResultResponse, error)
+	SearchVideoByImage(lib string, args *api.BaseRequest) (*api.SearchTaskResultResponse, error)
+	SearchVideoByVideo(lib string, args *api.BaseRequest) (*api.SearchTaskResultResponse, error)
+	GetSearchVideoByVideoResult(lib, source string) (*api.SearchTaskResultResponse, error)
+	GetSearchVideoByVideoResultById(lib, taskId string) (*api.SearchTaskResultResponse, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// check.go - check the remaining quota before creating the resources
+package quotacenter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+)
+
+const (
+	TYPE_QUOTA     = "QUOTA"
+	TYPE_WHITELIST = "WHITELIST"
+)
+
+// Limit - get the limit of the quota, the negative limit means unlimited
+func (q *QuotaModel) Limit() (int64, error) {
+	return parseQuotaValue("value", q.Value)
+}
+
+// Usage - get the used amount of the quota, zero if the service does not report it
+func (q *QuotaModel) Usage() (int64, error) {
+	if len(q.Used) == 0 {
+		return 0, nil
+	}
+	return parseQuotaValue("used", q.Used)
+}
+
+// Remaining - get the remaining amount of the quota, math.MaxInt64 if it is unlimited
+func (q *QuotaModel) Remaining() (int64, error) {
+	limit, err := q.Limit()
+	if err != nil {
+		return 0, err
+	}
+	if limit < 0 {
+		return math.MaxInt64, nil
+	}
+	used, err := q.Usage()
+	if err != nil {
+		return 0, err
+	}
+	if used > limit {
+		return 0, nil
+	}
+	return limit - used, nil
+}
+
+func parseQuotaValue(field, value string) (int64, error) {
+	v, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("the %s %q of the quota is not a number", field, value)
+	}
+	return v, nil
+}
+
+// QuotaExceededError defines the error of the quota which is not enough for the resources to
+// create, it is returned by the CheckQuota before creating
+type QuotaExceededError struct {
+	ServiceType string
+	Region      string
+	Name        string
+	Limit       int64
+	Used        int64
+	Required    int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota %s of %s in %s exceeded: limit %d, used %d, required %d",
+		e.Name, e.ServiceType, e.Region, e.Limit, e.Used, e.Required)
+}
+
+// IsQuotaExceeded - check whether the error is for the quota exceeded, including the
+// *QuotaExceededError returned by the CheckQuota and the service errors whose code contains
+// "QuotaExceed" returned by the creation apis of the services
+func IsQuotaExceeded(err error) bool {
+	switch e := err.(type) {
+	case *QuotaExceededError:
+		return true
+	case *bce.BceServiceError:
+		return strings.Contains(strings.ToLower(e.Code), "quotaexceed")
+	}
+	return false
+}
+
+// ListAllQuotas - list the quotas of the service in the region by following the markers
+//
+// PARAMS:
+//     - args: the arguments to query quota_center, the Marker is ignored
+// RETURNS:
+//     - []QuotaModel: all the quotas
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListAllQuotas(args *QuotaCenterQueryArgs) ([]QuotaModel, error) {
+	if args == nil {
+		args = &QuotaCenterQueryArgs{}
+	}
+	query := *args
+	query.Marker = ""
+	var quotas []QuotaModel
+	for {
+		result, err := c.QuotaCenterQuery(&query)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, result.Result...)
+		if !result.IsTruncated || len(result.NextMarker) == 0 {
+			return quotas, nil
+		}
+		query.Marker = result.NextMarker
+	}
+}
+
+// GetQuota - get the quota of the service in the region by the name
+//
+// PARAMS:
+//     - serviceType: the service type of the quota, see InfoQuery for the supported ones
+//     - region: the region of the quota
+//     - name: the name of the quota
+// RETURNS:
+//     - *QuotaModel: the quota with its limit and usage
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetQuota(serviceType, region, name string) (*QuotaModel, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("please set name argment")
+	}
+	quotas, err := c.ListAllQuotas(&QuotaCenterQueryArgs{
+		Type:        TYPE_QUOTA,
+		ServiceType: serviceType,
+		Region:      region,
+		Name:        name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range quotas {
+		if quotas[i].Name == name {
+			return &quotas[i], nil
+		}
+	}
+	return nil, fmt.Errorf("quota %s of %s in %s not found", name, serviceType, region)
+}
+
+// CheckQuota - check whether the remaining quota is enough to create the resources, so that the
+// creation is not attempted if it would fail for the quota
+//
+// PARAMS:
+//     - serviceType: the service type of the quota
+//     - region: the region of the quota
+//     - name: the name of the quota
+//     - required: the amount of the resources to create
+// RETURNS:
+//     - *QuotaModel: the queried quota
+//     - error: nil if the quota is enough, *QuotaExceededError if not, otherwise the query error
+func (c *Client) CheckQuota(serviceType, region, name string, required int64) (*QuotaModel, error) {
+	quota, err := c.GetQuota(serviceType, region, name)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := quota.Remaining()
+	if err != nil {
+		return quota, err
+	}
+	if remaining < required {
+		limit, _ := quota.Limit()
+		used, _ := quota.Usage()
+		return quota, &QuotaExceededError{
+			ServiceType: serviceType,
+			Region:      region,
+			Name:        name,
+			Limit:       limit,
+			Used:        used,
+			Required:    required,
+		}
+	}
+	return quota, nil
+}
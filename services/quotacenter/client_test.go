@@ -133,3 +133,24 @@ func TestClient_ApplicationDetail(t *testing.T) {
 		fmt.Println(string(r))
 	}
 }
+
+func TestClient_CheckQuota(t *testing.T) {
+	quotas, err := QUOTA_CENTER_CLIENT.ListAllQuotas(&QuotaCenterQueryArgs{
+		ServiceType: "EIP",
+		Type:        TYPE_QUOTA,
+		Region:      "su",
+	})
+	if err != nil || len(quotas) == 0 {
+		fmt.Println(err)
+		return
+	}
+	quota, err := QUOTA_CENTER_CLIENT.CheckQuota("EIP", "su", quotas[0].Name, 1)
+	if IsQuotaExceeded(err) {
+		fmt.Println("quota exceeded:", err)
+	} else if err != nil {
+		fmt.Println(err)
+	} else {
+		remaining, _ := quota.Remaining()
+		fmt.Println(quota.Name, remaining)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// backup.go - the backup and point-in-time recovery APIs of the RDS service
+package rds
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce/waiter"
+	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/util"
+)
+
+const (
+	INSTANCE_STATUS_AVAILABLE = "Available"
+)
+
+// GetBackupPolicy - get the backup policy of the instance
+//
+// PARAMS:
+//     - instanceId: id of the instance
+// RETURNS:
+//     - *BackupPolicy: the backup policy
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetBackupPolicy(instanceId string) (*BackupPolicy, error) {
+	result, err := c.GetDetail(instanceId)
+	if err != nil {
+		return nil, err
+	}
+	return &result.BackupPolicy, nil
+}
+
+// CreateBackup - create a manual backup of the instance, which is listed by GetBackupList
+//
+// PARAMS:
+//     - instanceId: id of the instance
+//     - args: the optional arguments to create the backup
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) CreateBackup(instanceId string, args *CreateBackupArgs) error {
+	if args == nil {
+		args = &CreateBackupArgs{}
+	}
+	return bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getRdsUriWithInstanceId(instanceId)+"/backup").
+		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE).
+		Do()
+}
+
+// ListAllBackups - list all backups of the instance by following the markers
+//
+// PARAMS:
+//     - instanceId: id of the instance
+// RETURNS:
+//     - []Snapshot: all backups of the instance
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListAllBackups(instanceId string) ([]Snapshot, error) {
+	args := &GetBackupListArgs{}
+	var backups []Snapshot
+	for {
+		result, err := c.GetBackupList(instanceId, args)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, result.Backups...)
+		if !result.IsTruncated || len(result.NextMarker) == 0 {
+			return backups, nil
+		}
+		args.Marker = result.NextMarker
+	}
+}
+
+// GetBackupDownloadUrl - get the url to download the backup file
+//
+// PARAMS:
+//     - instanceId: id of the instance
+//     - backupId: id of the backup
+// RETURNS:
+//     - string: the download url
+//     - string: the expiration time of the download url
+//     - error: nil if success otherwise the specific error
+func (c *Client) GetBackupDownloadUrl(instanceId, backupId string) (string, string, error) {
+	result, err := c.GetBackupDetail(instanceId, backupId)
+	if err != nil {
+		return "", "", err
+	}
+	if result.DownloadUrl == "" {
+		return "", "", fmt.Errorf("the backup %s of %s can not be downloaded, status: %s",
+			backupId, instanceId, result.SnapshotStatus)
+	}
+	return result.DownloadUrl, result.DownloadExpires, nil
+}
+
+// RecoveryTime - format the time to the Datetime of RecoveryToNewInstanceArgs
+func RecoveryTime(t time.Time) string {
+	return util.FormatISO8601Date(t.Unix())
+}
+
+// RecoveryToNewInstance - restore the data of the instance at the point in time or from the
+// backup to a new instance, use WaitInstanceAvailable to wait for the restore completion
+//
+// PARAMS:
+//     - args: the arguments with either the Datetime or the SnapshotId to restore
+// RETURNS:
+//     - *CreateResult: the result contains the new instance id
+//     - error: nil if success otherwise the specific error
+func (c *Client) RecoveryToNewInstance(args *RecoveryToNewInstanceArgs) (*CreateResult, error) {
+	if args == nil {
+		return nil, fmt.Errorf("unset args")
+	}
+
+	if args.SourceInstanceId == "" {
+		return nil, fmt.Errorf("unset SourceInstanceId")
+	}
+
+	if args.Billing.PaymentTiming == "" {
+		return nil, fmt.Errorf("unset PaymentTiming")
+	}
+
+	if (args.Datetime == "") == (args.SnapshotId == "") {
+		return nil, fmt.Errorf("set either Datetime or SnapshotId")
+	}
+
+	if args.Datetime != "" {
+		datetime, err := util.ParseISO8601Date(args.Datetime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Datetime %s: %v", args.Datetime, err)
+		}
+		if datetime.After(time.Now()) {
+			return nil, fmt.Errorf("the Datetime %s is in the future", args.Datetime)
+		}
+	}
+
+	result := &CreateResult{}
+	err := bce.NewRequestBuilder(c).
+		WithMethod(http.POST).
+		WithURL(getRdsUri()).
+		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithQueryParam("recoveryToNewInstance", "").
+		WithHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE).
+		WithBody(args).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// WaitInstanceAvailable - poll the instance with the default backoff of the waiter until it is
+// available, eg: after it is created or restored
+//
+// PARAMS:
+//     - ctx: the context to cancel the waiting or set the deadline
+//     - instanceId: id of the instance
+// RETURNS:
+//     - *Instance: the last queried instance
+//     - error: nil if ok, *waiter.TimeoutError if the context is done, otherwise the query error
+func (c *Client) WaitInstanceAvailable(ctx context.Context, instanceId string) (*Instance, error) {
+	op := func(ctx context.Context) (interface{}, error) {
+		return c.GetDetail(instanceId)
+	}
+	result, err := waiter.Wait(ctx, op, []waiter.Acceptor{
+		{State: waiter.StateSuccess, Matcher: func(result interface{}, err error) bool {
+			return err == nil && result.(*Instance).InstanceStatus == INSTANCE_STATUS_AVAILABLE
+		}},
+	}, nil)
+	instance, _ := result.(*Instance)
+	return instance, err
+}
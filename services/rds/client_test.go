@@ -1,6 +1,7 @@
 package rds
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -292,6 +293,37 @@ func TestClient_GetBackupList(t *testing.T) {
 	}
 }
 
+func TestClient_CreateBackup(t *testing.T) {
+	isAvailable(RDS_ID)
+	err := RDS_CLIENT.CreateBackup(RDS_ID, nil)
+	ExpectEqual(t.Errorf, nil, err)
+	backups, err := RDS_CLIENT.ListAllBackups(RDS_ID)
+	ExpectEqual(t.Errorf, nil, err)
+	for _, backup := range backups {
+		fmt.Println(backup.SnapshotId, backup.SnapshotStatus)
+	}
+}
+
+func TestClient_RecoveryToNewInstance(t *testing.T) {
+	isAvailable(RDS_ID)
+	args := &RecoveryToNewInstanceArgs{
+		Billing: Billing{
+			PaymentTiming: "Postpaid",
+		},
+		SourceInstanceId: RDS_ID,
+		Datetime:         RecoveryTime(time.Now().Add(-time.Hour)),
+		InstanceName:     SDK_NAME_PREFIX + strconv.FormatInt(time.Now().Unix(), 10),
+	}
+	result, err := RDS_CLIENT.RecoveryToNewInstance(args)
+	ExpectEqual(t.Errorf, nil, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+	instance, err := RDS_CLIENT.WaitInstanceAvailable(ctx, result.InstanceIds[0])
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, INSTANCE_STATUS_AVAILABLE, instance.InstanceStatus)
+}
+
 func TestClient_GetZoneList(t *testing.T) {
 	isAvailable(RDS_ID)
 	_, err := RDS_CLIENT.GetZoneList()
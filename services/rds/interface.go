@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the RDS client
+
+package rds
+
+import (
+	"context"
+)
+
+// Interface defines all the operations of the RDS client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	GetBackupPolicy(instanceId string) (*BackupPolicy, error)
+	CreateBackup(instanceId string, args *CreateBackupArgs) error
+	ListAllBackups(instanceId string) ([]Snapshot, error)
+	GetBackupDownloadUrl(instanceId, backupId string) (string, string, error)
+	RecoveryToNewInstance(args *RecoveryToNewInstanceArgs) (*CreateResult, error)
+	WaitInstanceAvailable(ctx context.Context, instanceId string) (*Instance, error)
+	CreateRds(args *CreateRdsArgs) (*CreateResult, error)
+	CreateReadReplica(args *CreateReadReplicaArgs) (*CreateResult, error)
+	CreateRdsProxy(args *CreateRdsProxyArgs) (*CreateResult, error)
+	ListRds(args *ListRdsArgs) (*ListRdsResult, error)
+	GetDetail(instanceId string) (*Instance, error)
+	DeleteRds(instanceIds string) error
+	ResizeRds(instanceId string, args *ResizeRdsArgs) error
+	CreateAccount(instanceId string, args *CreateAccountArgs) error
+	ListAccount(instanceId string) (*ListAccountResult, error)
+	GetAccount(instanceId, accountName string) (*Account, error)
+	DeleteAccount(instanceId, accountName string) error
+	RebootInstance(instanceId string) error
+	UpdateInstanceName(instanceId string, args *UpdateInstanceNameArgs) error
+	ModifySyncMode(instanceId string, args *ModifySyncModeArgs) error
+	ModifyEndpoint(instanceId string, args *ModifyEndpointArgs) error
+	ModifyPublicAccess(instanceId string, args *ModifyPublicAccessArgs) error
+	ModifyBackupPolicy(instanceId string, args *ModifyBackupPolicyArgs) error
+	GetBackupList(instanceId string, args *GetBackupListArgs) (*GetBackupListResult, error)
+	GetBackupDetail(instanceId string, backupId string) (*Snapshot, error)
+	GetZoneList() (*GetZoneListResult, error)
+	ListSubnets(args *ListSubnetsArgs) (*ListSubnetsResult, error)
+	GetSecurityIps(instanceId string) (*GetSecurityIpsResult, error)
+	UpdateSecurityIps(instanceId, Etag string, args *UpdateSecurityIpsArgs) error
+	ListParameters(instanceId string) (*ListParametersResult, error)
+	UpdateParameter(instanceId, Etag string, args *UpdateParameterArgs) error
+	AutoRenew(args *AutoRenewArgs) error
+	Request(method, uri string, body interface{}) (interface{}, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
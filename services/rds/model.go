@@ -247,6 +247,27 @@ type GetBackupListResult struct {
 	Backups     []Snapshot `json:"backups"`
 }
 
+type CreateBackupArgs struct {
+	ClientToken string `json:"-"`
+}
+
+type RecoveryToNewInstanceArgs struct {
+	ClientToken      string           `json:"-"`
+	Billing          Billing          `json:"billing"`
+	SourceInstanceId string           `json:"sourceInstanceId"`
+	Datetime         string           `json:"datetime,omitempty"`
+	SnapshotId       string           `json:"snapshotId,omitempty"`
+	InstanceName     string           `json:"instanceName,omitempty"`
+	CpuCount         int              `json:"cpuCount,omitempty"`
+	MemoryCapacity   float64          `json:"memoryCapacity,omitempty"`
+	VolumeCapacity   int              `json:"volumeCapacity,omitempty"`
+	ZoneNames        []string         `json:"zoneNames,omitempty"`
+	VpcId            string           `json:"vpcId,omitempty"`
+	IsDirectPay      bool             `json:"isDirectPay,omitempty"`
+	Subnets          []SubnetMap      `json:"subnets,omitempty"`
+	Tags             []model.TagModel `json:"tags,omitempty"`
+}
+
 type GetZoneListResult struct {
 	Zones []ZoneName `json:"zones"`
 }
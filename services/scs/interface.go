@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the SCS client
+
+package scs
+
+// Interface defines all the operations of the SCS client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	CreateInstance(args *CreateInstanceArgs) (*CreateInstanceResult, error)
+	ListInstances(args *ListInstancesArgs) (*ListInstancesResult, error)
+	GetInstanceDetail(instanceId string) (*GetInstanceDetailResult, error)
+	ResizeInstance(instanceId string, args *ResizeInstanceArgs) error
+	AddReplication(instanceId string, args *ReplicationArgs) error
+	DeleteReplication(instanceId string, args *ReplicationArgs) error
+	RestartInstance(instanceId string, args *RestartInstanceArgs) error
+	DeleteInstance(instanceId string, clientToken string) error
+	UpdateInstanceName(instanceId string, args *UpdateInstanceNameArgs) error
+	GetNodeTypeList() (*GetNodeTypeListResult, error)
+	ListSubnets(args *ListSubnetsArgs) (*ListSubnetsResult, error)
+	UpdateInstanceDomainName(instanceId string, args *UpdateInstanceDomainNameArgs) error
+	GetZoneList() (*GetZoneListResult, error)
+	FlushInstance(instanceId string, args *FlushInstanceArgs) error
+	BindingTag(instanceId string, args *BindingTagArgs) error
+	UnBindingTag(instanceId string, args *BindingTagArgs) error
+	GetSecurityIp(instanceId string) (*GetSecurityIpResult, error)
+	AddSecurityIp(instanceId string, args *SecurityIpArgs) error
+	DeleteSecurityIp(instanceId string, args *SecurityIpArgs) error
+	ModifyPassword(instanceId string, args *ModifyPasswordArgs) error
+	GetParameters(instanceId string) (*GetParametersResult, error)
+	ModifyParameters(instanceId string, args *ModifyParametersArgs) error
+	GetBackupList(instanceId string) (*GetBackupListResult, error)
+	ModifyBackupPolicy(instanceId string, args *ModifyBackupPolicyArgs) error
+	ListSecurityGroupByVpcId(vpcId string) (*ListVpcSecurityGroupsResult, error)
+	ListSecurityGroupByInstanceId(instanceId string) (*ListSecurityGroupResult, error)
+	BindSecurityGroups(args *SecurityGroupArgs) error
+	UnBindSecurityGroups(args *UnbindSecurityGroupArgs) error
+	ReplaceSecurityGroups(args *SecurityGroupArgs) error
+	ListRecycleInstances(marker *Marker) (*RecyclerInstanceList, error)
+	RecoverRecyclerInstances(instanceIds []string) error
+	DeleteRecyclerInstances(instanceIds []string) error
+	RenewInstances(args *RenewInstanceArgs) (*OrderIdResult, error)
+	ListLogByInstanceId(instanceId string, args *ListLogArgs) (*ListLogResult, error)
+	GetLogById(instanceId, logId string, args *GetLogArgs) (*LogItem, error)
+	GetMaintainTime(instanceId string) (*GetMaintainTimeResult, error)
+	ModifyMaintainTime(instanceId string, args *MaintainTime) error
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
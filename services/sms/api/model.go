@@ -26,6 +26,7 @@ type SendSmsArgs struct {
 	UserExtId     string                 `json:"userExtId,omitempty"`
 	CallbackUrlId string                 `json:"merchantUrlId,omitempty"`
 	ClientToken   string                 `json:"clientToken,omitempty"`
+	ScheduleTime  string                 `json:"scheduleTime,omitempty"`
 }
 
 // SendSmsResult defines the data structure of the result of sending a SMS request
@@ -160,3 +161,63 @@ type QueryQuotaRateResult struct {
 	RateLimitPerMinute   int  `json:"rateLimitPerMobilePerSignByMinute"`
 	RateLimitWhitelist   bool `json:"rateLimitWhitelist"`
 }
+
+// ListSignaturesArgs defines the input data structure for listing the signatures
+type ListSignaturesArgs struct {
+	Status   string
+	PageNo   int
+	PageSize int
+}
+
+// ListSignaturesResult defines the data structure of the result of listing the signatures
+type ListSignaturesResult struct {
+	TotalCount int                  `json:"totalCount"`
+	PageNo     int                  `json:"pageNo"`
+	PageSize   int                  `json:"pageSize"`
+	Signatures []GetSignatureResult `json:"signatures"`
+}
+
+// ListTemplatesArgs defines the input data structure for listing the templates
+type ListTemplatesArgs struct {
+	Status   string
+	PageNo   int
+	PageSize int
+}
+
+// ListTemplatesResult defines the data structure of the result of listing the templates
+type ListTemplatesResult struct {
+	TotalCount int                 `json:"totalCount"`
+	PageNo     int                 `json:"pageNo"`
+	PageSize   int                 `json:"pageSize"`
+	Templates  []GetTemplateResult `json:"templates"`
+}
+
+// ListReceiptsArgs defines the input data structure for querying the delivery receipts, the
+// StartTime and EndTime are in the format of "2006-01-02 15:04:05"
+type ListReceiptsArgs struct {
+	MessageId string
+	Mobile    string
+	StartTime string
+	EndTime   string
+	PageNo    int
+	PageSize  int
+}
+
+// Receipt defines the delivery receipt of a sent message
+type Receipt struct {
+	MessageId   string `json:"messageId"`
+	Mobile      string `json:"mobile"`
+	Status      string `json:"status"`
+	ErrorCode   string `json:"errorCode"`
+	Custom      string `json:"custom"`
+	SendTime    string `json:"sendTime"`
+	ReceiveTime string `json:"receiveTime"`
+}
+
+// ListReceiptsResult defines the data structure of the result of querying the delivery receipts
+type ListReceiptsResult struct {
+	TotalCount int       `json:"totalCount"`
+	PageNo     int       `json:"pageNo"`
+	PageSize   int       `json:"pageSize"`
+	Receipts   []Receipt `json:"receipts"`
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// receipt.go - the delivery receipt APIs definition supported by the SMS service
+
+package api
+
+import (
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+)
+
+// ListReceipts - query the delivery receipts of the sent messages
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - args: the message id, mobile, time range and paging arguments to filter the receipts
+// RETURNS:
+//     - *api.ListReceiptsResult: the delivery receipts
+//     - error: the return error if any occurs
+func ListReceipts(cli bce.Client, args *ListReceiptsArgs) (*ListReceiptsResult, error) {
+	if err := CheckError(args != nil, "ListReceiptsArgs can not be nil"); err != nil {
+		return nil, err
+	}
+	if err := CheckError(len(args.MessageId) > 0 || len(args.Mobile) > 0,
+		"messageId and mobile can not be both blank"); err != nil {
+		return nil, err
+	}
+	if err := checkTime(args.StartTime, "startTime"); err != nil {
+		return nil, err
+	}
+	if err := checkTime(args.EndTime, "endTime"); err != nil {
+		return nil, err
+	}
+	req := &bce.BceRequest{}
+	req.SetUri(REQUEST_URI_RECEIPT)
+	req.SetMethod(http.GET)
+	if len(args.MessageId) > 0 {
+		req.SetParam("messageId", args.MessageId)
+	}
+	if len(args.Mobile) > 0 {
+		req.SetParam("mobile", args.Mobile)
+	}
+	if len(args.StartTime) > 0 {
+		req.SetParam("startTime", args.StartTime)
+	}
+	if len(args.EndTime) > 0 {
+		req.SetParam("endTime", args.EndTime)
+	}
+	setPageParams(req, "", args.PageNo, args.PageSize)
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &ListReceiptsResult{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
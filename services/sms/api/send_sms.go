@@ -42,6 +42,9 @@ func SendSms(cli bce.Client, args *SendSmsArgs) (*SendSmsResult, error) {
 	if err := CheckError(len(args.Template) > 0, "templateId can not be blank"); err != nil {
 		return nil, err
 	}
+	if err := checkTime(args.ScheduleTime, "scheduleTime"); err != nil {
+		return nil, err
+	}
 	req := &bce.BceRequest{}
 	req.SetUri(REQUEST_URI_SEND_SMS)
 	req.SetMethod(http.POST)
@@ -171,3 +171,32 @@ func GetSignature(cli bce.Client, args *GetSignatureArgs) (*GetSignatureResult,
 	}
 	return result, nil
 }
+
+// ListSignatures - list the sms signatures of the user
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - args: the optional audit status and paging arguments
+// RETURNS:
+//     - *api.ListSignaturesResult: the signatures with their audit status
+//     - error: the return error if any occurs
+func ListSignatures(cli bce.Client, args *ListSignaturesArgs) (*ListSignaturesResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(REQUEST_URI_SIGNATURE)
+	req.SetMethod(http.GET)
+	if args != nil {
+		setPageParams(req, args.Status, args.PageNo, args.PageSize)
+	}
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &ListSignaturesResult{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
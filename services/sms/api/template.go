@@ -176,3 +176,32 @@ func GetTemplate(cli bce.Client, args *GetTemplateArgs) (*GetTemplateResult, err
 	}
 	return result, nil
 }
+
+// ListTemplates - list the sms templates of the user
+//
+// PARAMS:
+//     - cli: the client agent which can perform sending request
+//     - args: the optional audit status and paging arguments
+// RETURNS:
+//     - *api.ListTemplatesResult: the templates with their audit status
+//     - error: the return error if any occurs
+func ListTemplates(cli bce.Client, args *ListTemplatesArgs) (*ListTemplatesResult, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(REQUEST_URI_TEMPLATE)
+	req.SetMethod(http.GET)
+	if args != nil {
+		setPageParams(req, args.Status, args.PageNo, args.PageSize)
+	}
+	resp := &bce.BceResponse{}
+	if err := cli.SendRequest(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	result := &ListTemplatesResult{}
+	if err := resp.ParseJsonBody(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -18,6 +18,10 @@ package api
 
 import (
 	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
 )
 
 const (
@@ -25,7 +29,21 @@ const (
 	REQUEST_URI_SIGNATURE = "/sms/v3/signatureApply"
 	REQUEST_URI_TEMPLATE  = "/sms/v3/template"
 	REQUEST_URI_QUOTA     = "/sms/v3/quota"
+	REQUEST_URI_RECEIPT   = "/sms/v3/receipt"
 	CLIENT_TOKEN          = "clientToken"
+
+	// the audit status of the signatures and templates
+	AUDIT_STATUS_SUBMITTED = "SUBMITTED"
+	AUDIT_STATUS_READY     = "READY"
+	AUDIT_STATUS_REJECTED  = "REJECTED"
+
+	// the delivery status of the sent messages
+	RECEIPT_STATUS_SENDING   = "SENDING"
+	RECEIPT_STATUS_DELIVERED = "DELIVERED"
+	RECEIPT_STATUS_FAILED    = "FAILED"
+
+	// the layout of the schedule time and the time range to query the receipts
+	SMS_TIME_LAYOUT = "2006-01-02 15:04:05"
 )
 
 func CheckError(condition bool, errMessage string) error {
@@ -34,3 +52,25 @@ func CheckError(condition bool, errMessage string) error {
 	}
 	return nil
 }
+
+func checkTime(value, name string) error {
+	if len(value) == 0 {
+		return nil
+	}
+	if _, err := time.Parse(SMS_TIME_LAYOUT, value); err != nil {
+		return fmt.Errorf("%s should be in the format of %s", name, SMS_TIME_LAYOUT)
+	}
+	return nil
+}
+
+func setPageParams(req *bce.BceRequest, status string, pageNo, pageSize int) {
+	if len(status) > 0 {
+		req.SetParam("status", status)
+	}
+	if pageNo > 0 {
+		req.SetParam("pageNo", strconv.Itoa(pageNo))
+	}
+	if pageSize > 0 {
+		req.SetParam("pageSize", strconv.Itoa(pageSize))
+	}
+}
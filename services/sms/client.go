@@ -17,6 +17,9 @@
 package sms
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/baidubce/bce-sdk-go/auth"
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/services/sms/api"
@@ -77,6 +80,26 @@ func (c *Client) SendSms(args *api.SendSmsArgs) (*api.SendSmsResult, error) {
 	return api.SendSms(c, args)
 }
 
+// SendSmsAt - schedule an sms message to be sent at the given time
+//
+// PARAMS:
+//     - args: the arguments to send an sms message
+//     - at: the time to send the message, which should be in the future
+// RETURNS:
+//     - *api.SendSmsResult: the result of scheduling the sms message
+//     - error: the return error if any occurs
+func (c *Client) SendSmsAt(args *api.SendSmsArgs, at time.Time) (*api.SendSmsResult, error) {
+	if args == nil {
+		return nil, fmt.Errorf("SendSmsArgs can not be nil")
+	}
+	if !at.After(time.Now()) {
+		return nil, fmt.Errorf("the schedule time %s is not in the future", at.Format(api.SMS_TIME_LAYOUT))
+	}
+	scheduled := *args
+	scheduled.ScheduleTime = at.In(beijingLocation()).Format(api.SMS_TIME_LAYOUT)
+	return api.SendSms(c, &scheduled)
+}
+
 // CreateSignature - create an sms signature
 //
 // PARAMS:
@@ -119,6 +142,17 @@ func (c *Client) GetSignature(args *api.GetSignatureArgs) (*api.GetSignatureResu
 	return api.GetSignature(c, args)
 }
 
+// ListSignatures - list the sms signatures with their audit status
+//
+// PARAMS:
+//     - args: the optional audit status and paging arguments, nil to list all
+// RETURNS:
+//     - *api.ListSignaturesResult: the signatures
+//     - error: the return error if any occurs
+func (c *Client) ListSignatures(args *api.ListSignaturesArgs) (*api.ListSignaturesResult, error) {
+	return api.ListSignatures(c, args)
+}
+
 // CreateTemplate - create an sms template
 //
 // PARAMS:
@@ -160,6 +194,17 @@ func (c *Client) GetTemplate(args *api.GetTemplateArgs) (*api.GetTemplateResult,
 	return api.GetTemplate(c, args)
 }
 
+// ListTemplates - list the sms templates with their audit status
+//
+// PARAMS:
+//     - args: the optional audit status and paging arguments, nil to list all
+// RETURNS:
+//     - *api.ListTemplatesResult: the templates
+//     - error: the return error if any occurs
+func (c *Client) ListTemplates(args *api.ListTemplatesArgs) (*api.ListTemplatesResult, error) {
+	return api.ListTemplates(c, args)
+}
+
 // QueryQuotaAndRateLimit - query the quota and rate limit
 //
 // RETURNS:
@@ -177,3 +222,40 @@ func (c *Client) QueryQuotaAndRateLimit() (*api.QueryQuotaRateResult, error) {
 func (c *Client) UpdateQuotaAndRateLimit(args *api.UpdateQuotaRateArgs) error {
 	return api.UpdateQuotaRate(c, args)
 }
+
+// ListReceipts - query the delivery receipts of the sent messages
+//
+// PARAMS:
+//     - args: the message id or mobile with the optional time range and paging arguments
+// RETURNS:
+//     - *api.ListReceiptsResult: the delivery receipts
+//     - error: the return error if any occurs
+func (c *Client) ListReceipts(args *api.ListReceiptsArgs) (*api.ListReceiptsResult, error) {
+	return api.ListReceipts(c, args)
+}
+
+// GetReceipt - get the delivery receipt of a sent message to the mobile
+//
+// PARAMS:
+//     - messageId: the message id returned by SendSms
+//     - mobile: the mobile the message sent to
+// RETURNS:
+//     - *api.Receipt: the delivery receipt
+//     - error: the return error if any occurs
+func (c *Client) GetReceipt(messageId, mobile string) (*api.Receipt, error) {
+	result, err := api.ListReceipts(c, &api.ListReceiptsArgs{MessageId: messageId, Mobile: mobile})
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Receipts {
+		if len(mobile) == 0 || result.Receipts[i].Mobile == mobile {
+			return &result.Receipts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("receipt of message %s not found", messageId)
+}
+
+// beijingLocation - the schedule time is interpreted by the service in Beijing time
+func beijingLocation() *time.Location {
+	return time.FixedZone("CST", 8*3600)
+}
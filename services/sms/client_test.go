@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/baidubce/bce-sdk-go/services/sms/api"
 	"github.com/baidubce/bce-sdk-go/util/log"
@@ -89,6 +90,36 @@ func TestSendSms(t *testing.T) {
 	t.Logf("%v", result)
 }
 
+func TestSendSmsAt(t *testing.T) {
+	sendSmsArgs := &api.SendSmsArgs{
+		Mobile:      "13800138000",
+		Template:    "your template id",
+		SignatureId: "your signature id",
+		ContentVar:  map[string]interface{}{"code": "123"},
+	}
+	_, err := SMS_CLIENT.SendSmsAt(sendSmsArgs, time.Now().Add(-time.Minute))
+	ExpectEqual(t.Errorf, err != nil, true)
+	result, err := SMS_CLIENT.SendSmsAt(sendSmsArgs, time.Now().Add(time.Hour))
+	ExpectEqual(t.Errorf, err, nil)
+	ExpectEqual(t.Errorf, sendSmsArgs.ScheduleTime, "")
+	t.Logf("%v", result)
+}
+
+func TestListReceipts(t *testing.T) {
+	_, err := SMS_CLIENT.ListReceipts(&api.ListReceiptsArgs{})
+	ExpectEqual(t.Errorf, err != nil, true)
+	_, err = SMS_CLIENT.ListReceipts(&api.ListReceiptsArgs{Mobile: "13800138000", StartTime: "2022/01/01"})
+	ExpectEqual(t.Errorf, err != nil, true)
+	result, err := SMS_CLIENT.ListReceipts(&api.ListReceiptsArgs{
+		Mobile:    "13800138000",
+		StartTime: "2022-01-01 00:00:00",
+		EndTime:   "2022-01-02 00:00:00",
+		PageSize:  10,
+	})
+	ExpectEqual(t.Errorf, err, nil)
+	t.Logf("%v", result)
+}
+
 func TestCreateSignature(t *testing.T) {
 	result, err := SMS_CLIENT.CreateSignature(&api.CreateSignatureArgs{
 		Content:     "测试",
@@ -106,6 +137,14 @@ func TestGetSignature(t *testing.T) {
 	ExpectEqual(t.Errorf, err, nil)
 }
 
+func TestListSignatures(t *testing.T) {
+	result, err := SMS_CLIENT.ListSignatures(&api.ListSignaturesArgs{Status: api.AUDIT_STATUS_SUBMITTED})
+	ExpectEqual(t.Errorf, err, nil)
+	for _, sig := range result.Signatures {
+		ExpectEqual(t.Errorf, sig.Status, api.AUDIT_STATUS_SUBMITTED)
+	}
+}
+
 func TestModifySignature(t *testing.T) {
 	err := SMS_CLIENT.ModifySignature(&api.ModifySignatureArgs{
 		SignatureId:         TEST_SIGNATURE_ID,
@@ -141,6 +180,12 @@ func TestGetTemplate(t *testing.T) {
 	ExpectEqual(t.Errorf, err, nil)
 }
 
+func TestListTemplates(t *testing.T) {
+	result, err := SMS_CLIENT.ListTemplates(&api.ListTemplatesArgs{PageNo: 1, PageSize: 10})
+	ExpectEqual(t.Errorf, err, nil)
+	t.Logf("%v", result)
+}
+
 func TestModifyTemplate(t *testing.T) {
 	err := SMS_CLIENT.ModifyTemplate(&api.ModifyTemplateArgs{
 		TemplateId:  TEST_TEMPLATE_ID,
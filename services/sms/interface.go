@@ -0,0 +1,59 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the SMS client
+
+package sms
+
+import (
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/services/sms/api"
+)
+
+// Interface defines all the operations of the SMS client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	SendSms(args *api.SendSmsArgs) (*api.SendSmsResult, error)
+	SendSmsAt(args *api.SendSmsArgs, at time.Time) (*api.SendSmsResult, error)
+	CreateSignature(args *api.CreateSignatureArgs) (*api.CreateSignatureResult, error)
+	DeleteSignature(args *api.DeleteSignatureArgs) error
+	ModifySignature(args *api.ModifySignatureArgs) error
+	GetSignature(args *api.GetSignatureArgs) (*api.GetSignatureResult, error)
+	ListSignatures(args *api.ListSignaturesArgs) (*api.ListSignaturesResult, error)
+	CreateTemplate(args *api.CreateTemplateArgs) (*api.CreateTemplateResult, error)
+	DeleteTemplate(args *api.DeleteTemplateArgs) error
+	ModifyTemplate(args *api.ModifyTemplateArgs) error
+	GetTemplate(args *api.GetTemplateArgs) (*api.GetTemplateResult, error)
+	ListTemplates(args *api.ListTemplatesArgs) (*api.ListTemplatesResult, error)
+	QueryQuotaAndRateLimit() (*api.QueryQuotaRateResult, error)
+	UpdateQuotaAndRateLimit(args *api.UpdateQuotaRateArgs) error
+	ListReceipts(args *api.ListReceiptsArgs) (*api.ListReceiptsResult, error)
+	GetReceipt(messageId, mobile string) (*api.Receipt, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
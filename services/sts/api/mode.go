@@ -17,6 +17,7 @@ type AssumeRoleArgs struct {
 	UserId          string
 	RoleName        string
 	Acl             string
+	ExternalId      string // the external id required by the trust policy of the role, optional
 }
 
 type Credential struct {
@@ -114,6 +114,9 @@ func AssumeRole(cli bce.Client, args *AssumeRoleArgs) (*Credential, error) {
 	if args.UserId != "" {
 		req.SetParam("userId", args.UserId)
 	}
+	if args.ExternalId != "" {
+		req.SetParam("externalId", args.ExternalId)
+	}
 
 	if len(args.Acl) > 0 {
 		req.SetHeader(http.CONTENT_TYPE, bce.DEFAULT_CONTENT_TYPE)
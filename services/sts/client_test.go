@@ -2,12 +2,18 @@ package sts
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 
+	"github.com/baidubce/bce-sdk-go/auth"
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/sts/api"
 	"github.com/baidubce/bce-sdk-go/util/log"
 )
 
@@ -94,3 +100,45 @@ func TestGetSessionToken(t *testing.T) {
 	t.Logf("expiration: %v", res.Expiration)
 	t.Logf("userId: %v", res.UserId)
 }
+
+func TestAssumeRoleProvider(t *testing.T) {
+	provider := CLIENT.NewAssumeRoleProvider(&api.AssumeRoleArgs{
+		AccountId: "test_account_id",
+		RoleName:  "test_role_name",
+	})
+	cred, err := provider.GetCredentials()
+	ExpectEqual(t.Fatalf, err, nil)
+	t.Logf("ak: %v", cred.AccessKeyId)
+	t.Logf("expiration: %v", provider.Expiration())
+
+	again, err := provider.GetCredentials()
+	ExpectEqual(t.Errorf, err, nil)
+	ExpectEqual(t.Errorf, cred.SessionToken, again.SessionToken)
+}
+
+func TestAssumeRoleProviderRefreshFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client, _ := NewStsClient("ak", "sk", server.URL)
+	client.Config.Retry = bce.NewNoRetryPolicy()
+	provider := client.NewAssumeRoleProvider(&api.AssumeRoleArgs{
+		AccountId: "test_account_id",
+		RoleName:  "test_role_name",
+	})
+	_, err := provider.GetCredentials()
+	ExpectEqual(t.Errorf, true, err != nil)
+
+	// the current credentials are used until they expire if the refresh fails
+	cached, _ := auth.NewSessionBceCredentials("ak", "sk", "token")
+	provider.credentials, provider.expiration = cached, time.Now().Add(time.Minute)
+	cred, err := provider.GetCredentials()
+	ExpectEqual(t.Errorf, nil, err)
+	ExpectEqual(t.Errorf, cached, cred)
+
+	provider.expiration = time.Now().Add(-time.Second)
+	cred, err = provider.GetCredentials()
+	ExpectEqual(t.Errorf, true, err != nil)
+	ExpectEqual(t.Errorf, true, cred == nil)
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// provider.go - define the credentials provider by assuming role with automatic refreshing
+
+package sts
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/auth"
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/sts/api"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+// DEFAULT_REFRESH_AHEAD is the default duration to refresh the credentials before they expire
+const DEFAULT_REFRESH_AHEAD = 5 * time.Minute
+
+// AssumeRoleProvider provides the session credentials of the assumed role, the credentials are
+// refreshed automatically before they expire. It implements the auth.CredentialsProvider, so it
+// can be set to the Config.CredentialsProvider of any service client.
+type AssumeRoleProvider struct {
+	client *Client
+	args   api.AssumeRoleArgs
+
+	// RefreshAhead is the duration to refresh the credentials before they expire, it is limited
+	// to the half of the duration seconds of the role
+	RefreshAhead time.Duration
+
+	lock        sync.Mutex
+	credentials *auth.BceCredentials
+	expiration  time.Time
+}
+
+// NewAssumeRoleProvider - create the credentials provider to assume the role by the STS client
+//
+// PARAMS:
+//     - args: the arguments to assume the role, ExternalId is set if the role requires it
+// RETURNS:
+//     - *AssumeRoleProvider: the refreshable credentials provider
+func (c *Client) NewAssumeRoleProvider(args *api.AssumeRoleArgs) *AssumeRoleProvider {
+	provider := &AssumeRoleProvider{client: c, RefreshAhead: DEFAULT_REFRESH_AHEAD}
+	if args != nil {
+		provider.args = *args
+	}
+	if provider.args.DurationSeconds <= 0 {
+		provider.args.DurationSeconds = api.DEFAULT_ASSUMEROLE_DURATION_SECONDS
+	}
+	return provider
+}
+
+// AssumeRole - chain to assume another role with the credentials of this provider
+//
+// PARAMS:
+//     - args: the arguments to assume the next role
+// RETURNS:
+//     - *AssumeRoleProvider: the credentials provider of the next role
+func (p *AssumeRoleProvider) AssumeRole(args *api.AssumeRoleArgs) *AssumeRoleProvider {
+	conf := *p.client.Config
+	conf.Credentials = nil
+	conf.CredentialsProvider = p
+	client := &Client{bce.NewBceClient(&conf, p.client.Signer)}
+	return client.NewAssumeRoleProvider(args)
+}
+
+// GetCredentials - get the session credentials of the role, assume the role again if they are
+// not obtained yet or about to expire. If assuming the role fails before the current credentials
+// expire, the error is logged and the current credentials are returned to be refreshed later.
+//
+// RETURNS:
+//     - *auth.BceCredentials: the session credentials
+//     - error: nil if ok otherwise the error of assuming role
+func (p *AssumeRoleProvider) GetCredentials() (*auth.BceCredentials, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	refreshAhead := p.RefreshAhead
+	if half := time.Duration(p.args.DurationSeconds) * time.Second / 2; refreshAhead > half {
+		refreshAhead = half
+	}
+	if p.credentials != nil && time.Now().Add(refreshAhead).Before(p.expiration) {
+		return p.credentials, nil
+	}
+	credentials, expiration, err := p.assumeRole()
+	if err != nil {
+		if p.credentials != nil && time.Now().Before(p.expiration) {
+			log.Warnf("refresh the credentials of the role failed, use the current ones "+
+				"expiring at %v: %v", p.expiration, err)
+			return p.credentials, nil
+		}
+		return nil, err
+	}
+	p.credentials, p.expiration = credentials, expiration
+	return credentials, nil
+}
+
+// assumeRole - assume the role to get the new session credentials
+//
+// RETURNS:
+//     - *auth.BceCredentials: the session credentials
+//     - time.Time: the expiration time of the credentials
+//     - error: nil if ok otherwise the error of assuming role
+func (p *AssumeRoleProvider) assumeRole() (*auth.BceCredentials, time.Time, error) {
+	args := p.args
+	cred, err := api.AssumeRole(p.client, &args)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	credentials, err := auth.NewSessionBceCredentials(cred.AccessKeyId, cred.SecretAccessKey,
+		cred.SessionToken)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	expiration := cred.Expiration
+	if expiration.IsZero() {
+		expiration = time.Now().Add(time.Duration(p.args.DurationSeconds) * time.Second)
+	}
+	return credentials, expiration, nil
+}
+
+// Expiration - get the expiration time of the current credentials
+//
+// RETURNS:
+//     - time.Time: the expiration time, zero if the credentials are not obtained yet
+func (p *AssumeRoleProvider) Expiration() time.Time {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.expiration
+}
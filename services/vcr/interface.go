@@ -0,0 +1,48 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the VCR client
+
+package vcr
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/vcr/api"
+)
+
+// Interface defines all the operations of the VCR client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	PutMedia(args *api.PutMediaArgs) error
+	SimplePutMedia(source string, description string, preset string, notification string) error
+	GetMedia(source string) (*api.GetMediaResult, error)
+	PutText(args *api.PutTextArgs) (*api.PutTextResult, error)
+	SimplePutText(text string) (*api.PutTextResult, error)
+	PutImageSync(args *api.PutImageSyncArgs) (*api.PutImageSyncResult, error)
+	SimplePutImageSync(source string) (*api.PutImageSyncResult, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endpoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
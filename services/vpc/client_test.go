@@ -755,6 +755,23 @@ func TestDeleteAclRule(t *testing.T) {
 	ExpectEqual(t.Errorf, nil, err)
 }
 
+func TestUpdateRouteRule(t *testing.T) {
+	args := &UpdateRouteRuleArgs{
+		ClientToken: getClientToken(),
+		Description: "test route rule updated",
+	}
+	err := VPC_CLIENT.UpdateRouteRule(RouteRuleID, args)
+	ExpectEqual(t.Errorf, nil, err)
+
+	result, err := VPC_CLIENT.ListRouteRule(&ListRouteRuleArgs{RouteTableId: RouteTableID})
+	ExpectEqual(t.Errorf, nil, err)
+	for _, rule := range result.RouteRules {
+		if rule.RouteRuleId == RouteRuleID {
+			ExpectEqual(t.Errorf, "test route rule updated", rule.Description)
+		}
+	}
+}
+
 func TestDeleteRouteRule(t *testing.T) {
 	err := VPC_CLIENT.DeleteRouteRule(RouteRuleID, getClientToken())
 	ExpectEqual(t.Errorf, nil, err)
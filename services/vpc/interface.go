@@ -0,0 +1,88 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// interface.go - define the interface of the VPC client
+
+package vpc
+
+// Interface defines all the operations of the VPC client, which can be mocked in the tests
+// of the applications or replaced by other implementations.
+type Interface interface {
+	ListAclEntrys(vpcId string) (*ListAclEntrysResult, error)
+	CreateAclRule(args *CreateAclRuleArgs) error
+	ListAclRules(args *ListAclRulesArgs) (*ListAclRulesResult, error)
+	UpdateAclRule(aclRuleId string, args *UpdateAclRuleArgs) error
+	DeleteAclRule(aclRuleId, clientToken string) error
+	CreateNatGateway(args *CreateNatGatewayArgs) (*CreateNatGatewayResult, error)
+	ListNatGateway(args *ListNatGatewayArgs) (*ListNatGatewayResult, error)
+	GetNatGatewayDetail(natId string) (*NAT, error)
+	UpdateNatGateway(natId string, args *UpdateNatGatewayArgs) error
+	BindEips(natId string, args *BindEipsArgs) error
+	UnBindEips(natId string, args *UnBindEipsArgs) error
+	DeleteNatGateway(natId, clientToken string) error
+	RenewNatGateway(natId string, args *RenewNatGatewayArgs) error
+	CreateNatGatewaySnatRule(natId string, args *CreateNatGatewaySnatRuleArgs) (*CreateNatGatewaySnatRuleResult, error)
+	BatchCreateNatGatewaySnatRule(args *BatchCreateNatGatewaySnatRuleArgs) (*BatchCreateNatGatewaySnatRuleResult, error)
+	DeleteNatGatewaySnatRule(natId string, snatRuleId string, clientToken string) error
+	UpdateNatGatewaySnatRule(natId string, snatRuleId string, args *UpdateNatGatewaySnatRuleArgs) error
+	ListNatGatewaySnatRules(args *ListNatGatewaySnatRuleArgs) (*ListNatGatewaySnatRulesResult, error)
+	CreateNatGatewayDnatRule(natId string, args *CreateNatGatewayDnatRuleArgs) (*CreateNatGatewayDnatRuleResult, error)
+	BatchCreateNatGatewayDnatRule(natId string, args *BatchCreateNatGatewayDnatRuleArgs) (*BatchCreateNatGatewayDnatRuleResult, error)
+	DeleteNatGatewayDnatRule(natId string, dnatRuleId string, clientToken string) error
+	UpdateNatGatewayDnatRule(natId string, dnatRuleId string, args *UpdateNatGatewayDnatRuleArgs) error
+	ListNatGatewayDnatRules(natId string, args *ListNatGatewaDnatRuleArgs) (*ListNatGatewayDnatRulesResult, error)
+	CreatePeerConn(args *CreatePeerConnArgs) (*CreatePeerConnResult, error)
+	ListPeerConn(args *ListPeerConnsArgs) (*ListPeerConnsResult, error)
+	GetPeerConnDetail(peerConnId string, role PeerConnRoleType) (*PeerConn, error)
+	UpdatePeerConn(peerConnId string, args *UpdatePeerConnArgs) error
+	AcceptPeerConnApply(peerConnId, clientToken string) error
+	RejectPeerConnApply(peerConnId, clientToken string) error
+	DeletePeerConn(peerConnId, clientToken string) error
+	ResizePeerConn(peerConnId string, args *ResizePeerConnArgs) error
+	RenewPeerConn(peerConnId string, args *RenewPeerConnArgs) error
+	OpenPeerConnSyncDNS(peerConnId string, args *PeerConnSyncDNSArgs) error
+	ClosePeerConnSyncDNS(peerConnId string, args *PeerConnSyncDNSArgs) error
+	GetRouteTableDetail(routeTableId, vpcId string) (*GetRouteTableResult, error)
+	CreateRouteRule(args *CreateRouteRuleArgs) (*CreateRouteRuleResult, error)
+	DeleteRouteRule(routeRuleId, clientToken string) error
+	ListRouteRule(args *ListRouteRuleArgs) (*ListRouteRuleResult, error)
+	UpdateRouteRule(routeRuleId string, args *UpdateRouteRuleArgs) error
+	CreateSubnet(args *CreateSubnetArgs) (*CreateSubnetResult, error)
+	ListSubnets(args *ListSubnetArgs) (*ListSubnetResult, error)
+	GetSubnetDetail(subnetId string) (*GetSubnetDetailResult, error)
+	UpdateSubnet(subnetId string, args *UpdateSubnetArgs) error
+	DeleteSubnet(subnetId string, clientToken string) error
+	CreateVPC(args *CreateVPCArgs) (*CreateVPCResult, error)
+	ListVPC(args *ListVPCArgs) (*ListVPCResult, error)
+	GetVPCDetail(vpcId string) (*GetVPCDetailResult, error)
+	UpdateVPC(vpcId string, updateVPCArgs *UpdateVPCArgs) error
+	DeleteVPC(vpcId, clientToken string) error
+	GetPrivateIpAddressesInfo(args *GetVpcPrivateIpArgs) (*VpcPrivateIpAddressesResult, error)
+	GetNetworkTopologyInfo(args *GetNetworkTopologyArgs) (*NetworkTopologyResult, error)
+}
+
+var _ Interface = &Client{}
+
+// NewInterface - create the client as the Interface with the same parameters as the NewClient
+//
+// RETURNS:
+//     - Interface: the created client
+//     - error: nil if success otherwise the specific error
+func NewInterface(ak, sk, endPoint string) (Interface, error) {
+	client, err := NewClient(ak, sk, endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
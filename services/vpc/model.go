@@ -40,9 +40,15 @@ const (
 	SUBNET_TYPE_BCCNAT SubnetType = "BCC_NAT"
 	SUBNET_TYPE_BBC    SubnetType = "BBC"
 
-	NEXTHOP_TYPE_CUSTOM NexthopType = "custom"
-	NEXTHOP_TYPE_VPN    NexthopType = "vpn"
-	NEXTHOP_TYPE_NAT    NexthopType = "nat"
+	NEXTHOP_TYPE_CUSTOM         NexthopType = "custom"
+	NEXTHOP_TYPE_VPN            NexthopType = "vpn"
+	NEXTHOP_TYPE_NAT            NexthopType = "nat"
+	NEXTHOP_TYPE_DEFAULTGATEWAY NexthopType = "defaultGateway"
+	NEXTHOP_TYPE_PEERCONN       NexthopType = "peerConn"
+	NEXTHOP_TYPE_DCGATEWAY      NexthopType = "dcGateway"
+	NEXTHOP_TYPE_ENIC           NexthopType = "enic"
+	NEXTHOP_TYPE_HAVIP          NexthopType = "havip"
+	NEXTHOP_TYPE_IPV6GATEWAY    NexthopType = "ipv6gateway"
 
 	ACL_RULE_PROTOCOL_TCP  AclRuleProtocolType = "tcp"
 	ACL_RULE_PROTOCOL_UDP  AclRuleProtocolType = "udp"
@@ -257,6 +263,33 @@ type CreateRouteRuleResult struct {
 	RouteRuleId string `json:"routeRuleId"`
 }
 
+// UpdateRouteRuleArgs defines the structure of the input parameters for the UpdateRouteRule api
+type UpdateRouteRuleArgs struct {
+	ClientToken        string      `json:"-"`
+	SourceAddress      string      `json:"sourceAddress,omitempty"`
+	DestinationAddress string      `json:"destinationAddress,omitempty"`
+	NexthopId          string      `json:"nexthopId,omitempty"`
+	NexthopType        NexthopType `json:"nexthopType,omitempty"`
+	Description        string      `json:"description,omitempty"`
+}
+
+// ListRouteRuleArgs defines the structure of the input parameters for the ListRouteRule api
+type ListRouteRuleArgs struct {
+	RouteTableId string
+	VpcId        string
+	Marker       string
+	MaxKeys      int
+}
+
+// ListRouteRuleResult defines the structure of the output parameters for the ListRouteRule api
+type ListRouteRuleResult struct {
+	RouteRules  []RouteRule `json:"routeRules"`
+	Marker      string      `json:"marker"`
+	IsTruncated bool        `json:"isTruncated"`
+	NextMarker  string      `json:"nextMarker"`
+	MaxKeys     int         `json:"maxKeys"`
+}
+
 // ListAclEntrysResult defines the structure of the output parameters for the ListAclEntrys api
 type ListAclEntrysResult struct {
 	VpcId     string     `json:"vpcId"`
@@ -41,7 +41,7 @@ func (c *Client) CreatePeerConn(args *CreatePeerConnArgs) (*CreatePeerConnResult
 		WithURL(getURLForPeerConn()).
 		WithMethod(http.POST).
 		WithBody(args).
-		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithClientToken(args.ClientToken).
 		WithResult(result).
 		Do()
 
@@ -18,6 +18,7 @@ package vpc
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/http"
@@ -65,7 +66,7 @@ func (c *Client) CreateRouteRule(args *CreateRouteRuleArgs) (*CreateRouteRuleRes
 		WithURL(getURLForRouteRule()).
 		WithMethod(http.POST).
 		WithBody(args).
-		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithClientToken(args.ClientToken).
 		WithResult(result).
 		Do()
 
@@ -86,3 +87,53 @@ func (c *Client) DeleteRouteRule(routeRuleId, clientToken string) error {
 		WithQueryParamFilter("clientToken", clientToken).
 		Do()
 }
+
+// ListRouteRule - list the route rules of the given routeTableId or vpcId with pagination
+//
+// PARAMS:
+//     - args: the arguments to list route rules
+// RETURNS:
+//     - *ListRouteRuleResult: the result of the route rule list
+//     - error: nil if success otherwise the specific error
+func (c *Client) ListRouteRule(args *ListRouteRuleArgs) (*ListRouteRuleResult, error) {
+	if args == nil || (args.RouteTableId == "" && args.VpcId == "") {
+		return nil, fmt.Errorf("The routeTableId and vpcId cannot be blank at the same time.")
+	}
+	maxKeys := args.MaxKeys
+	if maxKeys == 0 {
+		maxKeys = 1000
+	}
+
+	result := &ListRouteRuleResult{}
+	err := bce.NewRequestBuilder(c).
+		WithURL(getURLForRouteRule()).
+		WithMethod(http.GET).
+		WithQueryParamFilter("routeTableId", args.RouteTableId).
+		WithQueryParamFilter("vpcId", args.VpcId).
+		WithQueryParamFilter("marker", args.Marker).
+		WithQueryParamFilter("maxKeys", strconv.Itoa(maxKeys)).
+		WithResult(result).
+		Do()
+
+	return result, err
+}
+
+// UpdateRouteRule - update the given route rule
+//
+// PARAMS:
+//     - routeRuleId: the id of the specific route rule
+//     - args: the arguments to update the route rule
+// RETURNS:
+//     - error: nil if success otherwise the specific error
+func (c *Client) UpdateRouteRule(routeRuleId string, args *UpdateRouteRuleArgs) error {
+	if args == nil {
+		return fmt.Errorf("UpdateRouteRuleArgs cannot be nil.")
+	}
+
+	return bce.NewRequestBuilder(c).
+		WithURL(getURLForRouteRuleId(routeRuleId)).
+		WithMethod(http.PUT).
+		WithBody(args).
+		WithClientToken(args.ClientToken).
+		Do()
+}
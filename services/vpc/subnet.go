@@ -41,7 +41,7 @@ func (c *Client) CreateSubnet(args *CreateSubnetArgs) (*CreateSubnetResult, erro
 		WithURL(getURLForSubnet()).
 		WithMethod(http.POST).
 		WithBody(args).
-		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithClientToken(args.ClientToken).
 		WithResult(result).
 		Do()
 
@@ -41,7 +41,7 @@ func (c *Client) CreateVPC(args *CreateVPCArgs) (*CreateVPCResult, error) {
 		WithURL(getURLForVPC()).
 		WithMethod(http.POST).
 		WithBody(args).
-		WithQueryParamFilter("clientToken", args.ClientToken).
+		WithClientToken(args.ClientToken).
 		WithResult(result).
 		Do()
 
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// checksum.go - define the streaming CRC checksums used to verify the uploaded and downloaded data
+
+// Package checksum computes CRC32, CRC32C and CRC64 checksums while the data is streamed, and
+// combines the checksums of consecutive parts into the checksum of the whole data.
+package checksum
+
+import (
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"io"
+	"strconv"
+)
+
+// Algorithm identifies the CRC variant used to compute a checksum.
+type Algorithm string
+
+const (
+	CRC32  Algorithm = "crc32"
+	CRC32C Algorithm = "crc32c"
+	CRC64  Algorithm = "crc64"
+)
+
+var (
+	crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+	crc64Table  = crc64.MakeTable(crc64.ECMA)
+)
+
+// reflected polynomials and bit widths used by Combine
+var polynomials = map[Algorithm]struct {
+	poly  uint64
+	width uint
+}{
+	CRC32:  {crc32.IEEE, 32},
+	CRC32C: {crc32.Castagnoli, 32},
+	CRC64:  {0xC96C5795D7870F42, 64},
+}
+
+// Valid - check whether the algorithm is supported
+func (a Algorithm) Valid() bool {
+	_, ok := polynomials[a]
+	return ok
+}
+
+// Hash computes the checksum of the data written to it and counts the written bytes.
+type Hash struct {
+	alg  Algorithm
+	h    hash.Hash
+	size int64
+}
+
+// New - create a streaming checksum hash of the given algorithm
+//
+// PARAMS:
+//     - alg: the checksum algorithm
+// RETURNS:
+//     - *Hash: the created hash
+//     - error: nil if ok otherwise the algorithm is not supported
+func New(alg Algorithm) (*Hash, error) {
+	var h hash.Hash
+	switch alg {
+	case CRC32:
+		h = crc32.NewIEEE()
+	case CRC32C:
+		h = crc32.New(crc32cTable)
+	case CRC64:
+		h = crc64.New(crc64Table)
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm: %q", alg)
+	}
+	return &Hash{alg: alg, h: h}, nil
+}
+
+// Write - implement the io.Writer interface, it never returns an error
+func (c *Hash) Write(p []byte) (int, error) {
+	n, _ := c.h.Write(p)
+	c.size += int64(n)
+	return n, nil
+}
+
+// Algorithm - return the algorithm of the hash
+func (c *Hash) Algorithm() Algorithm { return c.alg }
+
+// Size - return the number of bytes written so far
+func (c *Hash) Size() int64 { return c.size }
+
+// Sum64 - return the checksum of the data written so far
+func (c *Hash) Sum64() uint64 {
+	if h, ok := c.h.(hash.Hash32); ok {
+		return uint64(h.Sum32())
+	}
+	return c.h.(hash.Hash64).Sum64()
+}
+
+// String - return the checksum as the decimal string used by the `x-bce-content-crc*` headers
+func (c *Hash) String() string { return Format(c.Sum64()) }
+
+// Reset - reset the hash to its initial state
+func (c *Hash) Reset() {
+	c.h.Reset()
+	c.size = 0
+}
+
+// Format - format the checksum as a decimal string
+func Format(sum uint64) string { return strconv.FormatUint(sum, 10) }
+
+// Parse - parse the decimal checksum string returned by the service
+func Parse(s string) (uint64, error) { return strconv.ParseUint(s, 10, 64) }
+
+// MismatchError is returned when the checksum computed locally differs from the one returned by
+// the service.
+type MismatchError struct {
+	Algorithm Algorithm
+	Expected  string
+	Actual    string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch: expected %s, computed %s",
+		e.Algorithm, e.Expected, e.Actual)
+}
+
+// IsMismatch - check whether the error is caused by a checksum mismatch
+func IsMismatch(err error) bool {
+	_, ok := err.(*MismatchError)
+	return ok
+}
+
+// Verify - compare the computed checksum with the expected decimal string, an empty expected
+// value means the service did not return the checksum and is treated as matched.
+//
+// PARAMS:
+//     - h: the hash holding the computed checksum
+//     - expected: the expected checksum string
+// RETURNS:
+//     - error: nil if matched otherwise a *MismatchError
+func Verify(h *Hash, expected string) error {
+	return VerifySum(h.alg, h.Sum64(), expected)
+}
+
+// VerifySum - compare the given checksum with the expected decimal string like the Verify, it is
+// used for the checksums combined from the parts
+//
+// PARAMS:
+//     - alg: the algorithm of the checksum
+//     - sum: the computed checksum
+//     - expected: the expected checksum string
+// RETURNS:
+//     - error: nil if matched otherwise a *MismatchError
+func VerifySum(alg Algorithm, sum uint64, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if want, err := Parse(expected); err == nil && want == sum {
+		return nil
+	}
+	return &MismatchError{Algorithm: alg, Expected: expected, Actual: Format(sum)}
+}
+
+// TeeReader - return a reader that writes to the hash everything it reads from r
+func TeeReader(r io.Reader, h *Hash) io.Reader { return io.TeeReader(r, h) }
+
+type verifyReadCloser struct {
+	rc       io.ReadCloser
+	h        *Hash
+	expected string
+	err      error
+}
+
+// NewVerifyReadCloser - wrap the stream so that the checksum is computed while reading, when the
+// stream reaches io.EOF the checksum is compared with the expected value and a *MismatchError is
+// returned instead of io.EOF if they differ.
+//
+// PARAMS:
+//     - rc: the stream to read from
+//     - h: the hash to accumulate the checksum
+//     - expected: the expected decimal checksum, empty to skip the verification
+// RETURNS:
+//     - io.ReadCloser: the wrapped stream
+func NewVerifyReadCloser(rc io.ReadCloser, h *Hash, expected string) io.ReadCloser {
+	return &verifyReadCloser{rc: rc, h: h, expected: expected}
+}
+
+func (v *verifyReadCloser) Read(p []byte) (int, error) {
+	if v.err != nil {
+		return 0, v.err
+	}
+	n, err := v.rc.Read(p)
+	v.h.Write(p[:n])
+	if err == io.EOF {
+		if verr := Verify(v.h, v.expected); verr != nil {
+			err = verr
+		}
+		v.err = err
+	}
+	return n, err
+}
+
+func (v *verifyReadCloser) Close() error { return v.rc.Close() }
@@ -0,0 +1,92 @@
+package checksum
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// the check values of the "123456789" of each algorithm
+var checkValues = map[Algorithm]uint64{
+	CRC32:  0xCBF43926,
+	CRC32C: 0xE3069283,
+	CRC64:  0x995DC9BBDF1939FA,
+}
+
+func TestHash(t *testing.T) {
+	for alg, expected := range checkValues {
+		h, err := New(alg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write([]byte("1234"))
+		h.Write([]byte("56789"))
+		if h.Sum64() != expected || h.Size() != 9 {
+			t.Errorf("%s: got %x of %d bytes, expected %x", alg, h.Sum64(), h.Size(), expected)
+		}
+		if h.String() != Format(expected) {
+			t.Errorf("%s: got string %s", alg, h.String())
+		}
+		h.Reset()
+		if h.Sum64() != 0 || h.Size() != 0 {
+			t.Errorf("%s: reset got %x of %d bytes", alg, h.Sum64(), h.Size())
+		}
+	}
+	if _, err := New("md5"); err == nil || Algorithm("md5").Valid() {
+		t.Errorf("unsupported algorithm: expected error")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	h, _ := New(CRC32)
+	h.Write([]byte("123456789"))
+	cases := []struct {
+		expected string
+		mismatch bool
+	}{
+		{"", false},
+		{Format(checkValues[CRC32]), false},
+		{"12345", true},
+		{"not a number", true},
+	}
+	for _, c := range cases {
+		err := Verify(h, c.expected)
+		if IsMismatch(err) != c.mismatch || (!c.mismatch && err != nil) {
+			t.Errorf("verify %q: got %v", c.expected, err)
+		}
+	}
+	err := VerifySum(CRC64, 1, "2")
+	if e, ok := err.(*MismatchError); !ok || e.Algorithm != CRC64 || e.Expected != "2" || e.Actual != "1" {
+		t.Errorf("verify sum: got %v", err)
+	}
+}
+
+func TestVerifyReadCloser(t *testing.T) {
+	for _, c := range []struct {
+		expected string
+		err      error
+	}{
+		{Format(checkValues[CRC32C]), nil},
+		{"", nil},
+		{"1", &MismatchError{}},
+	} {
+		h, _ := New(CRC32C)
+		rc := NewVerifyReadCloser(ioutil.NopCloser(strings.NewReader("123456789")), h, c.expected)
+		data, err := ioutil.ReadAll(rc)
+		if string(data) != "123456789" {
+			t.Errorf("expected %q: got data %q", c.expected, data)
+		}
+		if IsMismatch(err) != (c.err != nil) || (c.err == nil && err != nil) {
+			t.Errorf("expected %q: got error %v", c.expected, err)
+		}
+		// the result is kept for the following reads
+		if _, again := rc.Read(make([]byte, 1)); c.err == nil && again != io.EOF ||
+			c.err != nil && !IsMismatch(again) {
+			t.Errorf("expected %q: got error %v of the following read", c.expected, again)
+		}
+		if err := rc.Close(); err != nil {
+			t.Error(err)
+		}
+	}
+}
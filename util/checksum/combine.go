@@ -0,0 +1,141 @@
+/*
+ * Copyright 2022 Baidu, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ */
+
+// combine.go - combine the checksums of consecutive data parts without reading the data again
+
+package checksum
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Combine - compute the checksum of the concatenation A+B from the checksum of A, the checksum
+// of B and the length of B. It uses the GF(2) matrix method of zlib `crc32_combine`.
+//
+// PARAMS:
+//     - alg: the checksum algorithm of both checksums
+//     - crc1: the checksum of the first part
+//     - crc2: the checksum of the second part
+//     - len2: the length in bytes of the second part
+// RETURNS:
+//     - uint64: the checksum of the concatenated data
+func Combine(alg Algorithm, crc1, crc2 uint64, len2 int64) uint64 {
+	p, ok := polynomials[alg]
+	if !ok || len2 <= 0 {
+		return crc1 ^ crc2
+	}
+	odd := make([]uint64, p.width)
+	even := make([]uint64, p.width)
+
+	// the operator for one zero bit
+	odd[0] = p.poly
+	row := uint64(1)
+	for n := uint(1); n < p.width; n++ {
+		odd[n] = row
+		row <<= 1
+	}
+	gf2MatrixSquare(even, odd) // two zero bits
+	gf2MatrixSquare(odd, even) // four zero bits
+
+	// apply len2 zero bytes to crc1, the first squaring gives the operator for one zero byte
+	for {
+		gf2MatrixSquare(even, odd)
+		if len2&1 != 0 {
+			crc1 = gf2MatrixTimes(even, crc1)
+		}
+		len2 >>= 1
+		if len2 == 0 {
+			break
+		}
+		gf2MatrixSquare(odd, even)
+		if len2&1 != 0 {
+			crc1 = gf2MatrixTimes(odd, crc1)
+		}
+		len2 >>= 1
+		if len2 == 0 {
+			break
+		}
+	}
+	return crc1 ^ crc2
+}
+
+func gf2MatrixTimes(mat []uint64, vec uint64) uint64 {
+	var sum uint64
+	for i := 0; vec != 0; i, vec = i+1, vec>>1 {
+		if vec&1 != 0 {
+			sum ^= mat[i]
+		}
+	}
+	return sum
+}
+
+func gf2MatrixSquare(square, mat []uint64) {
+	for n := range mat {
+		square[n] = gf2MatrixTimes(mat, mat[n])
+	}
+}
+
+// Part is the checksum and the size of one part of the data.
+type Part struct {
+	Number int
+	Sum    uint64
+	Size   int64
+}
+
+// Combiner accumulates the checksums of the parts uploaded in any order, such as the parts of a
+// multipart upload, and computes the checksum of the whole data ordered by the part number.
+type Combiner struct {
+	Algorithm Algorithm
+	parts     map[int]Part
+}
+
+// NewCombiner - create a combiner of the given algorithm
+func NewCombiner(alg Algorithm) *Combiner {
+	return &Combiner{Algorithm: alg, parts: make(map[int]Part)}
+}
+
+// Add - record the checksum of one part, a part added again overrides the previous one
+func (c *Combiner) Add(part Part) {
+	c.parts[part.Number] = part
+}
+
+// Sum64 - return the checksum of the whole data and its total size
+//
+// RETURNS:
+//     - uint64: the combined checksum
+//     - int64: the total size of all parts
+//     - error: nil if ok otherwise the parts are not numbered continuously from the first one
+func (c *Combiner) Sum64() (uint64, int64, error) {
+	numbers := make([]int, 0, len(c.parts))
+	for n := range c.parts {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+	var sum uint64
+	var size int64
+	for i, n := range numbers {
+		if i > 0 && n != numbers[i-1]+1 {
+			return 0, 0, fmt.Errorf("missing part %d before part %d", numbers[i-1]+1, n)
+		}
+		part := c.parts[n]
+		if i == 0 {
+			sum = part.Sum
+		} else {
+			sum = Combine(c.Algorithm, sum, part.Sum, part.Size)
+		}
+		size += part.Size
+	}
+	return sum, size, nil
+}
@@ -0,0 +1,80 @@
+package checksum
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func sumOf(t *testing.T, alg Algorithm, data []byte) uint64 {
+	h, err := New(alg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write(data)
+	return h.Sum64()
+}
+
+func TestCombine(t *testing.T) {
+	data := make([]byte, 100000)
+	rand.New(rand.NewSource(1)).Read(data)
+	splits := []int{0, 1, 7, 4096, 65537, len(data) - 1, len(data)}
+	for alg := range polynomials {
+		whole := sumOf(t, alg, data)
+		for _, at := range splits {
+			got := Combine(alg, sumOf(t, alg, data[:at]), sumOf(t, alg, data[at:]), int64(len(data)-at))
+			if got != whole {
+				t.Errorf("%s split at %d: got %x, expected %x", alg, at, got, whole)
+			}
+		}
+	}
+}
+
+func TestCombiner(t *testing.T) {
+	data := make([]byte, 50000)
+	rand.New(rand.NewSource(2)).Read(data)
+	// the part sizes including the zero-length parts at the start, the middle and the end
+	sizes := []int{0, 10000, 1, 0, 20000, 19999, 0}
+	for alg := range polynomials {
+		parts := make([]Part, 0, len(sizes))
+		offset := 0
+		for i, size := range sizes {
+			part := data[offset : offset+size]
+			parts = append(parts, Part{Number: i + 1, Sum: sumOf(t, alg, part), Size: int64(size)})
+			offset += size
+		}
+		combiner := NewCombiner(alg)
+		// the parts are added in any order and the part added again overrides the previous one
+		combiner.Add(Part{Number: 3, Sum: 1, Size: 1})
+		for _, i := range rand.New(rand.NewSource(3)).Perm(len(parts)) {
+			combiner.Add(parts[i])
+		}
+		sum, size, err := combiner.Sum64()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := sumOf(t, alg, data); sum != expected || size != int64(len(data)) {
+			t.Errorf("%s: got %x of %d bytes, expected %x of %d bytes", alg, sum, size,
+				expected, len(data))
+		}
+	}
+}
+
+func TestCombinerEdgeCases(t *testing.T) {
+	for alg := range polynomials {
+		sum, size, err := NewCombiner(alg).Sum64()
+		if sum != 0 || size != 0 || err != nil {
+			t.Errorf("%s empty: got %x, %d and %v", alg, sum, size, err)
+		}
+
+		combiner := NewCombiner(alg)
+		combiner.Add(Part{Number: 1, Sum: sumOf(t, alg, nil), Size: 0})
+		if sum, size, err := combiner.Sum64(); sum != 0 || size != 0 || err != nil {
+			t.Errorf("%s zero-length: got %x, %d and %v", alg, sum, size, err)
+		}
+
+		combiner.Add(Part{Number: 3, Sum: sumOf(t, alg, []byte("x")), Size: 1})
+		if _, _, err := combiner.Sum64(); err == nil {
+			t.Errorf("%s missing part: expected error", alg)
+		}
+	}
+}
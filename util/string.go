@@ -18,7 +18,6 @@
 package util
 
 import (
-	"bytes"
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
@@ -48,16 +47,33 @@ func CalculateContentMD5(data io.Reader, size int64) (string, error) {
 }
 
 func UriEncode(uri string, encodeSlash bool) string {
-	var byte_buf bytes.Buffer
-	for _, b := range []byte(uri) {
-		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
-			b == '-' || b == '_' || b == '.' || b == '~' || (b == '/' && !encodeSlash) {
-			byte_buf.WriteByte(b)
+	// Most of the header names, params and object keys need no escaping, return them directly
+	escaped := 0
+	for i := 0; i < len(uri); i++ {
+		if !isUnreserved(uri[i], encodeSlash) {
+			escaped++
+		}
+	}
+	if escaped == 0 {
+		return uri
+	}
+
+	const upperHex = "0123456789ABCDEF"
+	buf := make([]byte, 0, len(uri)+2*escaped)
+	for i := 0; i < len(uri); i++ {
+		b := uri[i]
+		if isUnreserved(b, encodeSlash) {
+			buf = append(buf, b)
 		} else {
-			byte_buf.WriteString(fmt.Sprintf("%%%02X", b))
+			buf = append(buf, '%', upperHex[b>>4], upperHex[b&15])
 		}
 	}
-	return byte_buf.String()
+	return string(buf)
+}
+
+func isUnreserved(b byte, encodeSlash bool) bool {
+	return (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+		b == '-' || b == '_' || b == '.' || b == '~' || (b == '/' && !encodeSlash)
 }
 
 func NewUUID() string {